@@ -0,0 +1,284 @@
+// Package binlog 解析ROW格式的binlog文件，从DML语句产生的行事件生成回滚语句
+package binlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// binlog事件的类型，只列出解析时用到的部分
+const (
+	QueryEvent             = 2
+	RotateEvent            = 4
+	FormatDescriptionEvent = 15
+	XIDEvent               = 16
+	TableMapEvent          = 19
+	WriteRowsEventV1       = 23
+	UpdateRowsEventV1      = 24
+	DeleteRowsEventV1      = 25
+	WriteRowsEventV2       = 30
+	UpdateRowsEventV2      = 31
+	DeleteRowsEventV2      = 32
+)
+
+const headerSize = 19
+
+// Magic binlog文件开头的4个字节
+var Magic = []byte{0xfe, 'b', 'i', 'n'}
+
+// Position binlog中的位置，SHOW MASTER STATUS的File和Position
+type Position struct {
+	File string
+	Pos  uint32
+}
+
+// String 位置的显示格式
+func (p Position) String() string {
+	return fmt.Sprintf("%s:%d", p.File, p.Pos)
+}
+
+// Compare 比较两个位置的先后，文件名按序号比较
+func (p Position) Compare(o Position) int {
+	if p.File != o.File {
+		if sequence(p.File) < sequence(o.File) {
+			return -1
+		}
+		return 1
+	}
+	switch {
+	case p.Pos < o.Pos:
+		return -1
+	case p.Pos > o.Pos:
+		return 1
+	}
+	return 0
+}
+
+// sequence binlog文件名中的序号，例如mysql-bin.000012的12
+func sequence(file string) uint64 {
+	n, _ := strconv.ParseUint(file[strings.LastIndex(file, ".")+1:], 10, 64)
+	return n
+}
+
+// Files 从起始位置到终止位置经过的binlog文件名
+func Files(start, end Position) []string {
+	files := []string{start.File}
+	i := strings.LastIndex(start.File, ".")
+	if i < 0 || start.File == end.File {
+		return files
+	}
+	prefix, width := start.File[:i+1], len(start.File)-i-1
+	for n := sequence(start.File) + 1; n <= sequence(end.File); n++ {
+		files = append(files, fmt.Sprintf("%s%0*d", prefix, width, n))
+	}
+	return files
+}
+
+// Header 事件的公共头部
+type Header struct {
+	Timestamp uint32
+	Type      byte
+	ServerID  uint32
+	Size      uint32
+	LogPos    uint32 // 事件结束的位置，也就是下一个事件开始的位置
+	Flags     uint16
+}
+
+// Event 解析后的事件
+type Event struct {
+	File   string
+	Header Header
+	Thread uint32     // 事件所属事务的线程号，来自事务开始时的QUERY_EVENT
+	Query  string     // QUERY_EVENT的语句
+	Rows   *RowsEvent // 行事件，其他事件为nil
+}
+
+// Position 事件结束的位置
+func (e *Event) Position() Position {
+	return Position{File: e.File, Pos: e.Header.LogPos}
+}
+
+// Reader 按顺序读取binlog文件中的事件
+type Reader struct {
+	File       string
+	r          *bufio.Reader
+	checksum   bool
+	postHeader []byte
+	tables     map[uint64]*TableMap
+	thread     uint32
+}
+
+// NewReader 创建读取器，检查文件开头的Magic
+func NewReader(r io.Reader, file string) (*Reader, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(Magic))
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(magic, Magic) {
+		return nil, fmt.Errorf("%s不是binlog文件", file)
+	}
+	return &Reader{
+		File:   file,
+		r:      br,
+		tables: map[uint64]*TableMap{},
+	}, nil
+}
+
+// Next 读取下一个事件，文件结束时返回io.EOF
+func (r *Reader) Next() (*Event, error) {
+	head := make([]byte, headerSize)
+	if _, err := io.ReadFull(r.r, head); err != nil {
+		return nil, err
+	}
+	h := Header{
+		Timestamp: binary.LittleEndian.Uint32(head[0:]),
+		Type:      head[4],
+		ServerID:  binary.LittleEndian.Uint32(head[5:]),
+		Size:      binary.LittleEndian.Uint32(head[9:]),
+		LogPos:    binary.LittleEndian.Uint32(head[13:]),
+		Flags:     binary.LittleEndian.Uint16(head[17:]),
+	}
+	if h.Size < headerSize {
+		return nil, fmt.Errorf("%s:%d 事件的长度错误", r.File, h.LogPos)
+	}
+	body := make([]byte, h.Size-headerSize)
+	if _, err := io.ReadFull(r.r, body); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	if h.Type == FormatDescriptionEvent {
+		if err := r.formatDescription(body); err != nil {
+			return nil, err
+		}
+	}
+	if r.checksum {
+		if len(body) < 4 {
+			return nil, fmt.Errorf("%s:%d 事件的长度错误", r.File, h.LogPos)
+		}
+		n := len(body) - 4
+		sum := crc32.ChecksumIEEE(append(append([]byte{}, head...), body[:n]...))
+		if sum != binary.LittleEndian.Uint32(body[n:]) {
+			return nil, fmt.Errorf("%s:%d 事件的校验和错误", r.File, h.LogPos)
+		}
+		body = body[:n]
+	}
+
+	e := &Event{File: r.File, Header: h}
+	var err error
+	switch h.Type {
+	case QueryEvent:
+		err = r.query(e, body)
+	case TableMapEvent:
+		var m *TableMap
+		if m, err = r.tableMap(body); err == nil {
+			r.tables[m.ID] = m
+		}
+	case WriteRowsEventV1, UpdateRowsEventV1, DeleteRowsEventV1,
+		WriteRowsEventV2, UpdateRowsEventV2, DeleteRowsEventV2:
+		e.Rows, err = r.rows(h.Type, body)
+	case XIDEvent:
+		// 事务结束，行事件使用的表映射随之失效
+		r.tables = map[uint64]*TableMap{}
+	case RotateEvent:
+		r.tables = map[uint64]*TableMap{}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s:%d %s", r.File, h.LogPos, err.Error())
+	}
+	e.Thread = r.thread
+	return e, nil
+}
+
+// formatDescription 解析FORMAT_DESCRIPTION_EVENT，得到各类事件头部之后的固定长度和校验方式
+func (r *Reader) formatDescription(body []byte) error {
+	// binlog_version(2) server_version(50) create_timestamp(4) header_length(1)
+	if len(body) < 57 {
+		return fmt.Errorf("%s FORMAT_DESCRIPTION_EVENT的长度错误", r.File)
+	}
+	version := string(bytes.TrimRight(body[2:52], "\x00"))
+	r.checksum = false
+	end := len(body)
+	if checksumSupported(version) {
+		// 5.6.1之后在最后增加了校验方式(1)和校验和(4)
+		if len(body) < 62 {
+			return fmt.Errorf("%s FORMAT_DESCRIPTION_EVENT的长度错误", r.File)
+		}
+		r.checksum = body[len(body)-5] == 1
+		end = len(body) - 5
+	}
+	r.postHeader = append([]byte{}, body[57:end]...)
+	return nil
+}
+
+// checksumSupported 服务器版本是否支持事件的校验和，MySQL 5.6.1和MariaDB 5.3开始支持
+func checksumSupported(version string) bool {
+	parts := strings.SplitN(strings.SplitN(version, "-", 2)[0], ".", 3)
+	v := [3]int{}
+	for i, part := range parts {
+		v[i], _ = strconv.Atoi(part)
+	}
+	return v[0] > 5 || v[0] == 5 && (v[1] > 6 || v[1] == 6 && v[2] >= 1)
+}
+
+// postHeaderLen 事件头部之后的固定长度
+func (r *Reader) postHeaderLen(tp byte, def int) int {
+	if int(tp) <= len(r.postHeader) && tp > 0 {
+		return int(r.postHeader[tp-1])
+	}
+	return def
+}
+
+// query 解析QUERY_EVENT，事务开始的BEGIN记录了执行事务的线程号
+func (r *Reader) query(e *Event, body []byte) error {
+	// thread_id(4) exec_time(4) schema_length(1) error_code(2) status_vars_length(2)
+	if len(body) < 13 {
+		return fmt.Errorf("QUERY_EVENT的长度错误")
+	}
+	thread := binary.LittleEndian.Uint32(body[0:])
+	schemaLen := int(body[8])
+	statusLen := int(binary.LittleEndian.Uint16(body[11:]))
+	pos := r.postHeaderLen(QueryEvent, 13) + statusLen + schemaLen + 1
+	if pos > len(body) {
+		return fmt.Errorf("QUERY_EVENT的长度错误")
+	}
+	e.Query = string(body[pos:])
+	r.thread = thread
+	return nil
+}
+
+// ReadFile 按顺序读取binlog文件中的所有事件
+func ReadFile(path string, fn func(e *Event) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	reader, err := NewReader(f, filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	for {
+		e, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = fn(e); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package binlog
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPosition(t *testing.T) {
+	a := Position{File: "mysql-bin.000009", Pos: 1308}
+	b := Position{File: "mysql-bin.000010", Pos: 4}
+	assert.Equal(t, -1, a.Compare(b))
+	assert.Equal(t, 1, b.Compare(a))
+	assert.Equal(t, 0, a.Compare(a))
+	assert.Equal(t, 1, a.Compare(Position{File: "mysql-bin.000009", Pos: 154}))
+	assert.Equal(t, "mysql-bin.000009:1308", a.String())
+
+	assert.Equal(t, []string{"mysql-bin.000009"}, Files(a, a))
+	assert.Equal(t, []string{"mysql-bin.000009", "mysql-bin.000010", "mysql-bin.000011"},
+		Files(a, Position{File: "mysql-bin.000011", Pos: 4}))
+}
+
+func TestReadFile(t *testing.T) {
+	types, threads := []byte{}, []uint32{}
+	var rows []*RowsEvent
+	err := ReadFile(filepath.Join("testdata", "mysql-bin.000001"), func(e *Event) error {
+		assert.Equal(t, "mysql-bin.000001", e.File)
+		types = append(types, e.Header.Type)
+		if e.Rows != nil {
+			rows = append(rows, e.Rows)
+			threads = append(threads, e.Thread)
+		}
+		if e.Header.Type == QueryEvent {
+			assert.Equal(t, "BEGIN", e.Query)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{FormatDescriptionEvent, 35,
+		34, QueryEvent, TableMapEvent, WriteRowsEventV2, XIDEvent,
+		34, QueryEvent, TableMapEvent, UpdateRowsEventV2, XIDEvent,
+		34, QueryEvent, TableMapEvent, UpdateRowsEventV2, XIDEvent,
+		RotateEvent}, types)
+	assert.Equal(t, []uint32{42, 43, 42}, threads)
+	if !assert.Len(t, rows, 3) {
+		return
+	}
+
+	m := rows[0].Table
+	assert.Equal(t, "db1", m.Database)
+	assert.Equal(t, "t1", m.Table)
+	assert.Len(t, m.Types, 15)
+	assert.True(t, m.Nullable[1])
+	assert.False(t, m.Nullable[0])
+
+	assert.True(t, rows[0].IsWrite())
+	assert.Len(t, rows[0].Rows, 2)
+	assert.Equal(t, Row{
+		int64(1), []byte("alice"), Decimal("12.50"), int64(-56),
+		Temporal("2020-06-01 10:00:00.123"), Timestamp("1591005600"), Bits(1), Bits(5),
+		[]byte("it's\na note"), Temporal("2020-06-01"), Temporal("01:30:00"), []byte("A1"),
+		int64(-5), 0.25, Temporal("2020"),
+	}, rows[0].Rows[0])
+	assert.Equal(t, Row{
+		int64(-1), nil, Decimal("-3.05"), int64(7),
+		Temporal("2020-06-01 10:00:01.000"), Timestamp("0"), Bits(3), Bits(0),
+		nil, Temporal("2020-01-31"), Temporal("-01:30:00"), []byte{0xff, 0xfe},
+		int64(9007199254740993), -1.5, Temporal("1999"),
+	}, rows[0].Rows[1])
+
+	assert.True(t, rows[2].IsUpdate())
+	assert.Len(t, rows[2].Rows, 2)
+	assert.Equal(t, []byte("bob"), rows[2].Rows[1][1])
+}
+
+func TestReadFileErrors(t *testing.T) {
+	_, err := NewReader(bytes.NewReader([]byte("not a binlog")), "x")
+	assert.Error(t, err)
+
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "mysql-bin.000001"))
+	assert.NoError(t, err)
+	// 修改第一个行事件中的数据，校验和不再匹配
+	data[300] ^= 0xff
+	r, err := NewReader(bytes.NewReader(data), "mysql-bin.000001")
+	assert.NoError(t, err)
+	for err == nil {
+		_, err = r.Next()
+	}
+	assert.Contains(t, err.Error(), "校验和错误")
+
+	// 文件被截断
+	data, _ = ioutil.ReadFile(filepath.Join("testdata", "mysql-bin.000001"))
+	r, _ = NewReader(bytes.NewReader(data[:400]), "mysql-bin.000001")
+	for err = nil; err == nil; {
+		_, err = r.Next()
+	}
+	assert.Contains(t, err.Error(), "unexpected EOF")
+}
@@ -0,0 +1,55 @@
+package binlog
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+)
+
+// Dump 使用mysqlbinlog从服务器读取原始格式的binlog文件保存到目录中，
+// defaults是包含用户名和密码的选项文件，避免密码出现在进程列表里
+func Dump(path, defaults, host string, port uint16, dir string, files []string) error {
+	args := []string{
+		fmt.Sprintf("--defaults-extra-file=%s", defaults),
+		"--read-from-remote-server",
+		"--raw",
+		fmt.Sprintf("--host=%s", host),
+		fmt.Sprintf("--port=%d", port),
+		fmt.Sprintf("--result-file=%s%c", dir, filepath.Separator),
+	}
+	args = append(args, files...)
+
+	var buf bytes.Buffer
+	cmd := exec.Command(path, args...)
+	cmd.Stdout = &buf
+	cmd.Stderr = &buf
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("mysqlbinlog执行失败, %s, %s", err.Error(), bytes.TrimSpace(buf.Bytes()))
+	}
+	return nil
+}
+
+// Collect 读取目录中的binlog文件，把行事件分配到产生它们的语句的范围中，
+// 返回的结果和ranges一一对应
+func Collect(dir string, files []string, ranges []*Range) ([][]*RowsEvent, error) {
+	result := make([][]*RowsEvent, len(ranges))
+	for _, file := range files {
+		err := ReadFile(filepath.Join(dir, file), func(e *Event) error {
+			if e.Rows == nil {
+				return nil
+			}
+			for i, r := range ranges {
+				if r.Contains(e) {
+					result[i] = append(result[i], e.Rows)
+					break
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
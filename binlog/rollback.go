@@ -0,0 +1,213 @@
+package binlog
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Range 一条语句执行前后的binlog位置和执行语句的连接的线程号
+type Range struct {
+	Start  Position
+	End    Position
+	Thread uint32
+}
+
+// Contains 事件是否是语句在执行期间产生的
+func (r *Range) Contains(e *Event) bool {
+	if e.Thread != r.Thread {
+		return false
+	}
+	// LogPos是事件结束的位置
+	pos := e.Position()
+	return pos.Compare(r.Start) > 0 && pos.Compare(r.End) <= 0
+}
+
+// Schema 生成回滚语句需要的表结构，列的顺序和表定义一致
+type Schema struct {
+	Columns    []string
+	Unsigned   []bool
+	PrimaryKey []int // 主键列的下标，没有主键时使用全部列定位行
+}
+
+// SchemaFunc 获取表结构
+type SchemaFunc func(database, table string) (*Schema, error)
+
+// Rollback 按执行的相反顺序生成回滚语句，INSERT对应DELETE，DELETE对应INSERT，UPDATE对应前后交换的UPDATE
+func Rollback(events []*RowsEvent, schemaOf SchemaFunc) ([]string, error) {
+	sqls := []string{}
+	schemas := map[string]*Schema{}
+	for i := len(events) - 1; i >= 0; i-- {
+		e := events[i]
+		key := e.Table.Database + "." + e.Table.Table
+		schema, ok := schemas[key]
+		if !ok {
+			var err error
+			if schema, err = schemaOf(e.Table.Database, e.Table.Table); err != nil {
+				return nil, err
+			}
+			if len(schema.Columns) != len(e.Table.Types) {
+				return nil, fmt.Errorf("表%s的结构在执行之后发生了变化", key)
+			}
+			schemas[key] = schema
+		}
+		g := &generator{event: e, schema: schema}
+		switch {
+		case e.IsWrite():
+			for j := len(e.Rows) - 1; j >= 0; j-- {
+				sqls = append(sqls, g.delete(e.Rows[j]))
+			}
+		case e.IsDelete():
+			for j := len(e.Rows) - 1; j >= 0; j-- {
+				sqls = append(sqls, g.insert(e.Rows[j]))
+			}
+		case e.IsUpdate():
+			for j := len(e.Rows) - 2; j >= 0; j -= 2 {
+				sqls = append(sqls, g.update(e.Rows[j], e.Rows[j+1]))
+			}
+		}
+	}
+	return sqls, nil
+}
+
+type generator struct {
+	event  *RowsEvent
+	schema *Schema
+}
+
+func (g *generator) table() string {
+	return fmt.Sprintf("%s.%s", Quote(g.event.Table.Database), Quote(g.event.Table.Table))
+}
+
+// columns 事件中出现的列
+func columns(present []bool) []int {
+	cols := []int{}
+	for i, ok := range present {
+		if ok {
+			cols = append(cols, i)
+		}
+	}
+	return cols
+}
+
+// where 定位一行的条件，有主键并且主键的值完整时只使用主键
+func (g *generator) where(row Row, present []bool) string {
+	cols := []int{}
+	for _, i := range g.schema.PrimaryKey {
+		if i >= len(row) || !present[i] || row[i] == nil {
+			cols = nil
+			break
+		}
+		cols = append(cols, i)
+	}
+	if len(cols) == 0 {
+		cols = columns(present)
+	}
+	conds := []string{}
+	for _, i := range cols {
+		if row[i] == nil {
+			conds = append(conds, fmt.Sprintf("%s IS NULL", Quote(g.schema.Columns[i])))
+		} else {
+			conds = append(conds, fmt.Sprintf("%s = %s", Quote(g.schema.Columns[i]), g.value(i, row[i])))
+		}
+	}
+	return strings.Join(conds, " AND ")
+}
+
+func (g *generator) delete(row Row) string {
+	return fmt.Sprintf("DELETE FROM %s WHERE %s LIMIT 1;", g.table(), g.where(row, g.event.Present))
+}
+
+func (g *generator) insert(row Row) string {
+	names, values := []string{}, []string{}
+	for _, i := range columns(g.event.Present) {
+		names = append(names, Quote(g.schema.Columns[i]))
+		values = append(values, g.value(i, row[i]))
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);", g.table(), strings.Join(names, ", "), strings.Join(values, ", "))
+}
+
+// update 修改后的数据作为条件，修改前的数据作为新值
+func (g *generator) update(before, after Row) string {
+	sets := []string{}
+	for _, i := range columns(g.event.Present) {
+		sets = append(sets, fmt.Sprintf("%s = %s", Quote(g.schema.Columns[i]), g.value(i, before[i])))
+	}
+	return fmt.Sprintf("UPDATE %s SET %s WHERE %s LIMIT 1;", g.table(), strings.Join(sets, ", "), g.where(after, g.event.PresentAfter))
+}
+
+// value 列的值在SQL中的写法
+func (g *generator) value(i int, v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case int64:
+		if g.schema.Unsigned[i] {
+			return strconv.FormatUint(unsigned(v, g.event.Table.Types[i]), 10)
+		}
+		return strconv.FormatInt(v, 10)
+	case float64:
+		if g.event.Table.Types[i] == TypeFloat {
+			return strconv.FormatFloat(v, 'g', -1, 32)
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case Decimal:
+		return string(v)
+	case Bits:
+		return strconv.FormatUint(uint64(v), 10)
+	case Temporal:
+		return "'" + string(v) + "'"
+	case Timestamp:
+		if strings.Trim(string(v), "0.") == "" {
+			return "'0000-00-00 00:00:00'"
+		}
+		return fmt.Sprintf("FROM_UNIXTIME(%s)", string(v))
+	case []byte:
+		return Literal(v)
+	}
+	return fmt.Sprintf("'%v'", v)
+}
+
+// unsigned 有符号数按列的宽度转换为无符号数
+func unsigned(v int64, tp byte) uint64 {
+	switch tp {
+	case TypeTiny:
+		return uint64(uint8(v))
+	case TypeShort:
+		return uint64(uint16(v))
+	case TypeInt24:
+		return uint64(v) & 0xffffff
+	case TypeLong:
+		return uint64(uint32(v))
+	}
+	return uint64(v)
+}
+
+// Literal 字节串在SQL中的写法，不是合法UTF-8的二进制数据使用十六进制
+func Literal(v []byte) string {
+	if utf8.Valid(v) {
+		return "'" + escape(string(v)) + "'"
+	}
+	return "0x" + hex.EncodeToString(v)
+}
+
+// Quote 库名、表名和列名加上反引号
+func Quote(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
+
+var escaper = strings.NewReplacer(
+	"\x00", `\0`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\x1a", `\Z`,
+	"'", `\'`,
+	`"`, `\"`,
+	`\`, `\\`,
+)
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
@@ -0,0 +1,100 @@
+package binlog
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func schemaOf(database, table string) (*Schema, error) {
+	if database != "db1" || table != "t1" {
+		return nil, fmt.Errorf("表%s.%s不存在", database, table)
+	}
+	unsigned := make([]bool, 15)
+	unsigned[0], unsigned[3] = true, true
+	return &Schema{
+		Columns:    []string{"id", "name", "price", "qty", "created", "updated", "status", "flags", "note", "day", "dur", "code", "big", "ratio", "yr"},
+		Unsigned:   unsigned,
+		PrimaryKey: []int{0},
+	}, nil
+}
+
+func TestRollback(t *testing.T) {
+	insert := &Range{Start: Position{"mysql-bin.000001", 154}, End: Position{"mysql-bin.000001", 526}, Thread: 42}
+	// 其他连接的事务在执行期间提交，按线程号排除
+	update := &Range{Start: Position{"mysql-bin.000001", 526}, End: Position{"mysql-bin.000001", 1308}, Thread: 42}
+	remove := &Range{Start: Position{"mysql-bin.000001", 1308}, End: Position{"mysql-bin.000002", 456}, Thread: 42}
+	files := Files(insert.Start, remove.End)
+	events, err := Collect("testdata", files, []*Range{insert, update, remove})
+	assert.NoError(t, err)
+	if !assert.Len(t, events, 3) {
+		return
+	}
+	assert.Len(t, events[0], 1)
+	assert.Len(t, events[1], 1)
+	assert.Len(t, events[2], 1)
+
+	sqls, err := Rollback(events[0], schemaOf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"DELETE FROM `db1`.`t1` WHERE `id` = 4294967295 LIMIT 1;",
+		"DELETE FROM `db1`.`t1` WHERE `id` = 1 LIMIT 1;",
+	}, sqls)
+
+	sqls, err = Rollback(events[1], schemaOf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"UPDATE `db1`.`t1` SET `id` = 1, `name` = 'alice', `price` = 12.50, `qty` = 200, " +
+			"`created` = '2020-06-01 10:00:00.123', `updated` = FROM_UNIXTIME(1591005600), `status` = 1, `flags` = 5, " +
+			"`note` = 'it\\'s\\na note', `day` = '2020-06-01', `dur` = '01:30:00', `code` = 'A1', " +
+			"`big` = -5, `ratio` = 0.25, `yr` = '2020' WHERE `id` = 1 LIMIT 1;",
+	}, sqls)
+
+	sqls, err = Rollback(events[2], schemaOf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"INSERT INTO `db1`.`t1` (`id`, `name`, `price`, `qty`, `created`, `updated`, `status`, `flags`, " +
+			"`note`, `day`, `dur`, `code`, `big`, `ratio`, `yr`) VALUES (4294967295, NULL, -3.05, 7, " +
+			"'2020-06-01 10:00:01.000', '0000-00-00 00:00:00', 3, 0, NULL, '2020-01-31', '-01:30:00', 0xfffe, " +
+			"9007199254740993, -1.5, '1999');",
+	}, sqls)
+
+	// 多个事件按相反的顺序回滚
+	sqls, err = Rollback(append(events[0], events[2]...), schemaOf)
+	assert.NoError(t, err)
+	assert.Len(t, sqls, 3)
+	assert.Contains(t, sqls[0], "INSERT INTO")
+	assert.Contains(t, sqls[2], "WHERE `id` = 1 LIMIT 1;")
+}
+
+func TestRollbackWithoutPrimaryKey(t *testing.T) {
+	events, err := Collect("testdata", []string{"mysql-bin.000002"}, []*Range{
+		{Start: Position{"mysql-bin.000002", 4}, End: Position{"mysql-bin.000002", 456}, Thread: 42},
+	})
+	assert.NoError(t, err)
+	sqls, err := Rollback(events[0], func(database, table string) (*Schema, error) {
+		schema, _ := schemaOf(database, table)
+		schema.PrimaryKey = nil
+		return schema, nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, sqls, 1)
+
+	// 反向执行INSERT时没有主键，使用全部列定位
+	events, _ = Collect("testdata", []string{"mysql-bin.000001"}, []*Range{
+		{Start: Position{"mysql-bin.000001", 154}, End: Position{"mysql-bin.000001", 526}, Thread: 42},
+	})
+	sqls, err = Rollback(events[0], func(database, table string) (*Schema, error) {
+		schema, _ := schemaOf(database, table)
+		schema.PrimaryKey = nil
+		return schema, nil
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, sqls[0], "WHERE `id` = 4294967295 AND `name` IS NULL AND `price` = -3.05")
+
+	_, err = Rollback(events[0], func(database, table string) (*Schema, error) {
+		return &Schema{Columns: []string{"id"}, Unsigned: []bool{true}}, nil
+	})
+	assert.Error(t, err)
+}
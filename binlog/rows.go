@@ -0,0 +1,482 @@
+package binlog
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// 列的类型，来自TABLE_MAP_EVENT
+const (
+	TypeDecimal    = 0
+	TypeTiny       = 1
+	TypeShort      = 2
+	TypeLong       = 3
+	TypeFloat      = 4
+	TypeDouble     = 5
+	TypeNull       = 6
+	TypeTimestamp  = 7
+	TypeLongLong   = 8
+	TypeInt24      = 9
+	TypeDate       = 10
+	TypeTime       = 11
+	TypeDatetime   = 12
+	TypeYear       = 13
+	TypeNewDate    = 14
+	TypeVarchar    = 15
+	TypeBit        = 16
+	TypeTimestamp2 = 17
+	TypeDatetime2  = 18
+	TypeTime2      = 19
+	TypeJSON       = 245
+	TypeNewDecimal = 246
+	TypeEnum       = 247
+	TypeSet        = 248
+	TypeTinyBlob   = 249
+	TypeMediumBlob = 250
+	TypeLongBlob   = 251
+	TypeBlob       = 252
+	TypeVarString  = 253
+	TypeString     = 254
+	TypeGeometry   = 255
+)
+
+// TableMap TABLE_MAP_EVENT中表的定义，行事件中通过ID引用
+type TableMap struct {
+	ID       uint64
+	Database string
+	Table    string
+	Types    []byte
+	Meta     []uint16
+	Nullable []bool
+}
+
+// Row 一行数据，NULL和没有出现在事件中的列都是nil
+type Row []interface{}
+
+// RowsEvent 行事件，Type是WRITE/UPDATE/DELETE，UPDATE的Rows中前后两行分别是修改前和修改后的数据，
+// Present标记了出现在事件中的列，UPDATE的修改后的数据使用PresentAfter
+type RowsEvent struct {
+	Type         byte
+	Table        *TableMap
+	Rows         []Row
+	Present      []bool
+	PresentAfter []bool
+}
+
+// IsWrite 是否是INSERT产生的事件
+func (e *RowsEvent) IsWrite() bool {
+	return e.Type == WriteRowsEventV1 || e.Type == WriteRowsEventV2
+}
+
+// IsUpdate 是否是UPDATE产生的事件
+func (e *RowsEvent) IsUpdate() bool {
+	return e.Type == UpdateRowsEventV1 || e.Type == UpdateRowsEventV2
+}
+
+// IsDelete 是否是DELETE产生的事件
+func (e *RowsEvent) IsDelete() bool {
+	return e.Type == DeleteRowsEventV1 || e.Type == DeleteRowsEventV2
+}
+
+// Decimal DECIMAL类型的值，保存为十进制的字符串
+type Decimal string
+
+// Temporal 日期和时间类型的值，保存为MySQL的字符串格式
+type Temporal string
+
+// Timestamp TIMESTAMP类型的值，保存为UNIX时间戳的秒数，可能包含小数
+type Timestamp string
+
+// Bits BIT、ENUM和SET类型的值，ENUM是序号，SET是位图
+type Bits uint64
+
+// tableMap 解析TABLE_MAP_EVENT
+func (r *Reader) tableMap(body []byte) (*TableMap, error) {
+	d := &decoder{data: body}
+	m := &TableMap{}
+	if r.postHeaderLen(TableMapEvent, 8) == 6 {
+		m.ID = uint64(d.fixed(4))
+	} else {
+		m.ID = d.fixed(6)
+	}
+	d.skip(2) // flags
+	m.Database = d.name()
+	m.Table = d.name()
+	n := int(d.lenenc())
+	m.Types = d.bytes(n)
+	if d.err != nil {
+		return nil, d.err
+	}
+	metaLen := int(d.lenenc())
+	meta := &decoder{data: d.bytes(metaLen)}
+	m.Meta = make([]uint16, n)
+	for i, tp := range m.Types {
+		switch tp {
+		case TypeString, TypeNewDecimal:
+			// 高位字节在前，STRING是真实类型和长度，DECIMAL是精度和小数位数
+			b := meta.bytes(2)
+			m.Meta[i] = uint16(b[0])<<8 | uint16(b[1])
+		case TypeVarchar, TypeVarString, TypeBit:
+			m.Meta[i] = uint16(meta.fixed(2))
+		case TypeBlob, TypeDouble, TypeFloat, TypeGeometry, TypeJSON,
+			TypeTime2, TypeDatetime2, TypeTimestamp2:
+			m.Meta[i] = uint16(meta.fixed(1))
+		}
+	}
+	nulls := d.bytes((n + 7) / 8)
+	m.Nullable = bitmap(nulls, n)
+	// 之后是8.0的可选元数据，回滚不需要
+	if d.err != nil {
+		return nil, d.err
+	}
+	if meta.err != nil {
+		return nil, meta.err
+	}
+	return m, nil
+}
+
+// rows 解析WRITE/UPDATE/DELETE_ROWS_EVENT
+func (r *Reader) rows(tp byte, body []byte) (*RowsEvent, error) {
+	d := &decoder{data: body}
+	var id uint64
+	if r.postHeaderLen(tp, 8) == 6 {
+		id = uint64(d.fixed(4))
+	} else {
+		id = d.fixed(6)
+	}
+	d.skip(2) // flags
+	if tp >= WriteRowsEventV2 {
+		// extra_data的长度包含自身的2个字节
+		d.skip(int(d.fixed(2)) - 2)
+	}
+	m, ok := r.tables[id]
+	if !ok {
+		return nil, fmt.Errorf("没有找到表(id=%d)的定义", id)
+	}
+	e := &RowsEvent{Type: tp, Table: m}
+
+	n := int(d.lenenc())
+	if n != len(m.Types) {
+		return nil, fmt.Errorf("表%s.%s的列数不一致", m.Database, m.Table)
+	}
+	present := [][]byte{d.bytes((n + 7) / 8)}
+	e.Present = bitmap(present[0], n)
+	if e.IsUpdate() {
+		present = append(present, d.bytes((n+7)/8))
+		e.PresentAfter = bitmap(present[1], n)
+	}
+	for d.err == nil && d.pos < len(d.data) {
+		for _, bitmap := range present {
+			row, err := d.row(m, bitmap)
+			if err != nil {
+				return nil, fmt.Errorf("表%s.%s %s", m.Database, m.Table, err.Error())
+			}
+			e.Rows = append(e.Rows, row)
+		}
+	}
+	return e, d.err
+}
+
+// decoder 按顺序读取事件的内容，越界时记录错误并返回零值
+type decoder struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (d *decoder) bytes(n int) []byte {
+	if d.err != nil || n < 0 || d.pos+n > len(d.data) {
+		if d.err == nil {
+			d.err = fmt.Errorf("事件的长度错误")
+		}
+		// 出错之后返回零值，调用方在读取结束时检查错误
+		if n < 0 || n > 8192 {
+			n = 8192
+		}
+		return make([]byte, n)
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b
+}
+
+func (d *decoder) skip(n int) {
+	d.bytes(n)
+}
+
+// fixed 小端的n字节无符号整数
+func (d *decoder) fixed(n int) uint64 {
+	var v uint64
+	for i, b := range d.bytes(n) {
+		v |= uint64(b) << (8 * uint(i))
+	}
+	return v
+}
+
+// big 大端的n字节无符号整数
+func (d *decoder) big(n int) uint64 {
+	var v uint64
+	for _, b := range d.bytes(n) {
+		v = v<<8 | uint64(b)
+	}
+	return v
+}
+
+// lenenc 长度编码的整数
+func (d *decoder) lenenc() uint64 {
+	b := d.bytes(1)[0]
+	switch b {
+	case 0xfc:
+		return d.fixed(2)
+	case 0xfd:
+		return d.fixed(3)
+	case 0xfe:
+		return d.fixed(8)
+	}
+	return uint64(b)
+}
+
+// name 1字节长度、内容和结尾的0
+func (d *decoder) name() string {
+	s := string(d.bytes(int(d.fixed(1))))
+	d.skip(1)
+	return s
+}
+
+func bitSet(bitmap []byte, i int) bool {
+	return bitmap[i/8]&(1<<uint(i%8)) != 0
+}
+
+func bitmap(b []byte, n int) []bool {
+	bits := make([]bool, n)
+	for i := range bits {
+		bits[i] = bitSet(b, i)
+	}
+	return bits
+}
+
+// row 解析一行数据，bitmap标记了出现在事件中的列
+func (d *decoder) row(m *TableMap, bitmap []byte) (Row, error) {
+	count := 0
+	for i := range m.Types {
+		if bitSet(bitmap, i) {
+			count++
+		}
+	}
+	nulls := d.bytes((count + 7) / 8)
+	row := make(Row, len(m.Types))
+	k := 0
+	for i, tp := range m.Types {
+		if !bitSet(bitmap, i) {
+			continue
+		}
+		if bitSet(nulls, k) {
+			k++
+			continue
+		}
+		k++
+		v, err := d.value(tp, m.Meta[i])
+		if err != nil {
+			return nil, err
+		}
+		row[i] = v
+	}
+	return row, d.err
+}
+
+// value 解析一列的值，整数统一为有符号数，是否无符号由生成回滚语句时根据表结构转换
+func (d *decoder) value(tp byte, meta uint16) (interface{}, error) {
+	length := 0
+	if tp == TypeString && meta >= 256 {
+		b0, b1 := byte(meta>>8), byte(meta&0xff)
+		if b0&0x30 != 0x30 {
+			// 长度超过255时高位保存在真实类型中
+			length = int(uint16(b1) | uint16((b0&0x30)^0x30)<<4)
+			tp = b0 | 0x30
+		} else {
+			length = int(b1)
+			tp = b0
+		}
+	} else if tp == TypeString {
+		length = int(meta)
+	}
+
+	switch tp {
+	case TypeTiny:
+		return int64(int8(d.fixed(1))), nil
+	case TypeShort:
+		return int64(int16(d.fixed(2))), nil
+	case TypeInt24:
+		v := int64(d.fixed(3))
+		if v&0x800000 != 0 {
+			v -= 0x1000000
+		}
+		return v, nil
+	case TypeLong:
+		return int64(int32(d.fixed(4))), nil
+	case TypeLongLong:
+		return int64(d.fixed(8)), nil
+	case TypeFloat:
+		return float64(math.Float32frombits(uint32(d.fixed(4)))), nil
+	case TypeDouble:
+		return math.Float64frombits(d.fixed(8)), nil
+	case TypeNewDecimal:
+		return d.decimal(int(meta>>8), int(meta&0xff)), nil
+	case TypeYear:
+		y := d.fixed(1)
+		if y == 0 {
+			return Temporal("0000"), nil
+		}
+		return Temporal(fmt.Sprintf("%04d", y+1900)), nil
+	case TypeDate, TypeNewDate:
+		v := d.fixed(3)
+		return Temporal(fmt.Sprintf("%04d-%02d-%02d", v>>9, (v>>5)&15, v&31)), nil
+	case TypeTime:
+		v := d.fixed(3)
+		return Temporal(fmt.Sprintf("%02d:%02d:%02d", v/10000, v%10000/100, v%100)), nil
+	case TypeTimestamp:
+		return Timestamp(fmt.Sprintf("%d", d.fixed(4))), nil
+	case TypeDatetime:
+		v := d.fixed(8)
+		date, clock := v/1000000, v%1000000
+		return Temporal(fmt.Sprintf("%04d-%02d-%02d %02d:%02d:%02d",
+			date/10000, date%10000/100, date%100, clock/10000, clock%10000/100, clock%100)), nil
+	case TypeTimestamp2:
+		sec := d.big(4)
+		return Timestamp(fmt.Sprintf("%d%s", sec, d.fraction(int(meta)))), nil
+	case TypeDatetime2:
+		return d.datetime2(int(meta)), nil
+	case TypeTime2:
+		return d.time2(int(meta)), nil
+	case TypeBit:
+		bits := int(meta>>8)*8 + int(meta&0xff)
+		return Bits(d.big((bits + 7) / 8)), nil
+	case TypeEnum:
+		return Bits(d.fixed(length)), nil
+	case TypeSet:
+		return Bits(d.fixed(length)), nil
+	case TypeVarchar, TypeVarString:
+		if meta < 256 {
+			return d.bytes(int(d.fixed(1))), nil
+		}
+		return d.bytes(int(d.fixed(2))), nil
+	case TypeString:
+		if length < 256 {
+			return d.bytes(int(d.fixed(1))), nil
+		}
+		return d.bytes(int(d.fixed(2))), nil
+	case TypeBlob, TypeGeometry:
+		return d.bytes(int(d.fixed(int(meta)))), nil
+	}
+	return nil, fmt.Errorf("不支持的列类型%d", tp)
+}
+
+// fraction 时间类型的小数秒，dec是小数位数，返回包含小数点的字符串
+func (d *decoder) fraction(dec int) string {
+	var usec uint64
+	switch dec {
+	case 1, 2:
+		usec = d.big(1) * 10000
+	case 3, 4:
+		usec = d.big(2) * 100
+	case 5, 6:
+		usec = d.big(3)
+	default:
+		return ""
+	}
+	return "." + fmt.Sprintf("%06d", usec)[:dec]
+}
+
+// datetime2 5.6.4开始的DATETIME格式，大端保存，年月合并为year*13+month
+func (d *decoder) datetime2(dec int) Temporal {
+	v := d.big(5) - 0x8000000000
+	frac := d.fraction(dec)
+	ymd, hms := v>>17, v&(1<<17-1)
+	ym := ymd >> 5
+	return Temporal(fmt.Sprintf("%04d-%02d-%02d %02d:%02d:%02d%s",
+		ym/13, ym%13, ymd&31, hms>>12, (hms>>6)&63, hms&63, frac))
+}
+
+// time2 5.6.4开始的TIME格式，负数的小数部分以补码保存
+func (d *decoder) time2(dec int) Temporal {
+	var packed int64
+	switch dec {
+	case 1, 2:
+		intpart, frac := int64(d.big(3))-0x800000, int64(d.big(1))
+		if intpart < 0 && frac != 0 {
+			intpart++
+			frac -= 0x100
+		}
+		packed = intpart<<24 + frac*10000
+	case 3, 4:
+		intpart, frac := int64(d.big(3))-0x800000, int64(d.big(2))
+		if intpart < 0 && frac != 0 {
+			intpart++
+			frac -= 0x10000
+		}
+		packed = intpart<<24 + frac*100
+	case 5, 6:
+		packed = int64(d.big(6)) - 0x800000000000
+	default:
+		packed = (int64(d.big(3)) - 0x800000) << 24
+	}
+	sign := ""
+	if packed < 0 {
+		sign, packed = "-", -packed
+	}
+	hms, usec := packed>>24, packed%(1<<24)
+	s := fmt.Sprintf("%s%02d:%02d:%02d", sign, (hms>>12)%(1<<10), (hms>>6)%64, hms%64)
+	if dec > 0 {
+		s += "." + fmt.Sprintf("%06d", usec)[:dec]
+	}
+	return Temporal(s)
+}
+
+// decimal 解析DECIMAL，整数和小数部分各自按9位十进制一组保存为4字节，不足9位的部分按位数压缩，
+// 负数时所有字节取反，第一个字节的最高位是符号位
+func (d *decoder) decimal(precision, scale int) Decimal {
+	digitsBytes := []int{0, 1, 1, 2, 2, 3, 3, 4, 4, 4}
+	intg := precision - scale
+	intg0, frac0 := intg/9, scale/9
+	intgx, fracx := intg-intg0*9, scale-frac0*9
+	size := intg0*4 + digitsBytes[intgx] + frac0*4 + digitsBytes[fracx]
+	buf := append([]byte{}, d.bytes(size)...)
+	if size == 0 {
+		return "0"
+	}
+
+	negative := buf[0]&0x80 == 0
+	buf[0] ^= 0x80
+	if negative {
+		for i := range buf {
+			buf[i] ^= 0xff
+		}
+	}
+	b := &decoder{data: buf}
+	var sb strings.Builder
+	if intgx > 0 {
+		fmt.Fprintf(&sb, "%d", b.big(digitsBytes[intgx]))
+	}
+	for i := 0; i < intg0; i++ {
+		fmt.Fprintf(&sb, "%09d", b.big(4))
+	}
+	s := strings.TrimLeft(sb.String(), "0")
+	if s == "" {
+		s = "0"
+	}
+	if scale > 0 {
+		sb.Reset()
+		for i := 0; i < frac0; i++ {
+			fmt.Fprintf(&sb, "%09d", b.big(4))
+		}
+		if fracx > 0 {
+			fmt.Fprintf(&sb, "%0*d", fracx, b.big(digitsBytes[fracx]))
+		}
+		s += "." + sb.String()
+	}
+	if negative {
+		s = "-" + s
+	}
+	return Decimal(s)
+}
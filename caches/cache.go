@@ -40,6 +40,12 @@ func Init() {
 	log.Info("[I] #9 Templates...")
 	TemplatesMap.Init()
 
+	log.Info("[I] #10 Rule profiles...")
+	RuleProfilesMap.Init()
+
+	log.Info("[I] #11 Waivers...")
+	WaiversMap.Init()
+
 	log.Info("[I] cache done")
 
 	LoopInit()
@@ -62,6 +68,8 @@ func LoopInit() {
 			ClustersMap.Init()
 			OptionsMap.Init()
 			RulesMap.Init()
+			RuleProfilesMap.Init()
+			WaiversMap.Init()
 			TemplatesMap.Init()
 		}
 	}()
@@ -126,3 +126,39 @@ func (c *SafeClustersMap) Init() {
 
 	c.M = m
 }
+
+// Replace 用新的元素替换缓存中群集编号相同的元素
+func (c *SafeClustersMap) Replace(item *models.Cluster) {
+	c.Lock()
+	defer c.Unlock()
+	for i, cluster := range c.M {
+		if cluster.ClusterID == item.ClusterID {
+			c.M[i] = item
+			break
+		}
+	}
+}
+
+// RefreshVersions 重新读取每个群集的版本，版本发生变化时保存到数据库。
+// 缓存中的群集会被其他协程读取，版本在副本上读取，变化后替换缓存中的元素
+func (c *SafeClustersMap) RefreshVersions(passwd func(*models.Cluster) []byte) {
+	clusters := c.Map(func(cluster *models.Cluster) *models.Cluster {
+		latest := *cluster
+		return &latest
+	})
+	for _, cluster := range clusters {
+		changed, err := cluster.DetectVersion(passwd)
+		if err != nil {
+			log.Warnf("[W] 群集(alias=%s)的版本获取失败，%s", cluster.Alias, err.Error())
+			continue
+		}
+		if !changed {
+			continue
+		}
+		if _, err := g.Engine.ID(cluster.ClusterID).NoVersionCheck().Cols("server_version", "version_comment").Update(cluster); err != nil {
+			log.Errorf("[E] %s", err.Error())
+			continue
+		}
+		c.Replace(cluster)
+	}
+}
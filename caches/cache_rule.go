@@ -13,6 +13,8 @@ import (
 type SafeRulesMap struct {
 	sync.RWMutex
 	M []*models.Rule
+	// Resolved 规则是否有实现，没有实现的规则在加载时关闭执行位，为空时不检查
+	Resolved func(*models.Rule) bool
 }
 
 // RulesMap 规则缓存对象
@@ -87,6 +89,20 @@ func (c *SafeRulesMap) Init() {
 		log.Printf("查询数据表`%s`时发生一个错误:%s", "rules", err.Error())
 		return
 	}
+	if c.Resolved != nil {
+		for _, r := range m {
+			if !c.Resolved(r) {
+				r.Bitwise &^= 1
+			}
+		}
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.M = m
+}
+
+// Load 使用导出的规则替换缓存，离线审核时没有数据库可以读取
+func (c *SafeRulesMap) Load(m []*models.Rule) {
 	c.Lock()
 	defer c.Unlock()
 	c.M = m
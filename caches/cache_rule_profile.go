@@ -0,0 +1,104 @@
+package caches
+
+import (
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mia0x75/halo/g"
+	"github.com/mia0x75/halo/models"
+)
+
+// SafeRuleProfilesMap 线程安全的数据缓存对象
+type SafeRuleProfilesMap struct {
+	sync.RWMutex
+	M []*models.RuleProfile
+}
+
+// RuleProfilesMap 规则配置缓存对象
+var RuleProfilesMap = &SafeRuleProfilesMap{}
+
+// Count 返回缓存条数
+func (c *SafeRuleProfilesMap) Count() int {
+	c.RLock()
+	defer c.RUnlock()
+	return len(c.M)
+}
+
+// Any returns the element if one of the element in the sliece satisfies the predicate f.
+func (c *SafeRuleProfilesMap) Any(f func(*models.RuleProfile) bool) *models.RuleProfile {
+	c.RLock()
+	defer c.RUnlock()
+	for _, v := range c.M {
+		if f(v) {
+			return v
+		}
+	}
+	return nil
+}
+
+// All returns all of the slice.
+func (c *SafeRuleProfilesMap) All() []*models.RuleProfile {
+	c.RLock()
+	defer c.RUnlock()
+	return c.M
+}
+
+// Resolve 返回群集上某个数据库实际生效的规则配置，数据库上的配置优先于群集上的配置，
+// 都没有时返回nil，使用规则本身的设置
+func (c *SafeRuleProfilesMap) Resolve(clusterID uint, database string) *models.RuleProfile {
+	c.RLock()
+	defer c.RUnlock()
+	var profile *models.RuleProfile
+	for _, v := range c.M {
+		for _, binding := range v.Bindings {
+			if binding.ClusterID != clusterID {
+				continue
+			}
+			if binding.Database == database && database != "" {
+				return v
+			}
+			if binding.Database == "" {
+				profile = v
+			}
+		}
+	}
+	return profile
+}
+
+// Init 缓存初始化，规则配置和覆盖、适用范围一起加载
+func (c *SafeRuleProfilesMap) Init() {
+	var m []*models.RuleProfile
+	var overrides []*models.RuleOverride
+	var bindings []*models.RuleBinding
+
+	if err := g.Engine.Find(&m); err != nil {
+		log.Printf("查询数据表`%s`时发生一个错误:%s", "rule_profiles", err.Error())
+		return
+	}
+	if err := g.Engine.Find(&overrides); err != nil {
+		log.Printf("查询数据表`%s`时发生一个错误:%s", "rule_overrides", err.Error())
+		return
+	}
+	if err := g.Engine.Find(&bindings); err != nil {
+		log.Printf("查询数据表`%s`时发生一个错误:%s", "rule_bindings", err.Error())
+		return
+	}
+	profiles := map[uint]*models.RuleProfile{}
+	for _, profile := range m {
+		profiles[profile.ProfileID] = profile
+	}
+	for _, override := range overrides {
+		if profile, ok := profiles[override.ProfileID]; ok {
+			profile.Overrides = append(profile.Overrides, override)
+		}
+	}
+	for _, binding := range bindings {
+		if profile, ok := profiles[binding.ProfileID]; ok {
+			profile.Bindings = append(profile.Bindings, binding)
+		}
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.M = m
+}
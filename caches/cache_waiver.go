@@ -0,0 +1,75 @@
+package caches
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mia0x75/halo/g"
+	"github.com/mia0x75/halo/models"
+)
+
+// SafeWaiversMap 线程安全的数据缓存对象
+type SafeWaiversMap struct {
+	sync.RWMutex
+	M []*models.Waiver
+}
+
+// WaiversMap 规则豁免缓存对象
+var WaiversMap = &SafeWaiversMap{}
+
+// Count 返回缓存条数
+func (c *SafeWaiversMap) Count() int {
+	c.RLock()
+	defer c.RUnlock()
+	return len(c.M)
+}
+
+// Any returns the element if one of the element in the sliece satisfies the predicate f.
+func (c *SafeWaiversMap) Any(f func(*models.Waiver) bool) *models.Waiver {
+	c.RLock()
+	defer c.RUnlock()
+	for _, v := range c.M {
+		if f(v) {
+			return v
+		}
+	}
+	return nil
+}
+
+// All returns all of the slice.
+func (c *SafeWaiversMap) All() []*models.Waiver {
+	c.RLock()
+	defer c.RUnlock()
+	return c.M
+}
+
+// Active 返回对工单有效的豁免，包括工单上的豁免和目标群集上未过期的豁免
+func (c *SafeWaiversMap) Active(ticketID uint, clusterID uint) []*models.Waiver {
+	c.RLock()
+	defer c.RUnlock()
+	now := uint(time.Now().Unix())
+	L := []*models.Waiver{}
+	for _, v := range c.M {
+		if v.Expired(now) {
+			continue
+		}
+		if (v.TicketID != 0 && v.TicketID == ticketID) || (v.TicketID == 0 && v.ClusterID == clusterID) {
+			L = append(L, v)
+		}
+	}
+	return L
+}
+
+// Init 缓存初始化
+func (c *SafeWaiversMap) Init() {
+	var m []*models.Waiver
+	if err := g.Engine.Find(&m); err != nil {
+		log.Printf("查询数据表`%s`时发生一个错误:%s", "waivers", err.Error())
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.M = m
+}
@@ -2,20 +2,23 @@ package cli
 
 import (
 	"bytes"
-	"database/sql"
 	"fmt"
 	"io/ioutil"
 	"os"
 
 	"github.com/go-xorm/core"
+	"github.com/mia0x75/parser/ast"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"xorm.io/xorm"
 
 	"github.com/mia0x75/halo/events"
+	"github.com/mia0x75/halo/executors"
 	"github.com/mia0x75/halo/g"
 	"github.com/mia0x75/halo/gqlapi"
 	"github.com/mia0x75/halo/models"
 	"github.com/mia0x75/halo/tools"
+	"github.com/mia0x75/halo/validate"
 )
 
 // executeCmd represents the execute command
@@ -75,7 +78,7 @@ L:
 		}
 
 		stmts := []*models.Statement{}
-		if err = g.Engine.Where("`ticket_id` = ?", ticket.TicketID).Find(&stmts); err != nil {
+		if err = g.Engine.Where("`ticket_id` = ?", ticket.TicketID).Asc("sequence").Find(&stmts); err != nil {
 			err = fmt.Errorf("错误代码: 1500, 错误信息: %s", err.Error())
 			break
 		}
@@ -92,21 +95,111 @@ L:
 			break
 		}
 
+		// 按语句的注释、群集的设置和表的大小选择执行方式，pt-osc的进度随时更新到语句上
+		selector := executors.NewSelector(cluster, ticket.Database, passwd(cluster))
+		selector.Engine = engine
+		selector.Progress = func(stmt *models.Statement) {
+			if _, err := g.Engine.ID(core.PK{stmt.TicketID, stmt.Sequence}).Cols("results").Update(stmt); err != nil {
+				log.Warnf("[W] 更新语句(uuid=%s)的执行进度失败: %s", stmt.UUID, err.Error())
+			}
+		}
+
+		if g.Config().Binlog.Enabled || g.Config().RowBackup.Enabled {
+			if e := g.InitBackupDB(); e != nil {
+				log.Warnf("[W] 连接备份库失败: %s", e.Error())
+			}
+		}
+
+		// 记录DML语句执行前后的binlog位置，执行结束后生成回滚语句
+		var recorder *executors.Recorder
+		if g.Config().Binlog.Enabled {
+			var e error
+			if recorder, e = executors.NewRecorder(cluster, ticket.Database, passwd(cluster), engine); e != nil {
+				log.Warnf("[W] 群集(uuid=%s)无法从binlog生成回滚语句: %s", cluster.UUID, e.Error())
+				recorder = nil
+			} else {
+				recorder.Backup = g.BackupEngine
+			}
+		}
+
+		// UPDATE和DELETE语句执行之前把将被修改的行复制到备份库
+		var backup *executors.RowBackup
+		if g.Config().RowBackup.Enabled {
+			if g.BackupEngine == nil {
+				log.Warnf("[W] 没有可用的备份库，工单(uuid=%s)不备份修改的行", ticket.UUID)
+			} else {
+				backup = executors.NewRowBackup(ticket.Database, engine, g.BackupEngine, g.Config().RowBackup.MaxSize)
+			}
+		}
+
+		// 执行之前的表结构，DDL语句的回滚语句根据执行前的结构生成，每执行一条语句模拟一次结构的变化
+		var schema *validate.Schema
+		if tables, e := cluster.Metadata(ticket.Database, passwd); e != nil {
+			log.Warnf("[W] 获取群集(uuid=%s)的表结构失败，无法生成DDL语句的回滚语句: %s", cluster.UUID, e.Error())
+		} else {
+			schema = validate.NewSchema(nil, tables)
+		}
+
 		ticket.Status = gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumDone]
 		for _, stmt := range stmts {
-			var result sql.Result
-			if result, err = engine.Exec(stmt.Content); err != nil {
+			// 继续执行失败的工单时，已经执行成功和审核人跳过的语句不再执行
+			if stmt.Status == gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumDone] || stmt.SkipReason != "" {
+				continue
+			}
+			var node ast.StmtNode
+			if nodes, e := validate.Parse(stmt.Content); e == nil && len(nodes) == 1 {
+				node = nodes[0]
+			}
+			reverse := ""
+			if schema != nil && node != nil {
+				var e error
+				if reverse, e = schema.Reverse(node, ticket.Database); e != nil {
+					log.Warnf("[W] 生成语句(uuid=%s)的回滚语句失败: %s", stmt.UUID, e.Error())
+				}
+			}
+			if backup != nil && executors.IsBackup(stmt) {
+				if count, truncated, e := backup.Save(stmt); e != nil {
+					log.Warnf("[W] 备份语句(uuid=%s)修改的行失败: %s", stmt.UUID, e.Error())
+				} else if truncated {
+					stmt.BackupTruncated = true
+					log.Warnf("[W] 语句(uuid=%s)修改的行超过%dMB，只备份了前%d行", stmt.UUID, g.Config().RowBackup.MaxSize, count)
+				}
+				// 根据执行之前的结构判断，生成恢复语句时拒绝修改了键的UPDATE，
+				// 语句无法解析或者结构未知时无法确认，同样拒绝
+				if node == nil && stmt.Type == gqlapi.StatementTypeEnumMap[gqlapi.StatementTypeEnumUpdate] ||
+					executors.ChangesKey(node, schema, ticket.Database) {
+					stmt.BackupKeyChanged = true
+				}
+			}
+			if recorder != nil {
+				recorder.Begin(stmt)
+			}
+			err = selector.Select(stmt).Execute(stmt)
+			if recorder != nil {
+				recorder.End(stmt)
+			}
+			if err == nil && node != nil {
+				if reverse != "" {
+					stmt.Rollback = reverse
+				}
+				if schema != nil {
+					if next := schema.Apply(node, ticket.Database); next != nil {
+						schema = next
+					}
+				}
+			}
+			if err != nil {
 				stmt.Status = gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumExecFailure]
-				stmt.Results = err.Error()
 				ticket.Status = gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumExecFailure]
 				buf.WriteString(stmt.Content)
 				err = fmt.Errorf("错误代码: 1500, 错误信息: %s", err.Error())
 				break
-			} else {
-				stmt.Status = gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumDone]
-				if ra, err := result.RowsAffected(); err == nil {
-					stmt.RowsAffected = uint(ra)
-				}
+			}
+			stmt.Status = gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumDone]
+		}
+		if recorder != nil {
+			if e := recorder.Rollback(); e != nil {
+				log.Warnf("[W] 工单(uuid=%s)生成回滚语句失败: %s", ticket.UUID, e.Error())
 			}
 		}
 		if err != nil {
@@ -128,8 +221,9 @@ L:
 			err = fmt.Errorf("错误代码: 1500, 错误信息: %s", err.Error())
 			break L
 		}
+		// gh-ost的回调在执行期间由halocli ghost直接写入，这里的hooks是执行之前读取的
 		for _, stmt := range stmts {
-			if _, err := session.ID(core.PK{stmt.TicketID, stmt.Sequence}).Update(stmt); err != nil {
+			if _, err := session.ID(core.PK{stmt.TicketID, stmt.Sequence}).Omit("hooks").Update(stmt); err != nil {
 				session.Rollback()
 				err = fmt.Errorf("错误代码: 1500, 错误信息: %s", err.Error())
 				break L
@@ -153,20 +247,3 @@ L:
 		os.Exit(0)
 	}
 }
-
-// 大表结构变更自动使用gh-ost
-// gh-ost -user=root \
-//        -port=3306 \
-//        -password=password \
-//        -host=127.0.0.1 \
-//        -allow-on-master \
-//        -max-load='Threads_running=100,Threads_connected=500' \
-//        -initially-drop-old-table \
-//        -initially-drop-ghost-table \
-//        -exact-rowcount=false \
-//        -database=starwars \ /* 目标数据库 */
-//        -table=t1 \          /* 目标表 */
-//        -alter="add ghostc1 varchar(20) default '' comment 'ghost测试'" \ /* 使用Specs的Restore */
-//        -execute \
-//        -hooks-enabled \
-//        -hooks-hint=${statementUUID}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
@@ -82,63 +83,77 @@ func ghost(cmd *cobra.Command, args []string) {
 			break
 		}
 
-		if onStartup {
-			fmt.Println("halocli ghost --onStartup invoked")
-			break
-		}
-		if onValidated {
-			fmt.Println("halocli ghost --onValidated invoked")
-			break
-		}
-		if onRowcountComplete {
-			fmt.Println("halocli ghost --onRowcountComplete invoked")
-			break
-		}
-		if onBeforeRowcopy {
-			fmt.Println("halocli ghost --onBeforeRowcopy invoked")
-			break
-		}
-		if onRowcopyComplete {
-			fmt.Println("halocli ghost --onRowcopyComplete invoked")
-			break
-		}
-		if onBeginPostponed {
-			fmt.Println("halocli ghost --onBeginPostponed invoked")
-			break
-		}
-		if onBeforeCutover {
-			fmt.Println("halocli ghost --onBeforeCutover invoked")
-			break
-		}
-		if onInteractiveCommand {
-			fmt.Println("halocli ghost --onInteractiveCommand invoked")
-			break
-		}
-		if onSuccess {
-			fmt.Println("halocli ghost --onSuccess invoked")
-			break
-		}
-		if onFailure {
-			fmt.Println("halocli ghost --onFailure invoked")
-			break
-		}
-		if onStatus {
+		hook := ""
+		switch {
+		case onStartup:
+			hook = models.HookStartup
+		case onValidated:
+			hook = models.HookValidated
+		case onRowcountComplete:
+			hook = models.HookRowcountComplete
+		case onBeforeRowcopy:
+			hook = models.HookBeforeRowCopy
+		case onRowcopyComplete:
+			hook = models.HookRowCopyComplete
+		case onBeginPostponed:
+			hook = models.HookBeginPostponed
+		case onBeforeCutover:
+			hook = models.HookBeforeCutOver
+		case onSuccess:
+			hook = models.HookSuccess
+		case onFailure:
+			hook = models.HookFailure
+		case onStatus:
 			if _, ok := os.LookupEnv("GH_OST_STATUS"); !ok {
 				break
 			}
-			fmt.Println("halocli ghost --onStatus invoked")
+			hook = models.HookStatus
+		case onInteractiveCommand:
+			fmt.Println("halocli ghost --onInteractiveCommand invoked")
+		case onStopReplication:
+			fmt.Println("halocli ghost --onStopReplication invoked")
+		case onStartReplication:
+			fmt.Println("halocli ghost --onStartReplication invoked")
+		}
+		if hook == "" {
 			break
 		}
-		if onStopReplication {
-			fmt.Println("halocli ghost --onStopReplication invoked")
+
+		if err = statement.AppendHook(hookEvent(hook)); err != nil {
 			break
 		}
-		if onStartReplication {
-			fmt.Println("halocli ghost --onStartReplication invoked")
+		if _, err = g.Engine.Where("`uuid` = ?", statementUUID).Cols("hooks").Update(statement); err != nil {
 			break
 		}
+		fmt.Printf("halocli ghost --on-%s recorded\n", hook)
 		break
 	}
+
+	// 回调失败会中止gh-ost，记录失败不影响变更的执行
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+}
+
+// hookEvent 从gh-ost传给回调的环境变量生成回调记录
+func hookEvent(hook string) *models.HookEvent {
+	env := func(name string) int64 {
+		v, _ := strconv.ParseInt(os.Getenv(name), 10, 64)
+		return v
+	}
+	event := &models.HookEvent{
+		Hook:      hook,
+		Copied:    env("GH_OST_COPIED_ROWS"),
+		Estimated: env("GH_OST_ESTIMATED_ROWS"),
+		Elapsed:   env("GH_OST_ELAPSED_SECONDS"),
+		Message:   os.Getenv("GH_OST_STATUS"),
+	}
+	if progress, err := strconv.ParseFloat(os.Getenv("GH_OST_PROGRESS"), 64); err == nil {
+		event.Progress = progress
+	} else if event.Estimated > 0 {
+		event.Progress = float64(event.Copied) * 100 / float64(event.Estimated)
+	}
+	return event
 }
 
 func isValidUUID(u string) bool {
@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/mia0x75/halo/g"
+	"github.com/mia0x75/halo/models"
+)
+
+// jsonStatement JSON报告中的单条语句
+type jsonStatement struct {
+	Sequence   uint16           `json:"sequence"`
+	Line       int              `json:"line"`
+	Content    string           `json:"content"`
+	Violations []*models.Clause `json:"violations"`
+}
+
+// jsonReport 生成JSON格式的报告
+func jsonReport(sc *script) []byte {
+	report := struct {
+		File       string          `json:"file"`
+		Statements []jsonStatement `json:"statements"`
+	}{
+		File:       sc.File,
+		Statements: []jsonStatement{},
+	}
+	for _, s := range sc.Stmts {
+		clauses := s.Violations.Clauses()
+		if clauses == nil {
+			clauses = []*models.Clause{}
+		}
+		report.Statements = append(report.Statements, jsonStatement{
+			Sequence:   s.Sequence,
+			Line:       sc.Lines[s],
+			Content:    s.Content,
+			Violations: clauses,
+		})
+	}
+	bs, _ := json.MarshalIndent(report, "", "  ")
+	return append(bs, '\n')
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+// junitReport 生成JUnit格式的报告，每条语句是一个用例，有错误级别的问题时用例失败，警告和被豁免的问题写入输出
+func junitReport(sc *script) []byte {
+	suite := junitTestSuite{
+		Name:  sc.File,
+		Tests: len(sc.Stmts),
+	}
+	for _, s := range sc.Stmts {
+		tc := junitTestCase{
+			Name:      fmt.Sprintf("#%d (line %d)", s.Sequence, sc.Lines[s]),
+			ClassName: sc.File,
+		}
+		var errors, warnings []string
+		for _, c := range s.Violations.Clauses() {
+			description := c.Description
+			if c.Rule != "" {
+				description = fmt.Sprintf("[%s] %s", c.Rule, c.Description)
+			}
+			if c.Waived {
+				// 被豁免的问题不影响用例的结果
+				warnings = append(warnings, fmt.Sprintf("[waived by %s] %s", c.WaivedBy, description))
+			} else if c.Level == 1 {
+				errors = append(errors, description)
+			} else {
+				warnings = append(warnings, description)
+			}
+		}
+		if len(errors) > 0 {
+			suite.Failures++
+			tc.Failure = &junitFailure{
+				Message: errors[0],
+				Type:    "error",
+				Text:    s.Content + "\n\n" + strings.Join(errors, "\n"),
+			}
+		}
+		if len(warnings) > 0 {
+			tc.SystemOut = strings.Join(warnings, "\n")
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	bs, _ := xml.MarshalIndent(suite, "", "  ")
+	return append([]byte(xml.Header), append(bs, '\n')...)
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	Level        string             `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifDriver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version,omitempty"`
+	InformationURI string `json:"informationUri"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+// sarifLevel 规则级别转换为SARIF的级别
+func sarifLevel(level uint8) string {
+	switch level {
+	case 1:
+		return "error"
+	case 2:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifReport 生成SARIF 2.1.0格式的报告
+func sarifReport(sc *script) []byte {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "halo",
+				Version:        g.Version,
+				InformationURI: "https://github.com/mia0x75/halo",
+			},
+		},
+		Results: []sarifResult{},
+	}
+	for _, s := range sc.Stmts {
+		for _, c := range s.Violations.Clauses() {
+			ruleID := c.Rule
+			if ruleID == "" {
+				ruleID = "halo"
+			}
+			// 描述没有定位时使用语句的起始行
+			region := sarifRegion{StartLine: c.Line, StartColumn: c.Column}
+			if region.StartLine == 0 {
+				region = sarifRegion{StartLine: sc.Lines[s]}
+			}
+			var suppressions []sarifSuppression
+			if c.Waived {
+				// 离线审核时只有内联注释的豁免
+				suppressions = []sarifSuppression{{Kind: "inSource", Justification: c.Reason}}
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:  ruleID,
+				Level:   sarifLevel(c.Level),
+				Message: sarifMessage{Text: c.Description},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: sc.File},
+						Region:           region,
+					},
+				}},
+				Suppressions: suppressions,
+			})
+		}
+	}
+	report := struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+	bs, _ := json.MarshalIndent(report, "", "  ")
+	return append(bs, '\n')
+}
@@ -0,0 +1,442 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/mia0x75/parser/driver"
+	"github.com/mia0x75/parser/format"
+	"github.com/spf13/cobra"
+
+	"github.com/mia0x75/halo/caches"
+	"github.com/mia0x75/halo/g"
+	"github.com/mia0x75/halo/models"
+	"github.com/mia0x75/halo/validate"
+)
+
+// 保留，不可以删除，解析语句需要注册驱动
+var _ = driver.ValueExpr{}
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate SQL script",
+	Long: `This subcommand validates a SQL script with the same rules used by tickets,
+exits with code 1 when any level-1 violation is found`,
+	Run: validateScript,
+}
+
+// rulesCmd represents the rules command
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Manage validation rules",
+	Long:  `This subcommand manages the validation rules`,
+}
+
+// rulesExportCmd represents the rules export command
+var rulesExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export validation rules",
+	Long:  `This subcommand exports the validation rules into a JSON file for offline validation`,
+	Run:   exportRules,
+}
+
+// rulesTestCmd represents the rules test command
+var rulesTestCmd = &cobra.Command{
+	Use:   "test [directory...]",
+	Short: "Run rule test fixtures",
+	Long: `This subcommand runs the .sql fixtures in the directories against the validation engine,
+schema.json or schema.sql in the directory or its parents is used as the schema,
+no MySQL is required when the rules are loaded from the exported rules file`,
+	Run: testRules,
+}
+
+// snapshotCmd represents the snapshot command
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Take schema snapshot",
+	Long:  `This subcommand saves the schema of a cluster or a mysqldump file into a JSON snapshot for offline validation`,
+	Run:   takeSnapshot,
+}
+
+func init() {
+	RootCmd.AddCommand(validateCmd)
+	validateCmd.Flags().StringP("file", "f", "", "specify the SQL script need to be validated")
+	validateCmd.Flags().StringP("database", "d", "", "specify the default database of the script")
+	validateCmd.Flags().StringP("rules", "r", "", "specify the exported rules file, rules are loaded from halo database if omitted")
+	validateCmd.Flags().StringP("cluster", "c", "", "specify the cluster UUID to read schema from")
+	validateCmd.Flags().StringP("dump", "", "", "specify the mysqldump --no-data file to read schema from")
+	validateCmd.Flags().StringP("snapshot", "", "", "specify the JSON snapshot file to read schema from")
+	validateCmd.Flags().StringP("server-version", "", "", "specify the server version of the target, e.g. 8.0.12, 10.3.2-MariaDB or 5.7.25-TiDB-v4.0.0")
+	validateCmd.Flags().StringP("json", "", "", "write JSON report into the file, - for stdout")
+	validateCmd.Flags().StringP("junit", "", "", "write JUnit report into the file, - for stdout")
+	validateCmd.Flags().StringP("sarif", "", "", "write SARIF report into the file, - for stdout")
+
+	RootCmd.AddCommand(rulesCmd)
+	rulesCmd.AddCommand(rulesExportCmd)
+	rulesExportCmd.Flags().StringP("output", "o", "", "specify the output file")
+	rulesCmd.AddCommand(rulesTestCmd)
+	rulesTestCmd.Flags().StringP("rules", "r", "", "specify the exported rules file, rules are loaded from halo database if omitted")
+	rulesTestCmd.Flags().BoolP("verbose", "v", false, "print the reported rules of each fixture")
+
+	RootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.Flags().StringP("cluster", "c", "", "specify the cluster UUID to read schema from")
+	snapshotCmd.Flags().StringP("dump", "", "", "specify the mysqldump --no-data file to read schema from")
+	snapshotCmd.Flags().StringP("database", "d", "", "specify the database of tables in the dump file without USE statement")
+	snapshotCmd.Flags().StringP("output", "o", "", "specify the output file")
+}
+
+// initHalo 读取配置并连接halo数据库
+func initHalo() error {
+	cfg, ok := os.LookupEnv("HALO_CFG")
+	if !ok {
+		return fmt.Errorf("Missing halo config")
+	}
+	g.ParseConfig(cfg)
+	return g.InitDB()
+}
+
+func validateScript(cmd *cobra.Command, args []string) {
+	var err error
+	failed := false
+L:
+	for {
+		file, _ := cmd.Flags().GetString("file")
+		database, _ := cmd.Flags().GetString("database")
+		rulesFile, _ := cmd.Flags().GetString("rules")
+		clusterUUID, _ := cmd.Flags().GetString("cluster")
+		dumpFile, _ := cmd.Flags().GetString("dump")
+		snapshotFile, _ := cmd.Flags().GetString("snapshot")
+		serverVersion, _ := cmd.Flags().GetString("server-version")
+
+		if file == "" {
+			err = fmt.Errorf("Missing SQL script")
+			break
+		}
+		var bs []byte
+		if bs, err = ioutil.ReadFile(file); err != nil {
+			break
+		}
+		content := string(bs)
+
+		sources := 0
+		for _, source := range []string{clusterUUID, dumpFile, snapshotFile} {
+			if source != "" {
+				sources++
+			}
+		}
+		if sources != 1 {
+			err = fmt.Errorf("Exactly one of --cluster, --dump and --snapshot is required")
+			break
+		}
+
+		// 规则或者结构需要从halo数据库读取时才连接数据库
+		if rulesFile == "" || clusterUUID != "" {
+			if err = initHalo(); err != nil {
+				break
+			}
+		}
+
+		if rulesFile != "" {
+			if bs, err = ioutil.ReadFile(rulesFile); err != nil {
+				break
+			}
+			rules := []*models.Rule{}
+			if err = json.Unmarshal(bs, &rules); err != nil {
+				break
+			}
+			caches.RulesMap.Load(rules)
+		} else {
+			caches.RulesMap.Init()
+		}
+
+		ctx := &validate.Context{
+			Ticket: &models.Ticket{Database: database, Content: content},
+		}
+
+		var provider validate.Provider
+		switch {
+		case clusterUUID != "":
+			var p *validate.ClusterProvider
+			if p, err = clusterProvider(clusterUUID); err != nil {
+				break L
+			}
+			ctx.Cluster = p.Cluster
+			provider = p
+		case dumpFile != "":
+			if provider, err = validate.NewDumpProvider(dumpFile, database); err != nil {
+				break L
+			}
+		default:
+			if provider, err = validate.NewSnapshotProvider(snapshotFile); err != nil {
+				break L
+			}
+		}
+		// 元数据读取不完整时和工单审核一样继续审核，把失败的部分写入每一条语句的报告
+		var loadErr error
+		if err = ctx.Load(provider); err != nil {
+			if _, ok := err.(*validate.LoadError); !ok {
+				break
+			}
+			fmt.Fprintf(os.Stderr, "[E] 元数据读取不完整: %s\n", err.Error())
+			loadErr, err = err, nil
+		}
+		// 导出文件和快照中可能没有版本，可以手工指定
+		if serverVersion != "" {
+			if ctx.Version = validate.ParseVersion(serverVersion, ""); ctx.Version == nil {
+				err = fmt.Errorf("Invalid server version %s", serverVersion)
+				break
+			}
+		}
+
+		sc := &script{File: file}
+		if err = sc.Parse(content); err != nil {
+			break
+		}
+		ctx.Stmts = sc.Stmts
+		if loadErr != nil {
+			ctx.DescribeLoadError(loadErr)
+		}
+		validate.RunContext(ctx)
+
+		reports := map[string]func(*script) []byte{
+			"json":  jsonReport,
+			"junit": junitReport,
+			"sarif": sarifReport,
+		}
+		written := 0
+		for _, name := range []string{"json", "junit", "sarif"} {
+			output, _ := cmd.Flags().GetString(name)
+			if output == "" {
+				continue
+			}
+			if err = writeReport(output, reports[name](sc)); err != nil {
+				break L
+			}
+			written++
+		}
+		if written == 0 {
+			if err = writeReport("-", jsonReport(sc)); err != nil {
+				break
+			}
+		}
+
+		for _, s := range ctx.Stmts {
+			for _, c := range s.Violations.Clauses() {
+				if c.Level == 1 && !c.Waived {
+					failed = true
+				}
+			}
+		}
+
+		break
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "错误代码: 1500, 错误信息: %s\n", err.Error())
+		os.Exit(2)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// script 待审核的脚本
+type script struct {
+	File  string                    // 脚本的文件名
+	Stmts []*models.Statement       // 拆分后的语句
+	Lines map[*models.Statement]int // 语句在脚本中的起始行
+}
+
+// Parse 拆分脚本并生成待审核的语句，记录每条语句在脚本中的起始行
+func (sc *script) Parse(content string) error {
+	stmts, err := validate.Parse(content)
+	if err != nil {
+		return err
+	}
+
+	for i, node := range stmts {
+		var sb strings.Builder
+		node.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb))
+		sql := sb.String()
+		if len(sql) == 0 {
+			sql = strings.TrimSpace(node.Text())
+		}
+		sc.Stmts = append(sc.Stmts, &models.Statement{
+			Sequence:   uint16(i + 1),
+			Content:    sql,
+			StmtNode:   node,
+			Violations: &models.Violations{},
+		})
+	}
+	// 与描述的行和列使用相同的定位方法
+	sc.Lines = validate.Lines(content, sc.Stmts)
+
+	return nil
+}
+
+// clusterProvider 根据UUID查找群集，需要先连接halo数据库
+func clusterProvider(clusterUUID string) (*validate.ClusterProvider, error) {
+	if !isValidUUID(clusterUUID) {
+		return nil, fmt.Errorf("String %s is not a valid UUID", clusterUUID)
+	}
+	cluster := &models.Cluster{}
+	if ok, err := g.Engine.Where("`uuid` = ?", clusterUUID).Get(cluster); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, fmt.Errorf("Unknown cluster %s", clusterUUID)
+	}
+	return validate.NewClusterProvider(cluster), nil
+}
+
+func writeReport(output string, bs []byte) error {
+	if output == "-" {
+		_, err := os.Stdout.Write(bs)
+		return err
+	}
+	return ioutil.WriteFile(output, bs, 0644)
+}
+
+func exportRules(cmd *cobra.Command, args []string) {
+	var err error
+	for {
+		output, _ := cmd.Flags().GetString("output")
+		if output == "" {
+			output = "-"
+		}
+		if err = initHalo(); err != nil {
+			break
+		}
+		rules := []*models.Rule{}
+		if err = g.Engine.Find(&rules); err != nil {
+			break
+		}
+		var bs []byte
+		if bs, err = json.MarshalIndent(rules, "", "  "); err != nil {
+			break
+		}
+		err = writeReport(output, bs)
+		break
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "错误代码: 1500, 错误信息: %s\n", err.Error())
+		os.Exit(2)
+	}
+}
+
+func testRules(cmd *cobra.Command, args []string) {
+	var err error
+	failed := 0
+	for {
+		rulesFile, _ := cmd.Flags().GetString("rules")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		if len(args) == 0 {
+			err = fmt.Errorf("Missing fixture directory")
+			break
+		}
+
+		if rulesFile != "" {
+			var bs []byte
+			if bs, err = ioutil.ReadFile(rulesFile); err != nil {
+				break
+			}
+			rules := []*models.Rule{}
+			if err = json.Unmarshal(bs, &rules); err != nil {
+				break
+			}
+			caches.RulesMap.Load(rules)
+		} else {
+			if err = initHalo(); err != nil {
+				break
+			}
+			caches.RulesMap.Init()
+		}
+		// 规则没有实现时所有用例都没有意义
+		if err = validate.Verify(caches.RulesMap.All()); err != nil {
+			break
+		}
+
+		fixtures := []*validate.Fixture{}
+		for _, dir := range args {
+			var L []*validate.Fixture
+			if L, err = validate.LoadFixtures(dir); err != nil {
+				break
+			}
+			fixtures = append(fixtures, L...)
+		}
+		if err != nil {
+			break
+		}
+
+		for _, f := range fixtures {
+			result := f.Run()
+			fmt.Println(result.String())
+			if verbose {
+				fmt.Printf("      reported: %s\n", strings.Join(result.Reported, ", "))
+			}
+			if !result.Passed() {
+				failed++
+			}
+		}
+		fmt.Printf("%d fixtures, %d passed, %d failed\n", len(fixtures), len(fixtures)-failed, failed)
+		break
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "错误代码: 1500, 错误信息: %s\n", err.Error())
+		os.Exit(2)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func takeSnapshot(cmd *cobra.Command, args []string) {
+	var err error
+	for {
+		clusterUUID, _ := cmd.Flags().GetString("cluster")
+		dumpFile, _ := cmd.Flags().GetString("dump")
+		database, _ := cmd.Flags().GetString("database")
+		output, _ := cmd.Flags().GetString("output")
+		if output == "" {
+			output = "-"
+		}
+
+		var provider validate.Provider
+		if clusterUUID != "" {
+			if err = initHalo(); err != nil {
+				break
+			}
+			if provider, err = clusterProvider(clusterUUID); err != nil {
+				break
+			}
+		} else if dumpFile != "" {
+			if provider, err = validate.NewDumpProvider(dumpFile, database); err != nil {
+				break
+			}
+		} else {
+			err = fmt.Errorf("One of --cluster and --dump is required")
+			break
+		}
+
+		var snapshot *validate.Snapshot
+		if snapshot, err = validate.TakeSnapshot(provider); err != nil {
+			break
+		}
+		var bs []byte
+		if bs, err = json.MarshalIndent(snapshot, "", "  "); err != nil {
+			break
+		}
+		err = writeReport(output, bs)
+		break
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "错误代码: 1500, 错误信息: %s\n", err.Error())
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mia0x75/halo/caches"
+	"github.com/mia0x75/halo/g"
+	"github.com/mia0x75/halo/models"
+	"github.com/mia0x75/halo/tools"
+)
+
+// refreshVersionsCmd represents the refresh-versions command
+var refreshVersionsCmd = &cobra.Command{
+	Use:   "refresh-versions",
+	Short: "Refresh server versions of clusters",
+	Long:  `This subcommand reads the server version of every cluster and saves the changed ones, scheduled hourly by halo`,
+	Run:   refreshVersions,
+}
+
+func init() {
+	RootCmd.AddCommand(refreshVersionsCmd)
+}
+
+func refreshVersions(cmd *cobra.Command, args []string) {
+	if err := initHalo(); err != nil {
+		fmt.Fprintf(os.Stderr, "错误代码: 1500, 错误信息: %s\n", err.Error())
+		os.Exit(2)
+	}
+	caches.ClustersMap.Init()
+	caches.ClustersMap.RefreshVersions(func(c *models.Cluster) []byte {
+		bs, _ := tools.DecryptAES(c.Password, g.Config().Secret.Crypto)
+		return bs
+	})
+}
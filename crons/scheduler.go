@@ -64,7 +64,7 @@ func (s *Scheduler) RunAfter(duration time.Duration, name string, params ...stri
 
 // RunEvery 给定周期，循环执行某一个任务
 func (s *Scheduler) RunEvery(interval time.Duration, name string, params ...string) (string, error) {
-	t, exists := s.taskExists(cmd)
+	t, exists := s.taskExists(name)
 
 	if exists && t.Interval == interval {
 		return t.UUID, nil
@@ -242,9 +242,12 @@ func (s *Scheduler) register(task *Task) {
 	s.Unlock()
 }
 
+// taskExists 按名称查找周期任务，服务重启时复用已经持久化的任务
 func (s *Scheduler) taskExists(name string) (*Task, bool) {
+	s.Lock()
+	defer s.Unlock()
 	for _, t := range s.tasks {
-		if t.Cmd == name {
+		if t.IsRecurring && t.Name == name {
 			return t, true
 		}
 	}
@@ -66,6 +66,12 @@ func init() {
 
 	ee.On(EventRuleBitwisePatched, RuleBitwisePatchedLogWriter)
 
+	ee.On(EventRuleProfileUpdated, RuleProfileUpdatedLogWriter)
+
+	ee.On(EventWaiverGranted, WaiverGrantedLogWriter)
+
+	ee.On(EventWaiverRevoked, WaiverRevokedLogWriter)
+
 	ee.On(EventOptionValuePatched, OptionValuePatchedLogWriter)
 
 	ee.On(EventCommentCreated, CommentCreatedLogWriter)
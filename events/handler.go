@@ -42,6 +42,9 @@ const (
 	EventUserStatusPatched    = "OnUserStatusPatched"    // 用户状态修改成功 - PASS
 	EventRuleValuesPatched    = "OnRuleValuesPatched"    // 规则取值修改成功 - PASS
 	EventRuleBitwisePatched   = "OnRuleBitwisePatched"   // 规则执行标志修改成功 - PASS
+	EventRuleProfileUpdated   = "OnRuleProfileUpdated"   // 规则配置修改成功
+	EventWaiverGranted        = "OnWaiverGranted"        // 规则豁免批准成功
+	EventWaiverRevoked        = "OnWaiverRevoked"        // 规则豁免撤销成功
 	EventOptionValuePatched   = "OnOptionValuePatched"   // 系统选项修改成功 - PASS
 	EventCommentCreated       = "OnCommentCreated"       // 添加审核意见成功 - PASS
 	EventCronCancelled        = "OnCronCancelled"        // 计划任务取消成功
@@ -636,6 +639,45 @@ func RuleBitwisePatchedLogWriter(e *Event) {
 	}
 }
 
+// RuleProfileUpdatedArgs 规则配置更新成功事件参数
+type RuleProfileUpdatedArgs struct {
+	Manager models.User
+	Profile models.RuleProfile
+}
+
+// RuleProfileUpdatedLogWriter 规则配置更新成功日志记录
+func RuleProfileUpdatedLogWriter(e *Event) {
+	if args, ok := e.Args.(*RuleProfileUpdatedArgs); ok {
+		LogWriter(args.Manager.UserID, fmt.Sprintf("管理员(uuid=%s)更新规则配置(uuid=%s)成功。\n", args.Manager.UUID, args.Profile.UUID))
+	}
+}
+
+// WaiverGrantedArgs 规则豁免批准成功事件参数
+type WaiverGrantedArgs struct {
+	Manager models.User
+	Waiver  models.Waiver
+}
+
+// WaiverGrantedLogWriter 规则豁免批准成功日志记录
+func WaiverGrantedLogWriter(e *Event) {
+	if args, ok := e.Args.(*WaiverGrantedArgs); ok {
+		LogWriter(args.Manager.UserID, fmt.Sprintf("管理员(uuid=%s)批准规则(name=%s)豁免(uuid=%s)成功。\n", args.Manager.UUID, args.Waiver.RuleName, args.Waiver.UUID))
+	}
+}
+
+// WaiverRevokedArgs 规则豁免撤销成功事件参数
+type WaiverRevokedArgs struct {
+	Manager models.User
+	Waiver  models.Waiver
+}
+
+// WaiverRevokedLogWriter 规则豁免撤销成功日志记录
+func WaiverRevokedLogWriter(e *Event) {
+	if args, ok := e.Args.(*WaiverRevokedArgs); ok {
+		LogWriter(args.Manager.UserID, fmt.Sprintf("管理员(uuid=%s)撤销规则(name=%s)豁免(uuid=%s)成功。\n", args.Manager.UUID, args.Waiver.RuleName, args.Waiver.UUID))
+	}
+}
+
 // OptionValuePatchedArgs 系统选项更新事件参数
 type OptionValuePatchedArgs struct {
 	Manager models.User
@@ -0,0 +1,260 @@
+package executors
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/go-xorm/core"
+	"github.com/mia0x75/parser/ast"
+	"github.com/mia0x75/parser/format"
+	"xorm.io/xorm"
+
+	"github.com/mia0x75/halo/binlog"
+	"github.com/mia0x75/halo/gqlapi"
+	"github.com/mia0x75/halo/models"
+	"github.com/mia0x75/halo/validate"
+)
+
+// backupBatch 每次写入备份库的行数
+const backupBatch = 100
+
+// RowBackup 在UPDATE和DELETE语句执行之前，把将被修改的行复制到备份库，
+// 恢复时不依赖binlog的格式和保留时间
+type RowBackup struct {
+	Database string
+	Engine   *xorm.Engine // 执行语句的连接
+	Backup   *xorm.Engine // 备份库
+	MaxSize  int64        // 每条语句备份的最大字节数
+}
+
+// NewRowBackup 创建行备份，maxSize的单位是MB
+func NewRowBackup(database string, engine, backup *xorm.Engine, maxSize int64) *RowBackup {
+	return &RowBackup{
+		Database: database,
+		Engine:   engine,
+		Backup:   backup,
+		MaxSize:  maxSize << 20,
+	}
+}
+
+// IsBackup 是否需要在执行之前备份行的语句
+func IsBackup(stmt *models.Statement) bool {
+	switch stmt.Type {
+	case gqlapi.StatementTypeEnumMap[gqlapi.StatementTypeEnumUpdate],
+		gqlapi.StatementTypeEnumMap[gqlapi.StatementTypeEnumDelete]:
+		return true
+	}
+	return false
+}
+
+// Save 备份语句将要修改的行，超过上限时只备份前面的行，返回备份的行数和是否被截断。
+// 备份和执行之间没有加锁，期间其他连接的修改不会反映在备份中
+func (b *RowBackup) Save(stmt *models.Statement) (count int, truncated bool, err error) {
+	if !IsBackup(stmt) {
+		return
+	}
+	var nodes []ast.StmtNode
+	if nodes, err = validate.Parse(stmt.Content); err != nil {
+		return
+	}
+	if len(nodes) != 1 {
+		err = fmt.Errorf("语句(uuid=%s)包含%d条语句", stmt.UUID, len(nodes))
+		return
+	}
+	var database, table, query string
+	if database, table, query, err = backupQuery(nodes[0], b.Database); err != nil {
+		return
+	}
+
+	rows, err := b.Engine.DB().Query(query)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return
+	}
+	raw := make([]sql.RawBytes, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range raw {
+		dest[i] = &raw[i]
+	}
+
+	var size int64
+	batch := []*models.RowBackup{}
+	for rows.Next() {
+		if err = rows.Scan(dest...); err != nil {
+			return
+		}
+		values := make([]string, len(raw))
+		for i, v := range raw {
+			if v == nil {
+				values[i] = "NULL"
+			} else {
+				values[i] = binlog.Literal(v)
+			}
+			size += int64(len(values[i]))
+		}
+		if size > b.MaxSize {
+			truncated = true
+			break
+		}
+		row := &models.RowBackup{
+			StatementUUID: stmt.UUID,
+			Database:      database,
+			Table:         table,
+		}
+		if err = row.SetRow(columns, values); err != nil {
+			return
+		}
+		batch = append(batch, row)
+		if len(batch) == backupBatch {
+			if _, err = b.Backup.Insert(&batch); err != nil {
+				return
+			}
+			count += len(batch)
+			batch = batch[:0]
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+	if len(batch) > 0 {
+		if _, err = b.Backup.Insert(&batch); err != nil {
+			return
+		}
+		count += len(batch)
+	}
+	return
+}
+
+// ChangesKey UPDATE语句是否修改了主键或者唯一键中的列。按原来的键REPLACE备份的行时，
+// 修改之后的行仍然保留，这样的语句不能用备份的行自动恢复。
+// 表结构未知时无法确认是否修改了键，UPDATE语句按修改了键处理
+func ChangesKey(node ast.StmtNode, schema *validate.Schema, current string) bool {
+	x, ok := node.(*ast.UpdateStmt)
+	if !ok {
+		return false
+	}
+	if schema == nil {
+		return true
+	}
+	database, name, _, err := backupQuery(node, current)
+	if err != nil {
+		return true
+	}
+	table := schema.Table(database, name)
+	if table == nil {
+		return true
+	}
+	keys := append([]string{}, table.PrimaryKeys...)
+	for _, index := range table.Indexes {
+		if index.Type == core.UniqueType {
+			keys = append(keys, index.Cols...)
+		}
+	}
+	for _, assignment := range x.List {
+		for _, key := range keys {
+			if strings.EqualFold(assignment.Column.Name.O, key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// backupQuery 查询单表UPDATE和DELETE将要修改的行，使用和原语句相同的表、条件、排序和行数限制
+func backupQuery(node ast.StmtNode, current string) (database, table, query string, err error) {
+	var refs *ast.TableRefsClause
+	var where ast.ExprNode
+	var order *ast.OrderByClause
+	var limit *ast.Limit
+	switch x := node.(type) {
+	case *ast.UpdateStmt:
+		if x.MultipleTable {
+			err = fmt.Errorf("不支持备份多表UPDATE修改的行")
+			return
+		}
+		refs, where, order, limit = x.TableRefs, x.Where, x.Order, x.Limit
+	case *ast.DeleteStmt:
+		if x.IsMultiTable {
+			err = fmt.Errorf("不支持备份多表DELETE删除的行")
+			return
+		}
+		refs, where, order, limit = x.TableRefs, x.Where, x.Order, x.Limit
+	default:
+		err = fmt.Errorf("只能备份UPDATE和DELETE修改的行")
+		return
+	}
+	if refs == nil || refs.TableRefs == nil || refs.TableRefs.Right != nil {
+		err = fmt.Errorf("不支持备份多表语句修改的行")
+		return
+	}
+	ts, ok := refs.TableRefs.Left.(*ast.TableSource)
+	if !ok {
+		err = fmt.Errorf("不支持备份多表语句修改的行")
+		return
+	}
+	tn, ok := ts.Source.(*ast.TableName)
+	if !ok {
+		err = fmt.Errorf("不支持备份子查询修改的行")
+		return
+	}
+	database, table = tn.Schema.O, tn.Name.O
+	if database == "" {
+		database = current
+	}
+
+	var sb strings.Builder
+	ctx := format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)
+	sb.WriteString("SELECT * FROM ")
+	if err = refs.Restore(ctx); err != nil {
+		return
+	}
+	if where != nil {
+		sb.WriteString(" WHERE ")
+		if err = where.Restore(ctx); err != nil {
+			return
+		}
+	}
+	if order != nil {
+		sb.WriteString(" ")
+		if err = order.Restore(ctx); err != nil {
+			return
+		}
+	}
+	if limit != nil {
+		sb.WriteString(" ")
+		if err = limit.Restore(ctx); err != nil {
+			return
+		}
+	}
+	query = sb.String()
+	return
+}
+
+// RestoreStatements 根据备份的行生成恢复语句，被删除的行重新插入，被修改的行按主键或者唯一键覆盖，
+// 没有主键和唯一键的表覆盖时会插入重复的行，需要人工处理。UPDATE修改了主键或者唯一键时，
+// 覆盖不会删除修改之后的行，调用方需要先用ChangesKey排除这样的语句
+func RestoreStatements(rows []*models.RowBackup) (string, error) {
+	sqls := []string{}
+	for _, row := range rows {
+		columns, values, err := row.ParseRow()
+		if err != nil {
+			return "", err
+		}
+		names := make([]string, len(columns))
+		for i, column := range columns {
+			names[i] = binlog.Quote(column)
+		}
+		sqls = append(sqls, fmt.Sprintf("REPLACE INTO %s.%s (%s) VALUES (%s);",
+			binlog.Quote(row.Database),
+			binlog.Quote(row.Table),
+			strings.Join(names, ", "),
+			strings.Join(values, ", "),
+		))
+	}
+	return strings.Join(sqls, "\n"), nil
+}
@@ -0,0 +1,100 @@
+package executors
+
+import (
+	"testing"
+
+	"github.com/go-xorm/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mia0x75/halo/gqlapi"
+	"github.com/mia0x75/halo/models"
+	"github.com/mia0x75/halo/validate"
+)
+
+func TestBackupQuery(t *testing.T) {
+	tests := []struct {
+		sql      string
+		database string
+		table    string
+		query    string
+	}{
+		{"UPDATE t1 SET c1 = c2 WHERE id > c3", "db1", "t1", "SELECT * FROM `t1` WHERE `id`>`c3`"},
+		{"DELETE FROM db2.t2 WHERE c1 IS NULL ORDER BY id LIMIT 10", "db2", "t2", "SELECT * FROM `db2`.`t2` WHERE `c1` IS NULL ORDER BY `id` LIMIT 10"},
+		{"UPDATE t1 AS a SET a.c1 = a.c2", "db1", "t1", "SELECT * FROM `t1` AS `a`"},
+	}
+	for _, test := range tests {
+		nodes, err := validate.Parse(test.sql)
+		if !assert.NoError(t, err, test.sql) {
+			continue
+		}
+		database, table, query, err := backupQuery(nodes[0], "db1")
+		assert.NoError(t, err, test.sql)
+		assert.Equal(t, test.database, database, test.sql)
+		assert.Equal(t, test.table, table, test.sql)
+		assert.Equal(t, test.query, query, test.sql)
+	}
+
+	for _, sql := range []string{
+		"UPDATE t1 JOIN t2 ON t1.id = t2.id SET t1.c1 = t2.c1",
+		"DELETE t1 FROM t1 JOIN t2 ON t1.id = t2.id",
+		"INSERT INTO t1 (c1) VALUES (1)",
+	} {
+		nodes, err := validate.Parse(sql)
+		if !assert.NoError(t, err, sql) {
+			continue
+		}
+		_, _, _, err = backupQuery(nodes[0], "db1")
+		assert.Error(t, err, sql)
+	}
+
+	assert.True(t, IsBackup(&models.Statement{Type: gqlapi.StatementTypeEnumMap[gqlapi.StatementTypeEnumDelete]}))
+	assert.False(t, IsBackup(&models.Statement{Type: gqlapi.StatementTypeEnumMap[gqlapi.StatementTypeEnumInsert]}))
+}
+
+func TestChangesKey(t *testing.T) {
+	table := core.NewEmptyTable()
+	table.Name = "t1"
+	for _, name := range []string{"id", "c1", "c2"} {
+		table.AddColumn(&core.Column{Name: name, Indexes: make(map[string]int)})
+	}
+	table.PrimaryKeys = []string{"id"}
+	index := core.NewIndex("uk_c1", core.UniqueType)
+	index.AddColumn("c1")
+	table.AddIndex(index)
+	schema := validate.NewSchema(nil, map[string][]*core.Table{"db1": {table}})
+
+	tests := []struct {
+		sql     string
+		changed bool
+	}{
+		{"UPDATE t1 SET c2 = 1 WHERE id = 1", false},
+		{"UPDATE t1 SET id = id + 100", true},
+		{"UPDATE db1.t1 SET C1 = 'a', c2 = 1", true},
+		{"UPDATE t9 SET id = 1", true},
+		{"UPDATE t1, t2 SET t1.c2 = 1", true},
+		{"DELETE FROM t1 WHERE id = 1", false},
+	}
+	for _, test := range tests {
+		nodes, err := validate.Parse(test.sql)
+		if !assert.NoError(t, err, test.sql) {
+			continue
+		}
+		assert.Equal(t, test.changed, ChangesKey(nodes[0], schema, "db1"), test.sql)
+	}
+	assert.False(t, ChangesKey(nil, nil, "db1"))
+	nodes, _ := validate.Parse("UPDATE t1 SET c2 = 1 WHERE id = 1")
+	assert.True(t, ChangesKey(nodes[0], nil, "db1"))
+}
+
+func TestRestoreStatements(t *testing.T) {
+	row := &models.RowBackup{Database: "db1", Table: "t1"}
+	assert.NoError(t, row.SetRow([]string{"id", "c1", "c2"}, []string{"'1'", "NULL", "0xff00"}))
+	sql, err := RestoreStatements([]*models.RowBackup{row, row})
+	assert.NoError(t, err)
+	assert.Equal(t, "REPLACE INTO `db1`.`t1` (`id`, `c1`, `c2`) VALUES ('1', NULL, 0xff00);\n"+
+		"REPLACE INTO `db1`.`t1` (`id`, `c1`, `c2`) VALUES ('1', NULL, 0xff00);", sql)
+
+	row.ColumnValues = `["'1'"]`
+	_, err = RestoreStatements([]*models.RowBackup{row})
+	assert.Error(t, err)
+}
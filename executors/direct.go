@@ -0,0 +1,35 @@
+package executors
+
+import (
+	"fmt"
+
+	"xorm.io/xorm"
+
+	"github.com/mia0x75/halo/models"
+)
+
+// DirectExecutor 直接在群集上执行语句
+type DirectExecutor struct {
+	Engine *xorm.Engine
+}
+
+// Name 执行方式的名称
+func (e *DirectExecutor) Name() string {
+	return Direct
+}
+
+// Execute 执行语句，记录影响的行数
+func (e *DirectExecutor) Execute(stmt *models.Statement) error {
+	if e.Engine == nil {
+		return fmt.Errorf("没有可用的连接")
+	}
+	result, err := e.Engine.Exec(stmt.Content)
+	if err != nil {
+		stmt.Results = err.Error()
+		return err
+	}
+	if ra, err := result.RowsAffected(); err == nil {
+		stmt.RowsAffected = uint(ra)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package executors
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mia0x75/parser/ast"
+	"github.com/mia0x75/parser/format"
+	log "github.com/sirupsen/logrus"
+	"xorm.io/xorm"
+
+	"github.com/mia0x75/halo/g"
+	"github.com/mia0x75/halo/models"
+	"github.com/mia0x75/halo/validate"
+)
+
+// 执行方式的名称，用于群集的设置和语句的halo:executor注释
+const (
+	Direct = "direct" // 直接在群集上执行
+	Ghost  = "gh-ost" // 使用gh-ost在线变更
+	PtOsc  = "pt-osc" // 使用pt-online-schema-change在线变更，支持有触发器的表
+)
+
+// Executor 语句的执行方式，执行结果写入语句的Results和RowsAffected
+type Executor interface {
+	// Name 执行方式的名称
+	Name() string
+	// Execute 执行语句，失败时语句的Results中是错误的详细信息
+	Execute(stmt *models.Statement) error
+}
+
+// DryRunner 支持在审核时检查而不实际执行的执行方式
+type DryRunner interface {
+	DryRun(stmt *models.Statement) error
+}
+
+// ExecutorAnnotation 指定语句执行方式的注释，例如：/* halo:executor pt-osc */
+const ExecutorAnnotation = "halo:executor"
+
+var annotationPattern = regexp.MustCompile(`halo:executor\s+(direct|gh-ost|pt-osc)\b`)
+
+// Annotation 语句原始文本中指定的执行方式，没有指定时返回空
+func Annotation(sql string) string {
+	if !strings.Contains(sql, ExecutorAnnotation) {
+		return ""
+	}
+	if match := annotationPattern.FindStringSubmatch(sql); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// Valid 执行方式的名称是否有效，空表示自动选择
+func Valid(name string) bool {
+	switch name {
+	case "", Direct, Ghost, PtOsc:
+		return true
+	}
+	return false
+}
+
+// Target 在线变更的目标表和去掉表名的变更内容
+type Target struct {
+	Database string
+	Table    string
+	Alter    string
+}
+
+// TargetOf ALTER TABLE语句的在线变更目标，其他语句和在线变更工具不支持的语句返回nil
+func TargetOf(node ast.StmtNode, database string) *Target {
+	at, ok := node.(*ast.AlterTableStmt)
+	if !ok {
+		return nil
+	}
+	specs := []string{}
+	for _, spec := range at.Specs {
+		switch spec.Tp {
+		case ast.AlterTableAlgorithm, ast.AlterTableLock:
+			// 由在线变更工具决定
+			continue
+		case ast.AlterTableRenameTable:
+			// 在线变更工具不支持重命名表
+			return nil
+		}
+		var sb strings.Builder
+		if err := spec.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+			return nil
+		}
+		specs = append(specs, sb.String())
+	}
+	if len(specs) == 0 {
+		return nil
+	}
+	if at.Table.Schema.O != "" {
+		database = at.Table.Schema.O
+	}
+	return &Target{
+		Database: database,
+		Table:    at.Table.Name.O,
+		Alter:    strings.Join(specs, ", "),
+	}
+}
+
+// Exceeds 目标表的大小(MB)是否超过了阈值，没有统计信息时视为没有超过
+func (t *Target) Exceeds(stats []models.TableStat, threshold int64) bool {
+	for _, stat := range stats {
+		if stat.Database == t.Database && stat.Name == t.Table {
+			return (stat.DataLength+stat.IndexLength)>>20 >= threshold
+		}
+	}
+	return false
+}
+
+// Selector 为工单中的每条语句选择执行方式，语句的注释优先，其次是群集的设置，
+// 群集没有设置时，启用了gh-ost并且表的大小超过阈值的ALTER TABLE使用gh-ost，其他语句直接执行
+type Selector struct {
+	Cluster  *models.Cluster
+	Database string
+	Passwd   []byte
+	Engine   *xorm.Engine // 直接执行使用的连接
+	Ghost    *g.GhostConfig
+	PtOsc    *g.PtOscConfig
+	Progress func(stmt *models.Statement) // 执行过程中语句的结果有变化时调用
+	stats    []models.TableStat
+}
+
+// NewSelector 使用全局配置创建选择器
+func NewSelector(cluster *models.Cluster, database string, passwd []byte) *Selector {
+	cfg := g.Config()
+	return &Selector{
+		Cluster:  cluster,
+		Database: database,
+		Passwd:   passwd,
+		Ghost:    cfg.Ghost,
+		PtOsc:    cfg.PtOsc,
+	}
+}
+
+// Select 语句的执行方式
+func (s *Selector) Select(stmt *models.Statement) Executor {
+	direct := &DirectExecutor{Engine: s.Engine}
+	name, explicit := stmt.Executor, stmt.Executor != ""
+	if !explicit {
+		name = s.Cluster.Executor
+	}
+	if name == Direct {
+		return direct
+	}
+	nodes, err := validate.Parse(stmt.Content)
+	if err != nil || len(nodes) != 1 {
+		return direct
+	}
+	target := TargetOf(nodes[0], s.Database)
+	if target == nil {
+		return direct
+	}
+
+	// 语句上指定的执行方式不受阈值限制
+	switch name {
+	case Ghost:
+		if explicit || target.Exceeds(s.tableStats(), s.Ghost.Threshold) {
+			return s.ghost(target)
+		}
+	case PtOsc:
+		if explicit || target.Exceeds(s.tableStats(), s.PtOsc.Threshold) {
+			return s.ptOsc(target)
+		}
+	default:
+		if s.Ghost.Enabled && target.Exceeds(s.tableStats(), s.Ghost.Threshold) {
+			return s.ghost(target)
+		}
+	}
+	return direct
+}
+
+func (s *Selector) ghost(target *Target) Executor {
+	return &GhostExecutor{
+		Cluster: s.Cluster,
+		Passwd:  s.Passwd,
+		Config:  s.Ghost,
+		Target:  target,
+	}
+}
+
+func (s *Selector) ptOsc(target *Target) Executor {
+	return &PtOscExecutor{
+		Cluster:  s.Cluster,
+		Passwd:   s.Passwd,
+		Config:   s.PtOsc,
+		Target:   target,
+		Progress: s.Progress,
+	}
+}
+
+// tableStats 群集上表的统计信息，只在需要时获取一次
+func (s *Selector) tableStats() []models.TableStat {
+	if s.stats == nil {
+		var err error
+		if s.stats, err = s.Cluster.TableStats(func(c *models.Cluster) []byte { return s.Passwd }); err != nil {
+			log.Warnf("[W] 获取群集(uuid=%s)表的统计信息失败: %s", s.Cluster.UUID, err.Error())
+		}
+		if s.stats == nil {
+			s.stats = []models.TableStat{}
+		}
+	}
+	return s.stats
+}
+
+// tail 保留输出的最后n个字节
+func tail(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[len(s)-n:]
+}
@@ -0,0 +1,101 @@
+package executors
+
+import (
+	"testing"
+
+	"github.com/mia0x75/parser/driver"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mia0x75/halo/g"
+	"github.com/mia0x75/halo/models"
+	"github.com/mia0x75/halo/validate"
+)
+
+var _ = driver.ValueExpr{}
+
+func targetOf(t *testing.T, sql string) *Target {
+	nodes, err := validate.Parse(sql)
+	assert.NoError(t, err)
+	return TargetOf(nodes[0], "db1")
+}
+
+func TestAnnotation(t *testing.T) {
+	assert.Equal(t, PtOsc, Annotation("/* halo:executor pt-osc */ ALTER TABLE t1 ADD COLUMN c1 INT"))
+	assert.Equal(t, Ghost, Annotation("ALTER TABLE t1 ADD COLUMN c1 INT -- halo:executor gh-ost"))
+	assert.Equal(t, Direct, Annotation("/*halo:executor   direct*/ ALTER TABLE t1 DROP COLUMN c1"))
+	assert.Equal(t, "", Annotation("/* halo:executor osc */ ALTER TABLE t1 DROP COLUMN c1"))
+	assert.Equal(t, "", Annotation("ALTER TABLE t1 DROP COLUMN c1"))
+
+	assert.True(t, Valid(""))
+	assert.True(t, Valid(PtOsc))
+	assert.False(t, Valid("osc"))
+}
+
+func TestTargetOf(t *testing.T) {
+	target := targetOf(t, "ALTER TABLE t1 ADD COLUMN c1 VARCHAR(20) NOT NULL COMMENT 'c1', ADD INDEX idx_c1 (c1), ALGORITHM=INPLACE, LOCK=NONE")
+	if assert.NotNil(t, target) {
+		assert.Equal(t, "db1", target.Database)
+		assert.Equal(t, "t1", target.Table)
+		assert.Equal(t, "ADD COLUMN `c1` VARCHAR(20) NOT NULL COMMENT 'c1', ADD INDEX `idx_c1`(`c1`)", target.Alter)
+	}
+	target = targetOf(t, "ALTER TABLE db2.t1 DROP COLUMN c1")
+	if assert.NotNil(t, target) {
+		assert.Equal(t, "db2", target.Database)
+	}
+	// 在线变更工具不支持的语句
+	assert.Nil(t, targetOf(t, "ALTER TABLE t1 RENAME TO t2"))
+	assert.Nil(t, targetOf(t, "ALTER TABLE t1 ALGORITHM=INPLACE"))
+	assert.Nil(t, targetOf(t, "CREATE TABLE t1 (id INT)"))
+
+	stats := []models.TableStat{
+		{Database: "db1", Name: "t1", DataLength: 800 << 20, IndexLength: 300 << 20},
+		{Database: "db1", Name: "t2", DataLength: 10 << 20},
+	}
+	target = targetOf(t, "ALTER TABLE t1 DROP COLUMN c1")
+	assert.True(t, target.Exceeds(stats, 1024))
+	assert.False(t, target.Exceeds(stats, 2048))
+	assert.False(t, targetOf(t, "ALTER TABLE t2 DROP COLUMN c1").Exceeds(stats, 1024))
+	assert.False(t, targetOf(t, "ALTER TABLE t3 DROP COLUMN c1").Exceeds(stats, 1024))
+}
+
+func TestSelect(t *testing.T) {
+	selector := func(executor string, enabled bool) *Selector {
+		return &Selector{
+			Cluster:  &models.Cluster{IP: "127.0.0.1", Port: 3306, User: "root", Executor: executor},
+			Database: "db1",
+			Ghost:    &g.GhostConfig{Enabled: enabled, Threshold: 1024},
+			PtOsc:    &g.PtOscConfig{Threshold: 1024},
+			stats: []models.TableStat{
+				{Database: "db1", Name: "big", DataLength: 2048 << 20},
+				{Database: "db1", Name: "small", DataLength: 10 << 20},
+			},
+		}
+	}
+	stmt := func(executor, sql string) *models.Statement {
+		return &models.Statement{Executor: executor, Content: sql}
+	}
+	cases := []struct {
+		cluster string
+		enabled bool
+		stmt    *models.Statement
+		expect  string
+	}{
+		// 自动选择
+		{"", true, stmt("", "ALTER TABLE big DROP COLUMN c1"), Ghost},
+		{"", true, stmt("", "ALTER TABLE small DROP COLUMN c1"), Direct},
+		{"", false, stmt("", "ALTER TABLE big DROP COLUMN c1"), Direct},
+		{"", true, stmt("", "DROP TABLE big"), Direct},
+		// 群集的设置受阈值限制
+		{PtOsc, false, stmt("", "ALTER TABLE big DROP COLUMN c1"), PtOsc},
+		{PtOsc, false, stmt("", "ALTER TABLE small DROP COLUMN c1"), Direct},
+		{Direct, true, stmt("", "ALTER TABLE big DROP COLUMN c1"), Direct},
+		// 语句的注释优先，不受阈值限制
+		{Ghost, true, stmt(PtOsc, "ALTER TABLE small DROP COLUMN c1"), PtOsc},
+		{"", false, stmt(Ghost, "ALTER TABLE small DROP COLUMN c1"), Ghost},
+		{Ghost, true, stmt(Direct, "ALTER TABLE big DROP COLUMN c1"), Direct},
+		{"", false, stmt(PtOsc, "ALTER TABLE big RENAME TO t2"), Direct},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expect, selector(c.cluster, c.enabled).Select(c.stmt).Name(), c.stmt.Content)
+	}
+}
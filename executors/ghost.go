@@ -0,0 +1,131 @@
+package executors
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mia0x75/halo/g"
+	"github.com/mia0x75/halo/models"
+)
+
+// ghostHooks gh-ost的回调，gh-ost执行hooks-path中以这些名称开头的文件
+var ghostHooks = []string{
+	"on-startup",
+	"on-validated",
+	"on-rowcount-complete",
+	"on-before-row-copy",
+	"on-row-copy-complete",
+	"on-begin-postponed",
+	"on-before-cut-over",
+	"on-interactive-command",
+	"on-success",
+	"on-failure",
+	"on-status",
+	"on-stop-replication",
+	"on-start-replication",
+}
+
+// GhostExecutor 使用gh-ost执行ALTER TABLE，回调通过halocli ghost记录到语句上
+type GhostExecutor struct {
+	Cluster *models.Cluster
+	Passwd  []byte
+	Config  *g.GhostConfig
+	Target  *Target
+}
+
+// Name 执行方式的名称
+func (e *GhostExecutor) Name() string {
+	return Ghost
+}
+
+// Args gh-ost的命令行参数，密码写在conf文件中，避免出现在进程列表里
+func (e *GhostExecutor) Args(stmt *models.Statement, hooksPath, conf string) []string {
+	cfg := e.Config
+	args := []string{
+		fmt.Sprintf("-host=%s", e.Cluster.IP),
+		fmt.Sprintf("-port=%d", e.Cluster.Port),
+		fmt.Sprintf("-conf=%s", conf),
+		fmt.Sprintf("-database=%s", e.Target.Database),
+		fmt.Sprintf("-table=%s", e.Target.Table),
+		fmt.Sprintf("-alter=%s", e.Target.Alter),
+		fmt.Sprintf("-chunk-size=%d", cfg.ChunkSize),
+		"-initially-drop-old-table",
+		"-initially-drop-ghost-table",
+		"-exact-rowcount=false",
+		"-hooks-enabled",
+		fmt.Sprintf("-hooks-path=%s", hooksPath),
+		fmt.Sprintf("-hooks-hint=%s", stmt.UUID),
+		fmt.Sprintf("-postpone-cut-over-flag-file=%s", stmt.PostponeFlagFile()),
+	}
+	if cfg.MaxLoad != "" {
+		args = append(args, fmt.Sprintf("-max-load=%s", cfg.MaxLoad))
+	}
+	if cfg.CriticalLoad != "" {
+		args = append(args, fmt.Sprintf("-critical-load=%s", cfg.CriticalLoad))
+	}
+	if cfg.AllowOnMaster {
+		args = append(args, "-allow-on-master")
+	}
+	args = append(args, cfg.Args...)
+	return append(args, "-execute")
+}
+
+// Execute 执行gh-ost，gh-ost的输出写入语句的结果
+func (e *GhostExecutor) Execute(stmt *models.Statement) (err error) {
+	// gh-ost退出之后标记文件没有意义，留下的文件会让之后的控制误以为推迟了切换
+	defer os.Remove(stmt.PostponeFlagFile())
+
+	var dir, exe, conf string
+	if dir, err = ioutil.TempDir("", "halocli-ghost-"); err != nil {
+		return
+	}
+	defer os.RemoveAll(dir)
+	if exe, err = os.Executable(); err != nil {
+		return
+	}
+	if err = writeGhostHooks(dir, exe); err != nil {
+		return
+	}
+	if conf, err = writeDefaults(dir, e.Cluster, e.Passwd); err != nil {
+		return
+	}
+	if e.Config.PostponeCutOver {
+		// 复制完成后等待unpostponeCutOver
+		if err = ioutil.WriteFile(stmt.PostponeFlagFile(), nil, 0644); err != nil {
+			return
+		}
+	}
+
+	var buf bytes.Buffer
+	cmd := exec.Command(e.Config.Path, e.Args(stmt, dir, conf)...)
+	cmd.Stdout = &buf
+	cmd.Stderr = &buf
+	err = cmd.Run()
+	stmt.Results = tail(buf.String(), 4096)
+	if err != nil {
+		err = fmt.Errorf("gh-ost执行失败, %s", err.Error())
+	}
+	return
+}
+
+// writeGhostHooks 在目录中生成回调脚本，每个回调调用halocli ghost记录
+func writeGhostHooks(dir, exe string) error {
+	for _, hook := range ghostHooks {
+		script := fmt.Sprintf("#!/bin/sh\nexec \"%s\" ghost --%s\n", exe, hook)
+		if err := ioutil.WriteFile(filepath.Join(dir, "gh-ost-"+hook), []byte(script), 0755); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeDefaults 在目录中生成连接群集的选项文件，只有当前用户可读
+func writeDefaults(dir string, cluster *models.Cluster, passwd []byte) (string, error) {
+	conf := filepath.Join(dir, "client.cnf")
+	content := fmt.Sprintf("[client]\nuser=%s\npassword=%s\n", cluster.User, string(passwd))
+	return conf, ioutil.WriteFile(conf, []byte(content), 0600)
+}
@@ -0,0 +1,58 @@
+package executors
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mia0x75/halo/g"
+	"github.com/mia0x75/halo/models"
+)
+
+func TestGhostArgs(t *testing.T) {
+	cfg := &g.GhostConfig{ChunkSize: 1000, MaxLoad: "Threads_running=100", AllowOnMaster: true, Args: []string{"-cut-over=default"}}
+	e := &GhostExecutor{
+		Cluster: &models.Cluster{IP: "127.0.0.1", Port: 3306, User: "root"},
+		Config:  cfg,
+		Target:  targetOf(t, "ALTER TABLE t1 DROP COLUMN c1"),
+	}
+	stmt := &models.Statement{UUID: "0b7f3c1e-2d4a-4e5b-8c6d-9a1b2c3d4e5f"}
+	args := e.Args(stmt, "/tmp/hooks", "/tmp/hooks/client.cnf")
+	assert.Contains(t, args, "-alter=DROP COLUMN `c1`")
+	assert.Contains(t, args, "-hooks-hint="+stmt.UUID)
+	assert.Contains(t, args, "-postpone-cut-over-flag-file="+stmt.PostponeFlagFile())
+	assert.Contains(t, args, "-max-load=Threads_running=100")
+	assert.Contains(t, args, "-allow-on-master")
+	assert.Contains(t, args, "-cut-over=default")
+	assert.NotContains(t, strings.Join(args, " "), "-critical-load")
+	assert.Equal(t, "-execute", args[len(args)-1])
+}
+
+func TestGhostExecute(t *testing.T) {
+	stub, err := filepath.Abs(filepath.Join("testdata", "gh-ost"))
+	assert.NoError(t, err)
+	executor := func(sql string) *GhostExecutor {
+		return &GhostExecutor{
+			Cluster: &models.Cluster{IP: "127.0.0.1", Port: 3306, User: "root"},
+			Passwd:  []byte("secret"),
+			Config:  &g.GhostConfig{Path: stub, ChunkSize: 1000, PostponeCutOver: true},
+			Target:  targetOf(t, sql),
+		}
+	}
+
+	stmt := &models.Statement{UUID: "0b7f3c1e-2d4a-4e5b-8c6d-9a1b2c3d4e5f"}
+	assert.NoError(t, executor("ALTER TABLE t1 ADD COLUMN c1 INT").Execute(stmt))
+	assert.Contains(t, stmt.Results, "-alter=ADD COLUMN `c1` INT\n")
+	assert.Contains(t, stmt.Results, "hooks ok\n")
+	assert.Contains(t, stmt.Results, "conf ok\n")
+	// 密码不出现在命令行中
+	assert.NotContains(t, stmt.Results, "secret")
+	// 执行结束后删除推迟切换的标记
+	_, err = os.Stat(stmt.PostponeFlagFile())
+	assert.True(t, os.IsNotExist(err))
+
+	assert.Error(t, executor("ALTER TABLE t1 ADD COLUMN fail INT").Execute(stmt))
+}
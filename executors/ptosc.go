@@ -0,0 +1,175 @@
+package executors
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mia0x75/halo/g"
+	"github.com/mia0x75/halo/models"
+)
+
+// PtOscExecutor 使用pt-online-schema-change执行ALTER TABLE，执行过程中的进度写入语句的结果
+type PtOscExecutor struct {
+	Cluster  *models.Cluster
+	Passwd   []byte
+	Config   *g.PtOscConfig
+	Target   *Target
+	Progress func(stmt *models.Statement) // 复制进度有变化时调用
+}
+
+// Name 执行方式的名称
+func (e *PtOscExecutor) Name() string {
+	return PtOsc
+}
+
+// Args pt-online-schema-change的命令行参数，mode是--execute或者--dry-run，
+// 密码写在选项文件中，通过DSN的F引用，避免出现在进程列表里
+func (e *PtOscExecutor) Args(conf, mode string) []string {
+	cfg := e.Config
+	args := []string{
+		fmt.Sprintf("--alter=%s", e.Target.Alter),
+		fmt.Sprintf("--chunk-size=%d", cfg.ChunkSize),
+		"--progress=percentage,1",
+	}
+	if cfg.MaxLoad != "" {
+		args = append(args, fmt.Sprintf("--max-load=%s", cfg.MaxLoad))
+	}
+	if cfg.CriticalLoad != "" {
+		args = append(args, fmt.Sprintf("--critical-load=%s", cfg.CriticalLoad))
+	}
+	if cfg.PreserveTriggers {
+		args = append(args, "--preserve-triggers")
+	}
+	args = append(args, cfg.Args...)
+	args = append(args, mode)
+	return append(args, fmt.Sprintf("F=%s,h=%s,P=%d,D=%s,t=%s", conf, e.Cluster.IP, e.Cluster.Port, e.Target.Database, e.Target.Table))
+}
+
+// Execute 执行变更
+func (e *PtOscExecutor) Execute(stmt *models.Statement) error {
+	return e.run(stmt, "--execute")
+}
+
+// DryRun 创建并修改新表，但是不复制数据也不切换，用于审核时检查变更能否执行
+func (e *PtOscExecutor) DryRun(stmt *models.Statement) error {
+	return e.run(&models.Statement{UUID: stmt.UUID, Content: stmt.Content}, "--dry-run")
+}
+
+func (e *PtOscExecutor) run(stmt *models.Statement, mode string) (err error) {
+	var dir, conf string
+	if dir, err = ioutil.TempDir("", "halocli-ptosc-"); err != nil {
+		return
+	}
+	defer os.RemoveAll(dir)
+	if conf, err = writeDefaults(dir, e.Cluster, e.Passwd); err != nil {
+		return
+	}
+
+	pr, pw := io.Pipe()
+	cmd := exec.Command(e.Config.Path, e.Args(conf, mode)...)
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	report := &PtOscReport{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(pr)
+		for scanner.Scan() {
+			if report.Feed(scanner.Text()) && e.Progress != nil {
+				stmt.Results = report.String()
+				e.Progress(stmt)
+			}
+		}
+		// 读取出错时丢弃剩余的输出，避免阻塞pt-osc
+		io.Copy(ioutil.Discard, pr)
+	}()
+
+	err = cmd.Run()
+	pw.Close()
+	<-done
+
+	stmt.Results = report.String()
+	if err != nil {
+		err = fmt.Errorf("pt-online-schema-change执行失败, %s", report.Summary(err.Error()))
+	}
+	return
+}
+
+var (
+	ptOscTimestamp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\s+`)
+	ptOscRows      = regexp.MustCompile(`^Copying approximately (\d+) rows`)
+	ptOscProgress  = regexp.MustCompile(`^Copying \S+:\s+(\d+)% (\S+) remain`)
+)
+
+// PtOscReport pt-online-schema-change输出中的进度和结果
+type PtOscReport struct {
+	Rows    int64    // 估算的行数
+	Percent int      // 复制进度的百分比
+	Remain  string   // 预计剩余的时间
+	Steps   []string // 已经完成的步骤
+	Result  string   // 最终的结果
+	last    string   // 正在执行的步骤，出错时是错误信息
+}
+
+// Feed 解析一行输出，复制进度有变化时返回true
+func (r *PtOscReport) Feed(line string) bool {
+	line = strings.TrimSpace(ptOscTimestamp.ReplaceAllString(strings.TrimSpace(line), ""))
+	if line == "" {
+		return false
+	}
+	if match := ptOscProgress.FindStringSubmatch(line); match != nil {
+		percent, _ := strconv.Atoi(match[1])
+		changed := percent != r.Percent
+		r.Percent, r.Remain = percent, match[2]
+		return changed
+	}
+	if match := ptOscRows.FindStringSubmatch(line); match != nil {
+		r.Rows, _ = strconv.ParseInt(match[1], 10, 64)
+		return false
+	}
+	switch {
+	case strings.HasPrefix(line, "Successfully altered"), strings.HasPrefix(line, "Dry run complete"):
+		r.Result = line
+	case strings.HasSuffix(line, "OK."):
+		r.Steps = append(r.Steps, line)
+		r.last = ""
+	default:
+		r.last = line
+	}
+	return false
+}
+
+// Summary 结果的摘要，没有结果时使用正在执行的步骤或者错误信息
+func (r *PtOscReport) Summary(fallback string) string {
+	if r.Result != "" {
+		return r.Result
+	}
+	if r.last != "" {
+		return r.last
+	}
+	return fallback
+}
+
+// String 写入语句结果的内容
+func (r *PtOscReport) String() string {
+	L := []string{}
+	if r.Rows > 0 {
+		L = append(L, fmt.Sprintf("估算行数：%d", r.Rows))
+	}
+	if r.Percent > 0 {
+		L = append(L, fmt.Sprintf("复制进度：%d%%，剩余时间：%s", r.Percent, r.Remain))
+	}
+	L = append(L, r.Steps...)
+	if s := r.Summary(""); s != "" {
+		L = append(L, s)
+	}
+	return strings.Join(L, "\n")
+}
@@ -0,0 +1,97 @@
+package executors
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mia0x75/halo/g"
+	"github.com/mia0x75/halo/models"
+)
+
+func TestPtOscReport(t *testing.T) {
+	r := &PtOscReport{}
+	output := []string{
+		"Altering `db1`.`t1`...",
+		"Creating new table...",
+		"Created new table db1._t1_new OK.",
+		"2020-06-01T10:00:00 Copying approximately 1000000 rows...",
+		"Copying `db1`.`t1`:  45% 00:30 remain",
+		"Copying `db1`.`t1`:  45% 00:28 remain",
+		"Copying `db1`.`t1`:  90% 00:05 remain",
+		"2020-06-01T10:01:00 Copied rows OK.",
+		"2020-06-01T10:01:00 Swapping tables...",
+	}
+	changes := 0
+	for _, line := range output {
+		if r.Feed(line) {
+			changes++
+		}
+	}
+	assert.Equal(t, 2, changes)
+	assert.Equal(t, int64(1000000), r.Rows)
+	assert.Equal(t, 90, r.Percent)
+	assert.Equal(t, "00:05", r.Remain)
+	assert.Equal(t, []string{"Created new table db1._t1_new OK.", "Copied rows OK."}, r.Steps)
+	// 没有结果时是正在执行的步骤
+	assert.Equal(t, "Swapping tables...", r.Summary("exit status 1"))
+	assert.Equal(t, "估算行数：1000000\n复制进度：90%，剩余时间：00:05\nCreated new table db1._t1_new OK.\nCopied rows OK.\nSwapping tables...", r.String())
+
+	r.Feed("Successfully altered `db1`.`t1`.")
+	assert.Equal(t, "Successfully altered `db1`.`t1`.", r.Summary(""))
+	assert.Equal(t, "exit status 1", (&PtOscReport{}).Summary("exit status 1"))
+}
+
+func TestPtOscArgs(t *testing.T) {
+	e := &PtOscExecutor{
+		Cluster: &models.Cluster{IP: "127.0.0.1", Port: 3306, User: "root"},
+		Config:  &g.PtOscConfig{ChunkSize: 500, CriticalLoad: "Threads_running=200", PreserveTriggers: true, Args: []string{"--no-drop-old-table"}},
+		Target:  targetOf(t, "ALTER TABLE t1 DROP COLUMN c1"),
+	}
+	args := e.Args("/tmp/client.cnf", "--dry-run")
+	assert.Contains(t, args, "--alter=DROP COLUMN `c1`")
+	assert.Contains(t, args, "--chunk-size=500")
+	assert.Contains(t, args, "--critical-load=Threads_running=200")
+	assert.Contains(t, args, "--preserve-triggers")
+	assert.Contains(t, args, "--no-drop-old-table")
+	assert.Contains(t, args, "--dry-run")
+	assert.NotContains(t, strings.Join(args, " "), "--max-load")
+	assert.Equal(t, "F=/tmp/client.cnf,h=127.0.0.1,P=3306,D=db1,t=t1", args[len(args)-1])
+}
+
+func TestPtOscExecute(t *testing.T) {
+	stub, err := filepath.Abs(filepath.Join("testdata", "pt-online-schema-change"))
+	assert.NoError(t, err)
+	progress := []string{}
+	executor := func(sql string) *PtOscExecutor {
+		return &PtOscExecutor{
+			Cluster:  &models.Cluster{IP: "127.0.0.1", Port: 3306, User: "root"},
+			Passwd:   []byte("secret"),
+			Config:   &g.PtOscConfig{Path: stub, ChunkSize: 1000},
+			Target:   targetOf(t, sql),
+			Progress: func(stmt *models.Statement) { progress = append(progress, stmt.Results) },
+		}
+	}
+
+	stmt := &models.Statement{UUID: "0b7f3c1e-2d4a-4e5b-8c6d-9a1b2c3d4e5f"}
+	assert.NoError(t, executor("ALTER TABLE t1 ADD COLUMN c1 INT").Execute(stmt))
+	assert.Len(t, progress, 2)
+	assert.Contains(t, progress[0], "复制进度：45%，剩余时间：00:30")
+	assert.Contains(t, stmt.Results, "估算行数：1000000\n复制进度：90%")
+	assert.Contains(t, stmt.Results, "Dropped triggers OK.\nSuccessfully altered `db1`.`t1`.")
+
+	// 检查不修改语句的结果
+	stmt = &models.Statement{UUID: stmt.UUID}
+	assert.NoError(t, executor("ALTER TABLE t1 ADD COLUMN c1 INT").DryRun(stmt))
+	assert.Equal(t, "", stmt.Results)
+
+	err = executor("ALTER TABLE t1 ADD COLUMN fail INT").DryRun(stmt)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Unknown column 'fail'")
+	}
+	err = executor("ALTER TABLE t1 ADD COLUMN fail INT").Execute(stmt)
+	assert.Error(t, err)
+	assert.Contains(t, stmt.Results, "Unknown column 'fail'")
+}
@@ -0,0 +1,259 @@
+package executors
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mia0x75/parser/ast"
+	log "github.com/sirupsen/logrus"
+	"xorm.io/xorm"
+
+	"github.com/mia0x75/halo/binlog"
+	"github.com/mia0x75/halo/g"
+	"github.com/mia0x75/halo/gqlapi"
+	"github.com/mia0x75/halo/models"
+	"github.com/mia0x75/halo/validate"
+)
+
+// maxRollbackSize 回滚语句的最大长度，和语句表中MEDIUMTEXT的上限一致
+const maxRollbackSize = 1<<24 - 1
+
+// Recorder 记录直接执行的DML语句前后的binlog位置，执行结束后从binlog生成回滚语句
+type Recorder struct {
+	Cluster  *models.Cluster
+	Database string
+	Passwd   []byte
+	Engine   *xorm.Engine // 执行语句的连接
+	Backup   *xorm.Engine // 备份库，为nil时不记录binlog位置
+	Config   *g.BinlogConfig
+	records  []*record
+	current  *record
+}
+
+type record struct {
+	stmt  *models.Statement
+	rng   *binlog.Range
+	begin time.Time
+}
+
+// NewRecorder 检查群集的binlog设置，只有ROW格式并且binlog_row_image是FULL时才能生成回滚语句
+func NewRecorder(cluster *models.Cluster, database string, passwd []byte, engine *xorm.Engine) (*Recorder, error) {
+	rows, err := engine.QueryString("SELECT @@log_bin AS log_bin, @@binlog_format AS binlog_format, @@binlog_row_image AS binlog_row_image")
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 || rows[0]["log_bin"] != "1" {
+		return nil, fmt.Errorf("群集没有开启binlog")
+	}
+	if rows[0]["binlog_format"] != "ROW" || rows[0]["binlog_row_image"] != "FULL" {
+		return nil, fmt.Errorf("群集的binlog_format是%s，binlog_row_image是%s，需要ROW和FULL", rows[0]["binlog_format"], rows[0]["binlog_row_image"])
+	}
+	// 所有语句在同一个连接上执行，binlog中按线程号区分其他连接的修改
+	engine.SetMaxOpenConns(1)
+	engine.SetConnMaxLifetime(0)
+	return &Recorder{
+		Cluster:  cluster,
+		Database: database,
+		Passwd:   passwd,
+		Engine:   engine,
+		Config:   g.Config().Binlog,
+	}, nil
+}
+
+// IsDML 是否需要生成回滚语句的DML语句
+func IsDML(stmt *models.Statement) bool {
+	switch stmt.Type {
+	case gqlapi.StatementTypeEnumMap[gqlapi.StatementTypeEnumInsert],
+		gqlapi.StatementTypeEnumMap[gqlapi.StatementTypeEnumUpdate],
+		gqlapi.StatementTypeEnumMap[gqlapi.StatementTypeEnumDelete]:
+		return true
+	}
+	return false
+}
+
+// Begin 语句执行前记录线程号和binlog位置
+func (r *Recorder) Begin(stmt *models.Statement) {
+	r.current = nil
+	if !IsDML(stmt) {
+		return
+	}
+	rows, err := r.Engine.QueryString("SELECT CONNECTION_ID() AS thread")
+	if err != nil || len(rows) == 0 {
+		log.Warnf("[W] 获取语句(uuid=%s)执行的线程号失败: %v", stmt.UUID, err)
+		return
+	}
+	thread, _ := strconv.ParseUint(rows[0]["thread"], 10, 32)
+	start, err := r.position()
+	if err != nil {
+		log.Warnf("[W] 获取语句(uuid=%s)执行前的binlog位置失败: %s", stmt.UUID, err.Error())
+		return
+	}
+	r.current = &record{
+		stmt:  stmt,
+		rng:   &binlog.Range{Start: start, Thread: uint32(thread)},
+		begin: time.Now(),
+	}
+}
+
+// End 语句执行后记录binlog位置
+func (r *Recorder) End(stmt *models.Statement) {
+	if r.current == nil || r.current.stmt != stmt {
+		return
+	}
+	end, err := r.position()
+	if err != nil {
+		log.Warnf("[W] 获取语句(uuid=%s)执行后的binlog位置失败: %s", stmt.UUID, err.Error())
+		return
+	}
+	r.current.rng.End = end
+	r.records = append(r.records, r.current)
+	r.current = nil
+}
+
+// position SHOW MASTER STATUS的位置
+func (r *Recorder) position() (pos binlog.Position, err error) {
+	rows := []map[string]string{}
+	if rows, err = r.Engine.QueryString("SHOW MASTER STATUS"); err != nil {
+		return
+	}
+	if len(rows) == 0 {
+		err = fmt.Errorf("群集没有开启binlog")
+		return
+	}
+	n, _ := strconv.ParseUint(rows[0]["Position"], 10, 32)
+	return binlog.Position{File: rows[0]["File"], Pos: uint32(n)}, nil
+}
+
+// Rollback 从binlog中读取语句执行期间产生的行事件，生成回滚语句保存到语句的Rollback
+func (r *Recorder) Rollback() (err error) {
+	if len(r.records) == 0 {
+		return
+	}
+	r.save()
+
+	var dir, conf string
+	if dir, err = ioutil.TempDir("", "halocli-binlog-"); err != nil {
+		return
+	}
+	defer os.RemoveAll(dir)
+	if conf, err = writeDefaults(dir, r.Cluster, r.Passwd); err != nil {
+		return
+	}
+	ranges := []*binlog.Range{}
+	for _, rec := range r.records {
+		ranges = append(ranges, rec.rng)
+	}
+	files := binlog.Files(ranges[0].Start, ranges[len(ranges)-1].End)
+	if err = binlog.Dump(r.Config.Path, conf, r.Cluster.IP, r.Cluster.Port, dir, files); err != nil {
+		return
+	}
+	events := [][]*binlog.RowsEvent{}
+	if events, err = binlog.Collect(dir, files, ranges); err != nil {
+		return
+	}
+
+	for i, rec := range r.records {
+		sqls, err := binlog.Rollback(events[i], r.schema)
+		if err != nil {
+			log.Warnf("[W] 生成语句(uuid=%s)的回滚语句失败: %s", rec.stmt.UUID, err.Error())
+			continue
+		}
+		rollback := strings.Join(sqls, "\n")
+		if len(rollback) > maxRollbackSize {
+			log.Warnf("[W] 语句(uuid=%s)的回滚语句超过%d字节，binlog位置%s到%s", rec.stmt.UUID, maxRollbackSize, rec.rng.Start, rec.rng.End)
+			continue
+		}
+		rec.stmt.Rollback = rollback
+	}
+	return
+}
+
+// save 在备份库中记录语句执行前后的binlog位置，即使回滚语句生成失败也可以根据位置手工处理
+func (r *Recorder) save() {
+	if r.Backup == nil {
+		return
+	}
+	for _, rec := range r.records {
+		table, tp := "", ""
+		if nodes, err := validate.Parse(rec.stmt.Content); err == nil && len(nodes) == 1 {
+			table = dmlTable(nodes[0])
+		}
+		for k, v := range gqlapi.StatementTypeEnumMap {
+			if v == rec.stmt.Type {
+				tp = string(k)
+			}
+		}
+		rollback := &models.Rollback{
+			OpidTime:        fmt.Sprintf("%d_%d_%d", rec.begin.Unix(), rec.rng.Thread, rec.stmt.Sequence),
+			StartBinlogFile: rec.rng.Start.File,
+			StartBinlogPos:  rec.rng.Start.Pos,
+			EndBinlogFile:   rec.rng.End.File,
+			EndBinlogPos:    rec.rng.End.Pos,
+			Content:         rec.stmt.Content,
+			Host:            r.Cluster.Host,
+			Database:        r.Database,
+			Table:           table,
+			Port:            r.Cluster.Port,
+			Duration:        rec.begin,
+			Type:            tp,
+		}
+		if _, err := r.Backup.Insert(rollback); err != nil {
+			log.Warnf("[W] 记录语句(uuid=%s)的binlog位置失败: %s", rec.stmt.UUID, err.Error())
+		}
+	}
+}
+
+// schema 生成回滚语句需要的表结构
+func (r *Recorder) schema(database, table string) (*binlog.Schema, error) {
+	sql := `
+	SELECT COLUMN_NAME,
+	       COLUMN_TYPE,
+	       COLUMN_KEY
+	  FROM information_schema.COLUMNS
+	 WHERE TABLE_SCHEMA = ?
+	   AND TABLE_NAME = ?
+	 ORDER BY ORDINAL_POSITION
+	`
+	rows, err := r.Engine.QueryString(sql, database, table)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("表%s.%s不存在", database, table)
+	}
+	schema := &binlog.Schema{}
+	for i, row := range rows {
+		schema.Columns = append(schema.Columns, row["COLUMN_NAME"])
+		schema.Unsigned = append(schema.Unsigned, strings.Contains(row["COLUMN_TYPE"], "unsigned"))
+		if row["COLUMN_KEY"] == "PRI" {
+			schema.PrimaryKey = append(schema.PrimaryKey, i)
+		}
+	}
+	return schema, nil
+}
+
+// dmlTable DML语句修改的第一个表
+func dmlTable(node ast.StmtNode) string {
+	var refs *ast.TableRefsClause
+	switch x := node.(type) {
+	case *ast.InsertStmt:
+		refs = x.Table
+	case *ast.UpdateStmt:
+		refs = x.TableRefs
+	case *ast.DeleteStmt:
+		refs = x.TableRefs
+	}
+	if refs == nil || refs.TableRefs == nil {
+		return ""
+	}
+	if ts, ok := refs.TableRefs.Left.(*ast.TableSource); ok {
+		if tn, ok := ts.Source.(*ast.TableName); ok {
+			return tn.Name.O
+		}
+	}
+	return ""
+}
@@ -0,0 +1,28 @@
+package executors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mia0x75/halo/gqlapi"
+	"github.com/mia0x75/halo/models"
+	"github.com/mia0x75/halo/validate"
+)
+
+func TestDMLTable(t *testing.T) {
+	for sql, table := range map[string]string{
+		"INSERT INTO t1 (c1) VALUES (1)":                   "t1",
+		"UPDATE db1.t2 SET c1 = 1 WHERE id = 1":            "t2",
+		"DELETE FROM t3 WHERE id = 1":                      "t3",
+		"UPDATE t1 JOIN t2 ON t1.id = t2.id SET t1.c1 = 1": "t1",
+		"ALTER TABLE t1 DROP COLUMN c1":                    "",
+	} {
+		nodes, err := validate.Parse(sql)
+		assert.NoError(t, err)
+		assert.Equal(t, table, dmlTable(nodes[0]), sql)
+	}
+
+	assert.True(t, IsDML(&models.Statement{Type: gqlapi.StatementTypeEnumMap[gqlapi.StatementTypeEnumUpdate]}))
+	assert.False(t, IsDML(&models.Statement{Type: gqlapi.StatementTypeEnumMap[gqlapi.StatementTypeEnumAlterTable]}))
+}
@@ -46,16 +46,57 @@ type MailConfig struct {
 	Encryption string `json:"encryption"`
 }
 
+// GhostConfig 大表结构变更使用gh-ost执行的配置
+type GhostConfig struct {
+	Enabled         bool     `json:"enabled"`           // 是否启用
+	Path            string   `json:"path"`              // gh-ost的路径，默认在PATH中查找
+	Threshold       int64    `json:"threshold"`         // 表的大小(MB)超过该值时使用gh-ost，默认1024
+	MaxLoad         string   `json:"max_load"`          // "Threads_running=100,Threads_connected=500"
+	CriticalLoad    string   `json:"critical_load"`     // "Threads_running=200"
+	ChunkSize       int      `json:"chunk_size"`        // 每次复制的行数，默认1000
+	AllowOnMaster   bool     `json:"allow_on_master"`   // 直接在主库上读取binlog
+	PostponeCutOver bool     `json:"postpone_cut_over"` // 复制完成后等待人工切换
+	Args            []string `json:"args"`              // 其他参数
+}
+
+// PtOscConfig 使用pt-online-schema-change执行结构变更的配置，群集上有触发器无法使用gh-ost时使用
+type PtOscConfig struct {
+	Path             string   `json:"path"`              // pt-online-schema-change的路径，默认在PATH中查找
+	Threshold        int64    `json:"threshold"`         // 表的大小(MB)超过该值时使用pt-osc，默认1024
+	MaxLoad          string   `json:"max_load"`          // "Threads_running=25"
+	CriticalLoad     string   `json:"critical_load"`     // "Threads_running=50"
+	ChunkSize        int      `json:"chunk_size"`        // 每次复制的行数，默认1000
+	PreserveTriggers bool     `json:"preserve_triggers"` // 保留原表上的触发器，需要MySQL 5.7.2以上
+	DryRun           bool     `json:"dry_run"`           // 审核时使用--dry-run检查
+	Args             []string `json:"args"`              // 其他参数
+}
+
+// BinlogConfig 从binlog生成DML回滚语句的配置，群集需要使用ROW格式和FULL的binlog_row_image
+type BinlogConfig struct {
+	Enabled bool   `json:"enabled"`
+	Path    string `json:"path"` // mysqlbinlog的路径，默认在PATH中查找
+}
+
+// RowBackupConfig UPDATE和DELETE语句执行之前把将被修改的行复制到备份库的配置
+type RowBackupConfig struct {
+	Enabled bool  `json:"enabled"`
+	MaxSize int64 `json:"max_size"` // 每条语句备份的最大数据量(MB)，超过时只备份前面的行，默认64
+}
+
 // GlobalConfig 配置
 type GlobalConfig struct {
-	Log      *LogConfig      `json:"log"`
-	Cert     string          `json:"cert"`
-	Key      string          `json:"key"`
-	Database *DatabaseConfig `json:"database"`
-	Backup   *DatabaseConfig `json:"backup"`
-	Mail     *MailConfig     `json:"mail"`
-	Listen   string          `json:"listen"`
-	Secret   *SecretConfig   `json:"secret"`
+	Log       *LogConfig       `json:"log"`
+	Cert      string           `json:"cert"`
+	Key       string           `json:"key"`
+	Database  *DatabaseConfig  `json:"database"`
+	Backup    *DatabaseConfig  `json:"backup"`
+	Mail      *MailConfig      `json:"mail"`
+	Listen    string           `json:"listen"`
+	Secret    *SecretConfig    `json:"secret"`
+	Ghost     *GhostConfig     `json:"ghost"`
+	PtOsc     *PtOscConfig     `json:"pt_osc"`
+	Binlog    *BinlogConfig    `json:"binlog"`
+	RowBackup *RowBackupConfig `json:"row_backup"`
 }
 
 var (
@@ -127,6 +168,42 @@ func ParseConfig(cfg string) {
 	if config.Key == "" {
 		config.Key = "key.pem"
 	}
+	if config.Ghost == nil {
+		config.Ghost = &GhostConfig{}
+	}
+	if config.Ghost.Path == "" {
+		config.Ghost.Path = "gh-ost"
+	}
+	if config.Ghost.Threshold <= 0 {
+		config.Ghost.Threshold = 1024
+	}
+	if config.Ghost.ChunkSize <= 0 {
+		config.Ghost.ChunkSize = 1000
+	}
+	if config.PtOsc == nil {
+		config.PtOsc = &PtOscConfig{}
+	}
+	if config.PtOsc.Path == "" {
+		config.PtOsc.Path = "pt-online-schema-change"
+	}
+	if config.PtOsc.Threshold <= 0 {
+		config.PtOsc.Threshold = 1024
+	}
+	if config.PtOsc.ChunkSize <= 0 {
+		config.PtOsc.ChunkSize = 1000
+	}
+	if config.Binlog == nil {
+		config.Binlog = &BinlogConfig{}
+	}
+	if config.Binlog.Path == "" {
+		config.Binlog.Path = "mysqlbinlog"
+	}
+	if config.RowBackup == nil {
+		config.RowBackup = &RowBackupConfig{}
+	}
+	if config.RowBackup.MaxSize <= 0 {
+		config.RowBackup.MaxSize = 64
+	}
 
 	log.Debugf("[D] 读取配置文件 \"%s\" 成功。", ConfigFile)
 }
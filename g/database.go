@@ -29,3 +29,24 @@ func InitDB() (err error) {
 	}
 	return
 }
+
+// BackupEngine 备份库的XORM引擎，没有配置备份库时为nil
+var BackupEngine *xorm.Engine
+
+// InitBackupDB 初始化备份库的连接，没有配置备份库时跳过
+func InitBackupDB() (err error) {
+	cfg := Config()
+	if cfg.Backup == nil || cfg.Backup.Addr == "" {
+		return
+	}
+	if BackupEngine, err = xorm.NewEngine("mysql", cfg.Backup.Addr); err != nil {
+		return
+	}
+	BackupEngine.SetMaxIdleConns(cfg.Backup.MaxIdle)
+	BackupEngine.SetMaxOpenConns(cfg.Backup.MaxConnections)
+	BackupEngine.SetConnMaxLifetime(time.Duration(cfg.Backup.WaitTimeout) * time.Second)
+	if err = BackupEngine.DB().Ping(); err != nil {
+		BackupEngine = nil
+	}
+	return
+}
@@ -28,6 +28,34 @@ type ActivatePayload struct {
 	Email string `json:"Email"`
 }
 
+// 工单自动修正的结果，工单内容保持不变，由开发人员决定是否采纳
+type AutofixPayload struct {
+	// 修正之后的工单内容
+	Content string `json:"Content"`
+	// 修正前后工单内容的差异，统一格式(unified)
+	Diff string `json:"Diff"`
+	// 所做的修正，Fix是修正的内容，行和列是在原工单内容中的位置
+	Fixes []*models.Clause `json:"Fixes,omitempty"`
+}
+
+type BackupRowConnection struct {
+	// 分页信息
+	PageInfo *PageInfo `json:"pageInfo"`
+	// 记录信息
+	Edges []*BackupRowEdge `json:"edges,omitempty"`
+	// 记录总数
+	TotalCount int `json:"totalCount"`
+	// 备份的行超过上限，只备份了语句修改的前面一部分行
+	Truncated bool `json:"truncated"`
+}
+
+type BackupRowEdge struct {
+	// 端
+	Node *models.RowBackup `json:"node,omitempty"`
+	// 分页时使用的光标
+	Cursor string `json:"cursor"`
+}
+
 type ClusterConnection struct {
 	// 分页信息
 	PageInfo *PageInfo `json:"pageInfo"`
@@ -145,6 +173,14 @@ type QueryEdge struct {
 	Cursor string `json:"cursor"`
 }
 
+// 根据备份的行生成的恢复语句
+type RestorePayload struct {
+	// 恢复语句，被删除的行重新插入，被修改的行按主键或者唯一键覆盖
+	Content string `json:"Content"`
+	// 备份的行超过上限被截断，恢复语句只包含前面一部分行
+	Truncated bool `json:"Truncated"`
+}
+
 type StatementConnection struct {
 	// 分页信息
 	PageInfo *PageInfo `json:"pageInfo"`
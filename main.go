@@ -21,6 +21,7 @@ import (
 	"github.com/mia0x75/halo/crons"
 	"github.com/mia0x75/halo/g"
 	"github.com/mia0x75/halo/routers"
+	"github.com/mia0x75/halo/validate"
 )
 
 const ticketLoaderKey = "ticketloader"
@@ -69,8 +70,21 @@ func main() {
 	if err := g.InitDB(); err != nil {
 		os.Exit(0)
 	}
+	// 浏览备份的行需要备份库，没有配置时相关的查询返回错误
+	if err := g.InitBackupDB(); err != nil {
+		log.Warnf("[W] 连接备份库失败: %s", err.Error())
+	}
+	// 规则没有对应的实现时关闭规则并记录错误，避免审核时静默跳过
+	caches.RulesMap.Resolved = validate.Resolved
 	caches.Init()
-	crons.NewScheduler()
+	if err := validate.Verify(caches.RulesMap.All()); err != nil {
+		log.Errorf("[E] 已关闭没有实现的规则: %s", err.Error())
+	}
+	s := crons.NewScheduler()
+	// 定期刷新群集的版本，版本相关的审核规则依赖这些信息
+	if _, err := s.RunEvery(time.Hour, "刷新群集版本", "refresh-versions"); err != nil {
+		log.Warnf("[W] 调度刷新群集版本的任务失败: %s", err.Error())
+	}
 
 	addr := g.Config().Listen
 	log.Infof("[I] http listening %s", addr)
@@ -3,6 +3,7 @@ package models
 import (
 	"bytes"
 	"crypto/sha1"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strconv"
@@ -16,19 +17,22 @@ import (
 
 // Cluster 群集的模型
 type Cluster struct {
-	ClusterID   uint   `xorm:"'cluster_id' notnull int pk autoincr"                      valid:"-"                                json:"cluster_id"  gqlgen:"-"`        //
-	UUID        string `xorm:"'uuid' notnull char(36) unique(unique_1)"                  valid:"-"                                json:"uuid"        gqlgen:"UUID"`     //
-	Host        string `xorm:"'host' notnull varchar(150) unique(unique_2)"              valid:"required,length(1|100),alphanum"  json:"host"        gqlgen:"Host"`     //
-	IP          string `xorm:"'ip' notnull varchar(15) unique(unique_3)"                 valid:"required,int,range(0|4294967295)" json:"ip"          gqlgen:"Ip"`       //
-	Port        uint16 `xorm:"'port' notnull smallint unique(unique_2) unique(unique_3)" valid:"required,port"                    json:"port"        gqlgen:"Port"`     //
-	Alias       string `xorm:"'alias' notnull varchar(75) unique(unique_4)"              valid:"required,runelength(1|100)"       json:"alias"       gqlgen:"Alias"`    //
-	User        string `xorm:"'user' notnull varchar(50)"                                valid:"required,length(1|50),alphanum"   json:"user"        gqlgen:"User"`     //
-	Password    []byte `xorm:"'password' notnull varbinary(48)"                          valid:"required"                         json:"-"           gqlgen:"-"`        // 双向加密
-	FingerPrint []byte `xorm:"'fingerprint' notnull varbinary(16)"                       valid:"required"                         json:"-"           gqlgen:"-"`        //
-	Status      uint8  `xorm:"'status' notnull tinyint"                                  valid:"required,matches(^[0-9]$)"        json:"status"      gqlgen:"Status"`   //
-	Version     int    `xorm:"'version'"                                                 valid:"-"                                json:"version"     gqlgen:"-"`        //
-	UpdateAt    uint   `xorm:"'update_at' notnull int"                                   valid:"-"                                json:"update_at"   gqlgen:"UpdateAt"` //
-	CreateAt    uint   `xorm:"'create_at' notnull int"                                   valid:"-"                                json:"create_at"   gqlgen:"CreateAt"` //
+	ClusterID      uint   `xorm:"'cluster_id' notnull int pk autoincr"                      valid:"-"                                json:"cluster_id"      gqlgen:"-"`              //
+	UUID           string `xorm:"'uuid' notnull char(36) unique(unique_1)"                  valid:"-"                                json:"uuid"            gqlgen:"UUID"`           //
+	Host           string `xorm:"'host' notnull varchar(150) unique(unique_2)"              valid:"required,length(1|100),alphanum"  json:"host"            gqlgen:"Host"`           //
+	IP             string `xorm:"'ip' notnull varchar(15) unique(unique_3)"                 valid:"required,int,range(0|4294967295)" json:"ip"              gqlgen:"Ip"`             //
+	Port           uint16 `xorm:"'port' notnull smallint unique(unique_2) unique(unique_3)" valid:"required,port"                    json:"port"            gqlgen:"Port"`           //
+	Alias          string `xorm:"'alias' notnull varchar(75) unique(unique_4)"              valid:"required,runelength(1|100)"       json:"alias"           gqlgen:"Alias"`          //
+	User           string `xorm:"'user' notnull varchar(50)"                                valid:"required,length(1|50),alphanum"   json:"user"            gqlgen:"User"`           //
+	Password       []byte `xorm:"'password' notnull varbinary(48)"                          valid:"required"                         json:"-"               gqlgen:"-"`              // 双向加密
+	FingerPrint    []byte `xorm:"'fingerprint' notnull varbinary(16)"                       valid:"required"                         json:"-"               gqlgen:"-"`              //
+	Status         uint8  `xorm:"'status' notnull tinyint"                                  valid:"required,matches(^[0-9]$)"        json:"status"          gqlgen:"Status"`         //
+	ServerVersion  string `xorm:"'server_version' notnull varchar(75)"                      valid:"-"                                json:"server_version"  gqlgen:"ServerVersion"`  // VERSION()
+	VersionComment string `xorm:"'version_comment' notnull varchar(150)"                    valid:"-"                                json:"version_comment" gqlgen:"VersionComment"` // @@version_comment
+	Executor       string `xorm:"'executor' notnull varchar(10)"                            valid:"-"                                json:"executor"        gqlgen:"Executor"`       // 结构变更的执行方式，空表示自动选择
+	Version        int    `xorm:"'version'"                                                 valid:"-"                                json:"version"         gqlgen:"-"`              //
+	UpdateAt       uint   `xorm:"'update_at' notnull int"                                   valid:"-"                                json:"update_at"       gqlgen:"UpdateAt"`       //
+	CreateAt       uint   `xorm:"'create_at' notnull int"                                   valid:"-"                                json:"create_at"       gqlgen:"CreateAt"`       //
 }
 
 // TableName 结构体到数据库表名称的映射
@@ -147,6 +151,32 @@ L:
 	return
 }
 
+// Explain 获取语句的执行计划，返回EXPLAIN FORMAT=JSON的原始结果，语句不会被执行
+func (m *Cluster) Explain(dbname string, passwd func(c *Cluster) []byte, sql string) (plan string, err error) {
+L:
+	for {
+		var engine *xorm.Engine
+		if engine, err = m.Connect(dbname, passwd); err != nil {
+			break L
+		}
+		defer engine.Close()
+
+		rows := []map[string]string{}
+		if rows, err = engine.QueryString(fmt.Sprintf("EXPLAIN FORMAT=JSON %s", sql)); err != nil {
+			break L
+		}
+		if len(rows) == 0 {
+			err = fmt.Errorf("No plan returned for statement")
+			break L
+		}
+		plan = rows[0]["EXPLAIN"]
+
+		break L
+	}
+
+	return
+}
+
 // Databases 获取群集上所有数据库的信息
 func (m *Cluster) Databases(passwd func(c *Cluster) []byte) (databases []Database, err error) {
 L:
@@ -184,6 +214,224 @@ L:
 	return
 }
 
+// Routines 获取群集上所有用户数据库中的存储函数和存储过程
+func (m *Cluster) Routines(passwd func(c *Cluster) []byte) (routines []Routine, err error) {
+L:
+	for {
+		var engine *xorm.Engine
+		if engine, err = m.Connect("information_schema", passwd); err != nil {
+			break L
+		}
+		defer engine.Close()
+
+		rows := []map[string]string{}
+		sql := `
+		SELECT ROUTINE_SCHEMA,
+		       ROUTINE_NAME,
+		       ROUTINE_TYPE
+		  FROM ROUTINES
+		 WHERE ROUTINE_SCHEMA NOT IN ('information_schema', 'performance_schema', 'mysql', 'sys')
+				;
+				`
+		if rows, err = engine.QueryString(sql); err != nil {
+			break L
+		}
+
+		for _, row := range rows {
+			routine := Routine{
+				Database: row["ROUTINE_SCHEMA"],
+				Name:     row["ROUTINE_NAME"],
+				Type:     row["ROUTINE_TYPE"],
+			}
+			routines = append(routines, routine)
+		}
+
+		break L
+	}
+
+	return
+}
+
+// Triggers 获取群集上所有用户数据库中的触发器
+func (m *Cluster) Triggers(passwd func(c *Cluster) []byte) (triggers []Trigger, err error) {
+L:
+	for {
+		var engine *xorm.Engine
+		if engine, err = m.Connect("information_schema", passwd); err != nil {
+			break L
+		}
+		defer engine.Close()
+
+		rows := []map[string]string{}
+		sql := `
+		SELECT TRIGGER_SCHEMA,
+		       TRIGGER_NAME,
+		       EVENT_OBJECT_TABLE,
+		       ACTION_TIMING,
+		       EVENT_MANIPULATION
+		  FROM TRIGGERS
+		 WHERE TRIGGER_SCHEMA NOT IN ('information_schema', 'performance_schema', 'mysql', 'sys')
+				;
+				`
+		if rows, err = engine.QueryString(sql); err != nil {
+			break L
+		}
+
+		for _, row := range rows {
+			trigger := Trigger{
+				Database: row["TRIGGER_SCHEMA"],
+				Name:     row["TRIGGER_NAME"],
+				Table:    row["EVENT_OBJECT_TABLE"],
+				Timing:   row["ACTION_TIMING"],
+				Event:    row["EVENT_MANIPULATION"],
+			}
+			triggers = append(triggers, trigger)
+		}
+
+		break L
+	}
+
+	return
+}
+
+// Events 获取群集上所有用户数据库中的事件
+func (m *Cluster) Events(passwd func(c *Cluster) []byte) (events []Event, err error) {
+L:
+	for {
+		var engine *xorm.Engine
+		if engine, err = m.Connect("information_schema", passwd); err != nil {
+			break L
+		}
+		defer engine.Close()
+
+		rows := []map[string]string{}
+		sql := `
+		SELECT EVENT_SCHEMA,
+		       EVENT_NAME,
+		       STATUS
+		  FROM EVENTS
+		 WHERE EVENT_SCHEMA NOT IN ('information_schema', 'performance_schema', 'mysql', 'sys')
+				;
+				`
+		if rows, err = engine.QueryString(sql); err != nil {
+			break L
+		}
+
+		for _, row := range rows {
+			event := Event{
+				Database: row["EVENT_SCHEMA"],
+				Name:     row["EVENT_NAME"],
+				Status:   row["STATUS"],
+			}
+			events = append(events, event)
+		}
+
+		break L
+	}
+
+	return
+}
+
+// TableStats 获取群集上所有用户数据库中表的统计信息，行数是InnoDB的估算值
+func (m *Cluster) TableStats(passwd func(c *Cluster) []byte) (stats []TableStat, err error) {
+L:
+	for {
+		var engine *xorm.Engine
+		if engine, err = m.Connect("information_schema", passwd); err != nil {
+			break L
+		}
+		defer engine.Close()
+
+		rows := []map[string]string{}
+		sql := `
+		SELECT TABLE_SCHEMA,
+		       TABLE_NAME,
+		       IFNULL(TABLE_ROWS, 0) AS TABLE_ROWS,
+		       IFNULL(DATA_LENGTH, 0) AS DATA_LENGTH,
+		       IFNULL(INDEX_LENGTH, 0) AS INDEX_LENGTH
+		  FROM TABLES
+		 WHERE TABLE_SCHEMA NOT IN ('information_schema', 'performance_schema', 'mysql', 'sys')
+		   AND TABLE_TYPE = 'BASE TABLE'
+				;
+				`
+		if rows, err = engine.QueryString(sql); err != nil {
+			break L
+		}
+
+		for _, row := range rows {
+			stat := TableStat{
+				Database: row["TABLE_SCHEMA"],
+				Name:     row["TABLE_NAME"],
+			}
+			stat.Rows, _ = strconv.ParseInt(row["TABLE_ROWS"], 10, 64)
+			stat.DataLength, _ = strconv.ParseInt(row["DATA_LENGTH"], 10, 64)
+			stat.IndexLength, _ = strconv.ParseInt(row["INDEX_LENGTH"], 10, 64)
+			stats = append(stats, stat)
+		}
+
+		break L
+	}
+
+	return
+}
+
+// Variables 获取群集上指定的全局变量
+func (m *Cluster) Variables(passwd func(c *Cluster) []byte, names ...string) (variables map[string]string, err error) {
+L:
+	for {
+		var engine *xorm.Engine
+		if engine, err = m.Connect("information_schema", passwd); err != nil {
+			break L
+		}
+		defer engine.Close()
+
+		variables = make(map[string]string, len(names))
+		if len(names) == 0 {
+			break L
+		}
+
+		args := []interface{}{}
+		for _, name := range names {
+			args = append(args, name)
+		}
+		rows := []map[string]string{}
+		sql := fmt.Sprintf("SHOW GLOBAL VARIABLES WHERE Variable_name IN (?%s)", strings.Repeat(", ?", len(names)-1))
+		if rows, err = engine.QueryString(append([]interface{}{sql}, args...)...); err != nil {
+			break L
+		}
+
+		for _, row := range rows {
+			variables[row["Variable_name"]] = row["Value"]
+		}
+
+		break L
+	}
+
+	return
+}
+
+// DetectVersion 读取群集的VERSION()和@@version_comment，版本发生变化时返回true
+func (m *Cluster) DetectVersion(passwd func(c *Cluster) []byte) (changed bool, err error) {
+L:
+	for {
+		variables := map[string]string{}
+		if variables, err = m.Variables(passwd, "version", "version_comment"); err != nil {
+			break L
+		}
+		if variables["version"] == "" {
+			err = fmt.Errorf("No version returned for cluster")
+			break L
+		}
+		changed = m.ServerVersion != variables["version"] || m.VersionComment != variables["version_comment"]
+		m.ServerVersion = variables["version"]
+		m.VersionComment = variables["version_comment"]
+
+		break L
+	}
+
+	return
+}
+
 // Metadata 获取群集上某一个具体的数据库的元数据信息
 func (m *Cluster) Metadata(database string, passwd func(c *Cluster) []byte) (tables map[string][]*core.Table, err error) {
 L:
@@ -270,22 +518,37 @@ L:
 	return
 }
 
-func (m *Cluster) columns(engine *xorm.Engine, database string) (cols map[string]map[string]*core.Column, err error) {
+// columns 按表分组的列，按照列在表中的顺序排列
+func (m *Cluster) columns(engine *xorm.Engine, database string) (cols map[string][]*core.Column, err error) {
+	// GENERATION_EXPRESSION从5.7开始才有，之前的版本没有生成列
+	generation := "NULL"
+	var n int
+	if err = engine.DB().QueryRow("SELECT COUNT(*) FROM `INFORMATION_SCHEMA`.`COLUMNS` " +
+		"WHERE `TABLE_SCHEMA` = 'information_schema' AND `TABLE_NAME` = 'COLUMNS' " +
+		"AND `COLUMN_NAME` = 'GENERATION_EXPRESSION';").Scan(&n); err != nil {
+		return nil, err
+	}
+	if n > 0 {
+		generation = "`GENERATION_EXPRESSION`"
+	}
+
 	args := []interface{}{}
 	sql := ""
 	if database == "*" {
 		sql = "SELECT `TABLE_SCHEMA`, `TABLE_NAME`, `COLUMN_NAME`, `IS_NULLABLE`, " +
-			"`COLUMN_DEFAULT`, `COLUMN_TYPE`, `COLUMN_KEY`, `EXTRA`,`COLUMN_COMMENT` " +
+			"`COLUMN_DEFAULT`, `COLUMN_TYPE`, `COLUMN_KEY`, `EXTRA`,`COLUMN_COMMENT`, " +
+			"`CHARACTER_SET_NAME`, `COLLATION_NAME`, " + generation + " " +
 			"FROM `INFORMATION_SCHEMA`.`COLUMNS` " +
 			"WHERE 1 = ? AND `TABLE_SCHEMA` NOT IN ('mysql', 'sys', 'information_schema') " +
-			"ORDER BY `TABLE_SCHEMA`, `TABLE_NAME`;"
+			"ORDER BY `TABLE_SCHEMA`, `TABLE_NAME`, `ORDINAL_POSITION`;"
 		args = []interface{}{1}
 	} else {
 		sql = "SELECT `TABLE_SCHEMA`, `TABLE_NAME`, `COLUMN_NAME`, `IS_NULLABLE`, " +
-			"`COLUMN_DEFAULT`, `COLUMN_TYPE`, `COLUMN_KEY`, `EXTRA`,`COLUMN_COMMENT` " +
+			"`COLUMN_DEFAULT`, `COLUMN_TYPE`, `COLUMN_KEY`, `EXTRA`,`COLUMN_COMMENT`, " +
+			"`CHARACTER_SET_NAME`, `COLLATION_NAME`, " + generation + " " +
 			"FROM `INFORMATION_SCHEMA`.`COLUMNS` " +
 			"WHERE `TABLE_SCHEMA` = ? AND `TABLE_SCHEMA` NOT IN ('mysql', 'sys', 'information_schema') " +
-			"ORDER BY `TABLE_SCHEMA`, `TABLE_NAME`;"
+			"ORDER BY `TABLE_SCHEMA`, `TABLE_NAME`, `ORDINAL_POSITION`;"
 		args = []interface{}{database}
 	}
 
@@ -296,17 +559,33 @@ func (m *Cluster) columns(engine *xorm.Engine, database string) (cols map[string
 	}
 	defer rows.Close()
 
-	cols = make(map[string]map[string]*core.Column)
+	cols = make(map[string][]*core.Column)
 	for rows.Next() {
 		col := new(core.Column)
 		col.Indexes = make(map[string]int)
 
 		var db, table, columnName, isNullable, colType, colKey, extra, comment string
-		var colDefault *string
-		err = rows.Scan(&db, &table, &columnName, &isNullable, &colDefault, &colType, &colKey, &extra, &comment)
+		var colDefault, charset, collation, expression *string
+		err = rows.Scan(&db, &table, &columnName, &isNullable, &colDefault, &colType, &colKey, &extra, &comment,
+			&charset, &collation, &expression)
 		if err != nil {
 			return nil, err
 		}
+		// 完整的类型、EXTRA、字符集和生成列的表达式在core.Column中没有对应的字段，单独保存
+		columnExtra := &ColumnExtra{
+			ColumnType: colType,
+			Extra:      extra,
+		}
+		if charset != nil {
+			columnExtra.Charset = *charset
+		}
+		if collation != nil {
+			columnExtra.Collation = *collation
+		}
+		if expression != nil {
+			columnExtra.Generation = *expression
+		}
+		SetColumnExtra(col, columnExtra)
 		col.Name = strings.Trim(columnName, "` ")
 		col.Comment = comment
 		if "YES" == isNullable {
@@ -390,11 +669,7 @@ func (m *Cluster) columns(engine *xorm.Engine, database string) (cols map[string
 			}
 		}
 		k := fmt.Sprintf("%s.%s", db, table)
-		if _, ok := cols[k]; ok {
-			cols[k][col.Name] = col
-		} else {
-			cols[k] = map[string]*core.Column{col.Name: col}
-		}
+		cols[k] = append(cols[k], col)
 	}
 
 	return
@@ -407,13 +682,13 @@ func (m *Cluster) indexes(engine *xorm.Engine, database string) (indexes map[str
 		sql = "SELECT `TABLE_SCHEMA`, `TABLE_NAME`, `INDEX_NAME`, `NON_UNIQUE`, `COLUMN_NAME` " +
 			"FROM `INFORMATION_SCHEMA`.`STATISTICS` " +
 			"WHERE 1 = ? AND `TABLE_SCHEMA` NOT IN ('mysql', 'sys', 'information_schema') " +
-			"ORDER BY `TABLE_SCHEMA`, `TABLE_NAME`;"
+			"ORDER BY `TABLE_SCHEMA`, `TABLE_NAME`, `INDEX_NAME`, `SEQ_IN_INDEX`;"
 		args = []interface{}{1}
 	} else {
 		sql = "SELECT `TABLE_SCHEMA`, `TABLE_NAME`, `INDEX_NAME`, `NON_UNIQUE`, `COLUMN_NAME` " +
 			"FROM `INFORMATION_SCHEMA`.`STATISTICS` " +
 			"WHERE `TABLE_SCHEMA` = ? AND `TABLE_SCHEMA` NOT IN ('mysql', 'sys', 'information_schema') " +
-			"ORDER BY `TABLE_SCHEMA`, `TABLE_NAME`;"
+			"ORDER BY `TABLE_SCHEMA`, `TABLE_NAME`, `INDEX_NAME`, `SEQ_IN_INDEX`;"
 		args = []interface{}{database}
 	}
 
@@ -514,6 +789,69 @@ type Database struct {
 	Collate string
 }
 
+// Routine 存储函数和存储过程的信息
+type Routine struct {
+	Database string
+	Name     string
+	Type     string // FUNCTION或者PROCEDURE
+}
+
+// Trigger 触发器的信息
+type Trigger struct {
+	Database string
+	Name     string
+	Table    string
+	Timing   string // BEFORE或者AFTER
+	Event    string // INSERT、UPDATE或者DELETE
+}
+
+// Event 事件的信息
+type Event struct {
+	Database string
+	Name     string
+	Status   string // ENABLED、DISABLED或者SLAVESIDE_DISABLED
+}
+
+// TableStat 表的统计信息，来自INFORMATION_SCHEMA.TABLES
+type TableStat struct {
+	Database    string
+	Name        string
+	Rows        int64 // 估算的行数
+	DataLength  int64 // 数据大小，单位字节
+	IndexLength int64 // 索引大小，单位字节
+}
+
+// ColumnExtra core.Column只有基础的类型，列定义中的其他部分保存在这里，生成回滚语句时用来还原列的完整定义
+type ColumnExtra struct {
+	ColumnType string `json:"column_type,omitempty"` // 完整的类型，例如int(10) unsigned zerofill
+	Extra      string `json:"extra,omitempty"`       // 例如on update CURRENT_TIMESTAMP、STORED GENERATED
+	Charset    string `json:"charset,omitempty"`     // 列的字符集
+	Collation  string `json:"collation,omitempty"`   // 列的排序规则
+	Generation string `json:"generation,omitempty"`  // 生成列的表达式
+}
+
+// SetColumnExtra 保存列的附加信息。元数据和模拟结构中的列不对应结构体，xorm不会使用FieldName，
+// 附加信息以JSON保存在FieldName中，复制列和生成快照时随列一起保留
+func SetColumnExtra(col *core.Column, extra *ColumnExtra) {
+	bs, err := json.Marshal(extra)
+	if err != nil {
+		return
+	}
+	col.FieldName = string(bs)
+}
+
+// GetColumnExtra 读取列的附加信息，没有保存时返回nil
+func GetColumnExtra(col *core.Column) *ColumnExtra {
+	if !strings.HasPrefix(col.FieldName, "{") {
+		return nil
+	}
+	extra := &ColumnExtra{}
+	if err := json.Unmarshal([]byte(col.FieldName), extra); err != nil {
+		return nil
+	}
+	return extra
+}
+
 // Table 重新封包向外直接暴露Columns
 type Table struct {
 	Name          string
@@ -33,6 +33,13 @@ type PatchTicketStatusInput struct {
 	Status     string `valid:"required"               gqlgen:"Status"`     //
 }
 
+// ResumeTicketInput GraphQL API交互所需要的结构体
+type ResumeTicketInput struct {
+	TicketUUID string  `valid:"required,length(36|36)" gqlgen:"TicketUUID"` //
+	Action     string  `valid:"required"               gqlgen:"Action"`     //
+	Reason     *string `valid:"-"                      gqlgen:"Reason"`     //
+}
+
 // ScheduleTicketInput GraphQL API交互所需要的结构体
 type ScheduleTicketInput struct {
 	TicketUUID string `valid:"required,length(36|36)" gqlgen:"TicketUUID"` //
@@ -143,8 +150,9 @@ type PatchUserStatusInput struct {
 
 // PatchRuleValuesInput GraphQL API交互所需要的结构体
 type PatchRuleValuesInput struct {
-	RuleUUID string `valid:"required,length(36|36)" gqlgen:"RuleUUID"` //
-	Values   string `valid:"required,length(1|150)" gqlgen:"Values"`   //
+	RuleUUID string  `valid:"required,length(36|36)" gqlgen:"RuleUUID"` //
+	Values   string  `valid:"required,length(1|150)" gqlgen:"Values"`   //
+	Path     *string `valid:"-"                      gqlgen:"Path"`     // 声明式规则的路径表达式，为空时保持不变
 }
 
 // PatchRuleBitwiseInput GraphQL API交互所需要的结构体
@@ -153,27 +161,68 @@ type PatchRuleBitwiseInput struct {
 	Enabled  string `valid:"required,matches(^(true|false)$)" gqlgen:"Enabled"`  //
 }
 
+// CreateRuleProfileInput GraphQL API交互所需要的结构体
+type CreateRuleProfileInput struct {
+	Name        string `valid:"required,length(1|50)" gqlgen:"Name"`        //
+	Description string `valid:"runelength(0|150)"     gqlgen:"Description"` //
+}
+
+// PatchRuleOverrideInput GraphQL API交互所需要的结构体
+type PatchRuleOverrideInput struct {
+	ProfileUUID string  `valid:"required,length(36|36)" gqlgen:"ProfileUUID"` //
+	RuleUUID    string  `valid:"required,length(36|36)" gqlgen:"RuleUUID"`    //
+	Level       *uint8  `valid:"-"                      gqlgen:"Level"`       // 为空表示不覆盖
+	Values      *string `valid:"-"                      gqlgen:"Values"`      // 为空表示不覆盖
+	Bitwise     *uint8  `valid:"-"                      gqlgen:"Bitwise"`     // 为空表示不覆盖
+}
+
+// BindRuleProfileInput GraphQL API交互所需要的结构体
+type BindRuleProfileInput struct {
+	ProfileUUID string `valid:"required,length(36|36)" gqlgen:"ProfileUUID"` //
+	ClusterUUID string `valid:"required,length(36|36)" gqlgen:"ClusterUUID"` //
+	Database    string `valid:"length(0|50)"           gqlgen:"Database"`    //
+}
+
+// UnbindRuleProfileInput GraphQL API交互所需要的结构体
+type UnbindRuleProfileInput struct {
+	ClusterUUID string `valid:"required,length(36|36)" gqlgen:"ClusterUUID"` //
+	Database    string `valid:"length(0|50)"           gqlgen:"Database"`    //
+}
+
+// GrantWaiverInput GraphQL API交互所需要的结构体
+type GrantWaiverInput struct {
+	RuleUUID    string `valid:"required,length(36|36)"     gqlgen:"RuleUUID"`    //
+	TicketUUID  string `valid:"length(0|36)"               gqlgen:"TicketUUID"`  // 为空时豁免群集上的某个表
+	ClusterUUID string `valid:"length(0|36)"               gqlgen:"ClusterUUID"` //
+	Database    string `valid:"length(0|50)"               gqlgen:"Database"`    //
+	Table       string `valid:"length(0|75)"               gqlgen:"Table"`       //
+	Reason      string `valid:"required,runelength(1|150)" gqlgen:"Reason"`      //
+	ExpireAt    uint   `valid:"required"                   gqlgen:"ExpireAt"`    //
+}
+
 // CreateClusterInput GraphQL API交互所需要的结构体
 type CreateClusterInput struct {
-	Host     string `valid:"required,length(1|75)"         gqlgen:"Host"`     //
-	IP       string `valid:"required,ipv4"                 gqlgen:"IP"`       //
-	Port     uint16 `valid:"required,port"                 gqlgen:"Port"`     //
-	Alias    string `valid:"required,length(4|75)"         gqlgen:"Alias"`    //
-	User     string `valid:"required,length(4|40)"         gqlgen:"User"`     //
-	Password string `valid:"required,length(4|40)"         gqlgen:"Password"` // 注意：密码最大长度40位，超过40位会导致数据库截断
-	Status   uint8  `valid:"required,int,matches(^(1|2)$)" gqlgen:"Status"`   //
+	Host     string  `valid:"required,length(1|75)"         gqlgen:"Host"`     //
+	IP       string  `valid:"required,ipv4"                 gqlgen:"IP"`       //
+	Port     uint16  `valid:"required,port"                 gqlgen:"Port"`     //
+	Alias    string  `valid:"required,length(4|75)"         gqlgen:"Alias"`    //
+	User     string  `valid:"required,length(4|40)"         gqlgen:"User"`     //
+	Password string  `valid:"required,length(4|40)"         gqlgen:"Password"` // 注意：密码最大长度40位，超过40位会导致数据库截断
+	Status   uint8   `valid:"required,int,matches(^(1|2)$)" gqlgen:"Status"`   //
+	Executor *string `valid:"-"                             gqlgen:"Executor"` // 结构变更的执行方式
 }
 
 // UpdateClusterInput GraphQL API交互所需要的结构体
 type UpdateClusterInput struct {
-	ClusterUUID string `valid:"required,length(36|36)"        gqlgen:"ClusterUUID"` //
-	Host        string `valid:"required,length(1|75)"         gqlgen:"Host"`        //
-	IP          string `valid:"required,ipv4"                 gqlgen:"IP"`          //
-	Port        uint16 `valid:"required,port"                 gqlgen:"Port"`        //
-	Alias       string `valid:"required,length(4|75)"         gqlgen:"Alias"`       //
-	User        string `valid:"required,length(4|40)"         gqlgen:"User"`        //
-	Status      uint8  `valid:"required,int,matches(^(1|2)$)" gqlgen:"Status"`      //
-	Password    string `valid:"required,length(4|40)"         gqlgen:"Password"`    //
+	ClusterUUID string  `valid:"required,length(36|36)"        gqlgen:"ClusterUUID"` //
+	Host        string  `valid:"required,length(1|75)"         gqlgen:"Host"`        //
+	IP          string  `valid:"required,ipv4"                 gqlgen:"IP"`          //
+	Port        uint16  `valid:"required,port"                 gqlgen:"Port"`        //
+	Alias       string  `valid:"required,length(4|75)"         gqlgen:"Alias"`       //
+	User        string  `valid:"required,length(4|40)"         gqlgen:"User"`        //
+	Status      uint8   `valid:"required,int,matches(^(1|2)$)" gqlgen:"Status"`      //
+	Password    string  `valid:"required,length(4|40)"         gqlgen:"Password"`    //
+	Executor    *string `valid:"-"                             gqlgen:"Executor"`    // 结构变更的执行方式，为空时保持不变
 }
 
 // PatchClusterStatusInput GraphQL API交互所需要的结构体
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"xorm.io/xorm"
+)
+
+// Rollback 回滚信息记录表，保存在备份库中，记录每条DML语句执行前后的binlog位置
+type Rollback struct {
+	OpidTime        string    `xorm:"'opid_time' notnull varchar(50)"         valid:"-" json:"opid_time"         gqlgen:"-"` // 时间戳+线程号+执行序号
+	StartBinlogFile string    `xorm:"'start_binlog_file' notnull varchar(25)" valid:"-" json:"start_binlog_file" gqlgen:"-"` //
+	StartBinlogPos  uint32    `xorm:"'start_binlog_pos' notnull int"          valid:"-" json:"start_binlog_pos"  gqlgen:"-"` //
+	EndBinlogFile   string    `xorm:"'end_binlog_file' notnull varchar(25)"   valid:"-" json:"end_binlog_file"   gqlgen:"-"` //
+	EndBinlogPos    uint32    `xorm:"'end_binlog_pos' notnull int"            valid:"-" json:"end_binlog_pos"    gqlgen:"-"` //
+	Content         string    `xorm:"'content' notnull text"                  valid:"-" json:"content"           gqlgen:"-"` // 执行的语句
+	Host            string    `xorm:"'host' notnull varchar(75)"              valid:"-" json:"host"              gqlgen:"-"` //
+	Database        string    `xorm:"'database' notnull varchar(75)"          valid:"-" json:"database"          gqlgen:"-"` //
+	Table           string    `xorm:"'table' notnull varchar(75)"             valid:"-" json:"table"             gqlgen:"-"` //
+	Port            uint16    `xorm:"'port' notnull smallint"                 valid:"-" json:"port"              gqlgen:"-"` //
+	Duration        time.Time `xorm:"'duration' notnull timestamp"            valid:"-" json:"duration"          gqlgen:"-"` // 开始执行的时间
+	Type            string    `xorm:"'type' notnull varchar(20)"              valid:"-" json:"type"              gqlgen:"-"` // INSERT/UPDATE/DELETE
+}
+
+// TableName 结构体到数据库表名称的映射
+func (m *Rollback) TableName() string {
+	return "$_$halo_rollbacks$_$"
+}
+
+// AfterSet ORM在执行数据更新后会调用该方法
+func (m *Rollback) AfterSet(colName string, _ xorm.Cell) {
+}
+
+// String 结构体输出到字符串的默认方式
+func (m *Rollback) String() string {
+	return fmt.Sprintf("opid_time: %s, start: %s:%d, end: %s:%d, type: %s",
+		m.OpidTime,
+		m.StartBinlogFile,
+		m.StartBinlogPos,
+		m.EndBinlogFile,
+		m.EndBinlogPos,
+		m.Type,
+	)
+}
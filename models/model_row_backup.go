@@ -0,0 +1,73 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"xorm.io/xorm"
+)
+
+// RowBackup UPDATE和DELETE语句执行之前备份的行，保存在备份库中，每行一条记录，
+// 列值以SQL字面量保存，恢复时不需要知道列的类型
+type RowBackup struct {
+	BackupID      uint64 `xorm:"'backup_id' notnull bigint pk autoincr"             valid:"-" json:"backup_id"      gqlgen:"-"`             //
+	StatementUUID string `xorm:"'statement_uuid' notnull char(36) index(index_1)"   valid:"-" json:"statement_uuid" gqlgen:"StatementUUID"` // 修改这一行的语句
+	Database      string `xorm:"'database' notnull varchar(75)"                     valid:"-" json:"database"       gqlgen:"Database"`      //
+	Table         string `xorm:"'table' notnull varchar(75)"                        valid:"-" json:"table"          gqlgen:"Table"`         //
+	ColumnNames   string `xorm:"'column_names' notnull text"                        valid:"-" json:"column_names"   gqlgen:"-"`             // JSON数组
+	ColumnValues  string `xorm:"'column_values' notnull mediumtext"                 valid:"-" json:"column_values"  gqlgen:"-"`             // JSON数组，元素是SQL字面量
+	CreateAt      uint   `xorm:"'create_at' notnull int"                            valid:"-" json:"create_at"      gqlgen:"CreateAt"`      //
+}
+
+// TableName 结构体到数据库表名称的映射
+func (m *RowBackup) TableName() string {
+	return "$_$halo_row_backups$_$"
+}
+
+// BeforeInsert ORM在执行数据插入前会调用该方法
+func (m *RowBackup) BeforeInsert() {
+	m.CreateAt = uint(time.Now().Unix())
+}
+
+// AfterSet ORM在执行数据更新后会调用该方法
+func (m *RowBackup) AfterSet(colName string, _ xorm.Cell) {
+}
+
+// SetRow 保存一行的列名和值
+func (m *RowBackup) SetRow(columns, values []string) error {
+	bs, err := json.Marshal(columns)
+	if err != nil {
+		return err
+	}
+	m.ColumnNames = string(bs)
+	if bs, err = json.Marshal(values); err != nil {
+		return err
+	}
+	m.ColumnValues = string(bs)
+	return nil
+}
+
+// ParseRow 还原一行的列名和值
+func (m *RowBackup) ParseRow() (columns, values []string, err error) {
+	if err = json.Unmarshal([]byte(m.ColumnNames), &columns); err != nil {
+		return
+	}
+	if err = json.Unmarshal([]byte(m.ColumnValues), &values); err != nil {
+		return
+	}
+	if len(columns) != len(values) {
+		err = fmt.Errorf("备份的行(backup_id=%d)列数和值的个数不一致", m.BackupID)
+	}
+	return
+}
+
+// String 结构体输出到字符串的默认方式
+func (m *RowBackup) String() string {
+	return fmt.Sprintf("backup_id: %d, statement_uuid: %s, database: %s, table: %s",
+		m.BackupID,
+		m.StatementUUID,
+		m.Database,
+		m.Table,
+	)
+}
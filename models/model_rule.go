@@ -19,6 +19,7 @@ type Rule struct {
 	Values      string `xorm:"'values' notnull varchar(150)"            valid:"required,length(1|150)"            json:"values"      gqlgen:"Values"`      //
 	Bitwise     uint8  `xorm:"'bitwise' notnull tinyint"                valid:"required,int,matches(^(4|5|6|7)$)" json:"bitwise"     gqlgen:"Bitwise"`     //
 	Func        string `xorm:"'func' notnull varchar(75) <-"            valid:"required,length(1|75)"             json:"func"        gqlgen:"Func"`        //
+	Path        string `xorm:"'path' notnull varchar(150)"              valid:"length(0|150)"                     json:"path"        gqlgen:"Path"`        // 声明式规则的路径表达式
 	Message     string `xorm:"'message' notnull varchar(150) <-"        valid:"required,runelength(1|150)"        json:"message"     gqlgen:"Message"`     //
 	Description string `xorm:"'description' notnull tinytext <-"        valid:"required,runelength(1|255)"        json:"description" gqlgen:"Description"` //
 	Element     string `xorm:"'element' notnull varchar(50) <-"         valid:"required,length(1|50)"             json:"element"     gqlgen:"Element"`     //
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"xorm.io/xorm"
+)
+
+// RuleBinding 规则配置的适用范围，Database为空时适用于整个群集
+type RuleBinding struct {
+	BindingID uint   `xorm:"'binding_id' notnull int pk autoincr"            valid:"-"            json:"binding_id" gqlgen:"-"`        //
+	UUID      string `xorm:"'uuid' notnull char(36) unique(unique_1)"        valid:"-"            json:"uuid"       gqlgen:"UUID"`     //
+	ProfileID uint   `xorm:"'profile_id' notnull int index(index_1)"         valid:"-"            json:"profile_id" gqlgen:"-"`        //
+	ClusterID uint   `xorm:"'cluster_id' notnull int unique(unique_2)"       valid:"-"            json:"cluster_id" gqlgen:"-"`        //
+	Database  string `xorm:"'database' notnull varchar(50) unique(unique_2)" valid:"length(0|50)" json:"database"   gqlgen:"Database"` //
+	Version   int    `xorm:"'version'"                                       valid:"-"            json:"version"    gqlgen:"-"`        //
+	UpdateAt  uint   `xorm:"'update_at' notnull int"                         valid:"-"            json:"update_at"  gqlgen:"UpdateAt"` //
+	CreateAt  uint   `xorm:"'create_at' notnull int"                         valid:"-"            json:"create_at"  gqlgen:"CreateAt"` //
+}
+
+// TableName 结构体到数据库表名称的映射
+func (m *RuleBinding) TableName() string {
+	return "mm_rule_bindings"
+}
+
+// BeforeInsert ORM在执行数据插入前会调用该方法
+func (m *RuleBinding) BeforeInsert() {
+	m.UUID = uuid.New().String()
+	m.CreateAt = uint(time.Now().Unix())
+}
+
+// BeforeUpdate ORM在执行数据更新前会调用该方法
+func (m *RuleBinding) BeforeUpdate() {
+	m.UpdateAt = uint(time.Now().Unix())
+}
+
+// AfterSet ORM在执行数据更新后会调用该方法
+func (m *RuleBinding) AfterSet(colName string, _ xorm.Cell) {
+}
+
+// String 结构体输出到字符串的默认方式
+func (m *RuleBinding) String() string {
+	return fmt.Sprintf("uuid: %s, profile_id: %d, cluster_id: %d, database: %s",
+		m.UUID,
+		m.ProfileID,
+		m.ClusterID,
+		m.Database,
+	)
+}
+
+// IsNode GraphQL的基类需要实现的接口，暂时不动
+func (RuleBinding) IsNode() {}
+
+// 创建时间
+func (m *RuleBinding) GetCreateAt() uint {
+	return m.CreateAt
+}
+
+// 最后一次修改时间
+func (m *RuleBinding) GetUpdateAt() *uint {
+	return &m.UpdateAt
+}
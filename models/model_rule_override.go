@@ -0,0 +1,78 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"xorm.io/xorm"
+)
+
+// RuleOverride 规则配置中的一条覆盖，为空的字段沿用规则本身的设置，设置了的字段即使是零值也会覆盖
+type RuleOverride struct {
+	OverrideID uint    `xorm:"'override_id' notnull int pk autoincr"         valid:"-"                      json:"override_id" gqlgen:"-"`        //
+	UUID       string  `xorm:"'uuid' notnull char(36) unique(unique_1)"      valid:"-"                      json:"uuid"        gqlgen:"UUID"`     //
+	ProfileID  uint    `xorm:"'profile_id' notnull int unique(unique_2)"     valid:"-"                      json:"profile_id"  gqlgen:"-"`        //
+	RuleName   string  `xorm:"'rule_name' notnull char(10) unique(unique_2)" valid:"required,length(10|10)" json:"rule_name"   gqlgen:"RuleName"` //
+	Level      *uint8  `xorm:"'level' null tinyint"                          valid:"-"                      json:"level"       gqlgen:"Level"`    // 为空表示不覆盖
+	Values     *string `xorm:"'values' null varchar(150)"                    valid:"-"                      json:"values"      gqlgen:"Values"`   // 为空表示不覆盖
+	Bitwise    *uint8  `xorm:"'bitwise' null tinyint"                        valid:"-"                      json:"bitwise"     gqlgen:"Bitwise"`  // 为空表示不覆盖
+	Version    int     `xorm:"'version'"                                     valid:"-"                      json:"version"     gqlgen:"-"`        //
+	UpdateAt   uint    `xorm:"'update_at' notnull int"                       valid:"-"                      json:"update_at"   gqlgen:"UpdateAt"` //
+	CreateAt   uint    `xorm:"'create_at' notnull int"                       valid:"-"                      json:"create_at"   gqlgen:"CreateAt"` //
+}
+
+// TableName 结构体到数据库表名称的映射
+func (m *RuleOverride) TableName() string {
+	return "mm_rule_overrides"
+}
+
+// BeforeInsert ORM在执行数据插入前会调用该方法
+func (m *RuleOverride) BeforeInsert() {
+	m.UUID = uuid.New().String()
+	m.CreateAt = uint(time.Now().Unix())
+}
+
+// BeforeUpdate ORM在执行数据更新前会调用该方法
+func (m *RuleOverride) BeforeUpdate() {
+	m.UpdateAt = uint(time.Now().Unix())
+}
+
+// AfterSet ORM在执行数据更新后会调用该方法
+func (m *RuleOverride) AfterSet(colName string, _ xorm.Cell) {
+}
+
+// String 结构体输出到字符串的默认方式
+func (m *RuleOverride) String() string {
+	level, values, bitwise := "nil", "nil", "nil"
+	if m.Level != nil {
+		level = fmt.Sprintf("%d", *m.Level)
+	}
+	if m.Values != nil {
+		values = *m.Values
+	}
+	if m.Bitwise != nil {
+		bitwise = fmt.Sprintf("%d", *m.Bitwise)
+	}
+	return fmt.Sprintf("uuid: %s, profile_id: %d, rule_name: %s, level: %s, values: %s, bitwise: %s",
+		m.UUID,
+		m.ProfileID,
+		m.RuleName,
+		level,
+		values,
+		bitwise,
+	)
+}
+
+// IsNode GraphQL的基类需要实现的接口，暂时不动
+func (RuleOverride) IsNode() {}
+
+// 创建时间
+func (m *RuleOverride) GetCreateAt() uint {
+	return m.CreateAt
+}
+
+// 最后一次修改时间
+func (m *RuleOverride) GetUpdateAt() *uint {
+	return &m.UpdateAt
+}
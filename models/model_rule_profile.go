@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"xorm.io/xorm"
+)
+
+// RuleProfile 规则配置，按群集或者数据库覆盖规则的级别、取值和状态位
+type RuleProfile struct {
+	ProfileID   uint            `xorm:"'profile_id' notnull int pk autoincr"        valid:"-"                     json:"profile_id"  gqlgen:"-"`           //
+	UUID        string          `xorm:"'uuid' notnull char(36) unique(unique_1)"    valid:"-"                     json:"uuid"        gqlgen:"UUID"`        //
+	Name        string          `xorm:"'name' notnull varchar(50) unique(unique_2)" valid:"required,length(1|50)" json:"name"        gqlgen:"Name"`        //
+	Description string          `xorm:"'description' notnull varchar(150)"          valid:"runelength(0|150)"     json:"description" gqlgen:"Description"` //
+	Overrides   []*RuleOverride `xorm:"-"                                           valid:"-"                     json:"overrides"   gqlgen:"Overrides"`   // 缓存加载时填充
+	Bindings    []*RuleBinding  `xorm:"-"                                           valid:"-"                     json:"bindings"    gqlgen:"Bindings"`    // 缓存加载时填充
+	Version     int             `xorm:"'version'"                                   valid:"-"                     json:"version"     gqlgen:"-"`           //
+	UpdateAt    uint            `xorm:"'update_at' notnull int"                     valid:"-"                     json:"update_at"   gqlgen:"UpdateAt"`    //
+	CreateAt    uint            `xorm:"'create_at' notnull int"                     valid:"-"                     json:"create_at"   gqlgen:"CreateAt"`    //
+}
+
+// TableName 结构体到数据库表名称的映射
+func (m *RuleProfile) TableName() string {
+	return "mm_rule_profiles"
+}
+
+// BeforeInsert ORM在执行数据插入前会调用该方法
+func (m *RuleProfile) BeforeInsert() {
+	m.UUID = uuid.New().String()
+	m.Name = strings.TrimSpace(m.Name)
+	m.CreateAt = uint(time.Now().Unix())
+}
+
+// BeforeUpdate ORM在执行数据更新前会调用该方法
+func (m *RuleProfile) BeforeUpdate() {
+	m.Name = strings.TrimSpace(m.Name)
+	m.UpdateAt = uint(time.Now().Unix())
+}
+
+// AfterSet ORM在执行数据更新后会调用该方法
+func (m *RuleProfile) AfterSet(colName string, _ xorm.Cell) {
+}
+
+// String 结构体输出到字符串的默认方式
+func (m *RuleProfile) String() string {
+	return fmt.Sprintf("uuid: %s, name: %s",
+		m.UUID,
+		m.Name,
+	)
+}
+
+// IsNode GraphQL的基类需要实现的接口，暂时不动
+func (RuleProfile) IsNode() {}
+
+// 创建时间
+func (m *RuleProfile) GetCreateAt() uint {
+	return m.CreateAt
+}
+
+// 最后一次修改时间
+func (m *RuleProfile) GetUpdateAt() *uint {
+	return &m.UpdateAt
+}
+
+// Apply 返回按配置覆盖之后的规则，被覆盖的规则是副本，缓存中的规则保持不变，
+// 没有配置时原样返回
+func (m *RuleProfile) Apply(L []*Rule) []*Rule {
+	if m == nil || len(m.Overrides) == 0 {
+		return L
+	}
+	overrides := map[string]*RuleOverride{}
+	for _, o := range m.Overrides {
+		overrides[o.RuleName] = o
+	}
+	rules := make([]*Rule, len(L))
+	for i, r := range L {
+		rules[i] = r
+		o, ok := overrides[r.Name]
+		if !ok {
+			continue
+		}
+		rule := *r
+		if o.Level != nil {
+			rule.Level = *o.Level
+		}
+		if o.Values != nil {
+			rule.Values = *o.Values
+		}
+		if o.Bitwise != nil {
+			rule.Bitwise = *o.Bitwise
+		}
+		rules[i] = &rule
+	}
+	return rules
+}
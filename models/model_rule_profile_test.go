@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleProfileApply(t *testing.T) {
+	L := []*Rule{
+		{Name: "CTB-L2-001", Level: 2, Values: "20", Bitwise: 7},
+		{Name: "CTB-L2-002", Level: 2, Values: "nil", Bitwise: 7},
+	}
+
+	// 没有配置时原样返回
+	var profile *RuleProfile
+	assert.Equal(t, L, profile.Apply(L))
+
+	level, bitwise, values, empty := uint8(1), uint8(6), "64", ""
+	disabled := uint8(0)
+	profile = &RuleProfile{
+		Overrides: []*RuleOverride{
+			{RuleName: "CTB-L2-001", Values: &values},
+			{RuleName: "CTB-L2-002", Level: &level, Bitwise: &bitwise},
+		},
+	}
+	rules := profile.Apply(L)
+	assert.Equal(t, "64", rules[0].Values)
+	assert.Equal(t, uint8(2), rules[0].Level)
+	assert.Equal(t, uint8(1), rules[1].Level)
+	assert.Equal(t, "nil", rules[1].Values)
+	assert.Equal(t, uint8(6), rules[1].Bitwise)
+
+	// 设置了的字段即使是零值也会覆盖
+	profile = &RuleProfile{
+		Overrides: []*RuleOverride{
+			{RuleName: "CTB-L2-001", Values: &empty, Bitwise: &disabled},
+		},
+	}
+	rules = profile.Apply(L)
+	assert.Equal(t, "", rules[0].Values)
+	assert.Equal(t, uint8(0), rules[0].Bitwise)
+	assert.Equal(t, uint8(2), rules[0].Level)
+	assert.Equal(t, L[1], rules[1])
+
+	// 缓存中的规则保持不变
+	assert.Equal(t, "20", L[0].Values)
+	assert.Equal(t, uint8(7), L[1].Bitwise)
+}
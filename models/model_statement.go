@@ -3,6 +3,7 @@ package models
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -14,21 +15,27 @@ import (
 
 // Statement 工单分解出来的单个SQL语句
 type Statement struct {
-	TicketID     uint         `xorm:"'ticket_id' notnull int pk"               valid:"required,int,range(0|4294967295)"  json:"ticket_id"     gqlgen:"-"`            //
-	Sequence     uint16       `xorm:"'sequence' notnull smallint pk"           valid:"required,int,range(0|65535)"       json:"sequence"      gqlgen:"Sequence"`     //
-	UUID         string       `xorm:"'uuid' notnull char(36) unique(unique_1)" valid:"-"                                 json:"uuid"          gqlgen:"UUID"`         //
-	Content      string       `xorm:"'content' notnull text"                   valid:"required,length(1|65535),ascii"    json:"content"       gqlgen:"Content"`      //
-	Type         uint8        `xorm:"'type' notnull tinyint"                   valid:"required,matches(^([1-9]?[0-9])$)" json:"type"          gqlgen:"Type"`         // 类型 0-99
-	Status       uint8        `xorm:"'status' notnull tinyint"                 valid:"required,matches(^([1-9]?[0-9])$)" json:"status"        gqlgen:"Status"`       // 状态 0-99
-	Report       string       `xorm:"'report' notnull json"                    valid:"required,length(1|65535)"          json:"report"        gqlgen:"Report"`       //
-	Plan         string       `xorm:"'plan' notnull json"                      valid:"required,length(1|65535)"          json:"plan"          gqlgen:"Plan"`         //
-	Results      string       `xorm:"'results' text"                           valid:"length(1|65535)"                   json:"results"       gqlgen:"Results"`      //
-	RowsAffected uint         `xorm:"'rows_affected' notnull int"              valid:"required,int,range(0|4294967295)"  json:"rows_affected" gqlgen:"RowsAffected"` //
-	Version      int          `xorm:"'version'"                                valid:"-"                                 json:"version"       gqlgen:"-"`            //
-	UpdateAt     uint         `xorm:"'update_at' notnull int"                  valid:"-"                                 json:"update_at"     gqlgen:"UpdateAt"`     //
-	CreateAt     uint         `xorm:"'create_at' notnull int"                  valid:"-"                                 json:"create_at"     gqlgen:"CreateAt"`     //
-	StmtNode     ast.StmtNode `xorm:"-"                                        valid:"-"                                 json:"-"             gqlgen:"-"`            //
-	Violations   *Violations  `xorm:"-"                                        valid:"-"                                 json:"-"             gqlgen:"-"`            //
+	TicketID         uint         `xorm:"'ticket_id' notnull int pk"               valid:"required,int,range(0|4294967295)"  json:"ticket_id"          gqlgen:"-"`               //
+	Sequence         uint16       `xorm:"'sequence' notnull smallint pk"           valid:"required,int,range(0|65535)"       json:"sequence"           gqlgen:"Sequence"`        //
+	UUID             string       `xorm:"'uuid' notnull char(36) unique(unique_1)" valid:"-"                                 json:"uuid"               gqlgen:"UUID"`            //
+	Content          string       `xorm:"'content' notnull text"                   valid:"required,length(1|65535),ascii"    json:"content"            gqlgen:"Content"`         //
+	Type             uint8        `xorm:"'type' notnull tinyint"                   valid:"required,matches(^([1-9]?[0-9])$)" json:"type"               gqlgen:"Type"`            // 类型 0-99
+	Status           uint8        `xorm:"'status' notnull tinyint"                 valid:"required,matches(^([1-9]?[0-9])$)" json:"status"             gqlgen:"Status"`          // 状态 0-99
+	Report           string       `xorm:"'report' notnull json"                    valid:"required,length(1|65535)"          json:"report"             gqlgen:"Report"`          //
+	Plan             string       `xorm:"'plan' notnull json"                      valid:"required,length(1|65535)"          json:"plan"               gqlgen:"Plan"`            //
+	Results          string       `xorm:"'results' text"                           valid:"length(1|65535)"                   json:"results"            gqlgen:"Results"`         //
+	RowsAffected     uint         `xorm:"'rows_affected' notnull int"              valid:"required,int,range(0|4294967295)"  json:"rows_affected"      gqlgen:"RowsAffected"`    //
+	Hooks            string       `xorm:"'hooks' text"                             valid:"-"                                 json:"hooks"              gqlgen:"-"`               // gh-ost回调的记录
+	Executor         string       `xorm:"'executor' notnull varchar(10)"           valid:"-"                                 json:"executor"           gqlgen:"Executor"`        // 指定的执行方式，来自halo:executor注释
+	Rollback         string       `xorm:"'rollback' mediumtext"                    valid:"-"                                 json:"rollback"           gqlgen:"Rollback"`        // 回滚语句，DML来自执行期间的binlog
+	SkipReason       string       `xorm:"'skip_reason' notnull varchar(150)"       valid:"-"                                 json:"skip_reason"        gqlgen:"SkipReason"`      // 执行失败后审核人跳过语句的理由，不为空时不再执行
+	BackupTruncated  bool         `xorm:"'backup_truncated' notnull bool"          valid:"-"                                 json:"backup_truncated"   gqlgen:"BackupTruncated"` // 执行之前备份的行超过上限，只备份了前面的行
+	BackupKeyChanged bool         `xorm:"'backup_key_changed' notnull bool"        valid:"-"                                 json:"backup_key_changed" gqlgen:"-"`               // UPDATE修改了主键或者唯一键，备份的行不能用REPLACE恢复
+	Version          int          `xorm:"'version'"                                valid:"-"                                 json:"version"            gqlgen:"-"`               //
+	UpdateAt         uint         `xorm:"'update_at' notnull int"                  valid:"-"                                 json:"update_at"          gqlgen:"UpdateAt"`        //
+	CreateAt         uint         `xorm:"'create_at' notnull int"                  valid:"-"                                 json:"create_at"          gqlgen:"CreateAt"`        //
+	StmtNode         ast.StmtNode `xorm:"-"                                        valid:"-"                                 json:"-"                  gqlgen:"-"`               //
+	Violations       *Violations  `xorm:"-"                                        valid:"-"                                 json:"-"                  gqlgen:"-"`               //
 }
 
 // TableName 结构体到数据库表名称的映射
@@ -81,6 +88,7 @@ func (m *Statement) GetUpdateAt() *uint {
 type Violations struct {
 	sync.Mutex
 	clauses []*Clause
+	scope   sync.Mutex // 规则独占语句执行，以便区分每个规则产生的描述
 }
 
 // Add 增加一个描述
@@ -121,8 +129,126 @@ func (v *Violations) Clauses() []*Clause {
 	return v.clauses
 }
 
-// Clause 语句审核不通过的描述结构体
+// Capture 独占语句执行规则，返回规则执行期间增加的描述
+func (v *Violations) Capture(f func()) []*Clause {
+	v.scope.Lock()
+	defer v.scope.Unlock()
+	n := len(v.Clauses())
+	f()
+	return v.Clauses()[n:]
+}
+
+// Stamp 记录描述对应的规则和对象，规则函数已经指定时保持不变
+func (v *Violations) Stamp(clauses []*Clause, rule string, object string) {
+	v.Lock()
+	defer v.Unlock()
+	for _, c := range clauses {
+		if c.Rule == "" {
+			c.Rule = rule
+		}
+		if c.Object == "" {
+			c.Object = object
+		}
+	}
+}
+
+// Waive 把描述标记为已豁免
+func (v *Violations) Waive(clauses []*Clause, by string, reason string) {
+	v.Lock()
+	defer v.Unlock()
+	for _, c := range clauses {
+		c.Waived = true
+		c.WaivedBy = by
+		c.Reason = reason
+	}
+}
+
+// Clause 语句审核不通过的描述结构体，被豁免的描述仍然出现在报告中，但不影响审核结果
 type Clause struct {
+	Rule        string `json:",omitempty"` // 规则名称，例如CTB-L2-008
 	Level       uint8
 	Description string
+	Object      string `json:",omitempty"` // 违反规则的对象，表、列或者索引的名称
+	Line        int    `json:",omitempty"` // 在工单内容中的行，从1开始
+	Column      int    `json:",omitempty"` // 在工单内容中的列，从1开始
+	Fix         string `json:",omitempty"` // 建议的修改方法
+	Waived      bool   `json:",omitempty"` // 是否已豁免
+	WaivedBy    string `json:",omitempty"` // 批准豁免的人，内联注释豁免时为工单的发起人
+	Reason      string `json:",omitempty"` // 豁免的理由
+}
+
+// ParseReport 从报告中还原描述，报告为空时返回nil
+func ParseReport(report string) ([]*Clause, error) {
+	if report == "" {
+		return nil, nil
+	}
+	clauses := []*Clause{}
+	if err := json.Unmarshal([]byte(report), &clauses); err != nil {
+		return nil, err
+	}
+	return clauses, nil
+}
+
+// 记录在语句上的gh-ost回调
+const (
+	HookStartup          = "startup"
+	HookValidated        = "validated"
+	HookRowcountComplete = "rowcount-complete"
+	HookBeforeRowCopy    = "before-row-copy"
+	HookRowCopyComplete  = "row-copy-complete"
+	HookBeginPostponed   = "begin-postponed"
+	HookBeforeCutOver    = "before-cut-over"
+	HookSuccess          = "success"
+	HookFailure          = "failure"
+	HookStatus           = "status"
+)
+
+// HookEvent gh-ost执行过程中的一次回调
+type HookEvent struct {
+	Hook      string  // 回调名称，例如before-cut-over
+	Copied    int64   `json:",omitempty"` // 已经复制的行数
+	Estimated int64   `json:",omitempty"` // 估算的总行数
+	Progress  float64 `json:",omitempty"` // 复制进度的百分比
+	Elapsed   int64   `json:",omitempty"` // 已经执行的秒数
+	Message   string  `json:",omitempty"` // 状态信息
+	CreateAt  uint
+}
+
+// ParseHooks 从语句上还原gh-ost的回调记录
+func (m *Statement) ParseHooks() ([]*HookEvent, error) {
+	if m.Hooks == "" {
+		return nil, nil
+	}
+	events := []*HookEvent{}
+	if err := json.Unmarshal([]byte(m.Hooks), &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// AppendHook 记录一次回调，连续的状态回调只保留最新的一次
+func (m *Statement) AppendHook(event *HookEvent) error {
+	events, err := m.ParseHooks()
+	if err != nil {
+		return err
+	}
+	if event.CreateAt == 0 {
+		event.CreateAt = uint(time.Now().Unix())
+	}
+	if n := len(events); n > 0 && event.Hook == HookStatus && events[n-1].Hook == HookStatus {
+		events[n-1] = event
+	} else {
+		events = append(events, event)
+	}
+	bs, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	m.Hooks = string(bs)
+	return nil
+}
+
+// PostponeFlagFile gh-ost推迟切换的标记文件，文件存在时gh-ost在复制完成后等待，删除文件后开始切换
+func (m *Statement) PostponeFlagFile() string {
+	return filepath.Join("/tmp", fmt.Sprintf("halocli-ghost-%s.postpone", m.UUID))
 }
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatementHooks(t *testing.T) {
+	stmt := &Statement{UUID: "6f1c2b8e-4c1d-4a8e-9d6e-3f7a2b1c0d9e"}
+	events, err := stmt.ParseHooks()
+	assert.NoError(t, err)
+	assert.Nil(t, events)
+
+	assert.NoError(t, stmt.AppendHook(&HookEvent{Hook: HookStartup}))
+	assert.NoError(t, stmt.AppendHook(&HookEvent{Hook: HookStatus, Copied: 100, Estimated: 1000, Progress: 10}))
+	// 连续的状态回调只保留最新的一次
+	assert.NoError(t, stmt.AppendHook(&HookEvent{Hook: HookStatus, Copied: 500, Estimated: 1000, Progress: 50}))
+	assert.NoError(t, stmt.AppendHook(&HookEvent{Hook: HookBeforeCutOver}))
+	assert.NoError(t, stmt.AppendHook(&HookEvent{Hook: HookSuccess}))
+
+	events, err = stmt.ParseHooks()
+	assert.NoError(t, err)
+	hooks := []string{}
+	for _, e := range events {
+		hooks = append(hooks, e.Hook)
+		assert.NotZero(t, e.CreateAt)
+	}
+	assert.Equal(t, []string{HookStartup, HookStatus, HookBeforeCutOver, HookSuccess}, hooks)
+	assert.Equal(t, int64(500), events[1].Copied)
+
+	assert.Equal(t, "/tmp/halocli-ghost-6f1c2b8e-4c1d-4a8e-9d6e-3f7a2b1c0d9e.postpone", stmt.PostponeFlagFile())
+
+	stmt.Hooks = "{"
+	_, err = stmt.ParseHooks()
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"xorm.io/xorm"
+)
+
+// Waiver 管理员批准的规则豁免，在有效期内对指定的工单或者指定的表不再审核该规则，
+// TicketID不为0时只对该工单生效，否则对群集上的某个表生效
+type Waiver struct {
+	WaiverID  uint   `xorm:"'waiver_id' notnull int pk autoincr"         valid:"-"                          json:"waiver_id"  gqlgen:"-"`        //
+	UUID      string `xorm:"'uuid' notnull char(36) unique(unique_1)"    valid:"-"                          json:"uuid"       gqlgen:"UUID"`     //
+	RuleName  string `xorm:"'rule_name' notnull char(10) index(index_1)" valid:"required,length(10|10)"     json:"rule_name"  gqlgen:"RuleName"` //
+	TicketID  uint   `xorm:"'ticket_id' notnull int index(index_2)"      valid:"-"                          json:"ticket_id"  gqlgen:"-"`        // 0表示不限定工单
+	ClusterID uint   `xorm:"'cluster_id' notnull int index(index_3)"     valid:"-"                          json:"cluster_id" gqlgen:"-"`        //
+	Database  string `xorm:"'database' notnull varchar(50)"              valid:"length(0|50)"               json:"database"   gqlgen:"Database"` //
+	Table     string `xorm:"'table' notnull varchar(75)"                 valid:"length(0|75)"               json:"table"      gqlgen:"Table"`    //
+	Reason    string `xorm:"'reason' notnull varchar(150)"               valid:"required,runelength(1|150)" json:"reason"     gqlgen:"Reason"`   //
+	UserID    uint   `xorm:"'user_id' notnull int"                       valid:"-"                          json:"user_id"    gqlgen:"-"`        // 批准豁免的管理员
+	ExpireAt  uint   `xorm:"'expire_at' notnull int"                     valid:"-"                          json:"expire_at"  gqlgen:"ExpireAt"` //
+	Version   int    `xorm:"'version'"                                   valid:"-"                          json:"version"    gqlgen:"-"`        //
+	UpdateAt  uint   `xorm:"'update_at' notnull int"                     valid:"-"                          json:"update_at"  gqlgen:"UpdateAt"` //
+	CreateAt  uint   `xorm:"'create_at' notnull int"                     valid:"-"                          json:"create_at"  gqlgen:"CreateAt"` //
+}
+
+// TableName 结构体到数据库表名称的映射
+func (m *Waiver) TableName() string {
+	return "mm_waivers"
+}
+
+// BeforeInsert ORM在执行数据插入前会调用该方法
+func (m *Waiver) BeforeInsert() {
+	m.UUID = uuid.New().String()
+	m.Reason = strings.TrimSpace(m.Reason)
+	m.CreateAt = uint(time.Now().Unix())
+}
+
+// BeforeUpdate ORM在执行数据更新前会调用该方法
+func (m *Waiver) BeforeUpdate() {
+	m.Reason = strings.TrimSpace(m.Reason)
+	m.UpdateAt = uint(time.Now().Unix())
+}
+
+// AfterSet ORM在执行数据更新后会调用该方法
+func (m *Waiver) AfterSet(colName string, _ xorm.Cell) {
+}
+
+// String 结构体输出到字符串的默认方式
+func (m *Waiver) String() string {
+	return fmt.Sprintf("uuid: %s, rule_name: %s, ticket_id: %d, cluster_id: %d, database: %s, table: %s, reason: %s, user_id: %d, expire_at: %d",
+		m.UUID,
+		m.RuleName,
+		m.TicketID,
+		m.ClusterID,
+		m.Database,
+		m.Table,
+		m.Reason,
+		m.UserID,
+		m.ExpireAt,
+	)
+}
+
+// Expired 豁免是否已经过期
+func (m *Waiver) Expired(now uint) bool {
+	return m.ExpireAt <= now
+}
+
+// Covers 豁免是否覆盖工单中的某个表，数据库和表的名称不区分大小写
+func (m *Waiver) Covers(ticketID uint, clusterID uint, database string, table string) bool {
+	if m.TicketID != 0 {
+		return m.TicketID == ticketID
+	}
+	return m.ClusterID == clusterID &&
+		strings.EqualFold(m.Database, database) &&
+		strings.EqualFold(m.Table, table)
+}
+
+// IsNode GraphQL的基类需要实现的接口，暂时不动
+func (Waiver) IsNode() {}
+
+// 创建时间
+func (m *Waiver) GetCreateAt() uint {
+	return m.CreateAt
+}
+
+// 最后一次修改时间
+func (m *Waiver) GetUpdateAt() *uint {
+	return &m.UpdateAt
+}
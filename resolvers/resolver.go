@@ -99,6 +99,11 @@ type mutationRootResolver struct{ *Resolver }
 type queryRootResolver struct{ *Resolver }
 type subscriptionRootResolver struct{ *Resolver }
 
+// BackupRow 语句执行之前备份的行
+func (r *Resolver) BackupRow() gqlapi.BackupRowResolver {
+	return &backupRowResolver{r}
+}
+
 // Comment TODO: 添加描述
 func (r *Resolver) Comment() gqlapi.CommentResolver {
 	return &commentResolver{r}
@@ -129,6 +134,11 @@ func (r *Resolver) Role() gqlapi.RoleResolver {
 	return &roleResolver{r}
 }
 
+// RuleBinding TODO: 添加描述
+func (r *Resolver) RuleBinding() gqlapi.RuleBindingResolver {
+	return &ruleBindingResolver{r}
+}
+
 // Statement TODO: 添加描述
 func (r *Resolver) Statement() gqlapi.StatementResolver {
 	return &statementResolver{r}
@@ -149,6 +159,11 @@ func (r *Resolver) User() gqlapi.UserResolver {
 	return &userResolver{r}
 }
 
+// Waiver TODO: 添加描述
+func (r *Resolver) Waiver() gqlapi.WaiverResolver {
+	return &waiverResolver{r}
+}
+
 // EncodeCursor 对分页的光标进行编码
 func EncodeCursor(s string) string {
 	i, _ := strconv.Atoi(s)
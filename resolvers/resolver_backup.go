@@ -0,0 +1,204 @@
+package resolvers
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mia0x75/halo/executors"
+	"github.com/mia0x75/halo/g"
+	"github.com/mia0x75/halo/gqlapi"
+	"github.com/mia0x75/halo/models"
+	"github.com/mia0x75/halo/tools"
+)
+
+type backupRowResolver struct{ *Resolver }
+
+// Columns 备份的列名
+func (r *backupRowResolver) Columns(ctx context.Context, obj *models.RowBackup) (columns []string, err error) {
+	rc := gqlapi.ReturnCodeOK
+	if columns, _, err = obj.ParseRow(); err != nil {
+		columns = nil
+		rc = gqlapi.ReturnCodeUnknowError
+		err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+	}
+	return
+}
+
+// Values 备份的列值
+func (r *backupRowResolver) Values(ctx context.Context, obj *models.RowBackup) (values []string, err error) {
+	rc := gqlapi.ReturnCodeOK
+	if _, values, err = obj.ParseRow(); err != nil {
+		values = nil
+		rc = gqlapi.ReturnCodeUnknowError
+		err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+	}
+	return
+}
+
+// BackupRows 按备份的顺序分页浏览语句执行之前备份的行，只支持向后翻页
+func (r *queryRootResolver) BackupRows(ctx context.Context, id string, after *string, first *int) (data *gqlapi.BackupRowConnection, err error) {
+	for {
+		rc := gqlapi.ReturnCodeOK
+		var stmt *models.Statement
+		if stmt, err = backupStatement(ctx, id); err != nil {
+			break
+		}
+
+		from := uint64(0)
+		if after != nil {
+			var bs []byte
+			if bs, err = base64.StdEncoding.DecodeString(*after); err != nil {
+				rc = gqlapi.ReturnCodeInvalidParams
+				err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+				break
+			}
+			if from, err = strconv.ParseUint(strings.TrimPrefix(string(bs), "cursor"), 10, 64); err != nil {
+				rc = gqlapi.ReturnCodeInvalidParams
+				err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+				break
+			}
+		}
+		limit := 50
+		if first != nil && *first > 0 {
+			limit = *first
+		}
+
+		rows := []*models.RowBackup{}
+		if err = g.BackupEngine.Where("`statement_uuid` = ? AND `backup_id` > ?", id, from).
+			Asc("backup_id").
+			Limit(limit).
+			Find(&rows); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		edges := []*gqlapi.BackupRowEdge{}
+		for _, row := range rows {
+			edges = append(edges, &gqlapi.BackupRowEdge{
+				Node:   row,
+				Cursor: EncodeCursor(fmt.Sprintf("%d", row.BackupID)),
+			})
+		}
+		data = &gqlapi.BackupRowConnection{
+			PageInfo: &gqlapi.PageInfo{
+				HasPreviousPage: after != nil,
+				HasNextPage:     len(rows) == limit,
+				StartCursor:     edges[0].Cursor,
+				EndCursor:       edges[len(edges)-1].Cursor,
+			},
+			Edges:      edges,
+			TotalCount: len(edges),
+			Truncated:  stmt.BackupTruncated,
+		}
+
+		// 退出for循环
+		break
+	}
+
+	return
+}
+
+// RestoreBackupRows 根据备份的行生成恢复语句，备份被截断时在结果中标明
+func (r *mutationRootResolver) RestoreBackupRows(ctx context.Context, id string) (payload *gqlapi.RestorePayload, err error) {
+	for {
+		rc := gqlapi.ReturnCodeOK
+		var stmt *models.Statement
+		if stmt, err = backupStatement(ctx, id); err != nil {
+			break
+		}
+		if stmt.BackupKeyChanged {
+			rc = gqlapi.ReturnCodeInvalidParams
+			err = fmt.Errorf("错误代码: %s, 错误信息: 语句(uuid=%s)修改了主键或者唯一键，REPLACE会保留修改之后的行，请根据备份的行人工恢复。", rc, id)
+			break
+		}
+
+		rows := []*models.RowBackup{}
+		if err = g.BackupEngine.Where("`statement_uuid` = ?", id).Asc("backup_id").Find(&rows); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		if len(rows) == 0 {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 语句(uuid=%s)没有备份的行。", rc, id)
+			break
+		}
+		sql := ""
+		if sql, err = executors.RestoreStatements(rows); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		payload = &gqlapi.RestorePayload{
+			Content:   sql,
+			Truncated: stmt.BackupTruncated,
+		}
+
+		// 退出for循环
+		break
+	}
+
+	return
+}
+
+// backupStatement 查找备份了行的语句，备份中有业务数据，只有工单的发起人和审核人可以查看
+func backupStatement(ctx context.Context, id string) (stmt *models.Statement, err error) {
+	for {
+		rc := gqlapi.ReturnCodeOK
+		found := false
+		credential := ctx.Value(g.CREDENTIAL_KEY).(tools.Credential)
+		user := credential.User
+
+		if g.BackupEngine == nil {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 没有配置备份库。", rc)
+			break
+		}
+
+		stmt = &models.Statement{}
+		if found, err = g.Engine.Where("`uuid` = ?", id).Get(stmt); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		if !found {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 语句(uuid=%s)不存在。", rc, id)
+			break
+		}
+
+		ticket := &models.Ticket{
+			TicketID: stmt.TicketID,
+		}
+		if found, err = g.Engine.Get(ticket); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		if !found {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 语句(uuid=%s)的工单不存在。", rc, id)
+			break
+		}
+		if ticket.UserID != user.UserID && ticket.ReviewerID != user.UserID {
+			rc = gqlapi.ReturnCodeForbidden
+			err = fmt.Errorf("错误代码: %s, 错误信息: 只有工单的发起人和审核人可以查看备份的行。", rc)
+			break
+		}
+
+		break
+	}
+
+	if err != nil {
+		stmt = nil
+	}
+
+	return
+}
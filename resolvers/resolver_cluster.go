@@ -10,9 +10,11 @@ import (
 	"github.com/fatih/structs"
 	"github.com/go-xorm/core"
 	"github.com/mia0x75/yql"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/mia0x75/halo/caches"
 	"github.com/mia0x75/halo/events"
+	"github.com/mia0x75/halo/executors"
 	"github.com/mia0x75/halo/g"
 	"github.com/mia0x75/halo/gqlapi"
 	"github.com/mia0x75/halo/models"
@@ -342,6 +344,18 @@ L:
 		cluster.Status = input.Status
 		cluster.Password = passwd
 		cluster.Alias = input.Alias
+		if input.Executor != nil {
+			if !executors.Valid(*input.Executor) {
+				rc = gqlapi.ReturnCodeInvalidParams
+				err = fmt.Errorf("错误代码: %s, 错误信息: 执行方式\"%s\"无效。", rc, *input.Executor)
+				break L
+			}
+			cluster.Executor = *input.Executor
+		}
+		// 群集暂时无法连接时不影响创建，版本信息等待定期刷新
+		if _, e := cluster.DetectVersion(func(c *models.Cluster) []byte { return []byte(input.Password) }); e != nil {
+			log.Warnf("[W] 群集(alias=%s)的版本获取失败，%s", cluster.Alias, e.Error())
+		}
 
 		if _, err = g.Engine.Insert(cluster); err != nil {
 			cluster = nil
@@ -431,6 +445,18 @@ L:
 		cluster.User = input.User
 		cluster.Alias = input.Alias
 		cluster.Status = input.Status
+		if input.Executor != nil {
+			if !executors.Valid(*input.Executor) {
+				rc = gqlapi.ReturnCodeInvalidParams
+				err = fmt.Errorf("错误代码: %s, 错误信息: 执行方式\"%s\"无效。", rc, *input.Executor)
+				break L
+			}
+			cluster.Executor = *input.Executor
+		}
+		// 连接信息可能指向了新的实例，重新获取版本
+		if _, e := cluster.DetectVersion(func(c *models.Cluster) []byte { return []byte(input.Password) }); e != nil {
+			log.Warnf("[W] 群集(alias=%s)的版本获取失败，%s", cluster.Alias, e.Error())
+		}
 
 		if _, err = g.Engine.ID(cluster.ClusterID).AllCols().Update(cluster); err != nil {
 			cluster = nil
@@ -12,6 +12,7 @@ import (
 	"github.com/mia0x75/halo/gqlapi"
 	"github.com/mia0x75/halo/models"
 	"github.com/mia0x75/halo/tools"
+	"github.com/mia0x75/halo/validate"
 )
 
 // PatchRuleValues 修改规则值
@@ -34,6 +35,19 @@ func (r *mutationRootResolver) PatchRuleValues(ctx context.Context, input models
 			err = fmt.Errorf("错误代码: %s, 错误信息: 规则(uuid=%s)不允许更新。", rc, input.RuleUUID)
 			break
 		}
+		if input.Path != nil {
+			if rule.Func != "Evaluate" {
+				rc = gqlapi.ReturnCodeInvalidParams
+				err = fmt.Errorf("错误代码: %s, 错误信息: 规则(uuid=%s)不是声明式规则，不能设置路径表达式。", rc, input.RuleUUID)
+				break
+			}
+			if err = validate.CheckPath(*input.Path); err != nil {
+				rc = gqlapi.ReturnCodeInvalidParams
+				err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+				break
+			}
+			rule.Path = *input.Path
+		}
 		rule.Values = input.Values
 		if _, err = g.Engine.Where("`uuid` = ?", rule.UUID).Update(rule); err != nil {
 			rc = gqlapi.ReturnCodeUnknowError
@@ -0,0 +1,353 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mia0x75/halo/caches"
+	"github.com/mia0x75/halo/events"
+	"github.com/mia0x75/halo/g"
+	"github.com/mia0x75/halo/gqlapi"
+	"github.com/mia0x75/halo/models"
+	"github.com/mia0x75/halo/tools"
+)
+
+// CreateRuleProfile 创建一个规则配置
+func (r *mutationRootResolver) CreateRuleProfile(ctx context.Context, input models.CreateRuleProfileInput) (profile *models.RuleProfile, err error) {
+	for {
+		rc := gqlapi.ReturnCodeOK
+		if caches.RuleProfilesMap.Any(func(elem *models.RuleProfile) bool {
+			if elem.Name == input.Name {
+				return true
+			}
+			return false
+		}) != nil {
+			rc = gqlapi.ReturnCodeInvalidParams
+			err = fmt.Errorf("错误代码: %s, 错误信息: 规则配置(name=%s)已经存在。", rc, input.Name)
+			break
+		}
+
+		profile = &models.RuleProfile{
+			Name:        input.Name,
+			Description: input.Description,
+		}
+		if _, err = g.Engine.Insert(profile); err != nil {
+			profile = nil
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+
+		// 主动刷新缓存
+		caches.RuleProfilesMap.Init()
+
+		credential := ctx.Value(g.CREDENTIAL_KEY).(tools.Credential)
+		events.Fire(events.EventRuleProfileUpdated, &events.RuleProfileUpdatedArgs{
+			Manager: *credential.User,
+			Profile: *profile,
+		})
+
+		// 退出for循环
+		break
+	}
+
+	return
+}
+
+// RemoveRuleProfile 删除一个规则配置，同时删除覆盖和适用范围
+func (r *mutationRootResolver) RemoveRuleProfile(ctx context.Context, id string) (ok bool, err error) {
+	for {
+		rc := gqlapi.ReturnCodeOK
+		profile := caches.RuleProfilesMap.Any(func(elem *models.RuleProfile) bool {
+			if elem.UUID == id {
+				return true
+			}
+			return false
+		})
+		if profile == nil {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 规则配置(uuid=%s)不存在。", rc, id)
+			break
+		}
+
+		session := g.Engine.NewSession()
+		defer session.Close()
+		session.Begin()
+
+		if _, err = session.Delete(&models.RuleOverride{ProfileID: profile.ProfileID}); err != nil {
+			session.Rollback()
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		if _, err = session.Delete(&models.RuleBinding{ProfileID: profile.ProfileID}); err != nil {
+			session.Rollback()
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		if _, err = session.ID(profile.ProfileID).Delete(&models.RuleProfile{}); err != nil {
+			session.Rollback()
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		if err = session.Commit(); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+
+		// 主动刷新缓存
+		caches.RuleProfilesMap.Init()
+
+		credential := ctx.Value(g.CREDENTIAL_KEY).(tools.Credential)
+		events.Fire(events.EventRuleProfileUpdated, &events.RuleProfileUpdatedArgs{
+			Manager: *credential.User,
+			Profile: *profile,
+		})
+
+		// 退出for循环
+		ok = true
+		break
+	}
+
+	return
+}
+
+// PatchRuleOverride 设置规则配置中的一条覆盖，只覆盖设置了的字段，全部为空时删除该覆盖
+func (r *mutationRootResolver) PatchRuleOverride(ctx context.Context, input models.PatchRuleOverrideInput) (ok bool, err error) {
+	for {
+		rc := gqlapi.ReturnCodeOK
+		profile := caches.RuleProfilesMap.Any(func(elem *models.RuleProfile) bool {
+			if elem.UUID == input.ProfileUUID {
+				return true
+			}
+			return false
+		})
+		if profile == nil {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 规则配置(uuid=%s)不存在。", rc, input.ProfileUUID)
+			break
+		}
+		rule := caches.RulesMap.Any(func(elem *models.Rule) bool {
+			if elem.UUID == input.RuleUUID {
+				return true
+			}
+			return false
+		})
+		if rule == nil {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 规则(uuid=%s)不存在。", rc, input.RuleUUID)
+			break
+		}
+		if rule.Bitwise&2 != 2 {
+			rc = gqlapi.ReturnCodeNotWritable
+			err = fmt.Errorf("错误代码: %s, 错误信息: 规则(uuid=%s)不允许更新。", rc, input.RuleUUID)
+			break
+		}
+		if input.Level != nil && *input.Level > 3 {
+			rc = gqlapi.ReturnCodeInvalidParams
+			err = fmt.Errorf("错误代码: %s, 错误信息: 规则的严重级别%d无效。", rc, *input.Level)
+			break
+		}
+		if input.Values != nil && len(*input.Values) > 150 {
+			rc = gqlapi.ReturnCodeInvalidParams
+			err = fmt.Errorf("错误代码: %s, 错误信息: 规则的设定值超过了150个字符。", rc)
+			break
+		}
+		if input.Bitwise != nil && *input.Bitwise != 0 && (*input.Bitwise < 4 || *input.Bitwise > 7) {
+			rc = gqlapi.ReturnCodeInvalidParams
+			err = fmt.Errorf("错误代码: %s, 错误信息: 规则的状态位%d无效。", rc, *input.Bitwise)
+			break
+		}
+
+		override := &models.RuleOverride{}
+		if _, err = g.Engine.Where("`profile_id` = ? AND `rule_name` = ?", profile.ProfileID, rule.Name).Get(override); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+
+		switch {
+		case input.Level == nil && input.Values == nil && input.Bitwise == nil:
+			// 全部为空时删除该覆盖
+			if override.OverrideID != 0 {
+				_, err = g.Engine.ID(override.OverrideID).Delete(&models.RuleOverride{})
+			}
+		case override.OverrideID == 0:
+			override.ProfileID = profile.ProfileID
+			override.RuleName = rule.Name
+			override.Level = input.Level
+			override.Values = input.Values
+			override.Bitwise = input.Bitwise
+			_, err = g.Engine.Insert(override)
+		default:
+			override.Level = input.Level
+			override.Values = input.Values
+			override.Bitwise = input.Bitwise
+			_, err = g.Engine.ID(override.OverrideID).Cols("level", "values", "bitwise").Update(override)
+		}
+		if err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+
+		// 主动刷新缓存
+		caches.RuleProfilesMap.Init()
+
+		credential := ctx.Value(g.CREDENTIAL_KEY).(tools.Credential)
+		events.Fire(events.EventRuleProfileUpdated, &events.RuleProfileUpdatedArgs{
+			Manager: *credential.User,
+			Profile: *profile,
+		})
+
+		// 退出for循环
+		ok = true
+		break
+	}
+
+	return
+}
+
+// BindRuleProfile 把规则配置指定给群集或者数据库，替换原来的配置
+func (r *mutationRootResolver) BindRuleProfile(ctx context.Context, input models.BindRuleProfileInput) (ok bool, err error) {
+	for {
+		rc := gqlapi.ReturnCodeOK
+		profile := caches.RuleProfilesMap.Any(func(elem *models.RuleProfile) bool {
+			if elem.UUID == input.ProfileUUID {
+				return true
+			}
+			return false
+		})
+		if profile == nil {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 规则配置(uuid=%s)不存在。", rc, input.ProfileUUID)
+			break
+		}
+		cluster := caches.ClustersMap.Any(func(elem *models.Cluster) bool {
+			if elem.UUID == input.ClusterUUID {
+				return true
+			}
+			return false
+		})
+		if cluster == nil {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 群集(uuid=%s)不存在。", rc, input.ClusterUUID)
+			break
+		}
+
+		binding := &models.RuleBinding{}
+		if _, err = g.Engine.Where("`cluster_id` = ? AND `database` = ?", cluster.ClusterID, input.Database).Get(binding); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		if binding.BindingID == 0 {
+			binding.ProfileID = profile.ProfileID
+			binding.ClusterID = cluster.ClusterID
+			binding.Database = input.Database
+			_, err = g.Engine.Insert(binding)
+		} else {
+			binding.ProfileID = profile.ProfileID
+			_, err = g.Engine.ID(binding.BindingID).Cols("profile_id").Update(binding)
+		}
+		if err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+
+		// 主动刷新缓存
+		caches.RuleProfilesMap.Init()
+
+		credential := ctx.Value(g.CREDENTIAL_KEY).(tools.Credential)
+		events.Fire(events.EventRuleProfileUpdated, &events.RuleProfileUpdatedArgs{
+			Manager: *credential.User,
+			Profile: *profile,
+		})
+
+		// 退出for循环
+		ok = true
+		break
+	}
+
+	return
+}
+
+// UnbindRuleProfile 取消群集或者数据库上的规则配置
+func (r *mutationRootResolver) UnbindRuleProfile(ctx context.Context, input models.UnbindRuleProfileInput) (ok bool, err error) {
+	for {
+		rc := gqlapi.ReturnCodeOK
+		cluster := caches.ClustersMap.Any(func(elem *models.Cluster) bool {
+			if elem.UUID == input.ClusterUUID {
+				return true
+			}
+			return false
+		})
+		if cluster == nil {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 群集(uuid=%s)不存在。", rc, input.ClusterUUID)
+			break
+		}
+		var profile *models.RuleProfile
+		var binding *models.RuleBinding
+		for _, elem := range caches.RuleProfilesMap.All() {
+			for _, b := range elem.Bindings {
+				if b.ClusterID == cluster.ClusterID && b.Database == input.Database {
+					profile, binding = elem, b
+				}
+			}
+		}
+		if binding == nil {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 群集(uuid=%s)上没有指定规则配置。", rc, input.ClusterUUID)
+			break
+		}
+		if _, err = g.Engine.ID(binding.BindingID).Delete(&models.RuleBinding{}); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+
+		// 主动刷新缓存
+		caches.RuleProfilesMap.Init()
+
+		credential := ctx.Value(g.CREDENTIAL_KEY).(tools.Credential)
+		events.Fire(events.EventRuleProfileUpdated, &events.RuleProfileUpdatedArgs{
+			Manager: *credential.User,
+			Profile: *profile,
+		})
+
+		// 退出for循环
+		ok = true
+		break
+	}
+
+	return
+}
+
+// RuleProfiles 规则配置在页面上是一个页面处理所有内容，所以不需要考虑分页
+func (r *queryRootResolver) RuleProfiles(ctx context.Context) (L []*models.RuleProfile, err error) {
+	L = caches.RuleProfilesMap.All()
+	return
+}
+
+type ruleBindingResolver struct{ *Resolver }
+
+// Cluster 规则配置适用的群集
+func (r *ruleBindingResolver) Cluster(ctx context.Context, obj *models.RuleBinding) (cluster *models.Cluster, err error) {
+	rc := gqlapi.ReturnCodeOK
+	cluster = caches.ClustersMap.Any(func(elem *models.Cluster) bool {
+		if elem.ClusterID == obj.ClusterID {
+			return true
+		}
+		return false
+	})
+	if cluster == nil {
+		rc = gqlapi.ReturnCodeNotFound
+		err = fmt.Errorf("错误代码: %s, 错误信息: 规则配置适用范围(uuid=%s)依赖的群集不存在。", rc, obj.UUID)
+	}
+	return
+}
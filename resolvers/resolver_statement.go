@@ -3,10 +3,14 @@ package resolvers
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
 
+	"github.com/mia0x75/halo/executors"
 	"github.com/mia0x75/halo/g"
 	"github.com/mia0x75/halo/gqlapi"
 	"github.com/mia0x75/halo/models"
+	"github.com/mia0x75/halo/tools"
 )
 
 type statementResolver struct{ *Resolver }
@@ -31,6 +35,18 @@ func (r *statementResolver) Ticket(ctx context.Context, obj *models.Statement) (
 	return
 }
 
+// Clauses 语句违反的规则，从审核报告中还原
+func (r *statementResolver) Clauses(ctx context.Context, obj *models.Statement) (clauses []*models.Clause, err error) {
+	rc := gqlapi.ReturnCodeOK
+	if clauses, err = models.ParseReport(obj.Report); err != nil {
+		clauses = nil
+		rc = gqlapi.ReturnCodeUnknowError
+		err = fmt.Errorf("错误代码: %s, 错误信息: 语句(uuid=%s)的审核报告无法解析，%s", rc, obj.UUID, err.Error())
+	}
+
+	return
+}
+
 // TypeDesc 语句类型
 func (r *statementResolver) TypeDesc(ctx context.Context, obj *models.Statement) (string, error) {
 	for k, v := range gqlapi.StatementTypeEnumMap {
@@ -40,3 +56,127 @@ func (r *statementResolver) TypeDesc(ctx context.Context, obj *models.Statement)
 	}
 	return "OTHER", nil
 }
+
+// HookEvents 使用gh-ost执行时的回调记录
+func (r *statementResolver) HookEvents(ctx context.Context, obj *models.Statement) (events []*models.HookEvent, err error) {
+	rc := gqlapi.ReturnCodeOK
+	if events, err = obj.ParseHooks(); err != nil {
+		events = nil
+		rc = gqlapi.ReturnCodeUnknowError
+		err = fmt.Errorf("错误代码: %s, 错误信息: 语句(uuid=%s)的回调记录无法解析，%s", rc, obj.UUID, err.Error())
+	}
+
+	return
+}
+
+// CutOverPostponed gh-ost是否推迟切换
+func (r *statementResolver) CutOverPostponed(ctx context.Context, obj *models.Statement) (bool, error) {
+	_, err := os.Stat(obj.PostponeFlagFile())
+	return err == nil, nil
+}
+
+// PostponeCutOver 推迟gh-ost的切换，创建标记文件，复制完成后gh-ost保持同步等待
+func (r *mutationRootResolver) PostponeCutOver(ctx context.Context, id string) (ok bool, err error) {
+	for {
+		rc := gqlapi.ReturnCodeOK
+		var stmt *models.Statement
+		if stmt, err = cutOverStatement(ctx, id); err != nil {
+			break
+		}
+		if err = ioutil.WriteFile(stmt.PostponeFlagFile(), nil, 0644); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+
+		ok = true
+		break
+	}
+
+	return
+}
+
+// UnpostponeCutOver 取消推迟，删除标记文件后gh-ost开始切换
+func (r *mutationRootResolver) UnpostponeCutOver(ctx context.Context, id string) (ok bool, err error) {
+	for {
+		rc := gqlapi.ReturnCodeOK
+		var stmt *models.Statement
+		if stmt, err = cutOverStatement(ctx, id); err != nil {
+			break
+		}
+		if err = os.Remove(stmt.PostponeFlagFile()); err != nil && !os.IsNotExist(err) {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		err = nil
+
+		ok = true
+		break
+	}
+
+	return
+}
+
+// cutOverStatement 查找需要控制切换的语句，只有工单的审核人可以控制
+func cutOverStatement(ctx context.Context, id string) (stmt *models.Statement, err error) {
+	for {
+		rc := gqlapi.ReturnCodeOK
+		found := false
+		credential := ctx.Value(g.CREDENTIAL_KEY).(tools.Credential)
+		user := credential.User
+
+		stmt = &models.Statement{}
+		if found, err = g.Engine.Where("`uuid` = ?", id).Get(stmt); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		if !found {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 语句(uuid=%s)不存在。", rc, id)
+			break
+		}
+
+		ticket := &models.Ticket{
+			TicketID: stmt.TicketID,
+		}
+		if found, err = g.Engine.Get(ticket); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		if !found {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 语句(uuid=%s)的工单不存在。", rc, id)
+			break
+		}
+		if ticket.ReviewerID != user.UserID {
+			rc = gqlapi.ReturnCodeForbidden
+			err = fmt.Errorf("错误代码: %s, 错误信息: 只有工单的审核人可以控制切换。", rc)
+			break
+		}
+		// 工单审核通过之后、语句执行结束之前才可以控制切换
+		if ticket.Status != gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumLgtm] ||
+			stmt.Status == gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumDone] ||
+			stmt.Status == gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumExecFailure] {
+			rc = gqlapi.ReturnCodeInvalidParams
+			err = fmt.Errorf("错误代码: %s, 错误信息: 语句(uuid=%s)不在执行中，不能控制切换。", rc, id)
+			break
+		}
+		// 语句指定了gh-ost，或者自动选择了gh-ost并且已经有回调记录
+		if stmt.Executor != executors.Ghost && stmt.Hooks == "" {
+			rc = gqlapi.ReturnCodeInvalidParams
+			err = fmt.Errorf("错误代码: %s, 错误信息: 语句(uuid=%s)不是使用gh-ost执行的，不能控制切换。", rc, id)
+			break
+		}
+
+		break
+	}
+
+	if err != nil {
+		stmt = nil
+	}
+
+	return
+}
@@ -6,12 +6,13 @@ import (
 	"encoding/base64"
 	"fmt"
 	"math"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/go-xorm/core"
-	"github.com/mia0x75/parser"
 	"github.com/mia0x75/parser/ast"
 	"github.com/mia0x75/parser/driver"
 	"github.com/mia0x75/parser/format"
@@ -20,6 +21,7 @@ import (
 	"github.com/mia0x75/halo/caches"
 	"github.com/mia0x75/halo/crons"
 	"github.com/mia0x75/halo/events"
+	"github.com/mia0x75/halo/executors"
 	"github.com/mia0x75/halo/g"
 	"github.com/mia0x75/halo/gqlapi"
 	"github.com/mia0x75/halo/models"
@@ -30,6 +32,9 @@ import (
 // 保留，不可以删除
 var _ = driver.ValueExpr{}
 
+// 同时审核的工单数量上限，审核和pt-osc的--dry-run都占用槽位，超出时排队等待
+var validations = make(chan struct{}, runtime.NumCPU())
+
 // CreateTicket 创建一个工单
 func (r *mutationRootResolver) CreateTicket(ctx context.Context, input models.CreateTicketInput) (ticket *models.Ticket, err error) {
 L:
@@ -132,9 +137,8 @@ L:
 		}()
 
 		// 拆分语句
-		p := parser.New()
 		stmts := []ast.StmtNode{}
-		stmts, _, err = p.Parse(input.Content, "", "")
+		stmts, err = validate.Parse(input.Content)
 		if err != nil {
 			rc = gqlapi.ReturnCodeUnknowError
 			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
@@ -177,6 +181,7 @@ L:
 				Type:       StatementType2Uint8(node),
 				Status:     gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumWaitingForVld],
 				TicketID:   ticket.TicketID,
+				Executor:   executors.Annotation(node.Text()),
 				StmtNode:   node,
 				Violations: &models.Violations{},
 			}
@@ -317,9 +322,8 @@ L:
 
 		// 拆分语句
 		i := 1
-		p := parser.New()
 		stmts := []ast.StmtNode{}
-		stmts, _, err = p.Parse(input.Content, "", "")
+		stmts, err = validate.Parse(input.Content)
 		if err != nil {
 			rc = gqlapi.ReturnCodeUnknowError
 			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
@@ -388,6 +392,7 @@ L:
 				Content:    sql,
 				Status:     gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumWaitingForVld],
 				TicketID:   ticket.TicketID,
+				Executor:   executors.Annotation(node.Text()),
 				StmtNode:   node,
 				Violations: &models.Violations{},
 			}
@@ -526,6 +531,180 @@ func (r *mutationRootResolver) RemoveTicket(ctx context.Context, id string) (ok
 	return
 }
 
+// AutofixTicket 自动修正工单中能够机械修正的问题，只返回修正之后的内容和差异，
+// 开发人员采纳之后通过UpdateTicket提交
+func (r *mutationRootResolver) AutofixTicket(ctx context.Context, id string) (payload *gqlapi.AutofixPayload, err error) {
+	for {
+		rc := gqlapi.ReturnCodeOK
+		found := false
+		credential := ctx.Value(g.CREDENTIAL_KEY).(tools.Credential)
+		user := credential.User
+		ticket := &models.Ticket{
+			UUID: id,
+		}
+		if found, err = g.Engine.Get(ticket); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		if !found {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 工单(uuid=%s)不存在。", rc, id)
+			break
+		}
+		if ticket.UserID != user.UserID {
+			rc = gqlapi.ReturnCodeForbidden
+			err = fmt.Errorf("错误代码: %s, 错误信息: 只有工单(uuid=%s)的发起人可以修正工单。", rc, id)
+			break
+		}
+		cluster := caches.ClustersMap.Any(func(elem *models.Cluster) bool {
+			if elem.ClusterID == ticket.ClusterID {
+				return true
+			}
+			return false
+		})
+		if cluster == nil {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 工单(uuid=%s)的目标群集不存在。", rc, id)
+			break
+		}
+
+		// 与审核使用相同的元数据和规则配置
+		p := validate.NewClusterProvider(cluster)
+		vctx := &validate.Context{
+			Cluster: cluster,
+			Passwd:  p.Passwd,
+			Ticket:  ticket,
+			Profile: caches.RuleProfilesMap.Resolve(cluster.ClusterID, ticket.Database),
+			Waivers: caches.WaiversMap.Active(ticket.TicketID, cluster.ClusterID),
+		}
+		if e := vctx.Load(p); e != nil {
+			log.Warnf("[W] Failed to load metadata of cluster %s, err: %s", cluster.Alias, e.Error())
+		}
+		var content string
+		var fixes []*models.Clause
+		if content, fixes, err = validate.Autofix(vctx, caches.RulesMap.All()); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+
+		payload = &gqlapi.AutofixPayload{
+			Content: content,
+			Diff:    tools.Diff(ticket.Content, content),
+			Fixes:   fixes,
+		}
+
+		// 退出for循环
+		break
+	}
+
+	return
+}
+
+// RollbackTicket 把已执行工单中语句的回滚语句按执行的相反顺序组成新工单，
+// 新工单的群集、数据库和审核人与原工单相同，需要重新审核
+func (r *mutationRootResolver) RollbackTicket(ctx context.Context, id string) (ticket *models.Ticket, err error) {
+	for {
+		rc := gqlapi.ReturnCodeOK
+		found := false
+		credential := ctx.Value(g.CREDENTIAL_KEY).(tools.Credential)
+		user := credential.User
+		origin := &models.Ticket{
+			UUID: id,
+		}
+		if found, err = g.Engine.Get(origin); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		if !found {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 工单(uuid=%s)不存在。", rc, id)
+			break
+		}
+		if origin.UserID != user.UserID {
+			rc = gqlapi.ReturnCodeForbidden
+			err = fmt.Errorf("错误代码: %s, 错误信息: 只有工单(uuid=%s)的发起人可以回滚工单。", rc, id)
+			break
+		}
+		if origin.Status != gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumDone] &&
+			origin.Status != gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumExecFailure] {
+			rc = gqlapi.ReturnCodeForbidden
+			err = fmt.Errorf("错误代码: %s, 错误信息: 只有已经执行的工单(uuid=%s)才可以回滚。", rc, id)
+			break
+		}
+
+		stmts := []*models.Statement{}
+		if err = g.Engine.Where("`ticket_id` = ? AND `status` = ?", origin.TicketID, gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumDone]).
+			Desc("sequence").
+			Find(&stmts); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		// 后执行的语句先回滚
+		sqls := []string{}
+		for _, stmt := range stmts {
+			if rollback := strings.TrimSpace(stmt.Rollback); rollback != "" {
+				sqls = append(sqls, rollback)
+			}
+		}
+		if len(sqls) == 0 {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 工单(uuid=%s)没有可以使用的回滚语句。", rc, id)
+			break
+		}
+		content := strings.Join(sqls, "\n")
+		if len(content) > 65535 {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: 工单(uuid=%s)的回滚语句超过65535字节，请手工处理。", rc, id)
+			break
+		}
+
+		// 查询缓存
+		cluster := caches.ClustersMap.Any(func(elem *models.Cluster) bool {
+			if elem.ClusterID == origin.ClusterID {
+				return true
+			}
+			return false
+		})
+		if cluster == nil {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 工单(uuid=%s)的目标群集不存在。", rc, id)
+			break
+		}
+		reviewer := caches.UsersMap.Any(func(elem *models.User) bool {
+			if elem.UserID == origin.ReviewerID {
+				return true
+			}
+			return false
+		})
+		if reviewer == nil {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 工单(uuid=%s)的审核用户不存在。", rc, id)
+			break
+		}
+
+		subject := []rune("回滚: " + origin.Subject)
+		if len(subject) > 50 {
+			subject = subject[:50]
+		}
+		ticket, err = r.CreateTicket(ctx, models.CreateTicketInput{
+			ClusterUUID:  cluster.UUID,
+			Database:     origin.Database,
+			Subject:      string(subject),
+			Content:      content,
+			ReviewerUUID: reviewer.UUID,
+		})
+
+		// 退出for循环
+		break
+	}
+
+	return
+}
+
 // PatchTicketStatus 修改工单状态
 // VLD_FAILURE -> CLOSED
 // VLD_WARNING -> CLOSED
@@ -641,6 +820,139 @@ func (r *mutationRootResolver) PatchTicketStatus(ctx context.Context, input mode
 	return
 }
 
+// ResumeTicket 审核人处理执行失败的工单，RETRY从失败的语句开始重新执行，SKIP记录理由后跳过失败的语句继续执行，
+// ABORT放弃剩余的语句并关闭工单，执行成功和跳过的语句不会再次执行
+func (r *mutationRootResolver) ResumeTicket(ctx context.Context, input models.ResumeTicketInput) (ok bool, err error) {
+	for {
+		rc := gqlapi.ReturnCodeOK
+		found := false
+		credential := ctx.Value(g.CREDENTIAL_KEY).(tools.Credential)
+		user := credential.User
+		ticket := &models.Ticket{}
+		if found, err = g.Engine.Where("`uuid` = ?", input.TicketUUID).Get(ticket); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		if !found {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 工单(uuid=%s)不存在。", rc, input.TicketUUID)
+			break
+		}
+		if ticket.ReviewerID != user.UserID {
+			rc = gqlapi.ReturnCodeForbidden
+			err = fmt.Errorf("错误代码: %s, 错误信息: 只有工单(uuid=%s)的审核人可以处理执行失败的工单。", rc, ticket.UUID)
+			break
+		}
+		if ticket.Status != gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumExecFailure] {
+			rc = gqlapi.ReturnCodeForbidden
+			err = fmt.Errorf("错误代码: %s, 错误信息: 只有执行失败的工单(uuid=%s)才可以继续执行。", rc, ticket.UUID)
+			break
+		}
+
+		reason := ""
+		if input.Reason != nil {
+			reason = strings.TrimSpace(*input.Reason)
+		}
+		if utf8.RuneCountInString(reason) > 150 {
+			rc = gqlapi.ReturnCodeInvalidParams
+			err = fmt.Errorf("错误代码: %s, 错误信息: 理由不能超过150个字符。", rc)
+			break
+		}
+
+		// 执行失败并且没有跳过的第一条语句，后面的语句还没有执行
+		failed := &models.Statement{}
+		if found, err = g.Engine.Where("`ticket_id` = ? AND `status` = ? AND `skip_reason` = ''",
+			ticket.TicketID, gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumExecFailure]).
+			Asc("sequence").
+			Get(failed); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		if !found {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 工单(uuid=%s)没有执行失败的语句。", rc, ticket.UUID)
+			break
+		}
+
+		switch input.Action {
+		case "RETRY":
+			ticket.Status = gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumLgtm]
+		case "SKIP":
+			if reason == "" {
+				rc = gqlapi.ReturnCodeInvalidParams
+				err = fmt.Errorf("错误代码: %s, 错误信息: 跳过语句需要填写理由。", rc)
+				break
+			}
+			failed.SkipReason = reason
+			ticket.Status = gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumLgtm]
+		case "ABORT":
+			ticket.Status = gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumClosed]
+		default:
+			rc = gqlapi.ReturnCodeInvalidParams
+			err = fmt.Errorf("错误代码: %s, 错误信息: 无效的处理方式(action=%s)。", rc, input.Action)
+		}
+		if err != nil {
+			break
+		}
+
+		session := g.Engine.NewSession()
+		defer session.Close()
+		if err = session.Begin(); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		if failed.SkipReason != "" {
+			if _, err = session.ID(core.PK{failed.TicketID, failed.Sequence}).Cols("skip_reason").Update(failed); err != nil {
+				session.Rollback()
+				rc = gqlapi.ReturnCodeUnknowError
+				err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+				break
+			}
+		}
+		if _, err = session.ID(ticket.TicketID).Update(ticket); err != nil {
+			session.Rollback()
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+		if err = session.Commit(); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+
+		cluster := caches.ClustersMap.Any(func(elem *models.Cluster) bool {
+			if elem.ClusterID == ticket.ClusterID {
+				return true
+			}
+			return false
+		})
+		if cluster != nil {
+			events.Fire(events.EventTicketStatusPatched, &events.TicketStatusPatchedArgs{
+				User:    *user,
+				Ticket:  *ticket,
+				Cluster: *cluster,
+			})
+		}
+
+		// 重新执行时工单已经是LGTM，启动失败时审核人可以再次手工执行
+		if ticket.Status == gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumLgtm] {
+			if _, err = r.ExecuteTicket(ctx, ticket.UUID); err != nil {
+				break
+			}
+		}
+
+		// 退出for循环
+		ok = true
+		break
+	}
+
+	return
+}
+
 // ExecuteTicket 执行一个工单，公用ScheduleTicket，所有错误由ScheduleTicket处理
 func (r *mutationRootResolver) ExecuteTicket(ctx context.Context, id string) (ok bool, err error) {
 	input := models.ScheduleTicketInput{
@@ -1036,7 +1348,13 @@ func (r *ticketResolver) Comments(ctx context.Context, obj *models.Ticket, after
 
 // 校验工单详情并处理请求结果
 func validation(stmts []*models.Statement, cluster *models.Cluster, ticket *models.Ticket) {
+	validations <- struct{}{}
+	defer func() { <-validations }()
+
 	validate.Run(stmts, cluster, ticket)
+	if g.Config().PtOsc.DryRun {
+		dryRun(stmts, cluster, ticket)
+	}
 
 	for _, s := range stmts {
 		s.Status = gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumWaitingForMrv]
@@ -1044,10 +1362,13 @@ func validation(stmts []*models.Statement, cluster *models.Cluster, ticket *mode
 		if len(clauses) == 0 {
 			continue
 		}
-		// 如果有问题，至少先是警告
-		s.Status = gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumVldWarning]
-
+		// 被豁免的问题和消息级别的描述仍然写入报告，但不影响审核结果
 		for _, c := range clauses {
+			if c.Waived || c.Level == 0 {
+				continue
+			}
+			// 如果有问题，至少先是警告
+			s.Status = gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumVldWarning]
 			// 如果存在严重的问题，则标记失败
 			if c.Level == 1 {
 				s.Status = gqlapi.TicketStatusEnumMap[gqlapi.TicketStatusEnumVldFailure]
@@ -1095,6 +1416,28 @@ func validation(stmts []*models.Statement, cluster *models.Cluster, ticket *mode
 	}
 }
 
+// dryRun 使用pt-osc执行的语句在审核时先用--dry-run检查，失败时作为错误写入报告
+func dryRun(stmts []*models.Statement, cluster *models.Cluster, ticket *models.Ticket) {
+	passwd, err := tools.DecryptAES(cluster.Password, g.Config().Secret.Crypto)
+	if err != nil {
+		log.Errorf("[E] 群集(uuid=%s)的密码无法解密: %s", cluster.UUID, err.Error())
+		return
+	}
+	selector := executors.NewSelector(cluster, ticket.Database, passwd)
+	for _, s := range stmts {
+		e, ok := selector.Select(s).(executors.DryRunner)
+		if !ok {
+			continue
+		}
+		if err := e.DryRun(s); err != nil {
+			s.Violations.Append(&models.Clause{
+				Description: err.Error(),
+				Level:       1,
+			})
+		}
+	}
+}
+
 // StatementType2Uint8 generates a label for a statement.
 func StatementType2Uint8(node ast.StmtNode) uint8 {
 	switch node.(type) {
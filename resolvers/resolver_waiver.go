@@ -0,0 +1,210 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mia0x75/halo/caches"
+	"github.com/mia0x75/halo/events"
+	"github.com/mia0x75/halo/g"
+	"github.com/mia0x75/halo/gqlapi"
+	"github.com/mia0x75/halo/models"
+	"github.com/mia0x75/halo/tools"
+)
+
+// GrantWaiver 批准规则豁免，指定工单时只豁免该工单，否则豁免群集上的某个表
+func (r *mutationRootResolver) GrantWaiver(ctx context.Context, input models.GrantWaiverInput) (waiver *models.Waiver, err error) {
+	for {
+		rc := gqlapi.ReturnCodeOK
+		rule := caches.RulesMap.Any(func(elem *models.Rule) bool {
+			if elem.UUID == input.RuleUUID {
+				return true
+			}
+			return false
+		})
+		if rule == nil {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 规则(uuid=%s)不存在。", rc, input.RuleUUID)
+			break
+		}
+		if input.ExpireAt <= uint(time.Now().Unix()) {
+			rc = gqlapi.ReturnCodeInvalidParams
+			err = fmt.Errorf("错误代码: %s, 错误信息: 豁免的过期时间必须晚于当前时间。", rc)
+			break
+		}
+
+		waiver = &models.Waiver{
+			RuleName: rule.Name,
+			Reason:   input.Reason,
+			ExpireAt: input.ExpireAt,
+		}
+		if input.TicketUUID != "" {
+			found := false
+			ticket := &models.Ticket{
+				UUID: input.TicketUUID,
+			}
+			if found, err = g.Engine.Get(ticket); err != nil {
+				waiver = nil
+				rc = gqlapi.ReturnCodeUnknowError
+				err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+				break
+			}
+			if !found {
+				waiver = nil
+				rc = gqlapi.ReturnCodeNotFound
+				err = fmt.Errorf("错误代码: %s, 错误信息: 工单(uuid=%s)不存在。", rc, input.TicketUUID)
+				break
+			}
+			waiver.TicketID = ticket.TicketID
+			waiver.ClusterID = ticket.ClusterID
+			waiver.Database = ticket.Database
+		} else {
+			cluster := caches.ClustersMap.Any(func(elem *models.Cluster) bool {
+				if elem.UUID == input.ClusterUUID {
+					return true
+				}
+				return false
+			})
+			if cluster == nil {
+				waiver = nil
+				rc = gqlapi.ReturnCodeNotFound
+				err = fmt.Errorf("错误代码: %s, 错误信息: 群集(uuid=%s)不存在。", rc, input.ClusterUUID)
+				break
+			}
+			if input.Database == "" || input.Table == "" {
+				waiver = nil
+				rc = gqlapi.ReturnCodeInvalidParams
+				err = fmt.Errorf("错误代码: %s, 错误信息: 没有指定工单时，必须指定豁免的数据库和表。", rc)
+				break
+			}
+			waiver.ClusterID = cluster.ClusterID
+			waiver.Database = input.Database
+			waiver.Table = input.Table
+		}
+
+		credential := ctx.Value(g.CREDENTIAL_KEY).(tools.Credential)
+		waiver.UserID = credential.User.UserID
+		if _, err = g.Engine.Insert(waiver); err != nil {
+			waiver = nil
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+
+		// 主动刷新缓存
+		caches.WaiversMap.Init()
+
+		events.Fire(events.EventWaiverGranted, &events.WaiverGrantedArgs{
+			Manager: *credential.User,
+			Waiver:  *waiver,
+		})
+
+		// 退出for循环
+		break
+	}
+
+	return
+}
+
+// RevokeWaiver 撤销规则豁免，已经审核过的工单不受影响
+func (r *mutationRootResolver) RevokeWaiver(ctx context.Context, id string) (ok bool, err error) {
+	for {
+		rc := gqlapi.ReturnCodeOK
+		waiver := caches.WaiversMap.Any(func(elem *models.Waiver) bool {
+			if elem.UUID == id {
+				return true
+			}
+			return false
+		})
+		if waiver == nil {
+			rc = gqlapi.ReturnCodeNotFound
+			err = fmt.Errorf("错误代码: %s, 错误信息: 规则豁免(uuid=%s)不存在。", rc, id)
+			break
+		}
+		if _, err = g.Engine.ID(waiver.WaiverID).Delete(&models.Waiver{}); err != nil {
+			rc = gqlapi.ReturnCodeUnknowError
+			err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+			break
+		}
+
+		// 主动刷新缓存
+		caches.WaiversMap.Init()
+
+		credential := ctx.Value(g.CREDENTIAL_KEY).(tools.Credential)
+		events.Fire(events.EventWaiverRevoked, &events.WaiverRevokedArgs{
+			Manager: *credential.User,
+			Waiver:  *waiver,
+		})
+
+		// 退出for循环
+		ok = true
+		break
+	}
+
+	return
+}
+
+// Waivers 规则豁免在页面上是一个页面处理所有内容，所以不需要考虑分页
+func (r *queryRootResolver) Waivers(ctx context.Context) (L []*models.Waiver, err error) {
+	L = caches.WaiversMap.All()
+	return
+}
+
+type waiverResolver struct{ *Resolver }
+
+// Ticket 豁免的工单，豁免群集上的表时为空
+func (r *waiverResolver) Ticket(ctx context.Context, obj *models.Waiver) (ticket *models.Ticket, err error) {
+	rc := gqlapi.ReturnCodeOK
+	if obj.TicketID == 0 {
+		return
+	}
+	found := false
+	ticket = &models.Ticket{
+		TicketID: obj.TicketID,
+	}
+	if found, err = g.Engine.Get(ticket); err != nil {
+		ticket = nil
+		rc = gqlapi.ReturnCodeUnknowError
+		err = fmt.Errorf("错误代码: %s, 错误信息: %s", rc, err.Error())
+	} else if !found {
+		ticket = nil
+		rc = gqlapi.ReturnCodeNotFound
+		err = fmt.Errorf("错误代码: %s, 错误信息: 规则豁免(uuid=%s)的工单不存在。", rc, obj.UUID)
+	}
+
+	return
+}
+
+// Cluster 豁免的群集
+func (r *waiverResolver) Cluster(ctx context.Context, obj *models.Waiver) (cluster *models.Cluster, err error) {
+	rc := gqlapi.ReturnCodeOK
+	cluster = caches.ClustersMap.Any(func(elem *models.Cluster) bool {
+		if elem.ClusterID == obj.ClusterID {
+			return true
+		}
+		return false
+	})
+	if cluster == nil {
+		rc = gqlapi.ReturnCodeNotFound
+		err = fmt.Errorf("错误代码: %s, 错误信息: 规则豁免(uuid=%s)依赖的群集不存在。", rc, obj.UUID)
+	}
+	return
+}
+
+// Approver 批准豁免的管理员
+func (r *waiverResolver) Approver(ctx context.Context, obj *models.Waiver) (user *models.User, err error) {
+	rc := gqlapi.ReturnCodeOK
+	user = caches.UsersMap.Any(func(elem *models.User) bool {
+		if elem.UserID == obj.UserID {
+			return true
+		}
+		return false
+	})
+
+	if user == nil {
+		rc = gqlapi.ReturnCodeNotFound
+		err = fmt.Errorf("错误代码: %s, 错误信息: 规则豁免(uuid=%s)的批准人不存在。", rc, obj.UUID)
+	}
+	return
+}
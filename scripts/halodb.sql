@@ -343,6 +343,7 @@ INSERT INTO `mm_glossaries` VALUES
 ('rules.group',14,'PROCEDURE 规则分组','4afe41e2-1fd2-4eb4-8538-2e49418b3445','用于检查存储过程相关的审核规则',1,1552357174, UNIX_TIMESTAMP()),
 ('rules.group',15,'其他 规则分组','571a3e92-d641-4ca0-b111-4a30b267361c','用于检查没有分类的其他操作的审核规则',1,1552357174, UNIX_TIMESTAMP()),
 ('rules.group',16,'合并拆分 规则分组','0dd6123b-c517-4302-9aee-c22ced97c7e6','用于检查没有分类的其他操作的审核规则',1,1552357174, UNIX_TIMESTAMP()),
+('rules.group',17,'版本兼容 规则分组','abcf3810-1ae0-4180-8ee6-a6a3fc940f66','用于检查目标群集版本不支持的特性的审核规则',1,1552357174, UNIX_TIMESTAMP()),
 ('rules.severity',0,'消息','664de121-0571-48d4-b839-653dc1821d76','保留',1,1552357174, UNIX_TIMESTAMP()),
 ('rules.severity',1,'错误','42dfa1f6-5e06-423e-91d7-49220b0c733d','错误级别的审核规则，不允许上线执行',1,1552357174, UNIX_TIMESTAMP()),
 ('rules.severity',2,'警告','cd36779c-18fe-407a-ab0d-22c0851592ee','警告级别的审核规则，不建议上线执行',1,1552357174, UNIX_TIMESTAMP()),
@@ -397,6 +398,18 @@ CREATE TABLE `mm_clusters` (
                 NOT NULL
                 DEFAULT 1
                 COMMENT '状态',
+  `server_version` VARCHAR(75)
+                NOT NULL
+                DEFAULT ''
+                COMMENT '服务器版本，VERSION()',
+  `version_comment` VARCHAR(150)
+                NOT NULL
+                DEFAULT ''
+                COMMENT '服务器版本说明，@@version_comment',
+  `executor`    VARCHAR(10)
+                NOT NULL
+                DEFAULT ''
+                COMMENT '结构变更的执行方式(direct|gh-ost|pt-osc)，空表示自动选择',
   `version`     INT UNSIGNED
                 NOT NULL
                 COMMENT '版本',
@@ -686,6 +699,10 @@ CREATE TABLE `mm_rules` (
   `element`     VARCHAR(50)
                 NOT NULL
                 COMMENT '展现组件类型',
+  `path`        VARCHAR(150)
+                NOT NULL
+                DEFAULT ''
+                COMMENT '声明式规则的路径表达式',
   `version`     INT UNSIGNED
                 NOT NULL
                 COMMENT '版本',
@@ -706,249 +723,436 @@ COMMENT = '规则表'
 
 LOCK TABLES `mm_rules` WRITE;
 INSERT INTO `mm_rules` VALUES
-('CDB-L2-001','e4b6f058-f8e3-469d-b754-ac754c9bd07e',10,'新建数据库时允许的字符集',2,100,'in','[\"utf8mb4\",\"binary\"]',7,'AvailableCharsets','建库禁用字符集\"%s\"，请使用\"%s\"。','checkboxes/key=charsets',4,0,UNIX_TIMESTAMP()),
-('CDB-L2-002','5126367e-19bf-4996-96eb-b92d51860acc',10,'新建数据库时允许的排序规则',2,100,'none','[\"utf8mb4_general_ci\", \"utf8mb4_bin\", \"utf8mb4_unicode_ci\"]',7,'AvailableCollates','建库禁用排序规则\"%s\"，请使用\"%s\"。','checkboxes/key=collates',1,0,UNIX_TIMESTAMP()),
-('CDB-L2-003','8e549891-ace6-48ba-bab7-6c333851098f',10,'新建数据库时字符集与排序规则必须匹配',2,100,'none','nil',5,'CharsetCollateMustMatch','建库使用的字符集\"%s\"和排序规则\"%s\"不匹配，请查阅官方文档。','none',1,0,UNIX_TIMESTAMP()),
-('CDB-L2-004','439a8103-7664-48c8-ae3d-227deb057416',10,'库名规则',2,100,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',7,'DatabaseNameQualified','库名\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CDB-L2-005','ce313eeb-aaa3-4ccc-ab52-6f8694bff63e',10,'库名必须小写',2,100,'none','^[_a-z0-9]+$',7,'DatabaseNameLowerCaseRequired','库名\"%s\"中含有大写字母。','none',1,0,UNIX_TIMESTAMP()),
-('CDB-L2-006','5575b7a8-fda5-4c07-8fed-5457839334bb',10,'库名最大长度',2,100,'lte','15',7,'DatabaseNameMaxLength','库名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('CDB-L2-007','d8a0fad2-773a-49d7-b1b9-d1219158222b',10,'新建数据库时目标库必须不存在',1,100,'none','nil',5,'TargetDatabaseDoesNotExist','目标库\"%s\"已存在。','none',1,0,UNIX_TIMESTAMP()),
-('CEV-L2-001','c8cf9058-f788-48d7-9d87-44201fd574a4',21,'创建事件时事件名规则',2,210,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'EventNameQualified','事件名\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CEV-L2-002','bf0dc49f-6c6e-40a8-a716-872ebf3bbd71',21,'创建事件时事件名必须小写',2,210,'regexp','^[_a-z0-9]+$',5,'EventNameLowerCaseRequired','事件名\"%s\"含有大写字母。','none',1,0,UNIX_TIMESTAMP()),
-('CEV-L2-003','8e4484d1-be13-4307-8293-870407428de9',21,'创建事件时事件名最大长度',2,210,'lte','25',7,'EventNameMaxLength','事件名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('CEV-L2-004','1f67c0d5-86ca-4f9e-95f6-ba6075b4eefc',21,'创建事件时事件名前缀规则',2,210,'regexp','^ev_[_a-zA-Z0-9]+$',5,'EventNamePrefixRequired','事件名\"%s\"需要满足前缀正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CEV-L3-001','65fe735f-306c-463e-90d7-30c293d352b7',21,'创建事件时目标库必须已存在',1,210,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('CEV-L3-002','23dd98bc-685a-413f-a63f-61485bc392cc',21,'创建事件时目标事件必须已存在',1,210,'none','nil',4,'TargetEventDoesNotExist','目标事件\"%s\"已存在。','none',1,0,UNIX_TIMESTAMP()),
-('CFU-L2-001','3a2fc192-febc-4ba5-84d4-ed95b963fdfc',19,'新建函数时函数名规则',2,190,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',7,'FuncNameQuilified','函数名\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CFU-L2-002','33004fce-753f-4a77-b552-e0cf7bbe4636',19,'新建函数时函数名必须小写',2,190,'regexp','^[_a-z0-9]+$',7,'FuncNameLowerCaseRequired','函数名\"%s\"含有大写字母。','none',1,0,UNIX_TIMESTAMP()),
-('CFU-L2-003','a924afeb-8def-4443-ab2a-d8d429e5dd49',19,'新建函数时函数名最大长度',2,190,'lte','25',7,'FuncNameMaxLength','函数名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('CFU-L2-004','0a74e7bd-c21d-421e-af12-874a60bc61f2',19,'新建函数时函数名前缀规则',2,190,'regexp','^fn_[_a-zA-Z0-9]+$',7,'FuncNamePrefixRequired','函数名\"%s\"需要满足前缀正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CFU-L3-001','5069d187-8cb1-41b9-8876-14e017daf992',19,'新建函数时目标库必须已存在',1,190,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('CFU-L3-002','b134e0b3-a6e5-4404-b845-c3f86712fcb9',19,'新建函数时目标函数必须不存在',1,190,'none','nil',4,'TargetFuncDoesNotExist','目标函数\"%s\"已存在。','none',1,0,UNIX_TIMESTAMP()),
-('CIX-L2-001','2221dc67-94c6-4a3d-a3b5-638a3d02b70a',15,'组合索引允许的最大列数',2,150,'lte','3',7,'MaxAllowedIndexColumnCount','索引\"%s\"中索引列数量超过允许的阈值%d。','number',1,0,UNIX_TIMESTAMP()),
-('CIX-L2-002','0076aae4-6196-4f0d-bfb3-37df11fd45ed',15,'索引名规则',2,150,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'IndexNameQualified','索引名\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CIX-L2-003','a316aa97-534b-4c9d-a881-278b9b7bdbdf',15,'索引名必须小写',2,150,'regexp','^[_a-z0-9]+$',5,'IndexNameLowerCaseRequired','索引名\"%s\"含有大写字母。','none',1,0,UNIX_TIMESTAMP()),
-('CIX-L2-004','71db9655-eeb1-4be3-8c0a-67cef0709b38',15,'索引名最大长度',2,150,'lte','10',5,'IndexNameMaxLength','索引名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('CIX-L2-005','6d676a9c-90a5-4c9c-81e6-00ec8e5ab350',15,'索引名前缀规则',2,150,'regexp','^index_[1-9][0-9]*$',5,'IndexNamePrefixRequired','索引名\"%s\"需要满足前缀正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CIX-L2-006','47ee8f95-9d48-4a4c-a917-c4679d5fe4f0',15,'组合索引中是否有重复列',2,150,'none','nil',5,'IndexColumnDuplicate','索引\"%s\"中索引了重复的列。','none',1,0,UNIX_TIMESTAMP()),
-('CIX-L3-001','034b0bcd-e246-4ade-9ab0-35522b5382f9',15,'目标库必须已存在',1,150,'none','nil',5,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('CIX-L3-002','2d222b20-a4a5-44c4-b7aa-4cda28a46369',15,'目标表必须已存在',1,150,'none','nil',5,'TargetTableDoesNotExist','目标表\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('CIX-L3-003','22b1f933-bce4-4d20-9b6f-7a0282c453c1',15,'索引列必须已存在',1,150,'none','nil',5,'TargetColumnDoesNotExist','目标列\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('CIX-L3-004','2e301212-02af-4b84-955f-3178812ded5a',15,'索引内容是否重复',1,150,'none','nil',4,'IndexOverlayNotAllowed','索引\"%s\"在已有索引\"%s\"相同或者存在覆盖关系。','none',1,0,UNIX_TIMESTAMP()),
-('CIX-L3-005','57f92fe9-3540-4541-a53c-0487db7c0702',15,'索引名是否重复',1,150,'none','nil',5,'IndexNameDuplicate','索引名\"%s\"在表\"%s\"已经存在，请使用另外一个索引名称。','none',1,0,UNIX_TIMESTAMP()),
-('CIX-L3-006','6f69fd99-4d89-4c6f-b8ce-1b9dd437e687',15,'最多能建多少个索引',1,150,'lte','5',6,'MaxAllowedIndexCount','索引数量超过允许的阈值%d。','number',1,0,UNIX_TIMESTAMP()),
-('CIX-L3-007','9525f4ff-efa2-4b09-a5af-ab3cc3deb24c',15,'禁止在BLOB/TEXT列上建索引',2,150,'none','nil',7,'IndexOnBlobColumnNotAllowed','禁止在BLOB/TEXT类型的列\"%s\"上建立索引。','none',1,0,UNIX_TIMESTAMP()),
-('CSP-L2-001','157bdbad-8862-4497-91aa-83827badae02',22,'新建存储过程时存储过程名规则',2,220,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'ProcNameQualified','存储过程名\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CSP-L2-002','6e82df00-56bf-44e3-adec-fb08bf4d5f3e',22,'新建存储过程时存储过程名必须小写',2,220,'regexp','^[_a-z0-9]+$',5,'ProcNameLowerCaseRequired','存储过程名\"%s\"含有大写字母。','none',1,0,UNIX_TIMESTAMP()),
-('CSP-L2-003','a4055ee1-caf6-4a72-828e-abea01123af2',22,'新建存储过程时存储过程名最大长度',2,220,'lte','25',5,'ProcNameMaxLength','存储过程名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('CSP-L2-004','150f0a36-d1e9-42b1-a30e-4b5e4b2edd71',22,'新建存储过程时存储过程名前缀规则',2,220,'regexp','^sp_[_a-zA-Z0-9]+$',5,'ProcNamePrefixRequired','存储过程名\"%s\"需要满足前缀正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CSP-L3-001','b467ce86-779c-41e6-9079-50b2c9ff3676',22,'新建存储过程时目标库必须已存在',1,220,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('CSP-L3-002','60f6be7b-0115-4e9a-bf30-79be2a7c2c00',22,'新建存储过程时目标存储过程必须不存在',1,220,'none','nil',4,'TargetProcDoesNotExist','目标存储过程\"%s\"已存在。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-001','2e7cbe13-90d3-472a-9da2-4594c8424df0',11,'允许的字符集',2,110,'in','[\"utf8mb4\"]',7,'AvailableCharsets','建表禁用字符集\"%s\"，请使用\"%s\"。','checkboxes/key=charsets',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-002','5028dfe3-949d-4220-b9ae-a29d8a16de52',11,'允许的排序规则',2,110,'in','[\"utf8mb4_unicode_ci\", \"utf8mb4_general_ci\", \"utf8mb4_bin\"]',5,'AvailableCollates','建表禁用排序规则\"%s\"，请使用\"%s\"。','checkboxes/key=collates',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-003','81b9dec9-3769-4dff-a29a-8739c3ac4ec9',11,'字符集与排序规则必须匹配',2,110,'none','nil',5,'TableCharsetCollateMustMatch','建表使用的字符集\"%s\"和排序规则\"%s\"不匹配，请查阅官方文档。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-004','a6dd1817-a41f-4688-924f-df959ffbb2db',11,'允许的存储引擎',2,110,'in','[\"innodb\", \"tokudb\", \"rocksdb\", \"archive\"]',7,'AvailableEngines','建表禁用存储引擎\"%s\"，请使用\"%s\"。','checkboxes/key=engines',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-005','0862861b-4328-49eb-86dc-07b15e0a2a6c',11,'表名规则',2,110,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'TableNameQualified','表名\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-006','f77613a7-6f1c-48ff-8946-e725d5431e72',11,'表名必须小写',2,110,'regexp','^[_a-z0-9]+$',5,'TableNameLowerCaseRequired','表名\"%s\"含有大写字母。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-007','19630e1b-fee5-4afc-8fd1-28f43efb74c6',11,'表名最大长度',2,110,'lte','20',7,'TableNameMaxLength','表名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-008','335bd848-2027-4c66-b5b0-09f225f02bb9',11,'表必须有注释',2,110,'none','nil',7,'TableCommentRequired','需要为表\"%s\"需要提供COMMENT注解。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-009','6f945479-4788-4bdb-b1e2-e21dc3b335d6',11,'禁止使用CREATE TABLE ... SELECT ...建表',2,110,'none','nil',5,'CreateTableFromSelectNotAllowed','禁止使用CREATE TABLE AS SELECT的方式建表。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-010','0b0e3799-7f6e-4a46-a054-7437b5485d06',11,'列名规则',2,110,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'ColumnNameQualified','列名\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-011','d99679c9-ffbf-48e6-9dc8-bb1a82d10ff6',11,'列名必须小写',2,110,'regexp','^[_a-z0-9]+$',7,'ColumnNameLowerCaseRequired','列名\"%s\"中含有大写字母。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-012','86530dce-57e4-41b6-a296-0ccbec5036a3',11,'列名最大长度',2,110,'lte','20',7,'ColumnNameMaxLength','列名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-013','97842c81-7b38-406b-a9a3-88244ea79271',11,'列名是否重复',2,110,'none','nil',5,'ColumnNameDuplicate','表\"%s\"中的定义了重复的列\"%s\"。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-014','2c98b6b7-0e0e-438e-8ccd-fa73fbcdbbea',11,'表允许的最大列数',2,110,'lte','25',7,'MaxAllowedColumnCount','表\"%s\"中定义%d个列，数量超出了规则允许的上限%d，请考虑拆分表。','number',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-015','31ab4d7a-4243-4951-bf7e-ddbdbc03254f',11,'列禁用的数据类型',2,110,'not-in','[\"bit\", \"enum\", \"set\", \"double\", \"real\", \"float\"]',7,'ColumnTypesDoesNotExpect','列\"%s\"使用了不期望的数据类型\"%s\"，请避免使用\"%s\"数据类型。','checkboxes/key=data-types',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-016','b14a529b-bbfc-4d9a-8c1a-067f99449e26',11,'列必须有注释',2,110,'none','nil',5,'ColumnCommentRequired','列\"%s\"需要提供COMMENT注解。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-017','95aa9816-ed7c-41a7-8f4f-6929757a92d8',11,'列允许的字符集',2,110,'in','[\"utf8mb4\", \"binary\"]',7,'ColumnAvailableCharsets','列\"%s\"禁用字符集\"%s\"，请使用\"%s\"。','checkboxes/key=charsets',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-018','858cabba-39f1-4c47-af8c-85c891605400',11,'列允许的排序规则',2,110,'in','[\"utf8mb4_unicode_ci\", \"utf8mb4_general_ci\", \"utf8mb4_bin\", \"binary\"]',7,'ColumnAvailableCollates','列\"%s\"禁用排序规则\"%s\"，请使用\"%s\"。','checkboxes/key=collates',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-019','55e72c3d-f0d2-45e9-bd3e-0c172ae6ad69',11,'列字符集与排序规则必须匹配',2,110,'none','nil',5,'ColumnCharsetCollateMustMatch','列\"%s\"使用的字符集\"%s\"和排序规则\"%s\"不匹配，请查阅官方文档。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-020','dba74873-c113-482a-8552-8d81dd640bd4',11,'非空列必须有默认值',2,110,'none','nil',5,'ColumnNotNullWithDefaultRequired','列\"%s\"不允许为空，但没有指定默认值。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-021','bc94e7f3-9e3e-48c7-b21b-85593b2c2af7',11,'自增列允许的数据类型',2,110,'in','[\"int\", \"bigint\"]',7,'ColumnAutoIncAvailableTypes','自增列\"%s\"禁用\"%s\"类型，请使用\"%s\"。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-022','d6894864-4d41-4930-b3fd-62da07588239',11,'自增列必须是无符号',2,110,'none','nil',7,'ColumnAutoIncUnsignedRequired','自增列\"%s\"必须使用无符号的整数。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-023','526ac72c-55d4-484b-8bb2-88eda1e67d6c',11,'自增列必须是主键',2,110,'none','nil',5,'ColumnAutoIncMustPrimaryKey','自增列\"%s\"不是主键。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-024','84fcb061-af77-4a7f-8a0e-dfc166b2c531',11,'仅允许一个时间戳类型的列',2,110,'none','nil',7,'MaxAllowedTimestampCount','表\"%s\"中的定义了多个时间戳列，请改用DATETIME类型。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-025','0df017c0-d106-4df5-845c-bf50557d497f',11,'单一索引最大列数',2,110,'lte','3',7,'MaxAllowedIndexColumnCount','索引\"%s\"索引的列数超出了规则允许的上限，请控制在%d个列以内。','number',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-026','8d319516-890e-45f2-af90-8061b37e9a04',11,'必须有主键',2,110,'none','nil',5,'PrimaryKeyRequired','必须为表指定一个主键。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-027','31e193df-bf11-4462-9558-c182eb8366e5',11,'主键是否显式命名',2,110,'none','nil',4,'PrimaryKeyNameExplicit','主键没有提供名称。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-028','bedd8dfa-b325-451b-981a-a1cede6bc787',11,'主键名规则',2,110,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',4,'PrimaryKeyNameQualified','主键名\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-029','dee29598-1f59-4d5f-9881-ad16fa4038fd',11,'主键名必须小写',2,110,'regexp','^[_a-z0-9]+$',4,'PrimryKeyLowerCaseRequired','主键名\"%s\"含有大写字母。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-030','c47768d2-24b3-4e9c-9079-8ef39bbd0a53',11,'主键名最大长度',2,110,'lte','20',4,'PrimryKeyMaxLength','主键名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-031','bc1164e0-45ee-4d58-b4c5-cea244ebeb3f',11,'主键名前缀规则',2,110,'regexp','^pk_[_a-zA-Z0-9]+$',4,'PrimryKeyPrefixRequired','主键名\"%s\"需要满足前缀正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-032','7f7182f3-3da8-43c0-a43f-dbe44ccf0130',11,'索引必须命名',2,110,'none','nil',5,'IndexNameExplicit','一个或多个索引没有提供索引名称。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-033','364f58af-29a8-4a49-a3ad-a35ea500352c',11,'索引名规则',2,110,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'IndexNameQualified','索引名\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-034','1dccb327-2b34-4f3d-a900-9560a7b9cd3b',11,'索引名必须小写',2,110,'regexp','^[_a-z0-9]+$',5,'IndexNameLowerCaseRequired','索引名\"%s\"含有大写字母。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-035','0e51b2a9-b251-4a25-be86-7895285ad77a',11,'索引名最大长度',2,110,'lte','10',7,'IndexNameMaxLength','索引名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-036','abac99f7-ad0a-4d24-bdf4-00439e4abad6',11,'索引名前缀规则',2,110,'regexp','^index_[1-9][0-9]*$',5,'IndexNamePrefixRequired','索引名\"%s\"需要满足前缀正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-037','de701fa6-6ed4-4608-a95c-53087c43e177',11,'唯一索引必须命名',2,110,'none','nil',5,'UniqueNameExplicit','一个或多个唯一索引没有提供索引名称。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-038','26d84e04-25e6-4865-b76b-00995ef56f81',11,'唯一索引索名规则',2,110,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'UniqueNameQualified','唯一索引\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-039','3b07760f-c055-4d3c-b27a-d23077a0c768',11,'唯一索引名必须小写',2,110,'regexp','^[_a-z0-9]+$',5,'UniqueNameLowerCaseRequired','唯一索引\"%s\"含有大写字母。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-040','f9a615b4-cbaa-40a7-b6c2-28a0fc78a35e',11,'唯一索引名最大长度',2,110,'lte','10',7,'UniqueNameMaxLength','唯一索引\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-041','8aab8c07-59d3-43d5-a74f-0005697955ee',11,'唯一索引名前缀规则',2,110,'regexp','^unique_[1-9][0-9]*$',5,'UniqueNamePrefixRequired','唯一索引\"%s\"需要满足前缀正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-042','30a7e9c3-0502-4793-9b88-fca8223efa90',11,'禁止外键',2,110,'none','nil',5,'ForeignKeyNotAllowed','禁止外键。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-043','8c599732-e3d0-432e-9396-4b4ed6b05479',11,'外键是否显式命名',2,110,'none','nil',5,'ForeignKeyNameExplicit','没有为外键指定名称。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-044','77708821-c945-4a6d-aa0d-84329b1b01fe',11,'外键名规则',2,110,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'ForeignKeyNameQualified','外键名\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-045','867fa702-b10f-482d-b1a2-a71d5be32f21',11,'外键名必须小写',2,110,'regexp','^[_a-z0-9]+$',5,'ForeignKeyNameLowerCaseRequired','外键名\"%s\"含有大写字母。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-046','3c3052b4-fc6d-4976-98d1-e2a49ecd3927',11,'外键名最大长度',2,110,'lte','25',5,'ForeignKeyNameMaxLength','外键名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-047','35207240-5094-4696-b209-1260865fde8a',11,'外键名前缀规则',2,110,'regexp','^fk_[_a-zA-Z0-9]+$',5,'ForeignKeyNamePrefixRequired','外键名\"%s\"需要满足前缀正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-048','e5c85872-1cff-48c0-b705-0c61b04070b9',11,'表中最多可建多少个索引',2,110,'lte','5',7,'MaxAllowedIndexCount','表\"%s\"中定义了%d个索引，数量超过允许的阈值%d。','number',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-049','7eedb2fb-5e26-41a7-baa7-8c37b8ef3170',11,'禁止使用CREATE TABLE ... LIKE ...建表',2,110,'none','nil',5,'CreateTableUseLikeNotAllowed','禁止使用CREATE TABLE LIKE的方式建表。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-050','5ace374b-0f35-4132-8941-44283bbc87c1',11,'仅允许定义一个自增列',2,110,'nil','nil',5,'AutoIncColumnDuplicate','表\"%s\"中定义了多个自增列。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L2-051','b9dce6ba-5612-4bc4-afdb-730d7b15930a',11,'仅允许定义一个主键',2,110,'nil','nil',5,'PrimaryKeyDuplicate','表\"%s\"中定义了多个主键。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L3-001','887cf290-2288-451a-be70-57394e23dde4',11,'目标库必须已存在',1,110,'none','nil',5,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('CTB-L3-002','1e75c5a2-7bcc-42c4-ac3f-5a64ead5b25c',11,'目标表必须不存在',1,110,'none','nil',5,'TargetTableDoesNotExist','目标表\"%s\"已存在。','none',1,0,UNIX_TIMESTAMP()),
-('CTG-L2-001','56661395-36fc-4cdc-9346-8cb4608f6f44',20,'新建触发器时触发器名规则',2,200,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'TriggerNameQualified','触发器名\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CTG-L2-002','0d99687d-810f-4f08-aa28-9738ea29eadc',20,'新建触发器时触发器名必须小写',2,200,'regexp','^[_a-z0-9]+$',7,'TriggerNameLowerCaseRequired','触发器名\"%s\"含有大写字母。','none',1,0,UNIX_TIMESTAMP()),
-('CTG-L2-003','73120f74-fdf3-4230-8f31-3f27e7571005',20,'新建触发器时触发器名最大长度',2,200,'lte','25',7,'TriggerNameMaxLength','触发器名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('CTG-L2-004','447e8fe4-4583-4986-ab11-d62ece063b7b',20,'新建触发器时触发器名前缀规则',2,200,'regexp','^tg_[_a-zA-Z0-9]+$',5,'TriggerPrefixRequired','触发器名\"%s\"需要满足前缀正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CTG-L3-001','27849545-075f-4fb6-8d47-f947c9894dac',20,'新建触发器时目标库必须已存在',2,200,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('CTG-L3-002','35a926d4-f842-4c91-a109-a22327114c52',20,'新建触发器时目标表必须已存在',2,200,'none','nil',4,'TargetTableDoesNotExist','目标表\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('CTG-L3-003','f2fabd72-4453-45ac-badf-105f46b6f00a',20,'新建触发器时目标触发器必须不存在',2,200,'none','nil',4,'TargetTriggerDoesNotExist','目标触发器\"%s\"已存在。','none',1,0,UNIX_TIMESTAMP()),
-('CVW-L2-001','217771f6-c3de-4956-8656-2fd8e482f5b7',18,'新建视图时视图名规则',2,180,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'ViewNameQualified','视图名\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CVW-L2-002','8d9a1c43-325a-47ac-9891-c1155697da3a',18,'新建视图时视图名必须小写',2,180,'regexp','^[_a-z0-9]+$',5,'ViewNameLowerCaseRequired','视图名\"%s\"含有大写字母。','none',1,0,UNIX_TIMESTAMP()),
-('CVW-L2-003','19f69c74-7f29-4eba-8131-dbc5992ae3c4',18,'新建视图时视图名最大长度',2,180,'lte','25',7,'ViewNameMaxLength','视图名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('CVW-L2-004','a776c690-8576-4664-ab29-5db0885960b3',18,'新建视图时视图名前缀规则',2,180,'regexp','^vw_[_a-zA-Z0-9]+$',7,'ViewNamePrefixRequired','视图名\"%s\"需要满足前缀正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('CVW-L3-001','dff7c8a6-f9db-4d86-9c7f-df8b1614a307',18,'新建视图时目标库必须已存在',1,180,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('CVW-L3-002','5fba8576-d5fb-4135-9dc2-d01578ebfb9c',18,'新建视图时目标视图必须不存在',1,180,'none','nil',4,'TargetViewDoesNotExist','目标视图\"%s\"已存在。','none',1,0,UNIX_TIMESTAMP()),
-('DDB-L2-001','1abf3c57-d84d-4295-9cbe-4b95f9e3c9bc',10,'删除数据库时目标库必须已存在',1,102,'none','nil',5,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('DEL-L2-001','ed326131-edce-456a-a19e-1fdece47060e',16,'禁止没有WHERE的删除',2,163,'none','nil',5,'WithoutWhereNotAllowed','禁止没有WHERE从句的DELETE语句。','none',1,0,UNIX_TIMESTAMP()),
-('DEL-L3-001','de3855f6-b835-49eb-89ff-bf854c515a02',16,'单次删除的最大行数',1,163,'lte','1000',5,'MaxAllowedDeleteRows','单条DELETE语句不得操作超过%d条记录。','number',1,0,UNIX_TIMESTAMP()),
-('DEL-L3-002','7ebccfe3-6ad7-4c83-b7a4-9cbfa12e35db',16,'目标库必须已存在',1,163,'none','nil',5,'TargetDatabaseDoesNotExist','DELETE语句中指定的库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('DEL-L3-003','0b5dbb5e-8223-42a0-bb76-a31ed16bf537',16,'目标表必须已存在',1,163,'none','nil',5,'TargetTableDoesNotDoesNotExist','DELETE语句中指定的表\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('DEL-L3-004','36b9693f-26e1-47b0-8ebb-e26f6ae9d5fa',16,'条件过滤列必须已存在',1,163,'none','nil',4,'TargetColumnDoesNotDoesNotExist','DELETE语句中条件限定列\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('DEV-L3-001','3f2405f2-4fde-40b3-825f-16cb29dea9b9',21,'删除事件时目标库必须已存在',1,212,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('DEV-L3-002','7d46d4bf-6085-401f-9939-63a5120017ad',21,'删除事件时目标事件必须已存在',1,212,'none','nil',4,'TargetEventDoesNotExist','目标事件\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('DFU-L3-001','dfaf91bb-7353-4af6-b88c-3a6f04ad6ab8',19,'删除函数时目标库必须已存在',1,192,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('DFU-L3-002','e8f789bd-cf1f-4b3e-aa2e-3359979f5485',19,'删除函数时目标函数必须已存在',1,192,'none','nil',4,'TargetFuncDoesNotExist','目标函数\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('DSP-L3-001','bafb9ff1-cafb-4178-98c9-823ecd8f6600',22,'删除存储过程时目标库必须已存在',1,222,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('DSP-L3-002','4d627159-45a5-47d9-8942-153b49ac795f',22,'删除存储过程时目标存储过程必须已存在',1,222,'none','nil',4,'TargetProcDoesNotExist','目标存储过程\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('DTB-L3-001','da2d4fd4-af73-4cbf-9b83-ca32d5594396',14,'目标库必须已存在',1,140,'none','nil',5,'TableDropTargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('DTB-L3-002','a2d5b7ec-0fda-42f9-9fea-71687a3066f7',14,'目标表必须已存在',1,140,'none','nil',5,'TableDropTargetTableDoesNotExist','目标表\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('DTG-L3-001','f9e45bbe-528d-4ab3-b6dd-4f79749fc12b',20,'删除触发器时目标库必须已存在',1,202,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('DTG-L3-002','d55a27c3-0501-4e73-8d6f-c850bbab411e',20,'删除触发器时目标表必须已存在',1,202,'none','nil',4,'TargetTableDoesNotExist','目标表\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('DTG-L3-003','6607f3d3-74c3-4894-b590-2cb073e983d3',20,'删除触发器时目标触发器必须已存在',1,202,'none','nil',4,'TargetTriggerDoesNotExist','目标触发器\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('DVW-L3-001','4b1e7450-4662-4d47-948f-7e6d61f9d1f3',18,'删除视图时目标库必须已存在',1,182,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('DVW-L3-002','fe4af9de-5292-45a5-82e3-16348b1a9da1',18,'删除视图时目标视图必须已存在',1,182,'none','nil',4,'TargetViewDoesNotExist','目标视图\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('INS-L2-001','4a5c2e53-1b50-43ce-954b-ce2d60eeeed7',16,'INSERT时强制显式列申明',2,160,'none','nil',5,'ExplicitColumnRequired','禁止没有显式提供列列表的INSERT语句。','none',1,0,UNIX_TIMESTAMP()),
-('INS-L2-002','6454269e-fff7-4f75-857f-f06bbf1f3a73',16,'禁止INSERT...SELECT',2,160,'none','nil',7,'UsingSelectNotAllowed','禁止INSERT ... SELECT ...语句。','none',1,0,UNIX_TIMESTAMP()),
-('INS-L2-005','ab221728-8c92-4019-92e4-4cf0fc57941b',16,'INSERT时列类型、值是否匹配',2,160,'none','nil',5,'ColumnsValuesMustMatch','INSERT语句的列数量和值数量不匹配。','none',1,0,UNIX_TIMESTAMP()),
-('INS-L3-001','d37860b1-d9d6-4c4f-9af6-7e93dd33c312',16,'INSERT时目标库必须已存在',1,160,'none','nil',5,'TargetDatabaseDoesNotExist','INSERT语句中指定的库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('INS-L3-002','75d3d38e-b32b-438c-b01c-761fff3b6786',16,'INSERT时目标表必须已存在',1,160,'none','nil',5,'TargetTableDoesNotExist','INSERT语句中指定的表\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('INS-L3-003','355540e6-733a-4b83-88e7-e04e838fa8c2',16,'INSERT时目标列必须已存在',1,160,'none','nil',4,'TargetColumnDoesNotExist','INSERT语句中插入的列\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('INS-L3-004','9f6cae35-89b7-46ae-ae5c-a7962f473e44',16,'INSERT时非空列是否有值',1,160,'none','nil',4,'ValueForNotNullColumnRequired','INSERT语句没有为非空列\"%s\"提供值。','none',1,0,UNIX_TIMESTAMP()),
-('MDB-L2-001','78ab3a56-28dd-463d-b8dd-bcb1929d2314',10,'修改数据库时允许的字符集',2,101,'in','[\"utf8mb4\"]',7,'AvailableCharsets','改库禁用字符集\"%s\"，请使用\"%s\"。','checkboxes/key=charsets',1,0,UNIX_TIMESTAMP()),
-('MDB-L2-002','a28e9a5d-2d26-433b-b4c6-fb68faa05daa',10,'修改数据库时允许的排序规则',2,101,'in','[\"utf8mb4_unicode_ci\", \"utf8mb4_general_ci\", \"utf8mb4_bin\"]',7,'AvailableCollates','改库禁用排序规则\"%s\"，请使用\"%s\"。','checkboxes/key=collates',1,0,UNIX_TIMESTAMP()),
-('MDB-L2-003','3c10b2cc-ebfb-4bf6-8543-44a91506d86b',10,'修改数据库时字符集与排序规则必须匹配',2,101,'none','nil',5,'CharsetCollateMustMatch','改库使用的字符集\"%s\"和排序规则\"%s\"不匹配，请查阅官方文档。','none',1,0,UNIX_TIMESTAMP()),
-('MDB-L2-004','c370fd11-b92a-4619-a64a-2deae0873224',10,'修改数据库时目标库必须已存在',1,101,'none','nil',5,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('MEV-L3-001','d29700b0-1588-4034-aba9-1c2dbc92be05',21,'修改事件时目标库必须已存在',1,211,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('MEV-L3-002','65003024-7556-4812-b2f6-8aceb77aa052',21,'修改事件时目标事件必须已存在',1,211,'none','nil',4,'TargetEventDoesNotExist','目标事件\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('MFU-L3-001','d3ed9d76-9825-44f3-af9c-b61b9f704e43',19,'修改函数时目标库必须已存在',1,191,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('MFU-L3-002','ddec411d-9b2c-427b-965e-86e65d7edfce',19,'修改函数时目标函数必须已存在',1,191,'none','nil',4,'TargetFuncDoesNotExist','目标函数\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('MSC-L1-001','ddcee0ba-b76c-4037-8ea8-49616c381f8f',23,'禁止LOCK TABLE',1,230,'none','nil',5,'LockTableProhibited','禁止LOCK TABLE。','none',1,0,UNIX_TIMESTAMP()),
-('MSC-L1-002','f64e59c8-327e-4d77-9b60-be99d6978421',23,'禁止FLUSH TABLE',1,230,'none','nil',5,'FlushTableProhibited','禁止FLUSH操作。','none',1,0,UNIX_TIMESTAMP()),
-('MSC-L1-003','21194aaf-b646-4817-a30d-39e6d1c655b5',23,'禁止TRUNCATE TABLE',1,230,'none','nil',5,'TruncateTableProhibited','禁止TRUNCATE TABLE。','none',1,0,UNIX_TIMESTAMP()),
-('MSC-L1-004','f377fbd7-4256-4807-af22-8f8b69d5f6a5',23,'对同一个表/库的操作需要合并',1,230,'none','nil',5,'MergeRequired','对同一个对象\"%s\"的多个操作需要合并。','none',1,0,UNIX_TIMESTAMP()),
-('MSC-L1-005','364f7fb1-e5b8-4308-8166-7849b9e6a08a',23,'禁止PURGE LOG',1,230,'none','nil',5,'PurgeLogsProhibited','禁止PURGE LOGS。','none',1,0,UNIX_TIMESTAMP()),
-('MSC-L1-006','e2e2492e-f0fa-4593-a72b-2a9187bd2005',23,'禁止UNLOCK TABLE',1,230,'none','nil',5,'UnlockTableProhibited','禁止UNLOCK TABLES。','none',1,0,UNIX_TIMESTAMP()),
-('MSC-L1-007','6d4bce85-5608-4b1a-a810-36a5db17435d',23,'禁止KILL',1,230,'none','nil',5,'KillProhibited','禁止KILL。','none',1,0,UNIX_TIMESTAMP()),
-('MSC-L1-008','404354d1-9592-45d9-9f06-81740c5ad3c3',23,'禁止同时出现DDL、DML',1,230,'none','nil',5,'SplitRequired','禁止在一个工单中同时出现DML和DDL操作，请分开多个工单提交。','none',1,0,UNIX_TIMESTAMP()),
-('MSP-L3-001','841f30ac-bd86-4088-a958-c0bf2e7d26fd',22,'修改存储过程时目标库必须已存在',1,221,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('MSP-L3-002','a46eb708-2fdb-495a-8303-ebe72781c875',22,'修改存储过程时目标存储过程必须已存在',1,221,'none','nil',4,'TargetProcDoesNotExist','目标存储过程\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-001','b17d640a-16fd-4628-a845-d62cdb582884',12,'改表允许的字符集',2,120,'in','[\"utf8mb4\"]',7,'AvailableCharsets','表禁用字符集\"%s\"，请使用\"%s\"。','checkboxes/key=charsets',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-002','11460f45-3517-42d6-91ec-5b36d041cf4e',12,'改表允许的校验规则',2,120,'in','[\"utf8mb4_unicode_ci\", \"utf8mb4_general_ci\", \"utf8mb4_bin\"]',7,'AvailableCollates','表禁用排序规则\"%s\"，请使用\"%s\"。','checkboxes/key=collates',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-003','b4ae4473-9677-4db5-b2d4-7987fe904e88',12,'表的字符集与排序规则必须匹配',2,120,'none','nil',5,'TableCharsetCollateMustMatch','表字符集\"%s\"和排序规则\"%s\"不匹配，请查阅官方文档。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-004','aee962c0-411e-4875-9291-d708473a1286',12,'改表允许的存储引擎',2,120,'in','[\"innodb\", \"tokudb\", \"rocksdb\", \"archive\"]',7,'AvailableEngines','不支持的存储引擎\"%s\"，请使用\"%s\"。','checkboxes/key=engines',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-005','07ca1452-fa25-4e33-b2dd-6623215a018e',12,'列名必须符合命名规范',2,120,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'ColumnNameQualified','列名\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-006','8463928d-8d32-47fd-a882-efa93983ea3d',12,'列名必须小写',2,120,'regexp','^[_a-z0-9]+$',7,'ColumnNameLowerCaseRequired','列名\"%s\"中含有除小写字母、数字和下划线以外的字符。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-007','d4a3a9c9-c16f-485a-a741-2c60ffda1b10',12,'列名最大长度',2,120,'lte','20',7,'ColumnNameMaxLength','列名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-008','8ceb2794-a706-406d-859d-4d7fe67ed970',12,'列禁用的数据类型',2,120,'not-in','[\"bit\", \"enum\", \"set\", \"double\", \"real\", \"float\"]',7,'ColumnUnwantedTypes','列\"%s\"使用了不期望的数据类型\"%s\"，请避免使用\"%s\"数据类型。','checkboxes/key=data-types',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-009','f5b265d1-30c2-4c98-ab43-8876177c6ccb',12,'列必须有注释',2,120,'none','nil',5,'ColumnCommentRequired','列\"%s\"需要提供COMMENT注解。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-010','77130452-bf96-46f6-bb3e-8ce779d82dfc',12,'列允许的字符集',2,120,'in','[\"utf8mb4\", \"binary\"]',7,'ColumnAvailableCharsets','列\"%s\"禁用字符集\"%s\"，请使用\"%s\"。','checkboxes/key=charsets',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-011','d6401716-b714-42a9-b06c-10f515f69cde',12,'列允许的排序规则',2,120,'in','[\"utf8mb4_unicode_ci\", \"utf8mb4_general_ci\", \"utf8mb4_bin\", \"binary\"]',7,'ColumnAvailableCollates','列\"%s\"禁用排序规则\"%s\"，请使用\"%s\"。','checkboxes/key=collates',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-012','9c7f24ec-2d91-435f-b4ee-c31936670e6c',12,'列的字符集与排序规则必须匹配',2,120,'none','nil',5,'ColumnCharsetCollateMustMatch','列\"%s\"使用的字符集\"%s\"和排序规则\"%s\"不匹配，请查阅官方文档。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-013','76b8f1fd-7ebb-41fc-9b57-763e2590121d',12,'非空列必须有默认值',2,120,'none','nil',5,'ColumnNotNullWithDefaultRequired','列\"%s\"不允许为空，但没有指定默认值。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-014','90c0dece-d69c-4c56-a1a4-7824fe00fe22',12,'索引必须命名',2,120,'none','nil',7,'IndexNameExplicit','一个或多个索引没有提供索引名称。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-015','a28f4d53-4c81-4b73-a8a9-2bb441bbfb4b',12,'索引名标识符必须满足规则',2,120,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',7,'IndexNameQualified','索引名\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-016','b462710c-af50-4c96-85de-e3e6ddb20f47',12,'索引名必须小写',2,120,'regexp','^[_a-z0-9]+$',7,'IndexNameLowerCaseRequired','索引名\"%s\"含有除小写字母、数字和下划线以外的字符。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-017','543b5732-8a6d-4690-a02b-f3c8055435c5',12,'索引名最大长度',2,120,'lte','10',7,'IndexNameMaxLength','索引名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-018','9f752e8b-df62-46b0-bae4-e2c6941f3d88',12,'索引名前缀规则',2,120,'regexp','^index_[1-9][0-9]*$',7,'IndexNamePrefixRequired','索引名\"%s\"需要满足前缀正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-019','1885389b-4a1b-475b-a37b-582670a908f8',12,'唯一索引必须命名',2,120,'none','nil',7,'UniqueNameExplicit','一个或多个唯一索引没有提供索引名称。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-020','66dd81be-fd2c-4304-b782-86006c52fc5d',12,'唯一索引索名标识符必须符合规则',2,120,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',7,'UniqueNameQualified','唯一索引\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-021','b68ffe6e-8482-475b-afa4-90b26b44aeb4',12,'唯一索引名必须小写',2,120,'regexp','^[_a-z0-9]+$',7,'UniqueNameLowerCaseRequired','唯一索引\"%s\"含有除小写字母、数字和下划线以外的字符。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-022','9dbce3a5-46b7-4465-af71-e5b6291ebc7a',12,'唯一索引名不能超过最大长度',2,120,'lte','10',7,'UniqueNameMaxLength','唯一索引\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-023','7d77c21e-e0c8-48c0-a115-fc460e820c2b',12,'唯一索引名前缀必须符合规则',2,120,'regexp','^unique_[1-9][0-9]*$',7,'UniqueNamePrefixRequired','唯一索引\"%s\"需要满足前缀正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-024','690b58b4-9be8-4560-ade6-be534a34120b',12,'禁止外键',2,120,'none','nil',7,'ForeignKeyNotAllowed','禁止外键。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-025','98436226-6cb8-4776-bc0b-6bb53ac8cfb4',12,'外键是否显式命名',2,120,'none','nil',5,'ForeignKeyNameExplicit','没有为外键指定名称。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-026','8ee524ec-2359-4a40-ad91-6c3716dc07b6',12,'外键名标识符规则',2,120,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'ForeignKeyNameQualified','外键名\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-027','b107886d-b28d-41c9-9268-81b6fd631c1a',12,'外键名必须小写',2,120,'regexp','^[_a-z0-9]+$',5,'ForeignKeyNameLowerCaseRequired','外键名\"%s\"含有除小写字母、数字和下划线以外的字符。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-028','f3bfe2da-162e-46db-8be5-6e152a16696b',12,'外键名最大长度',2,120,'lte','25',5,'ForeignKeyNameMaxLength','外键名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-029','9df7d265-a301-4fa4-9933-87fe3f0fcae2',12,'外键名前缀规则',2,120,'regexp','^fk_[_a-zA-Z0-9]+$',5,'ForeignKeyNamePrefixRequired','外键名\"%s\"需要满足前缀正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-030','7024faae-3902-4af1-a849-55b09a7cd85a',12,'更名新表规则',2,120,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',7,'NewTableNameQualified','目标表\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-031','92ee30e7-d138-463e-873f-899fb2be4375',12,'更名新表必须小写',2,120,'regexp','^[_a-z0-9]+$',7,'NewTableNameLowerCaseRequired','目标表\"%s\"含有除小写字母、数字和下划线以外的字符。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-032','106db8a3-da54-423c-8104-ed92625f80f4',12,'更名新表最大长度',2,120,'lte','20',7,'NewTableNameMaxLength','目标表\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-033','b9a1a81d-c328-48b9-8919-d47f14f50906',12,'禁用全文索引',2,120,'none','nil',7,'FullTextIndexNotAllowed','禁止使用全文索引。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-034','40375d77-dea4-410b-b8de-9fe4960a138d',12,'索引必须命名',2,120,'none','nil',7,'FullTextIndexNameExplicit','一个或多个全文索引没有提供索引名称。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-035','36d7aa62-fa25-4aa5-a7fa-afb15479b037',12,'索引名标识符规则',2,120,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',7,'FullTextIndexNameQualified','全文索引\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-036','86dd974c-f6ca-475a-84c8-981d43f489ff',12,'索引名必须小写',2,120,'regexp','^[_a-z0-9]+$',7,'FullTextIndexNameLowerCaseRequired','全文索引\"%s\"含有除小写字母、数字和下划线以外的字符。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-037','b928f242-aacd-41b9-8347-aa80ab679746',12,'索引名不能超过最大长度',2,120,'lte','10',7,'FullTextIndexNameMaxLength','全文索引\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-038','f754c334-32cf-4c4c-9c46-92a666d24192',12,'索引名前缀必须匹配规则',2,120,'regexp','^ft_[1-9][0-9]*$',7,'FullTextIndexNamePrefixRequired','全文索引\"%s\"需要满足前缀正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('MTB-L2-039','9280ff7c-ff2b-4c3e-bf6c-4886d49634d1',12,'单一索引最大列数',2,120,'lte','3',7,'MaxAllowedIndexColumnCount','索引\"%s\"索引的列数超出了规则允许的上限，请控制在%d个列以内。','number',1,0,UNIX_TIMESTAMP()),
-('MTB-L3-001','768fb105-d609-4b7b-8ff9-0d3854cabfff',12,'目标库必须已存在',1,120,'none','nil',5,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L3-002','aa482f88-074c-45ab-97b9-81509bd4567f',12,'目标表必须已存在',1,120,'none','nil',5,'TargetTableDoesNotExist','目标表\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L3-004','cfe017e3-339a-45a5-b821-825d656b85e8',12,'位置标记列必须已存在',1,120,'none','nil',5,'PositionColumnDoesNotExist','位置标记列\"%s\"(BEFORE/AFTER)不存在。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L3-005','a130f2c4-e6c0-4dbd-9e55-51af3cb2ecab',12,'列名是否重复',2,120,'none','nil',5,'ColumnNameDuplicate','表\"%s\"中的定义了重复的列\"%s\"。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L3-006','6677109a-5798-44b9-9f8e-597cce76f168',12,'表允许的最大列数',2,120,'lte','25',6,'MaxAllowedColumnCount','表\"%s\"中定义%d个列，数量超出了规则允许的上限%d，请考虑拆分表。','number',1,0,UNIX_TIMESTAMP()),
-('MTB-L3-007','5e179511-ae0a-4e55-abf6-23120adda97f',12,'仅允许一个时间戳类型的列',2,120,'none','nil',6,'MaxAllowedTimestampCount','表\"%s\"中的定义了多个时间戳列，请改用DATETIME类型。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L3-008','084397d9-86fd-415f-b37e-eaa44ba47be9',12,'删除列时目标列必须已存在',1,120,'none','nil',5,'ColumnNameDoesNotExist','目标列\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L3-013','b4fd2895-118e-4e80-b792-da47fdedcb20',12,'添加索引时索引必须不存在',1,120,'none','nil',5,'IndexNameDuplicate','索引名\"%s\"在表\"%s\"已经存在，请使用另外一个索引名称。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L3-014','08fb5c7f-f4f9-490f-a3a6-a8c4c82bd1a1',12,'覆盖索引检查',1,120,'none','nil',5,'IndexOverlayNotAllowed','目标表\"%s\"上已存在索引\"%s\"。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L3-015','c49c27b8-5d1d-4675-a734-8670eee65d9b',12,'同名外键检查',1,120,'none','nil',5,'IndexColumnDoesNotExist','目标表\"%s\"上已存在外键\"%s\"。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L3-016','afcc5ed3-1e4c-47df-b837-b6f3e5dee543',12,'添加外键时外键必须不存在',1,120,'none','nil',5,'IndexOnBlobColumnNotAllowed','目标表\"%s\"上已存在外键\"%s\"。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L3-017','a21ea970-7e26-420d-95f3-e1621f9c5630',12,'启用禁用KEY时KEY必须已存在',1,120,'none','nil',5,'IndexDoesNotExist','目标KEY\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L3-018','061df5d6-cd83-4b1a-abbe-9af10307e9dd',12,'删主键时主键必须存在',1,120,'none','nil',5,'PrimaryKeyDoesNotExist','目标表\"%s\"上未定义主键。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L3-020','7012f584-f7d5-492e-8cd4-37834845558c',12,'删外键时外键必须存在',1,120,'none','nil',5,'ForeignKeyDoesNotExist','目标表\"%s\"上未定义外键\"%s\"。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L3-021','2f2af8e5-2cf1-4ced-a1ec-a47c7c744178',12,'改名时目标表已存在',1,120,'none','nil',5,'TargetTableDuplicate','目标表\"%s\"已存在。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L3-022','86df78a8-ce02-4b06-81c0-f56a7d99ae09',12,'全文索引必须不存在',1,120,'none','nil',5,'FullTextIndexNameDuplicate','目标表\"%s\"上已存在全文索引\"%s\"。','none',1,0,UNIX_TIMESTAMP()),
-('MTB-L3-023','c74c9e43-4600-42f5-8711-9b2114917438',12,'删全文索引时索引必须存在',1,120,'none','nil',4,'FullTextIndexNameDoesNotExist','目标表\"%s\"上未定义全文索引\"%s\"。','none',1,0,UNIX_TIMESTAMP()),
-('MTG-L3-001','e6cbd545-643c-40c2-b2d9-924ef0f2691e',20,'修改触发器时目标库必须已存在',1,201,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('MTG-L3-002','10f58b5f-33ed-4ca6-b2af-ad2e01cf360b',20,'修改触发器时目标表必须已存在',1,201,'none','nil',4,'TargetTableDoesNotExist','目标表\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('MTG-L3-003','7dbfe6fb-e4eb-460d-af51-0427cfe4a600',20,'修改触发器时目标触发器必须已存在',1,201,'none','nil',4,'TargetTriggerDoesNotExist','目标触发器\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('MVW-L3-001','2da54955-a029-4ae5-8535-f77bb461973d',18,'修改视图时目标库必须已存在',1,181,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('MVW-L3-002','50edc670-7d6e-4c6f-92f0-24539074404b',18,'修改视图时目标视图必须已存在',1,181,'none','nil',4,'TargetViewDoesNotExist','目标视图\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('RIX-L3-001','43181c58-4cba-4209-99ad-4534c38e455a',15,'目标库必须已存在',1,151,'none','nil',5,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('RIX-L3-002','342f0510-6e41-4b63-871e-b0c7ae95715e',15,'目标表必须已存在',1,151,'none','nil',5,'TargetTableDoesNotExist','目标表\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('RIX-L3-003','6df03432-6248-4ff6-af35-04bd678e8812',15,'目标索引必须已存在',1,151,'none','nil',5,'TargetIndexDoesNotExist','目标索引\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('RPL-L2-001','f3578823-a5c8-4cdf-be09-55fc899d6875',16,'REPLACE时强制显式列申明',2,161,'none','nil',5,'ExplicitColumnRequired','禁止没有显式提供列列表的REPLACE语句。','none',1,0,UNIX_TIMESTAMP()),
-('RPL-L2-002','41b437f0-eca7-46c3-9935-69c2bcacae70',16,'禁止REPLACE...SELECT',2,161,'none','nil',5,'UsingSelectNotAllowed','禁止REPLACE ... SELECT ...语句。','none',1,0,UNIX_TIMESTAMP()),
-('RPL-L2-005','eaaa918c-611f-467c-9c65-03ff4124a998',16,'REPLACE时列类型、值是否匹配',2,161,'none','nil',5,'ColumnsValuesMustMatch','REPLACE语句的列数量和值数量不匹配。','none',1,0,UNIX_TIMESTAMP()),
-('RPL-L3-001','5679453f-d160-4f0a-860f-5f6f5c50d505',16,'REPLACE时目标库必须已存在',1,161,'none','nil',4,'TargetDatabaseDoesNotExist','REPLACE语句中指定的库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('RPL-L3-002','612b1902-88c1-46e2-b139-5fec2b74c692',16,'REPLACE时目标表必须已存在',1,161,'none','nil',4,'TargetTableDoesNotExist','REPLACE语句中指定的表\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('RPL-L3-003','d54e672f-e360-4789-bd2d-5ca5f14fdf5a',16,'REPLACE时目标列必须已存在',1,161,'none','nil',4,'TargetColumnDoesNotExist','REPLACE语句中替换的列\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('RPL-L3-004','68b029cc-8fff-4da7-8a3e-62b124b779a3',16,'REPLACE时非空列是否有值',1,161,'none','nil',4,'ValueForNotNullColumnRequired','REPLACE语句没有为非空列\"%s\"提供值。','none',1,0,UNIX_TIMESTAMP()),
-('RTB-L2-001','3ec2eaff-5471-405f-ab34-bc2158b8f884',13,'目标表跟源表是同一个表',1,130,'none','nil',5,'TablesIdentical','源表\"%s\"和目标表\"%s\"相同。','none',1,0,UNIX_TIMESTAMP()),
-('RTB-L2-002','95c374ee-52a4-48f2-b24a-ac273e5e8f36',13,'目标表名规则',2,130,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'TargetTableNameQualified','目标表名\"%s\"需要满足正则\"%s\"。','regexp',1,0,UNIX_TIMESTAMP()),
-('RTB-L2-003','7daae444-78ea-46ac-a7e9-18149c725545',13,'目标表名必须小写',2,130,'none','^[_a-z0-9]+$',5,'TargetTableNameLowerCaseRequired','目标表名\"%s\"含有大写字母。','none',1,0,UNIX_TIMESTAMP()),
-('RTB-L2-004','22e5db11-6afb-4e50-9fa1-c77c09dfb168',13,'目标表名最大长度',2,130,'lte','20',7,'TargetTableNameMaxLength','目标表名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number',1,0,UNIX_TIMESTAMP()),
-('RTB-L3-001','8e75b778-e6c5-4d34-9edd-03097a4450d2',13,'源库必须已存在',1,130,'none','nil',4,'SourceTableDoesNotExist','源库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('RTB-L3-002','8a1a4f9b-35da-4ff0-9ee2-d190b54f2ba5',13,'源表必须已存在',1,130,'none','nil',4,'SourceDatabaseDoesNotExist','源表\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('RTB-L3-003','bb56fcfd-736f-4033-aa6f-2ce9ea1108f8',13,'目标库必须已存在',1,130,'none','nil',4,'TargetTableDoesNotExist','目标库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('RTB-L3-004','2affbef0-4856-4eb3-a9a7-a1bf74a531c2',13,'目标表必须不存在',1,130,'none','nil',4,'TargetDatabaseDoesNotExist','目标表\"%s\"已存在。','none',1,0,UNIX_TIMESTAMP()),
-('SEL-L2-001','1b163a6b-633b-4285-978f-fefd0af3fb9c',17,'禁止没有WHERE的查询',2,170,'none','nil',5,'WithoutWhereNotAllowed','禁止没有WHERE从句的查询语句。','none',1,0,UNIX_TIMESTAMP()),
-('SEL-L2-002','f9d57f6c-2ad0-4bb6-a4f8-a56fbff3cb65',17,'禁止没有LIMIT的查询',2,170,'none','nil',7,'WithoutLimitNotAllowed','禁止没有LIMIT从句的查询语句。','none',1,0,UNIX_TIMESTAMP()),
-('SEL-L2-003','b44f75af-51f0-48f5-8d1c-b8f8b1eb225d',17,'禁止SELECT STAR',2,170,'none','nil',7,'UseWildcardNotAllowed','禁止SELECT语句使用通配符，需要显式指定需要查询的列。','none',1,0,UNIX_TIMESTAMP()),
-('SEL-L2-004','e1018221-684c-49f0-baf4-2ea77c80fd3d',17,'禁止SELECT FOR UPDATE',2,170,'none','nil',5,'UseExplicitLockNotAllowed','禁止在SELECT语句中显示使用锁。','none',1,0,UNIX_TIMESTAMP()),
-('SEL-L3-001','bbd20e8b-4eeb-4467-a481-75bde843913f',17,'目标数据库必须已存在',1,170,'none','nil',5,'TargetDatabaseDoesNotExist','SELECT语句中指定的库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('SEL-L3-002','2430a63b-67c1-48f6-8b34-a4f1161a63b1',17,'目标表必须已存在',1,170,'none','nil',5,'TargetTableDoesNotExist','SELECT语句中指定的表\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('SEL-L3-003','90f2b354-19c5-4d4d-be3f-8361ecbe968d',17,'目标列必须已存在',1,170,'none','nil',4,'TargetColumnDoesNotDoesNotExist','SELECT语句中返回的列\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('SEL-L3-004','d59e3dad-cf02-41b6-a306-987e1e07814e',17,'是否允许返回BLOB/TEXT列',1,170,'none','nil',4,'ReturnBlobOrTextNotAllowed','查询语句中指的列\"%s\"是BLOB/TEXT类型。','none',1,0,UNIX_TIMESTAMP()),
-('UPD-L2-001','67fa90a8-5df6-479c-bfd4-11ab0bb9fa8b',16,'禁止没有WHERE的更新',1,162,'none','nil',5,'WithoutWhereNotAllowed','禁止没有WHERE从句的UPDATE语句。','none',1,0,UNIX_TIMESTAMP()),
-('UPD-L3-001','022dbea9-af6f-44cb-b0ae-3f4712a014c8',16,'目标库必须已存在',1,162,'none','nil',5,'TargetDatabaseDoesNotExist','UPDATE语句中指定的库\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('UPD-L3-002','749e0a05-843b-4c8e-8d5c-6ef252b261ad',16,'目标表必须已存在',1,162,'none','nil',5,'TargetTableDoesNotExist','UPDATE语句中指定的表\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('UPD-L3-003','bdf11d37-9534-4553-826d-15d3b08f1059',16,'目标列必须已存在',1,162,'none','nil',4,'TargetColumnDoesNotExist','UPDATE语句中更新的列\"%s\"不存在。','none',1,0,UNIX_TIMESTAMP()),
-('UPD-L3-005','a72f3d07-7512-46bb-a442-8a505610a72e',16,'允许单次更新的最大行数',1,162,'lte','1000',6,'MaxAllowedUpdateRows','单条UPDATE语句不得操作超过%d条记录。','number',1,0,UNIX_TIMESTAMP());
+('CDB-L2-001','e4b6f058-f8e3-469d-b754-ac754c9bd07e',10,'新建数据库时允许的字符集',2,100,'in','[\"utf8mb4\",\"binary\"]',7,'AvailableCharsets','建库禁用字符集\"%s\"，请使用\"%s\"。','checkboxes/key=charsets','',4,0,UNIX_TIMESTAMP()),
+('CDB-L2-002','5126367e-19bf-4996-96eb-b92d51860acc',10,'新建数据库时允许的排序规则',2,100,'none','[\"utf8mb4_general_ci\", \"utf8mb4_bin\", \"utf8mb4_unicode_ci\"]',7,'AvailableCollates','建库禁用排序规则\"%s\"，请使用\"%s\"。','checkboxes/key=collates','',1,0,UNIX_TIMESTAMP()),
+('CDB-L2-003','8e549891-ace6-48ba-bab7-6c333851098f',10,'新建数据库时字符集与排序规则必须匹配',2,100,'none','nil',5,'CharsetCollateMustMatch','建库使用的字符集\"%s\"和排序规则\"%s\"不匹配，请查阅官方文档。','none','',1,0,UNIX_TIMESTAMP()),
+('CDB-L2-004','439a8103-7664-48c8-ae3d-227deb057416',10,'库名规则',2,100,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',7,'DatabaseNameQualified','库名\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CDB-L2-005','ce313eeb-aaa3-4ccc-ab52-6f8694bff63e',10,'库名必须小写',2,100,'none','^[_a-z0-9]+$',7,'DatabaseNameLowerCaseRequired','库名\"%s\"中含有大写字母。','none','',1,0,UNIX_TIMESTAMP()),
+('CDB-L2-006','5575b7a8-fda5-4c07-8fed-5457839334bb',10,'库名最大长度',2,100,'lte','15',7,'DatabaseNameMaxLength','库名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('CDB-L2-007','d8a0fad2-773a-49d7-b1b9-d1219158222b',10,'新建数据库时目标库必须不存在',1,100,'none','nil',5,'TargetDatabaseDoesNotExist','目标库\"%s\"已存在。','none','',1,0,UNIX_TIMESTAMP()),
+('CEV-L2-001','c8cf9058-f788-48d7-9d87-44201fd574a4',21,'创建事件时事件名规则',2,210,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'EventNameQualified','事件名\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CEV-L2-002','bf0dc49f-6c6e-40a8-a716-872ebf3bbd71',21,'创建事件时事件名必须小写',2,210,'regexp','^[_a-z0-9]+$',5,'EventNameLowerCaseRequired','事件名\"%s\"含有大写字母。','none','',1,0,UNIX_TIMESTAMP()),
+('CEV-L2-003','8e4484d1-be13-4307-8293-870407428de9',21,'创建事件时事件名最大长度',2,210,'lte','25',7,'EventNameMaxLength','事件名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('CEV-L2-004','1f67c0d5-86ca-4f9e-95f6-ba6075b4eefc',21,'创建事件时事件名前缀规则',2,210,'regexp','^ev_[_a-zA-Z0-9]+$',5,'EventNamePrefixRequired','事件名\"%s\"需要满足前缀正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CEV-L3-001','65fe735f-306c-463e-90d7-30c293d352b7',21,'创建事件时目标库必须已存在',1,210,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('CEV-L3-002','23dd98bc-685a-413f-a63f-61485bc392cc',21,'创建事件时目标事件必须已存在',1,210,'none','nil',4,'TargetEventDoesNotExist','目标事件\"%s\"已存在。','none','',1,0,UNIX_TIMESTAMP()),
+('CEV-L3-003','33273f7d-704e-4bd6-b5eb-d2f89e98d744',21,'创建事件时事件调度器必须开启',2,210,'none','nil',5,'EventSchedulerDisabled','事件调度器没有开启，事件\"%s\"不会被执行。','none','',1,0,UNIX_TIMESTAMP()),
+('CEV-L3-004','c2a4d2b3-4e62-4b5d-8bf3-10c1d865a286',21,'创建事件时事件中的语句必须通过审核',1,210,'none','nil',5,'EventBodyValidated','事件\"%s\"中的语句\"%s\"：%s','none','',1,0,UNIX_TIMESTAMP()),
+('CFU-L2-001','3a2fc192-febc-4ba5-84d4-ed95b963fdfc',19,'新建函数时函数名规则',2,190,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',7,'FuncNameQuilified','函数名\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CFU-L2-002','33004fce-753f-4a77-b552-e0cf7bbe4636',19,'新建函数时函数名必须小写',2,190,'regexp','^[_a-z0-9]+$',7,'FuncNameLowerCaseRequired','函数名\"%s\"含有大写字母。','none','',1,0,UNIX_TIMESTAMP()),
+('CFU-L2-003','a924afeb-8def-4443-ab2a-d8d429e5dd49',19,'新建函数时函数名最大长度',2,190,'lte','25',7,'FuncNameMaxLength','函数名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('CFU-L2-004','0a74e7bd-c21d-421e-af12-874a60bc61f2',19,'新建函数时函数名前缀规则',2,190,'regexp','^fn_[_a-zA-Z0-9]+$',7,'FuncNamePrefixRequired','函数名\"%s\"需要满足前缀正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CFU-L3-001','5069d187-8cb1-41b9-8876-14e017daf992',19,'新建函数时目标库必须已存在',1,190,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('CFU-L3-002','b134e0b3-a6e5-4404-b845-c3f86712fcb9',19,'新建函数时目标函数必须不存在',1,190,'none','nil',4,'TargetFuncDoesNotExist','目标函数\"%s\"已存在。','none','',1,0,UNIX_TIMESTAMP()),
+('CIX-L2-001','2221dc67-94c6-4a3d-a3b5-638a3d02b70a',15,'组合索引允许的最大列数',2,150,'lte','3',7,'MaxAllowedIndexColumnCount','索引\"%s\"中索引列数量超过允许的阈值%d。','number','',1,0,UNIX_TIMESTAMP()),
+('CIX-L2-002','0076aae4-6196-4f0d-bfb3-37df11fd45ed',15,'索引名规则',2,150,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'IndexNameQualified','索引名\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CIX-L2-003','a316aa97-534b-4c9d-a881-278b9b7bdbdf',15,'索引名必须小写',2,150,'regexp','^[_a-z0-9]+$',5,'IndexNameLowerCaseRequired','索引名\"%s\"含有大写字母。','none','',1,0,UNIX_TIMESTAMP()),
+('CIX-L2-004','71db9655-eeb1-4be3-8c0a-67cef0709b38',15,'索引名最大长度',2,150,'lte','10',5,'IndexNameMaxLength','索引名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('CIX-L2-005','6d676a9c-90a5-4c9c-81e6-00ec8e5ab350',15,'索引名前缀规则',2,150,'regexp','^index_[1-9][0-9]*$',5,'IndexNamePrefixRequired','索引名\"%s\"需要满足前缀正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CIX-L2-006','47ee8f95-9d48-4a4c-a917-c4679d5fe4f0',15,'组合索引中是否有重复列',2,150,'none','nil',5,'IndexColumnDuplicate','索引\"%s\"中索引了重复的列。','none','',1,0,UNIX_TIMESTAMP()),
+('CIX-L3-001','034b0bcd-e246-4ade-9ab0-35522b5382f9',15,'目标库必须已存在',1,150,'none','nil',5,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('CIX-L3-002','2d222b20-a4a5-44c4-b7aa-4cda28a46369',15,'目标表必须已存在',1,150,'none','nil',5,'TargetTableDoesNotExist','目标表\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('CIX-L3-003','22b1f933-bce4-4d20-9b6f-7a0282c453c1',15,'索引列必须已存在',1,150,'none','nil',5,'TargetColumnDoesNotExist','目标列\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('CIX-L3-004','2e301212-02af-4b84-955f-3178812ded5a',15,'索引内容是否重复',1,150,'none','nil',4,'IndexOverlayNotAllowed','索引\"%s\"在已有索引\"%s\"相同或者存在覆盖关系。','none','',1,0,UNIX_TIMESTAMP()),
+('CIX-L3-005','57f92fe9-3540-4541-a53c-0487db7c0702',15,'索引名是否重复',1,150,'none','nil',5,'IndexNameDuplicate','索引名\"%s\"在表\"%s\"已经存在，请使用另外一个索引名称。','none','',1,0,UNIX_TIMESTAMP()),
+('CIX-L3-006','6f69fd99-4d89-4c6f-b8ce-1b9dd437e687',15,'最多能建多少个索引',1,150,'lte','5',6,'MaxAllowedIndexCount','索引数量超过允许的阈值%d。','number','',1,0,UNIX_TIMESTAMP()),
+('CIX-L3-007','9525f4ff-efa2-4b09-a5af-ab3cc3deb24c',15,'禁止在BLOB/TEXT列上建索引',2,150,'none','nil',7,'IndexOnBlobColumnNotAllowed','禁止在BLOB/TEXT类型的列\"%s\"上建立索引。','none','',1,0,UNIX_TIMESTAMP()),
+('CSP-L2-001','157bdbad-8862-4497-91aa-83827badae02',22,'新建存储过程时存储过程名规则',2,220,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'ProcNameQualified','存储过程名\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CSP-L2-002','6e82df00-56bf-44e3-adec-fb08bf4d5f3e',22,'新建存储过程时存储过程名必须小写',2,220,'regexp','^[_a-z0-9]+$',5,'ProcNameLowerCaseRequired','存储过程名\"%s\"含有大写字母。','none','',1,0,UNIX_TIMESTAMP()),
+('CSP-L2-003','a4055ee1-caf6-4a72-828e-abea01123af2',22,'新建存储过程时存储过程名最大长度',2,220,'lte','25',5,'ProcNameMaxLength','存储过程名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('CSP-L2-004','150f0a36-d1e9-42b1-a30e-4b5e4b2edd71',22,'新建存储过程时存储过程名前缀规则',2,220,'regexp','^sp_[_a-zA-Z0-9]+$',5,'ProcNamePrefixRequired','存储过程名\"%s\"需要满足前缀正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CSP-L3-001','b467ce86-779c-41e6-9079-50b2c9ff3676',22,'新建存储过程时目标库必须已存在',1,220,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('CSP-L3-002','60f6be7b-0115-4e9a-bf30-79be2a7c2c00',22,'新建存储过程时目标存储过程必须不存在',1,220,'none','nil',4,'TargetProcDoesNotExist','目标存储过程\"%s\"已存在。','none','',1,0,UNIX_TIMESTAMP()),
+('CSP-L3-003','1bedeb04-2bdc-4d9e-9826-2223c930aa02',22,'新建存储过程时存储过程中的语句必须通过审核',1,220,'none','nil',5,'ProcBodyValidated','存储过程\"%s\"中的语句\"%s\"：%s','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-001','2e7cbe13-90d3-472a-9da2-4594c8424df0',11,'允许的字符集',2,110,'in','[\"utf8mb4\"]',7,'AvailableCharsets','建表禁用字符集\"%s\"，请使用\"%s\"。','checkboxes/key=charsets','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-002','5028dfe3-949d-4220-b9ae-a29d8a16de52',11,'允许的排序规则',2,110,'in','[\"utf8mb4_unicode_ci\", \"utf8mb4_general_ci\", \"utf8mb4_bin\"]',5,'AvailableCollates','建表禁用排序规则\"%s\"，请使用\"%s\"。','checkboxes/key=collates','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-003','81b9dec9-3769-4dff-a29a-8739c3ac4ec9',11,'字符集与排序规则必须匹配',2,110,'none','nil',5,'TableCharsetCollateMustMatch','建表使用的字符集\"%s\"和排序规则\"%s\"不匹配，请查阅官方文档。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-004','a6dd1817-a41f-4688-924f-df959ffbb2db',11,'允许的存储引擎',2,110,'in','[\"innodb\", \"tokudb\", \"rocksdb\", \"archive\"]',7,'AvailableEngines','建表禁用存储引擎\"%s\"，请使用\"%s\"。','checkboxes/key=engines','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-005','0862861b-4328-49eb-86dc-07b15e0a2a6c',11,'表名规则',2,110,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'TableNameQualified','表名\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-006','f77613a7-6f1c-48ff-8946-e725d5431e72',11,'表名必须小写',2,110,'regexp','^[_a-z0-9]+$',5,'TableNameLowerCaseRequired','表名\"%s\"含有大写字母。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-007','19630e1b-fee5-4afc-8fd1-28f43efb74c6',11,'表名最大长度',2,110,'lte','20',7,'TableNameMaxLength','表名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-008','335bd848-2027-4c66-b5b0-09f225f02bb9',11,'表必须有注释',2,110,'none','nil',7,'TableCommentRequired','需要为表\"%s\"需要提供COMMENT注解。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-009','6f945479-4788-4bdb-b1e2-e21dc3b335d6',11,'禁止使用CREATE TABLE ... SELECT ...建表',2,110,'none','nil',5,'CreateTableFromSelectNotAllowed','禁止使用CREATE TABLE AS SELECT的方式建表。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-010','0b0e3799-7f6e-4a46-a054-7437b5485d06',11,'列名规则',2,110,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'ColumnNameQualified','列名\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-011','d99679c9-ffbf-48e6-9dc8-bb1a82d10ff6',11,'列名必须小写',2,110,'regexp','^[_a-z0-9]+$',7,'ColumnNameLowerCaseRequired','列名\"%s\"中含有大写字母。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-012','86530dce-57e4-41b6-a296-0ccbec5036a3',11,'列名最大长度',2,110,'lte','20',7,'ColumnNameMaxLength','列名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-013','97842c81-7b38-406b-a9a3-88244ea79271',11,'列名是否重复',2,110,'none','nil',5,'ColumnNameDuplicate','表\"%s\"中的定义了重复的列\"%s\"。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-014','2c98b6b7-0e0e-438e-8ccd-fa73fbcdbbea',11,'表允许的最大列数',2,110,'lte','25',7,'MaxAllowedColumnCount','表\"%s\"中定义%d个列，数量超出了规则允许的上限%d，请考虑拆分表。','number','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-015','31ab4d7a-4243-4951-bf7e-ddbdbc03254f',11,'列禁用的数据类型',2,110,'not-in','[\"bit\", \"enum\", \"set\", \"double\", \"real\", \"float\"]',7,'ColumnTypesDoesNotExpect','列\"%s\"使用了不期望的数据类型\"%s\"，请避免使用\"%s\"数据类型。','checkboxes/key=data-types','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-016','b14a529b-bbfc-4d9a-8c1a-067f99449e26',11,'列必须有注释',2,110,'none','nil',5,'ColumnCommentRequired','列\"%s\"需要提供COMMENT注解。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-017','95aa9816-ed7c-41a7-8f4f-6929757a92d8',11,'列允许的字符集',2,110,'in','[\"utf8mb4\", \"binary\"]',7,'ColumnAvailableCharsets','列\"%s\"禁用字符集\"%s\"，请使用\"%s\"。','checkboxes/key=charsets','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-018','858cabba-39f1-4c47-af8c-85c891605400',11,'列允许的排序规则',2,110,'in','[\"utf8mb4_unicode_ci\", \"utf8mb4_general_ci\", \"utf8mb4_bin\", \"binary\"]',7,'ColumnAvailableCollates','列\"%s\"禁用排序规则\"%s\"，请使用\"%s\"。','checkboxes/key=collates','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-019','55e72c3d-f0d2-45e9-bd3e-0c172ae6ad69',11,'列字符集与排序规则必须匹配',2,110,'none','nil',5,'ColumnCharsetCollateMustMatch','列\"%s\"使用的字符集\"%s\"和排序规则\"%s\"不匹配，请查阅官方文档。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-020','dba74873-c113-482a-8552-8d81dd640bd4',11,'非空列必须有默认值',2,110,'none','nil',5,'ColumnNotNullWithDefaultRequired','列\"%s\"不允许为空，但没有指定默认值。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-021','bc94e7f3-9e3e-48c7-b21b-85593b2c2af7',11,'自增列允许的数据类型',2,110,'in','[\"int\", \"bigint\"]',7,'ColumnAutoIncAvailableTypes','自增列\"%s\"禁用\"%s\"类型，请使用\"%s\"。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-022','d6894864-4d41-4930-b3fd-62da07588239',11,'自增列必须是无符号',2,110,'none','nil',7,'ColumnAutoIncUnsignedRequired','自增列\"%s\"必须使用无符号的整数。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-023','526ac72c-55d4-484b-8bb2-88eda1e67d6c',11,'自增列必须是主键',2,110,'none','nil',5,'ColumnAutoIncMustPrimaryKey','自增列\"%s\"不是主键。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-024','84fcb061-af77-4a7f-8a0e-dfc166b2c531',11,'仅允许一个时间戳类型的列',2,110,'none','nil',7,'MaxAllowedTimestampCount','表\"%s\"中的定义了多个时间戳列，请改用DATETIME类型。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-025','0df017c0-d106-4df5-845c-bf50557d497f',11,'单一索引最大列数',2,110,'lte','3',7,'MaxAllowedIndexColumnCount','索引\"%s\"索引的列数超出了规则允许的上限，请控制在%d个列以内。','number','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-026','8d319516-890e-45f2-af90-8061b37e9a04',11,'必须有主键',2,110,'none','nil',5,'PrimaryKeyRequired','必须为表指定一个主键。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-027','31e193df-bf11-4462-9558-c182eb8366e5',11,'主键是否显式命名',2,110,'none','nil',4,'PrimaryKeyNameExplicit','主键没有提供名称。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-028','bedd8dfa-b325-451b-981a-a1cede6bc787',11,'主键名规则',2,110,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',4,'PrimaryKeyNameQualified','主键名\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-029','dee29598-1f59-4d5f-9881-ad16fa4038fd',11,'主键名必须小写',2,110,'regexp','^[_a-z0-9]+$',4,'PrimryKeyLowerCaseRequired','主键名\"%s\"含有大写字母。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-030','c47768d2-24b3-4e9c-9079-8ef39bbd0a53',11,'主键名最大长度',2,110,'lte','20',4,'PrimryKeyMaxLength','主键名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-031','bc1164e0-45ee-4d58-b4c5-cea244ebeb3f',11,'主键名前缀规则',2,110,'regexp','^pk_[_a-zA-Z0-9]+$',4,'PrimryKeyPrefixRequired','主键名\"%s\"需要满足前缀正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-032','7f7182f3-3da8-43c0-a43f-dbe44ccf0130',11,'索引必须命名',2,110,'none','nil',5,'IndexNameExplicit','一个或多个索引没有提供索引名称。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-033','364f58af-29a8-4a49-a3ad-a35ea500352c',11,'索引名规则',2,110,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'IndexNameQualified','索引名\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-034','1dccb327-2b34-4f3d-a900-9560a7b9cd3b',11,'索引名必须小写',2,110,'regexp','^[_a-z0-9]+$',5,'IndexNameLowerCaseRequired','索引名\"%s\"含有大写字母。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-035','0e51b2a9-b251-4a25-be86-7895285ad77a',11,'索引名最大长度',2,110,'lte','10',7,'IndexNameMaxLength','索引名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-036','abac99f7-ad0a-4d24-bdf4-00439e4abad6',11,'索引名前缀规则',2,110,'regexp','^index_[1-9][0-9]*$',5,'IndexNamePrefixRequired','索引名\"%s\"需要满足前缀正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-037','de701fa6-6ed4-4608-a95c-53087c43e177',11,'唯一索引必须命名',2,110,'none','nil',5,'UniqueNameExplicit','一个或多个唯一索引没有提供索引名称。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-038','26d84e04-25e6-4865-b76b-00995ef56f81',11,'唯一索引索名规则',2,110,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'UniqueNameQualified','唯一索引\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-039','3b07760f-c055-4d3c-b27a-d23077a0c768',11,'唯一索引名必须小写',2,110,'regexp','^[_a-z0-9]+$',5,'UniqueNameLowerCaseRequired','唯一索引\"%s\"含有大写字母。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-040','f9a615b4-cbaa-40a7-b6c2-28a0fc78a35e',11,'唯一索引名最大长度',2,110,'lte','10',7,'UniqueNameMaxLength','唯一索引\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-041','8aab8c07-59d3-43d5-a74f-0005697955ee',11,'唯一索引名前缀规则',2,110,'regexp','^unique_[1-9][0-9]*$',5,'UniqueNamePrefixRequired','唯一索引\"%s\"需要满足前缀正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-042','30a7e9c3-0502-4793-9b88-fca8223efa90',11,'禁止外键',2,110,'none','nil',5,'ForeignKeyNotAllowed','禁止外键。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-043','8c599732-e3d0-432e-9396-4b4ed6b05479',11,'外键是否显式命名',2,110,'none','nil',5,'ForeignKeyNameExplicit','没有为外键指定名称。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-044','77708821-c945-4a6d-aa0d-84329b1b01fe',11,'外键名规则',2,110,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'ForeignKeyNameQualified','外键名\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-045','867fa702-b10f-482d-b1a2-a71d5be32f21',11,'外键名必须小写',2,110,'regexp','^[_a-z0-9]+$',5,'ForeignKeyNameLowerCaseRequired','外键名\"%s\"含有大写字母。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-046','3c3052b4-fc6d-4976-98d1-e2a49ecd3927',11,'外键名最大长度',2,110,'lte','25',5,'ForeignKeyNameMaxLength','外键名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-047','35207240-5094-4696-b209-1260865fde8a',11,'外键名前缀规则',2,110,'regexp','^fk_[_a-zA-Z0-9]+$',5,'ForeignKeyNamePrefixRequired','外键名\"%s\"需要满足前缀正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-048','e5c85872-1cff-48c0-b705-0c61b04070b9',11,'表中最多可建多少个索引',2,110,'lte','5',7,'MaxAllowedIndexCount','表\"%s\"中定义了%d个索引，数量超过允许的阈值%d。','number','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-049','7eedb2fb-5e26-41a7-baa7-8c37b8ef3170',11,'禁止使用CREATE TABLE ... LIKE ...建表',2,110,'none','nil',5,'CreateTableUseLikeNotAllowed','禁止使用CREATE TABLE LIKE的方式建表。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-050','5ace374b-0f35-4132-8941-44283bbc87c1',11,'仅允许定义一个自增列',2,110,'nil','nil',5,'AutoIncColumnDuplicate','表\"%s\"中定义了多个自增列。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-051','b9dce6ba-5612-4bc4-afdb-730d7b15930a',11,'仅允许定义一个主键',2,110,'nil','nil',5,'PrimaryKeyDuplicate','表\"%s\"中定义了多个主键。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L2-052','2c4737fa-c6fe-441f-8f07-5d8e483f18c5',11,'禁止使用的列类型',2,110,'not-regexp','^(enum|set)\\(',4,'Evaluate','列类型\"%s\"被禁止使用。','regexp','CreateTable.Columns[*].Type',1,0,UNIX_TIMESTAMP()),
+('CTB-L3-001','887cf290-2288-451a-be70-57394e23dde4',11,'目标库必须已存在',1,110,'none','nil',5,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('CTB-L3-002','1e75c5a2-7bcc-42c4-ac3f-5a64ead5b25c',11,'目标表必须不存在',1,110,'none','nil',5,'TargetTableDoesNotExist','目标表\"%s\"已存在。','none','',1,0,UNIX_TIMESTAMP()),
+('CTG-L2-001','56661395-36fc-4cdc-9346-8cb4608f6f44',20,'新建触发器时触发器名规则',2,200,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'TriggerNameQualified','触发器名\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CTG-L2-002','0d99687d-810f-4f08-aa28-9738ea29eadc',20,'新建触发器时触发器名必须小写',2,200,'regexp','^[_a-z0-9]+$',7,'TriggerNameLowerCaseRequired','触发器名\"%s\"含有大写字母。','none','',1,0,UNIX_TIMESTAMP()),
+('CTG-L2-003','73120f74-fdf3-4230-8f31-3f27e7571005',20,'新建触发器时触发器名最大长度',2,200,'lte','25',7,'TriggerNameMaxLength','触发器名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('CTG-L2-004','447e8fe4-4583-4986-ab11-d62ece063b7b',20,'新建触发器时触发器名前缀规则',2,200,'regexp','^tg_[_a-zA-Z0-9]+$',5,'TriggerPrefixRequired','触发器名\"%s\"需要满足前缀正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CTG-L3-001','27849545-075f-4fb6-8d47-f947c9894dac',20,'新建触发器时目标库必须已存在',2,200,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('CTG-L3-002','35a926d4-f842-4c91-a109-a22327114c52',20,'新建触发器时目标表必须已存在',2,200,'none','nil',4,'TargetTableDoesNotExist','目标表\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('CTG-L3-003','f2fabd72-4453-45ac-badf-105f46b6f00a',20,'新建触发器时目标触发器必须不存在',2,200,'none','nil',4,'TargetTriggerDoesNotExist','目标触发器\"%s\"已存在。','none','',1,0,UNIX_TIMESTAMP()),
+('CTG-L3-004','c0d3c079-681b-4f3c-8191-58f3ea9a12ef',20,'新建触发器时同一张表的同一时机和事件只能有一个触发器',2,200,'none','nil',5,'TriggerTimingEventDuplicated','表\"%s\"上已存在%s %s触发器\"%s\"。','none','',1,0,UNIX_TIMESTAMP()),
+('CVW-L2-001','217771f6-c3de-4956-8656-2fd8e482f5b7',18,'新建视图时视图名规则',2,180,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'ViewNameQualified','视图名\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CVW-L2-002','8d9a1c43-325a-47ac-9891-c1155697da3a',18,'新建视图时视图名必须小写',2,180,'regexp','^[_a-z0-9]+$',5,'ViewNameLowerCaseRequired','视图名\"%s\"含有大写字母。','none','',1,0,UNIX_TIMESTAMP()),
+('CVW-L2-003','19f69c74-7f29-4eba-8131-dbc5992ae3c4',18,'新建视图时视图名最大长度',2,180,'lte','25',7,'ViewNameMaxLength','视图名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('CVW-L2-004','a776c690-8576-4664-ab29-5db0885960b3',18,'新建视图时视图名前缀规则',2,180,'regexp','^vw_[_a-zA-Z0-9]+$',7,'ViewNamePrefixRequired','视图名\"%s\"需要满足前缀正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('CVW-L3-001','dff7c8a6-f9db-4d86-9c7f-df8b1614a307',18,'新建视图时目标库必须已存在',1,180,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('CVW-L3-002','5fba8576-d5fb-4135-9dc2-d01578ebfb9c',18,'新建视图时目标视图必须不存在',1,180,'none','nil',4,'TargetViewDoesNotExist','目标视图\"%s\"已存在。','none','',1,0,UNIX_TIMESTAMP()),
+('DDB-L2-001','1abf3c57-d84d-4295-9cbe-4b95f9e3c9bc',10,'删除数据库时目标库必须已存在',1,102,'none','nil',5,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('DEL-L2-001','ed326131-edce-456a-a19e-1fdece47060e',16,'禁止没有WHERE的删除',2,163,'none','nil',5,'WithoutWhereNotAllowed','禁止没有WHERE从句的DELETE语句。','none','',1,0,UNIX_TIMESTAMP()),
+('DEL-L3-001','de3855f6-b835-49eb-89ff-bf854c515a02',16,'单次删除的最大行数',1,163,'lte','1000',5,'MaxAllowedDeleteRows','单条DELETE语句不得操作超过%d条记录。','number','',1,0,UNIX_TIMESTAMP()),
+('DEL-L3-002','7ebccfe3-6ad7-4c83-b7a4-9cbfa12e35db',16,'目标库必须已存在',1,163,'none','nil',5,'TargetDatabaseDoesNotExist','DELETE语句中指定的库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('DEL-L3-003','0b5dbb5e-8223-42a0-bb76-a31ed16bf537',16,'目标表必须已存在',1,163,'none','nil',5,'TargetTableDoesNotDoesNotExist','DELETE语句中指定的表\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('DEL-L3-004','36b9693f-26e1-47b0-8ebb-e26f6ae9d5fa',16,'条件过滤列必须已存在',1,163,'none','nil',4,'TargetColumnDoesNotDoesNotExist','DELETE语句中条件限定列\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('DEV-L3-001','3f2405f2-4fde-40b3-825f-16cb29dea9b9',21,'删除事件时目标库必须已存在',1,212,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('DEV-L3-002','7d46d4bf-6085-401f-9939-63a5120017ad',21,'删除事件时目标事件必须已存在',1,212,'none','nil',4,'TargetEventDoesNotExist','目标事件\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('DFU-L3-001','dfaf91bb-7353-4af6-b88c-3a6f04ad6ab8',19,'删除函数时目标库必须已存在',1,192,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('DFU-L3-002','e8f789bd-cf1f-4b3e-aa2e-3359979f5485',19,'删除函数时目标函数必须已存在',1,192,'none','nil',4,'TargetFuncDoesNotExist','目标函数\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('DSP-L3-001','bafb9ff1-cafb-4178-98c9-823ecd8f6600',22,'删除存储过程时目标库必须已存在',1,222,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('DSP-L3-002','4d627159-45a5-47d9-8942-153b49ac795f',22,'删除存储过程时目标存储过程必须已存在',1,222,'none','nil',4,'TargetProcDoesNotExist','目标存储过程\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('DTB-L3-001','da2d4fd4-af73-4cbf-9b83-ca32d5594396',14,'目标库必须已存在',1,140,'none','nil',5,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('DTB-L3-002','a2d5b7ec-0fda-42f9-9fea-71687a3066f7',14,'目标表必须已存在',1,140,'none','nil',5,'TargetTableDoesNotExist','目标表\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('DTG-L3-001','f9e45bbe-528d-4ab3-b6dd-4f79749fc12b',20,'删除触发器时目标库必须已存在',1,202,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('DTG-L3-002','d55a27c3-0501-4e73-8d6f-c850bbab411e',20,'删除触发器时目标表必须已存在',1,202,'none','nil',4,'TargetTableDoesNotExist','目标表\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('DTG-L3-003','6607f3d3-74c3-4894-b590-2cb073e983d3',20,'删除触发器时目标触发器必须已存在',1,202,'none','nil',4,'TargetTriggerDoesNotExist','目标触发器\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('DVW-L3-001','4b1e7450-4662-4d47-948f-7e6d61f9d1f3',18,'删除视图时目标库必须已存在',1,182,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('DVW-L3-002','fe4af9de-5292-45a5-82e3-16348b1a9da1',18,'删除视图时目标视图必须已存在',1,182,'none','nil',4,'TargetViewDoesNotExist','目标视图\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('INS-L2-001','4a5c2e53-1b50-43ce-954b-ce2d60eeeed7',16,'INSERT时强制显式列申明',2,160,'none','nil',5,'ExplicitColumnRequired','禁止没有显式提供列列表的INSERT语句。','none','',1,0,UNIX_TIMESTAMP()),
+('INS-L2-002','6454269e-fff7-4f75-857f-f06bbf1f3a73',16,'禁止INSERT...SELECT',2,160,'none','nil',7,'UsingSelectNotAllowed','禁止INSERT ... SELECT ...语句。','none','',1,0,UNIX_TIMESTAMP()),
+('INS-L2-005','ab221728-8c92-4019-92e4-4cf0fc57941b',16,'INSERT时列类型、值是否匹配',2,160,'none','nil',5,'ColumnsValuesMustMatch','INSERT语句的列数量和值数量不匹配。','none','',1,0,UNIX_TIMESTAMP()),
+('INS-L3-001','d37860b1-d9d6-4c4f-9af6-7e93dd33c312',16,'INSERT时目标库必须已存在',1,160,'none','nil',5,'TargetDatabaseDoesNotExist','INSERT语句中指定的库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('INS-L3-002','75d3d38e-b32b-438c-b01c-761fff3b6786',16,'INSERT时目标表必须已存在',1,160,'none','nil',5,'TargetTableDoesNotExist','INSERT语句中指定的表\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('INS-L3-003','355540e6-733a-4b83-88e7-e04e838fa8c2',16,'INSERT时目标列必须已存在',1,160,'none','nil',4,'TargetColumnDoesNotExist','INSERT语句中插入的列\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('INS-L3-004','9f6cae35-89b7-46ae-ae5c-a7962f473e44',16,'INSERT时非空列是否有值',1,160,'none','nil',4,'ValueForNotNullColumnRequired','INSERT语句没有为非空列\"%s\"提供值。','none','',1,0,UNIX_TIMESTAMP()),
+('MDB-L2-001','78ab3a56-28dd-463d-b8dd-bcb1929d2314',10,'修改数据库时允许的字符集',2,101,'in','[\"utf8mb4\"]',7,'AvailableCharsets','改库禁用字符集\"%s\"，请使用\"%s\"。','checkboxes/key=charsets','',1,0,UNIX_TIMESTAMP()),
+('MDB-L2-002','a28e9a5d-2d26-433b-b4c6-fb68faa05daa',10,'修改数据库时允许的排序规则',2,101,'in','[\"utf8mb4_unicode_ci\", \"utf8mb4_general_ci\", \"utf8mb4_bin\"]',7,'AvailableCollates','改库禁用排序规则\"%s\"，请使用\"%s\"。','checkboxes/key=collates','',1,0,UNIX_TIMESTAMP()),
+('MDB-L2-003','3c10b2cc-ebfb-4bf6-8543-44a91506d86b',10,'修改数据库时字符集与排序规则必须匹配',2,101,'none','nil',5,'CharsetCollateMustMatch','改库使用的字符集\"%s\"和排序规则\"%s\"不匹配，请查阅官方文档。','none','',1,0,UNIX_TIMESTAMP()),
+('MDB-L2-004','c370fd11-b92a-4619-a64a-2deae0873224',10,'修改数据库时目标库必须已存在',1,101,'none','nil',5,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('MEV-L3-001','d29700b0-1588-4034-aba9-1c2dbc92be05',21,'修改事件时目标库必须已存在',1,211,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('MEV-L3-002','65003024-7556-4812-b2f6-8aceb77aa052',21,'修改事件时目标事件必须已存在',1,211,'none','nil',4,'TargetEventDoesNotExist','目标事件\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('MEV-L3-003','e5d1a286-81bb-4a02-ba35-37ff185c8f79',21,'修改事件时事件中的语句必须通过审核',1,211,'none','nil',5,'EventBodyValidated','事件\"%s\"中的语句\"%s\"：%s','none','',1,0,UNIX_TIMESTAMP()),
+('MFU-L3-001','d3ed9d76-9825-44f3-af9c-b61b9f704e43',19,'修改函数时目标库必须已存在',1,191,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('MFU-L3-002','ddec411d-9b2c-427b-965e-86e65d7edfce',19,'修改函数时目标函数必须已存在',1,191,'none','nil',4,'TargetFuncDoesNotExist','目标函数\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('MSC-L1-001','ddcee0ba-b76c-4037-8ea8-49616c381f8f',23,'禁止LOCK TABLE',1,230,'none','nil',5,'LockTableProhibited','禁止LOCK TABLE。','none','',1,0,UNIX_TIMESTAMP()),
+('MSC-L1-002','f64e59c8-327e-4d77-9b60-be99d6978421',23,'禁止FLUSH TABLE',1,230,'none','nil',5,'FlushTableProhibited','禁止FLUSH操作。','none','',1,0,UNIX_TIMESTAMP()),
+('MSC-L1-003','21194aaf-b646-4817-a30d-39e6d1c655b5',23,'禁止TRUNCATE TABLE',1,230,'none','nil',5,'TruncateTableProhibited','禁止TRUNCATE TABLE。','none','',1,0,UNIX_TIMESTAMP()),
+('MSC-L1-004','f377fbd7-4256-4807-af22-8f8b69d5f6a5',23,'对同一个表/库的操作需要合并',1,230,'none','nil',5,'MergeRequired','对同一个对象\"%s\"的多个操作需要合并。','none','',1,0,UNIX_TIMESTAMP()),
+('MSC-L1-005','364f7fb1-e5b8-4308-8166-7849b9e6a08a',23,'禁止PURGE LOG',1,230,'none','nil',5,'PurgeLogsProhibited','禁止PURGE LOGS。','none','',1,0,UNIX_TIMESTAMP()),
+('MSC-L1-006','e2e2492e-f0fa-4593-a72b-2a9187bd2005',23,'禁止UNLOCK TABLE',1,230,'none','nil',5,'UnlockTableProhibited','禁止UNLOCK TABLES。','none','',1,0,UNIX_TIMESTAMP()),
+('MSC-L1-007','6d4bce85-5608-4b1a-a810-36a5db17435d',23,'禁止KILL',1,230,'none','nil',5,'KillProhibited','禁止KILL。','none','',1,0,UNIX_TIMESTAMP()),
+('MSC-L1-008','404354d1-9592-45d9-9f06-81740c5ad3c3',23,'禁止同时出现DDL、DML',1,230,'none','nil',5,'SplitRequired','禁止在一个工单中同时出现DML和DDL操作，请分开多个工单提交。','none','',1,0,UNIX_TIMESTAMP()),
+('MSP-L3-001','841f30ac-bd86-4088-a958-c0bf2e7d26fd',22,'修改存储过程时目标库必须已存在',1,221,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('MSP-L3-002','a46eb708-2fdb-495a-8303-ebe72781c875',22,'修改存储过程时目标存储过程必须已存在',1,221,'none','nil',4,'TargetProcDoesNotExist','目标存储过程\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-001','b17d640a-16fd-4628-a845-d62cdb582884',12,'改表允许的字符集',2,120,'in','[\"utf8mb4\"]',7,'AvailableCharsets','表禁用字符集\"%s\"，请使用\"%s\"。','checkboxes/key=charsets','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-002','11460f45-3517-42d6-91ec-5b36d041cf4e',12,'改表允许的校验规则',2,120,'in','[\"utf8mb4_unicode_ci\", \"utf8mb4_general_ci\", \"utf8mb4_bin\"]',7,'AvailableCollates','表禁用排序规则\"%s\"，请使用\"%s\"。','checkboxes/key=collates','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-003','b4ae4473-9677-4db5-b2d4-7987fe904e88',12,'表的字符集与排序规则必须匹配',2,120,'none','nil',5,'TableCharsetCollateMustMatch','表字符集\"%s\"和排序规则\"%s\"不匹配，请查阅官方文档。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-004','aee962c0-411e-4875-9291-d708473a1286',12,'改表允许的存储引擎',2,120,'in','[\"innodb\", \"tokudb\", \"rocksdb\", \"archive\"]',7,'AvailableEngines','不支持的存储引擎\"%s\"，请使用\"%s\"。','checkboxes/key=engines','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-005','07ca1452-fa25-4e33-b2dd-6623215a018e',12,'列名必须符合命名规范',2,120,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'ColumnNameQualified','列名\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-006','8463928d-8d32-47fd-a882-efa93983ea3d',12,'列名必须小写',2,120,'regexp','^[_a-z0-9]+$',7,'ColumnNameLowerCaseRequired','列名\"%s\"中含有除小写字母、数字和下划线以外的字符。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-007','d4a3a9c9-c16f-485a-a741-2c60ffda1b10',12,'列名最大长度',2,120,'lte','20',7,'ColumnNameMaxLength','列名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-008','8ceb2794-a706-406d-859d-4d7fe67ed970',12,'列禁用的数据类型',2,120,'not-in','[\"bit\", \"enum\", \"set\", \"double\", \"real\", \"float\"]',7,'ColumnUnwantedTypes','列\"%s\"使用了不期望的数据类型\"%s\"，请避免使用\"%s\"数据类型。','checkboxes/key=data-types','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-009','f5b265d1-30c2-4c98-ab43-8876177c6ccb',12,'列必须有注释',2,120,'none','nil',5,'ColumnCommentRequired','列\"%s\"需要提供COMMENT注解。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-010','77130452-bf96-46f6-bb3e-8ce779d82dfc',12,'列允许的字符集',2,120,'in','[\"utf8mb4\", \"binary\"]',7,'ColumnAvailableCharsets','列\"%s\"禁用字符集\"%s\"，请使用\"%s\"。','checkboxes/key=charsets','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-011','d6401716-b714-42a9-b06c-10f515f69cde',12,'列允许的排序规则',2,120,'in','[\"utf8mb4_unicode_ci\", \"utf8mb4_general_ci\", \"utf8mb4_bin\", \"binary\"]',7,'ColumnAvailableCollates','列\"%s\"禁用排序规则\"%s\"，请使用\"%s\"。','checkboxes/key=collates','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-012','9c7f24ec-2d91-435f-b4ee-c31936670e6c',12,'列的字符集与排序规则必须匹配',2,120,'none','nil',5,'ColumnCharsetCollateMustMatch','列\"%s\"使用的字符集\"%s\"和排序规则\"%s\"不匹配，请查阅官方文档。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-013','76b8f1fd-7ebb-41fc-9b57-763e2590121d',12,'非空列必须有默认值',2,120,'none','nil',5,'ColumnNotNullWithDefaultRequired','列\"%s\"不允许为空，但没有指定默认值。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-014','90c0dece-d69c-4c56-a1a4-7824fe00fe22',12,'索引必须命名',2,120,'none','nil',7,'IndexNameExplicit','一个或多个索引没有提供索引名称。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-015','a28f4d53-4c81-4b73-a8a9-2bb441bbfb4b',12,'索引名标识符必须满足规则',2,120,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',7,'IndexNameQualified','索引名\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-016','b462710c-af50-4c96-85de-e3e6ddb20f47',12,'索引名必须小写',2,120,'regexp','^[_a-z0-9]+$',7,'IndexNameLowerCaseRequired','索引名\"%s\"含有除小写字母、数字和下划线以外的字符。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-017','543b5732-8a6d-4690-a02b-f3c8055435c5',12,'索引名最大长度',2,120,'lte','10',7,'IndexNameMaxLength','索引名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-018','9f752e8b-df62-46b0-bae4-e2c6941f3d88',12,'索引名前缀规则',2,120,'regexp','^index_[1-9][0-9]*$',7,'IndexNamePrefixRequired','索引名\"%s\"需要满足前缀正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-019','1885389b-4a1b-475b-a37b-582670a908f8',12,'唯一索引必须命名',2,120,'none','nil',7,'UniqueNameExplicit','一个或多个唯一索引没有提供索引名称。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-020','66dd81be-fd2c-4304-b782-86006c52fc5d',12,'唯一索引索名标识符必须符合规则',2,120,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',7,'UniqueNameQualified','唯一索引\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-021','b68ffe6e-8482-475b-afa4-90b26b44aeb4',12,'唯一索引名必须小写',2,120,'regexp','^[_a-z0-9]+$',7,'UniqueNameLowerCaseRequired','唯一索引\"%s\"含有除小写字母、数字和下划线以外的字符。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-022','9dbce3a5-46b7-4465-af71-e5b6291ebc7a',12,'唯一索引名不能超过最大长度',2,120,'lte','10',7,'UniqueNameMaxLength','唯一索引\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-023','7d77c21e-e0c8-48c0-a115-fc460e820c2b',12,'唯一索引名前缀必须符合规则',2,120,'regexp','^unique_[1-9][0-9]*$',7,'UniqueNamePrefixRequired','唯一索引\"%s\"需要满足前缀正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-024','690b58b4-9be8-4560-ade6-be534a34120b',12,'禁止外键',2,120,'none','nil',7,'ForeignKeyNotAllowed','禁止外键。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-025','98436226-6cb8-4776-bc0b-6bb53ac8cfb4',12,'外键是否显式命名',2,120,'none','nil',5,'ForeignKeyNameExplicit','没有为外键指定名称。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-026','8ee524ec-2359-4a40-ad91-6c3716dc07b6',12,'外键名标识符规则',2,120,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'ForeignKeyNameQualified','外键名\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-027','b107886d-b28d-41c9-9268-81b6fd631c1a',12,'外键名必须小写',2,120,'regexp','^[_a-z0-9]+$',5,'ForeignKeyNameLowerCaseRequired','外键名\"%s\"含有除小写字母、数字和下划线以外的字符。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-028','f3bfe2da-162e-46db-8be5-6e152a16696b',12,'外键名最大长度',2,120,'lte','25',5,'ForeignKeyNameMaxLength','外键名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-029','9df7d265-a301-4fa4-9933-87fe3f0fcae2',12,'外键名前缀规则',2,120,'regexp','^fk_[_a-zA-Z0-9]+$',5,'ForeignKeyNamePrefixRequired','外键名\"%s\"需要满足前缀正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-030','7024faae-3902-4af1-a849-55b09a7cd85a',12,'更名新表规则',2,120,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',7,'NewTableNameQualified','目标表\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-031','92ee30e7-d138-463e-873f-899fb2be4375',12,'更名新表必须小写',2,120,'regexp','^[_a-z0-9]+$',7,'NewTableNameLowerCaseRequired','目标表\"%s\"含有除小写字母、数字和下划线以外的字符。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-032','106db8a3-da54-423c-8104-ed92625f80f4',12,'更名新表最大长度',2,120,'lte','20',7,'NewTableNameMaxLength','目标表\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-033','b9a1a81d-c328-48b9-8919-d47f14f50906',12,'禁用全文索引',2,120,'none','nil',7,'FullTextIndexNotAllowed','禁止使用全文索引。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-034','40375d77-dea4-410b-b8de-9fe4960a138d',12,'索引必须命名',2,120,'none','nil',7,'FullTextIndexExplicit','一个或多个全文索引没有提供索引名称。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-035','36d7aa62-fa25-4aa5-a7fa-afb15479b037',12,'索引名标识符规则',2,120,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',7,'FullTextIndexNameQualified','全文索引\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-036','86dd974c-f6ca-475a-84c8-981d43f489ff',12,'索引名必须小写',2,120,'regexp','^[_a-z0-9]+$',7,'FullTextIndexNameLowerCaseRequired','全文索引\"%s\"含有除小写字母、数字和下划线以外的字符。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-037','b928f242-aacd-41b9-8347-aa80ab679746',12,'索引名不能超过最大长度',2,120,'lte','10',7,'FullTextIndexNameMaxLength','全文索引\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-038','f754c334-32cf-4c4c-9c46-92a666d24192',12,'索引名前缀必须匹配规则',2,120,'regexp','^ft_[1-9][0-9]*$',7,'FullTextIndexNamePrefixRequired','全文索引\"%s\"需要满足前缀正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-039','9280ff7c-ff2b-4c3e-bf6c-4886d49634d1',12,'单一索引最大列数',2,120,'lte','3',7,'MaxAllowedIndexColumnCount','索引\"%s\"索引的列数超出了规则允许的上限，请控制在%d个列以内。','number','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-040','5d0b8e27-3c4a-4f61-9e1d-7a2c6b9f0e13',12,'需要复制表的变更，目标表的最大行数',2,120,'lte','1000000',7,'CopyAlterMaxRows','表\"%s\"约有%d行，需要复制表的ALTER TABLE会阻塞写入，请控制在%d行以内或者使用在线变更工具。','number','',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-041','a84c1f90-6e2b-4d7a-b3f5-0c9e7d2a6b58',12,'需要复制表的变更，目标表的最大尺寸(MB)',2,120,'lte','1024',7,'CopyAlterMaxSize','表\"%s\"的大小约为%dMB，需要复制表的ALTER TABLE会阻塞写入，请控制在%dMB以内或者使用在线变更工具。','number','',1,0,UNIX_TIMESTAMP()),
+('MTB-L3-001','768fb105-d609-4b7b-8ff9-0d3854cabfff',12,'目标库必须已存在',1,120,'none','nil',5,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L3-002','aa482f88-074c-45ab-97b9-81509bd4567f',12,'目标表必须已存在',1,120,'none','nil',5,'TargetTableDoesNotExist','目标表\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L3-004','cfe017e3-339a-45a5-b821-825d656b85e8',12,'位置标记列必须已存在',1,120,'none','nil',5,'PositionColumnDoesNotExist','位置标记列\"%s\"(BEFORE/AFTER)不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L3-005','a130f2c4-e6c0-4dbd-9e55-51af3cb2ecab',12,'列名是否重复',2,120,'none','nil',5,'ColumnNameDuplicate','表\"%s\"中的定义了重复的列\"%s\"。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L3-006','6677109a-5798-44b9-9f8e-597cce76f168',12,'表允许的最大列数',2,120,'lte','25',6,'MaxAllowedColumnCount','表\"%s\"中定义%d个列，数量超出了规则允许的上限%d，请考虑拆分表。','number','',1,0,UNIX_TIMESTAMP()),
+('MTB-L3-007','5e179511-ae0a-4e55-abf6-23120adda97f',12,'仅允许一个时间戳类型的列',2,120,'none','nil',6,'MaxAllowedTimestampCount','表\"%s\"中的定义了多个时间戳列，请改用DATETIME类型。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L3-008','084397d9-86fd-415f-b37e-eaa44ba47be9',12,'删除列时目标列必须已存在',1,120,'none','nil',5,'ColumnNameDoesNotExist','目标列\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L3-013','b4fd2895-118e-4e80-b792-da47fdedcb20',12,'添加索引时索引必须不存在',1,120,'none','nil',5,'IndexNameDuplicate','索引名\"%s\"在表\"%s\"已经存在，请使用另外一个索引名称。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L3-014','08fb5c7f-f4f9-490f-a3a6-a8c4c82bd1a1',12,'覆盖索引检查',1,120,'none','nil',5,'IndexOverlayNotAllowed','目标表\"%s\"上已存在索引\"%s\"。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L3-015','c49c27b8-5d1d-4675-a734-8670eee65d9b',12,'同名外键检查',1,120,'none','nil',5,'IndexColumnDoesNotExist','目标表\"%s\"上已存在外键\"%s\"。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L3-016','afcc5ed3-1e4c-47df-b837-b6f3e5dee543',12,'添加外键时外键必须不存在',1,120,'none','nil',5,'IndexOnBlobColumnNotAllowed','目标表\"%s\"上已存在外键\"%s\"。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L3-017','a21ea970-7e26-420d-95f3-e1621f9c5630',12,'启用禁用KEY时KEY必须已存在',1,120,'none','nil',5,'IndexDoesNotExist','目标KEY\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L3-018','061df5d6-cd83-4b1a-abbe-9af10307e9dd',12,'删主键时主键必须存在',1,120,'none','nil',5,'PrimaryKeyDoesNotExist','目标表\"%s\"上未定义主键。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L3-020','7012f584-f7d5-492e-8cd4-37834845558c',12,'删外键时外键必须存在',1,120,'none','nil',5,'ForeignKeyDoesNotExist','目标表\"%s\"上未定义外键\"%s\"。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L3-021','2f2af8e5-2cf1-4ced-a1ec-a47c7c744178',12,'改名时目标表已存在',1,120,'none','nil',5,'TargetTableDuplicate','目标表\"%s\"已存在。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L3-022','86df78a8-ce02-4b06-81c0-f56a7d99ae09',12,'全文索引必须不存在',1,120,'none','nil',5,'FullTextIndexNameDuplicate','目标表\"%s\"上已存在全文索引\"%s\"。','none','',1,0,UNIX_TIMESTAMP()),
+('MTB-L3-023','c74c9e43-4600-42f5-8711-9b2114917438',12,'删全文索引时索引必须存在',1,120,'none','nil',4,'FullTextIndexNameDoesNotExist','目标表\"%s\"上未定义全文索引\"%s\"。','none','',1,0,UNIX_TIMESTAMP()),
+('MTG-L3-001','e6cbd545-643c-40c2-b2d9-924ef0f2691e',20,'修改触发器时目标库必须已存在',1,201,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('MTG-L3-002','10f58b5f-33ed-4ca6-b2af-ad2e01cf360b',20,'修改触发器时目标表必须已存在',1,201,'none','nil',4,'TargetTableDoesNotExist','目标表\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('MTG-L3-003','7dbfe6fb-e4eb-460d-af51-0427cfe4a600',20,'修改触发器时目标触发器必须已存在',1,201,'none','nil',4,'TargetTriggerDoesNotExist','目标触发器\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('MVW-L3-001','2da54955-a029-4ae5-8535-f77bb461973d',18,'修改视图时目标库必须已存在',1,181,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('MVW-L3-002','50edc670-7d6e-4c6f-92f0-24539074404b',18,'修改视图时目标视图必须已存在',1,181,'none','nil',4,'TargetViewDoesNotExist','目标视图\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('RIX-L3-001','43181c58-4cba-4209-99ad-4534c38e455a',15,'目标库必须已存在',1,151,'none','nil',5,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('RIX-L3-002','342f0510-6e41-4b63-871e-b0c7ae95715e',15,'目标表必须已存在',1,151,'none','nil',5,'TargetTableDoesNotExist','目标表\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('RIX-L3-003','6df03432-6248-4ff6-af35-04bd678e8812',15,'目标索引必须已存在',1,151,'none','nil',5,'TargetIndexDoesNotExist','目标索引\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('RPL-L2-001','f3578823-a5c8-4cdf-be09-55fc899d6875',16,'REPLACE时强制显式列申明',2,161,'none','nil',5,'ExplicitColumnRequired','禁止没有显式提供列列表的REPLACE语句。','none','',1,0,UNIX_TIMESTAMP()),
+('RPL-L2-002','41b437f0-eca7-46c3-9935-69c2bcacae70',16,'禁止REPLACE...SELECT',2,161,'none','nil',5,'UsingSelectNotAllowed','禁止REPLACE ... SELECT ...语句。','none','',1,0,UNIX_TIMESTAMP()),
+('RPL-L2-005','eaaa918c-611f-467c-9c65-03ff4124a998',16,'REPLACE时列类型、值是否匹配',2,161,'none','nil',5,'ColumnsValuesMustMatch','REPLACE语句的列数量和值数量不匹配。','none','',1,0,UNIX_TIMESTAMP()),
+('RPL-L3-001','5679453f-d160-4f0a-860f-5f6f5c50d505',16,'REPLACE时目标库必须已存在',1,161,'none','nil',4,'TargetDatabaseDoesNotExist','REPLACE语句中指定的库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('RPL-L3-002','612b1902-88c1-46e2-b139-5fec2b74c692',16,'REPLACE时目标表必须已存在',1,161,'none','nil',4,'TargetTableDoesNotExist','REPLACE语句中指定的表\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('RPL-L3-003','d54e672f-e360-4789-bd2d-5ca5f14fdf5a',16,'REPLACE时目标列必须已存在',1,161,'none','nil',4,'TargetColumnDoesNotExist','REPLACE语句中替换的列\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('RPL-L3-004','68b029cc-8fff-4da7-8a3e-62b124b779a3',16,'REPLACE时非空列是否有值',1,161,'none','nil',4,'ValueForNotNullColumnRequired','REPLACE语句没有为非空列\"%s\"提供值。','none','',1,0,UNIX_TIMESTAMP()),
+('RTB-L2-001','3ec2eaff-5471-405f-ab34-bc2158b8f884',13,'目标表跟源表是同一个表',1,130,'none','nil',5,'TablesIdentical','源表\"%s\"和目标表\"%s\"相同。','none','',1,0,UNIX_TIMESTAMP()),
+('RTB-L2-002','95c374ee-52a4-48f2-b24a-ac273e5e8f36',13,'目标表名规则',2,130,'regexp','^[a-zA-Z][_a-zA-Z0-9]*$',5,'TargetTableNameQualified','目标表名\"%s\"需要满足正则\"%s\"。','regexp','',1,0,UNIX_TIMESTAMP()),
+('RTB-L2-003','7daae444-78ea-46ac-a7e9-18149c725545',13,'目标表名必须小写',2,130,'none','^[_a-z0-9]+$',5,'TargetTableNameLowerCaseRequired','目标表名\"%s\"含有大写字母。','none','',1,0,UNIX_TIMESTAMP()),
+('RTB-L2-004','22e5db11-6afb-4e50-9fa1-c77c09dfb168',13,'目标表名最大长度',2,130,'lte','20',7,'TargetTableNameMaxLength','目标表名\"%s\"的长度超出了规则允许的上限，请控制在%d个字符以内。','number','',1,0,UNIX_TIMESTAMP()),
+('RTB-L3-001','8e75b778-e6c5-4d34-9edd-03097a4450d2',13,'源库必须已存在',1,130,'none','nil',4,'SourceDatabaseDoesNotExist','源库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('RTB-L3-002','8a1a4f9b-35da-4ff0-9ee2-d190b54f2ba5',13,'源表必须已存在',1,130,'none','nil',4,'SourceTableDoesNotExist','源表\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('RTB-L3-003','bb56fcfd-736f-4033-aa6f-2ce9ea1108f8',13,'目标库必须已存在',1,130,'none','nil',4,'TargetDatabaseDoesNotExist','目标库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('RTB-L3-004','2affbef0-4856-4eb3-a9a7-a1bf74a531c2',13,'目标表必须不存在',1,130,'none','nil',4,'TargetTableDoesNotExist','目标表\"%s\"已存在。','none','',1,0,UNIX_TIMESTAMP()),
+('SEL-L2-001','1b163a6b-633b-4285-978f-fefd0af3fb9c',17,'禁止没有WHERE的查询',2,170,'none','nil',5,'WithoutWhereNotAllowed','禁止没有WHERE从句的查询语句。','none','',1,0,UNIX_TIMESTAMP()),
+('SEL-L2-002','f9d57f6c-2ad0-4bb6-a4f8-a56fbff3cb65',17,'禁止没有LIMIT的查询',2,170,'none','nil',7,'WithoutLimitNotAllowed','禁止没有LIMIT从句的查询语句。','none','',1,0,UNIX_TIMESTAMP()),
+('SEL-L2-003','b44f75af-51f0-48f5-8d1c-b8f8b1eb225d',17,'禁止SELECT STAR',2,170,'none','nil',7,'UseWildcardNotAllowed','禁止SELECT语句使用通配符，需要显式指定需要查询的列。','none','',1,0,UNIX_TIMESTAMP()),
+('SEL-L2-004','e1018221-684c-49f0-baf4-2ea77c80fd3d',17,'禁止SELECT FOR UPDATE',2,170,'none','nil',5,'UseExplicitLockNotAllowed','禁止在SELECT语句中显示使用锁。','none','',1,0,UNIX_TIMESTAMP()),
+('SEL-L3-001','bbd20e8b-4eeb-4467-a481-75bde843913f',17,'目标数据库必须已存在',1,170,'none','nil',5,'TargetDatabaseDoesNotExist','SELECT语句中指定的库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('SEL-L3-002','2430a63b-67c1-48f6-8b34-a4f1161a63b1',17,'目标表必须已存在',1,170,'none','nil',5,'TargetTableDoesNotExist','SELECT语句中指定的表\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('SEL-L3-003','90f2b354-19c5-4d4d-be3f-8361ecbe968d',17,'目标列必须已存在',1,170,'none','nil',4,'TargetColumnDoesNotDoesNotExist','SELECT语句中返回的列\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('SEL-L3-004','d59e3dad-cf02-41b6-a306-987e1e07814e',17,'是否允许返回BLOB/TEXT列',1,170,'none','nil',4,'ReturnBlobOrTextNotAllowed','查询语句中指的列\"%s\"是BLOB/TEXT类型。','none','',1,0,UNIX_TIMESTAMP()),
+('UPD-L2-001','67fa90a8-5df6-479c-bfd4-11ab0bb9fa8b',16,'禁止没有WHERE的更新',1,162,'none','nil',5,'WithoutWhereNotAllowed','禁止没有WHERE从句的UPDATE语句。','none','',1,0,UNIX_TIMESTAMP()),
+('UPD-L3-001','022dbea9-af6f-44cb-b0ae-3f4712a014c8',16,'目标库必须已存在',1,162,'none','nil',5,'TargetDatabaseDoesNotExist','UPDATE语句中指定的库\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('UPD-L3-002','749e0a05-843b-4c8e-8d5c-6ef252b261ad',16,'目标表必须已存在',1,162,'none','nil',5,'TargetTableDoesNotExist','UPDATE语句中指定的表\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('UPD-L3-003','bdf11d37-9534-4553-826d-15d3b08f1059',16,'目标列必须已存在',1,162,'none','nil',4,'TargetColumnDoesNotExist','UPDATE语句中更新的列\"%s\"不存在。','none','',1,0,UNIX_TIMESTAMP()),
+('UPD-L3-005','a72f3d07-7512-46bb-a442-8a505610a72e',16,'允许单次更新的最大行数',1,162,'lte','1000',6,'MaxAllowedUpdateRows','单条UPDATE语句不得操作超过%d条记录。','number','',1,0,UNIX_TIMESTAMP()),
+('VER-L1-001','feb95aa0-52b7-41d4-9dac-a5a32eb17b78',24,'排序规则的版本要求',1,240,'none','{\"MySQL\": \"8.0.1\"}',5,'CollationVersionRequired','排序规则\"%s\"在目标群集%s上不可用，要求的版本为%s。','none','',1,0,UNIX_TIMESTAMP()),
+('VER-L1-002','ef54db74-620a-446c-a2f9-dabfa08ebf3c',24,'JSON数据类型的版本要求',1,240,'none','{\"MySQL\": \"5.7.8\", \"MariaDB\": \"10.2.7\", \"TiDB\": \"2.1.0\"}',5,'JSONColumnVersionRequired','列\"%s\"使用的JSON数据类型在目标群集%s上不可用，要求的版本为%s。','none','',1,0,UNIX_TIMESTAMP()),
+('VER-L1-003','a38986aa-8ac1-4897-8b03-a82a3315f2b6',24,'生成列的版本要求',1,240,'none','{\"MySQL\": \"5.7.6\", \"MariaDB\": \"5.2.0\", \"TiDB\": \"2.1.0\"}',5,'GeneratedColumnVersionRequired','生成列\"%s\"在目标群集%s上不可用，要求的版本为%s。','none','',1,0,UNIX_TIMESTAMP()),
+('VER-L1-004','8a00800f-ae3f-42c0-aa54-826d5e6c2fdb',24,'ALGORITHM=INSTANT的版本要求',1,240,'none','{\"MySQL\": \"8.0.12\", \"MariaDB\": \"10.3.2\"}',5,'InstantAlgorithmVersionRequired','\"%s\"在目标群集%s上不可用，要求的版本为%s。','none','',1,0,UNIX_TIMESTAMP()),
+('VER-L1-005','919168d4-3588-4241-954f-f7faa3e564b5',24,'存储程序的版本要求',1,240,'none','{\"MySQL\": \"5.1.6\", \"MariaDB\": \"5.1.6\"}',5,'StoredProgramVersionRequired','存储程序\"%s\"在目标群集%s上不可用，要求的版本为%s。','none','',1,0,UNIX_TIMESTAMP());
 UNLOCK TABLES;
 
 
+DROP TABLE IF EXISTS `mm_rule_profiles`;
+CREATE TABLE `mm_rule_profiles` (
+  `profile_id`  INT UNSIGNED
+                NOT NULL
+                AUTO_INCREMENT
+                COMMENT '自增主键',
+  `uuid`        CHAR(36)
+                NOT NULL
+                COMMENT 'UUID',
+  `name`        VARCHAR(50)
+                NOT NULL
+                COMMENT '配置名称',
+  `description` VARCHAR(150)
+                NOT NULL
+                DEFAULT ''
+                COMMENT '配置描述',
+  `version`     INT UNSIGNED
+                NOT NULL
+                COMMENT '版本',
+  `update_at`   INT UNSIGNED
+                COMMENT '修改时间',
+  `create_at`   INT UNSIGNED
+                NOT NULL
+                COMMENT '创建时间',
+
+  PRIMARY KEY (`profile_id`),
+  UNIQUE KEY `unique_1` (`uuid`),
+  UNIQUE KEY `unique_2` (`name`)
+)
+ENGINE = InnoDB
+CHARSET = utf8mb4
+COLLATE = utf8mb4_unicode_ci
+COMMENT = '规则配置表'
+;
+
+DROP TABLE IF EXISTS `mm_rule_overrides`;
+CREATE TABLE `mm_rule_overrides` (
+  `override_id` INT UNSIGNED
+                NOT NULL
+                AUTO_INCREMENT
+                COMMENT '自增主键',
+  `uuid`        CHAR(36)
+                NOT NULL
+                COMMENT 'UUID',
+  `profile_id`  INT UNSIGNED
+                NOT NULL
+                COMMENT '规则配置',
+  `rule_name`   CHAR(10)
+                NOT NULL
+                COMMENT '规则名称',
+  `level`       TINYINT UNSIGNED
+                DEFAULT NULL
+                COMMENT '严重级别，NULL表示不覆盖',
+  `values`      VARCHAR(150)
+                DEFAULT NULL
+                COMMENT '有效值，NULL表示不覆盖',
+  `bitwise`     TINYINT UNSIGNED
+                DEFAULT NULL
+                COMMENT '是否可用，NULL表示不覆盖',
+  `version`     INT UNSIGNED
+                NOT NULL
+                COMMENT '版本',
+  `update_at`   INT UNSIGNED
+                COMMENT '修改时间',
+  `create_at`   INT UNSIGNED
+                NOT NULL
+                COMMENT '创建时间',
+
+  PRIMARY KEY (`override_id`),
+  UNIQUE KEY `unique_1` (`uuid`),
+  UNIQUE KEY `unique_2` (`profile_id`,`rule_name`)
+)
+ENGINE = InnoDB
+CHARSET = utf8mb4
+COLLATE = utf8mb4_unicode_ci
+COMMENT = '规则覆盖表'
+;
+
+DROP TABLE IF EXISTS `mm_rule_bindings`;
+CREATE TABLE `mm_rule_bindings` (
+  `binding_id` INT UNSIGNED
+               NOT NULL
+               AUTO_INCREMENT
+               COMMENT '自增主键',
+  `uuid`       CHAR(36)
+               NOT NULL
+               COMMENT 'UUID',
+  `profile_id` INT UNSIGNED
+               NOT NULL
+               COMMENT '规则配置',
+  `cluster_id` INT UNSIGNED
+               NOT NULL
+               COMMENT '目标群集',
+  `database`   VARCHAR(50)
+               NOT NULL
+               DEFAULT ''
+               COMMENT '目标数据库，空表示整个群集',
+  `version`    INT UNSIGNED
+               NOT NULL
+               COMMENT '版本',
+  `update_at`  INT UNSIGNED
+               COMMENT '修改时间',
+  `create_at`  INT UNSIGNED
+               NOT NULL
+               COMMENT '创建时间',
+
+  PRIMARY KEY (`binding_id`),
+  UNIQUE KEY `unique_1` (`uuid`),
+  UNIQUE KEY `unique_2` (`cluster_id`,`database`),
+  KEY `index_1` (`profile_id`)
+)
+ENGINE = InnoDB
+CHARSET = utf8mb4
+COLLATE = utf8mb4_unicode_ci
+COMMENT = '规则配置适用范围表'
+;
+
+DROP TABLE IF EXISTS `mm_waivers`;
+CREATE TABLE `mm_waivers` (
+  `waiver_id`  INT UNSIGNED
+               NOT NULL
+               AUTO_INCREMENT
+               COMMENT '自增主键',
+  `uuid`       CHAR(36)
+               NOT NULL
+               COMMENT 'UUID',
+  `rule_name`  CHAR(10)
+               NOT NULL
+               COMMENT '豁免的规则',
+  `ticket_id`  INT UNSIGNED
+               NOT NULL
+               DEFAULT 0
+               COMMENT '豁免的工单，0表示豁免群集上的某个表',
+  `cluster_id` INT UNSIGNED
+               NOT NULL
+               COMMENT '目标群集',
+  `database`   VARCHAR(50)
+               NOT NULL
+               DEFAULT ''
+               COMMENT '目标数据库',
+  `table`      VARCHAR(75)
+               NOT NULL
+               DEFAULT ''
+               COMMENT '目标表',
+  `reason`     VARCHAR(150)
+               NOT NULL
+               COMMENT '豁免的理由',
+  `user_id`    INT UNSIGNED
+               NOT NULL
+               COMMENT '批准豁免的管理员',
+  `expire_at`  INT UNSIGNED
+               NOT NULL
+               COMMENT '过期时间',
+  `version`    INT UNSIGNED
+               NOT NULL
+               COMMENT '版本',
+  `update_at`  INT UNSIGNED
+               COMMENT '修改时间',
+  `create_at`  INT UNSIGNED
+               NOT NULL
+               COMMENT '创建时间',
+
+  PRIMARY KEY (`waiver_id`),
+  UNIQUE KEY `unique_1` (`uuid`),
+  KEY `index_1` (`rule_name`),
+  KEY `index_2` (`ticket_id`),
+  KEY `index_3` (`cluster_id`)
+)
+ENGINE = InnoDB
+CHARSET = utf8mb4
+COLLATE = utf8mb4_unicode_ci
+COMMENT = '规则豁免表'
+;
+
 DROP TABLE IF EXISTS `mm_statements`;
 CREATE TABLE `mm_statements` (
   `ticket_id`     INT UNSIGNED
@@ -978,6 +1182,26 @@ CREATE TABLE `mm_statements` (
                   COMMENT '执行结果',
   `rows_affected` INT UNSIGNED
                   COMMENT '在服务器正确执行后影响的行数',
+  `hooks`         TEXT
+                  COMMENT 'gh-ost回调记录',
+  `executor`      VARCHAR(10)
+                  NOT NULL
+                  DEFAULT ''
+                  COMMENT '指定的执行方式',
+  `rollback`      MEDIUMTEXT
+                  COMMENT '回滚语句',
+  `skip_reason`   VARCHAR(150)
+                  NOT NULL
+                  DEFAULT ''
+                  COMMENT '跳过语句的理由',
+  `backup_truncated`   TINYINT(1)
+                  NOT NULL
+                  DEFAULT 0
+                  COMMENT '备份的行是否超过上限被截断',
+  `backup_key_changed` TINYINT(1)
+                  NOT NULL
+                  DEFAULT 0
+                  COMMENT 'UPDATE是否修改了主键或者唯一键',
   `version`       INT UNSIGNED
                   NOT NULL
                   COMMENT '版本',
@@ -44,3 +44,37 @@ CHARSET = utf8mb4
 COLLATE = utf8mb4_unicode_ci
 COMMENT = '回滚信息记录表'
 ;
+
+DROP TABLE IF EXISTS `$_$halo_row_backups$_$`;
+CREATE TABLE `$_$halo_row_backups$_$` (
+   `backup_id`         BIGINT UNSIGNED
+                       NOT NULL
+                       AUTO_INCREMENT
+                       COMMENT '自增主键',
+   `statement_uuid`    CHAR(36)
+                       NOT NULL
+                       COMMENT '修改这一行的语句',
+   `database`          VARCHAR(75)
+                       NOT NULL
+                       COMMENT '库名',
+   `table`             VARCHAR(75)
+                       NOT NULL
+                       COMMENT '表名',
+   `column_names`      TEXT
+                       NOT NULL
+                       COMMENT '列名，JSON数组',
+   `column_values`     MEDIUMTEXT
+                       NOT NULL
+                       COMMENT '列值，JSON数组，元素是SQL字面量',
+   `create_at`         INT UNSIGNED
+                       NOT NULL
+                       COMMENT '备份时间',
+
+   PRIMARY KEY (`backup_id`),
+   KEY `index_1` (`statement_uuid`)
+)
+ENGINE = InnoDB
+CHARSET = utf8mb4
+COLLATE = utf8mb4_unicode_ci
+COMMENT = '语句执行之前备份的行'
+;
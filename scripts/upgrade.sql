@@ -0,0 +1,249 @@
+USE `halodb`;
+
+-- 新增的触发器规则：同一张表的同一时机和事件只能有一个触发器
+INSERT IGNORE INTO `mm_rules` (`name`, `uuid`, `group`, `description`, `level`, `vldr_group`, `operator`, `values`, `bitwise`, `func`, `message`, `element`, `version`, `update_at`, `create_at`) VALUES
+('CTG-L3-004','c0d3c079-681b-4f3c-8191-58f3ea9a12ef',20,'新建触发器时同一张表的同一时机和事件只能有一个触发器',2,200,'none','nil',5,'TriggerTimingEventDuplicated','表\"%s\"上已存在%s %s触发器\"%s\"。','none',1,0,UNIX_TIMESTAMP());
+
+-- 新增的事件规则：事件调度器必须开启，事件中的语句必须通过审核
+INSERT IGNORE INTO `mm_rules` (`name`, `uuid`, `group`, `description`, `level`, `vldr_group`, `operator`, `values`, `bitwise`, `func`, `message`, `element`, `version`, `update_at`, `create_at`) VALUES
+('CEV-L3-003','33273f7d-704e-4bd6-b5eb-d2f89e98d744',21,'创建事件时事件调度器必须开启',2,210,'none','nil',5,'EventSchedulerDisabled','事件调度器没有开启，事件\"%s\"不会被执行。','none',1,0,UNIX_TIMESTAMP()),
+('CEV-L3-004','c2a4d2b3-4e62-4b5d-8bf3-10c1d865a286',21,'创建事件时事件中的语句必须通过审核',1,210,'none','nil',5,'EventBodyValidated','事件\"%s\"中的语句\"%s\"：%s','none',1,0,UNIX_TIMESTAMP()),
+('MEV-L3-003','e5d1a286-81bb-4a02-ba35-37ff185c8f79',21,'修改事件时事件中的语句必须通过审核',1,211,'none','nil',5,'EventBodyValidated','事件\"%s\"中的语句\"%s\"：%s','none',1,0,UNIX_TIMESTAMP());
+
+-- 新增的存储过程规则：存储过程中的语句必须通过审核
+INSERT IGNORE INTO `mm_rules` (`name`, `uuid`, `group`, `description`, `level`, `vldr_group`, `operator`, `values`, `bitwise`, `func`, `message`, `element`, `version`, `update_at`, `create_at`) VALUES
+('CSP-L3-003','1bedeb04-2bdc-4d9e-9826-2223c930aa02',22,'新建存储过程时存储过程中的语句必须通过审核',1,220,'none','nil',5,'ProcBodyValidated','存储过程\"%s\"中的语句\"%s\"：%s','none',1,0,UNIX_TIMESTAMP());
+
+-- 声明式规则的路径表达式，规则的func为Evaluate时按路径取值比较，不需要编写代码
+ALTER TABLE `mm_rules`
+  ADD COLUMN `path` VARCHAR(150) NOT NULL DEFAULT '' COMMENT '声明式规则的路径表达式' AFTER `element`;
+INSERT IGNORE INTO `mm_rules` (`name`, `uuid`, `group`, `description`, `level`, `vldr_group`, `operator`, `values`, `bitwise`, `func`, `message`, `element`, `path`, `version`, `update_at`, `create_at`) VALUES
+('CTB-L2-052','2c4737fa-c6fe-441f-8f07-5d8e483f18c5',11,'禁止使用的列类型',2,110,'not-regexp','^(enum|set)\\(',4,'Evaluate','列类型\"%s\"被禁止使用。','regexp','CreateTable.Columns[*].Type',1,0,UNIX_TIMESTAMP());
+UPDATE `mm_rules` SET `func` = 'Evaluate', `path` = 'CreateTable.Columns[*].Type' WHERE `name` = 'CTB-L2-052';
+
+-- 规则的func必须与规则组上的方法同名，服务启动时会检查，
+-- 从旧版本升级时需要执行以下语句修正已有的规则
+
+-- 更名表时源库、源表、目标库、目标表的规则与实现对应
+UPDATE `mm_rules` SET `func` = 'SourceDatabaseDoesNotExist' WHERE `name` = 'RTB-L3-001';
+UPDATE `mm_rules` SET `func` = 'SourceTableDoesNotExist'    WHERE `name` = 'RTB-L3-002';
+UPDATE `mm_rules` SET `func` = 'TargetDatabaseDoesNotExist' WHERE `name` = 'RTB-L3-003';
+UPDATE `mm_rules` SET `func` = 'TargetTableDoesNotExist'    WHERE `name` = 'RTB-L3-004';
+
+-- 删除表的规则和全文索引命名规则使用了不存在的方法名
+UPDATE `mm_rules` SET `func` = 'TargetDatabaseDoesNotExist' WHERE `name` = 'DTB-L3-001';
+UPDATE `mm_rules` SET `func` = 'TargetTableDoesNotExist'    WHERE `name` = 'DTB-L3-002';
+UPDATE `mm_rules` SET `func` = 'FullTextIndexExplicit'      WHERE `name` = 'MTB-L2-034';
+
+-- 规则配置：按群集或者数据库覆盖规则的级别、取值和状态位，覆盖的列为NULL表示不覆盖
+CREATE TABLE IF NOT EXISTS `mm_rule_profiles` (
+  `profile_id`  INT UNSIGNED
+                NOT NULL
+                AUTO_INCREMENT
+                COMMENT '自增主键',
+  `uuid`        CHAR(36)
+                NOT NULL
+                COMMENT 'UUID',
+  `name`        VARCHAR(50)
+                NOT NULL
+                COMMENT '配置名称',
+  `description` VARCHAR(150)
+                NOT NULL
+                DEFAULT ''
+                COMMENT '配置描述',
+  `version`     INT UNSIGNED
+                NOT NULL
+                COMMENT '版本',
+  `update_at`   INT UNSIGNED
+                COMMENT '修改时间',
+  `create_at`   INT UNSIGNED
+                NOT NULL
+                COMMENT '创建时间',
+
+  PRIMARY KEY (`profile_id`),
+  UNIQUE KEY `unique_1` (`uuid`),
+  UNIQUE KEY `unique_2` (`name`)
+)
+ENGINE = InnoDB
+CHARSET = utf8mb4
+COLLATE = utf8mb4_unicode_ci
+COMMENT = '规则配置表'
+;
+
+CREATE TABLE IF NOT EXISTS `mm_rule_overrides` (
+  `override_id` INT UNSIGNED
+                NOT NULL
+                AUTO_INCREMENT
+                COMMENT '自增主键',
+  `uuid`        CHAR(36)
+                NOT NULL
+                COMMENT 'UUID',
+  `profile_id`  INT UNSIGNED
+                NOT NULL
+                COMMENT '规则配置',
+  `rule_name`   CHAR(10)
+                NOT NULL
+                COMMENT '规则名称',
+  `level`       TINYINT UNSIGNED
+                DEFAULT NULL
+                COMMENT '严重级别，NULL表示不覆盖',
+  `values`      VARCHAR(150)
+                DEFAULT NULL
+                COMMENT '有效值，NULL表示不覆盖',
+  `bitwise`     TINYINT UNSIGNED
+                DEFAULT NULL
+                COMMENT '是否可用，NULL表示不覆盖',
+  `version`     INT UNSIGNED
+                NOT NULL
+                COMMENT '版本',
+  `update_at`   INT UNSIGNED
+                COMMENT '修改时间',
+  `create_at`   INT UNSIGNED
+                NOT NULL
+                COMMENT '创建时间',
+
+  PRIMARY KEY (`override_id`),
+  UNIQUE KEY `unique_1` (`uuid`),
+  UNIQUE KEY `unique_2` (`profile_id`,`rule_name`)
+)
+ENGINE = InnoDB
+CHARSET = utf8mb4
+COLLATE = utf8mb4_unicode_ci
+COMMENT = '规则覆盖表'
+;
+
+CREATE TABLE IF NOT EXISTS `mm_rule_bindings` (
+  `binding_id` INT UNSIGNED
+               NOT NULL
+               AUTO_INCREMENT
+               COMMENT '自增主键',
+  `uuid`       CHAR(36)
+               NOT NULL
+               COMMENT 'UUID',
+  `profile_id` INT UNSIGNED
+               NOT NULL
+               COMMENT '规则配置',
+  `cluster_id` INT UNSIGNED
+               NOT NULL
+               COMMENT '目标群集',
+  `database`   VARCHAR(50)
+               NOT NULL
+               DEFAULT ''
+               COMMENT '目标数据库，空表示整个群集',
+  `version`    INT UNSIGNED
+               NOT NULL
+               COMMENT '版本',
+  `update_at`  INT UNSIGNED
+               COMMENT '修改时间',
+  `create_at`  INT UNSIGNED
+               NOT NULL
+               COMMENT '创建时间',
+
+  PRIMARY KEY (`binding_id`),
+  UNIQUE KEY `unique_1` (`uuid`),
+  UNIQUE KEY `unique_2` (`cluster_id`,`database`),
+  KEY `index_1` (`profile_id`)
+)
+ENGINE = InnoDB
+CHARSET = utf8mb4
+COLLATE = utf8mb4_unicode_ci
+COMMENT = '规则配置适用范围表'
+;
+
+-- 规则豁免：按工单或者按群集上的表豁免某条规则，过期之后失效
+CREATE TABLE IF NOT EXISTS `mm_waivers` (
+  `waiver_id`  INT UNSIGNED
+               NOT NULL
+               AUTO_INCREMENT
+               COMMENT '自增主键',
+  `uuid`       CHAR(36)
+               NOT NULL
+               COMMENT 'UUID',
+  `rule_name`  CHAR(10)
+               NOT NULL
+               COMMENT '豁免的规则',
+  `ticket_id`  INT UNSIGNED
+               NOT NULL
+               DEFAULT 0
+               COMMENT '豁免的工单，0表示豁免群集上的某个表',
+  `cluster_id` INT UNSIGNED
+               NOT NULL
+               COMMENT '目标群集',
+  `database`   VARCHAR(50)
+               NOT NULL
+               DEFAULT ''
+               COMMENT '目标数据库',
+  `table`      VARCHAR(75)
+               NOT NULL
+               DEFAULT ''
+               COMMENT '目标表',
+  `reason`     VARCHAR(150)
+               NOT NULL
+               COMMENT '豁免的理由',
+  `user_id`    INT UNSIGNED
+               NOT NULL
+               COMMENT '批准豁免的管理员',
+  `expire_at`  INT UNSIGNED
+               NOT NULL
+               COMMENT '过期时间',
+  `version`    INT UNSIGNED
+               NOT NULL
+               COMMENT '版本',
+  `update_at`  INT UNSIGNED
+               COMMENT '修改时间',
+  `create_at`  INT UNSIGNED
+               NOT NULL
+               COMMENT '创建时间',
+
+  PRIMARY KEY (`waiver_id`),
+  UNIQUE KEY `unique_1` (`uuid`),
+  KEY `index_1` (`rule_name`),
+  KEY `index_2` (`ticket_id`),
+  KEY `index_3` (`cluster_id`)
+)
+ENGINE = InnoDB
+CHARSET = utf8mb4
+COLLATE = utf8mb4_unicode_ci
+COMMENT = '规则豁免表'
+;
+
+-- 群集的版本，定期刷新，版本兼容规则根据目标群集的版本检查语句使用的特性
+ALTER TABLE `mm_clusters`
+  ADD COLUMN `server_version`  VARCHAR(75)  NOT NULL DEFAULT '' COMMENT '服务器版本，VERSION()' AFTER `status`,
+  ADD COLUMN `version_comment` VARCHAR(150) NOT NULL DEFAULT '' COMMENT '服务器版本说明，@@version_comment' AFTER `server_version`;
+INSERT IGNORE INTO `mm_glossaries` (`group`, `key`, `value`, `uuid`, `description`, `version`, `update_at`, `create_at`) VALUES
+('rules.group',17,'版本兼容 规则分组','abcf3810-1ae0-4180-8ee6-a6a3fc940f66','用于检查目标群集版本不支持的特性的审核规则',1,1552357174, UNIX_TIMESTAMP());
+INSERT IGNORE INTO `mm_rules` (`name`, `uuid`, `group`, `description`, `level`, `vldr_group`, `operator`, `values`, `bitwise`, `func`, `message`, `element`, `version`, `update_at`, `create_at`) VALUES
+('VER-L1-001','feb95aa0-52b7-41d4-9dac-a5a32eb17b78',24,'排序规则的版本要求',1,240,'none','{\"MySQL\": \"8.0.1\"}',5,'CollationVersionRequired','排序规则\"%s\"在目标群集%s上不可用，要求的版本为%s。','none',1,0,UNIX_TIMESTAMP()),
+('VER-L1-002','ef54db74-620a-446c-a2f9-dabfa08ebf3c',24,'JSON数据类型的版本要求',1,240,'none','{\"MySQL\": \"5.7.8\", \"MariaDB\": \"10.2.7\", \"TiDB\": \"2.1.0\"}',5,'JSONColumnVersionRequired','列\"%s\"使用的JSON数据类型在目标群集%s上不可用，要求的版本为%s。','none',1,0,UNIX_TIMESTAMP()),
+('VER-L1-003','a38986aa-8ac1-4897-8b03-a82a3315f2b6',24,'生成列的版本要求',1,240,'none','{\"MySQL\": \"5.7.6\", \"MariaDB\": \"5.2.0\", \"TiDB\": \"2.1.0\"}',5,'GeneratedColumnVersionRequired','生成列\"%s\"在目标群集%s上不可用，要求的版本为%s。','none',1,0,UNIX_TIMESTAMP()),
+('VER-L1-004','8a00800f-ae3f-42c0-aa54-826d5e6c2fdb',24,'ALGORITHM=INSTANT的版本要求',1,240,'none','{\"MySQL\": \"8.0.12\", \"MariaDB\": \"10.3.2\"}',5,'InstantAlgorithmVersionRequired','\"%s\"在目标群集%s上不可用，要求的版本为%s。','none',1,0,UNIX_TIMESTAMP()),
+('VER-L1-005','919168d4-3588-4241-954f-f7faa3e564b5',24,'存储程序的版本要求',1,240,'none','{\"MySQL\": \"5.1.6\", \"MariaDB\": \"5.1.6\"}',5,'StoredProgramVersionRequired','存储程序\"%s\"在目标群集%s上不可用，要求的版本为%s。','none',1,0,UNIX_TIMESTAMP());
+
+-- 新增的修改表规则：需要复制表的ALTER TABLE限制目标表的行数和尺寸
+INSERT IGNORE INTO `mm_rules` (`name`, `uuid`, `group`, `description`, `level`, `vldr_group`, `operator`, `values`, `bitwise`, `func`, `message`, `element`, `version`, `update_at`, `create_at`) VALUES
+('MTB-L2-040','5d0b8e27-3c4a-4f61-9e1d-7a2c6b9f0e13',12,'需要复制表的变更，目标表的最大行数',2,120,'lte','1000000',7,'CopyAlterMaxRows','表\"%s\"约有%d行，需要复制表的ALTER TABLE会阻塞写入，请控制在%d行以内或者使用在线变更工具。','number',1,0,UNIX_TIMESTAMP()),
+('MTB-L2-041','a84c1f90-6e2b-4d7a-b3f5-0c9e7d2a6b58',12,'需要复制表的变更，目标表的最大尺寸(MB)',2,120,'lte','1024',7,'CopyAlterMaxSize','表\"%s\"的大小约为%dMB，需要复制表的ALTER TABLE会阻塞写入，请控制在%dMB以内或者使用在线变更工具。','number',1,0,UNIX_TIMESTAMP());
+
+-- gh-ost的回调记录，执行期间由halocli ghost写入
+ALTER TABLE `mm_statements`
+  ADD COLUMN `hooks` TEXT COMMENT 'gh-ost回调记录' AFTER `rows_affected`;
+
+-- 结构变更的执行方式，群集上是默认值，语句上来自halo:executor注释，空表示自动选择
+ALTER TABLE `mm_clusters`
+  ADD COLUMN `executor` VARCHAR(10) NOT NULL DEFAULT '' COMMENT '结构变更的执行方式(direct|gh-ost|pt-osc)，空表示自动选择' AFTER `version_comment`;
+ALTER TABLE `mm_statements`
+  ADD COLUMN `executor` VARCHAR(10) NOT NULL DEFAULT '' COMMENT '指定的执行方式' AFTER `hooks`;
+
+-- 执行成功之后生成的回滚语句
+ALTER TABLE `mm_statements`
+  ADD COLUMN `rollback` MEDIUMTEXT COMMENT '回滚语句' AFTER `executor`;
+
+-- 执行时跳过语句的理由，空表示没有跳过
+ALTER TABLE `mm_statements`
+  ADD COLUMN `skip_reason` VARCHAR(150) NOT NULL DEFAULT '' COMMENT '跳过语句的理由' AFTER `rollback`;
+
+-- 记录执行之前备份的行是否被截断，以及UPDATE是否修改了主键或者唯一键
+ALTER TABLE `mm_statements`
+  ADD COLUMN `backup_truncated`   TINYINT(1) NOT NULL DEFAULT 0 COMMENT '备份的行是否超过上限被截断' AFTER `skip_reason`,
+  ADD COLUMN `backup_key_changed` TINYINT(1) NOT NULL DEFAULT 0 COMMENT 'UPDATE是否修改了主键或者唯一键' AFTER `backup_truncated`;
@@ -0,0 +1,157 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 差异中每一行的操作
+const (
+	diffEqual  = ' '
+	diffDelete = '-'
+	diffInsert = '+'
+)
+
+// 差异的上下文行数
+const diffContext = 3
+
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// Diff 按行比较两段文本，返回统一格式(unified)的差异，内容相同时返回空
+func Diff(a, b string) string {
+	if a == b {
+		return ""
+	}
+	lines := diffLines(strings.Split(a, "\n"), strings.Split(b, "\n"))
+
+	// 找出所有变化的行，相距不超过两倍上下文的变化合并到同一个块
+	var sb strings.Builder
+	for i := 0; i < len(lines); {
+		if lines[i].op == diffEqual {
+			i++
+			continue
+		}
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end, equals := i, 0
+		for j := i; j < len(lines) && equals <= 2*diffContext; j++ {
+			if lines[j].op == diffEqual {
+				equals++
+				continue
+			}
+			end, equals = j, 0
+		}
+		stop := end + diffContext + 1
+		if stop > len(lines) {
+			stop = len(lines)
+		}
+		writeHunk(&sb, lines, start, stop)
+		i = stop
+	}
+	return sb.String()
+}
+
+// writeHunk 输出一个差异块，行号从1开始
+func writeHunk(sb *strings.Builder, lines []diffLine, start, stop int) {
+	aStart, bStart := 1, 1
+	for _, l := range lines[:start] {
+		if l.op != diffInsert {
+			aStart++
+		}
+		if l.op != diffDelete {
+			bStart++
+		}
+	}
+	aCount, bCount := 0, 0
+	for _, l := range lines[start:stop] {
+		if l.op != diffInsert {
+			aCount++
+		}
+		if l.op != diffDelete {
+			bCount++
+		}
+	}
+	// 没有行的一侧按照惯例使用前一行的行号
+	if aCount == 0 {
+		aStart--
+	}
+	if bCount == 0 {
+		bStart--
+	}
+	fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", aStart, aCount, bStart, bCount)
+	for _, l := range lines[start:stop] {
+		sb.WriteByte(l.op)
+		sb.WriteString(l.text)
+		sb.WriteByte('\n')
+	}
+}
+
+// diffLines 使用Myers算法计算最短的编辑序列
+func diffLines(a, b []string) []diffLine {
+	n, m := len(a), len(b)
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+2)
+	trace := [][]int{}
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		done := false
+		for k := -d; k <= d; k += 2 {
+			x := 0
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				done = true
+				break
+			}
+		}
+		if done {
+			break
+		}
+	}
+
+	// 从终点回溯，得到倒序的编辑序列
+	lines := []diffLine{}
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			lines = append(lines, diffLine{diffEqual, a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				lines = append(lines, diffLine{diffInsert, b[y-1]})
+			} else {
+				lines = append(lines, diffLine{diffDelete, a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+	return lines
+}
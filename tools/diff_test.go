@@ -0,0 +1,32 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	if d := Diff("a\nb", "a\nb"); d != "" {
+		t.Fatalf("expected no diff, got %q", d)
+	}
+
+	a := strings.Join([]string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"}, "\n")
+	b := strings.Join([]string{"1", "2", "3", "4", "five", "6", "7", "8", "9", "10", "11", "12", "13"}, "\n")
+	expected := "@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n" +
+		"@@ -10,3 +10,4 @@\n 10\n 11\n 12\n+13\n"
+	if d := Diff(a, b); d != expected {
+		t.Fatalf("unexpected diff:\n%s", d)
+	}
+
+	// 相距较近的变化合并到同一个块
+	expected = "@@ -1,4 +1,3 @@\n-x\n a\n-b\n+B\n c\n"
+	if d := Diff("x\na\nb\nc", "a\nB\nc"); d != expected {
+		t.Fatalf("unexpected diff:\n%s", d)
+	}
+
+	// 空文本也是一行
+	expected = "@@ -1,1 +1,1 @@\n-\n+a\n"
+	if d := Diff("", "a"); d != expected {
+		t.Fatalf("unexpected diff:\n%s", d)
+	}
+}
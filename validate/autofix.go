@@ -0,0 +1,631 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mia0x75/parser/ast"
+	"github.com/mia0x75/parser/charset"
+	"github.com/mia0x75/parser/format"
+	"github.com/mia0x75/parser/model"
+
+	"github.com/mia0x75/halo/models"
+)
+
+// Placeholder 自动补充的注释，提醒开发人员替换为有意义的内容
+const Placeholder = "TODO"
+
+// Fixer 自动修正函数，直接修改语句的语法树，并将所做的修正记录到s.Violations中，
+// 只处理能够机械修正的问题，无法确定如何修正时保持语句不变
+type Fixer func(ctx *Context, s *models.Statement, r *models.Rule)
+
+// 自动修正函数，按规则组和规则的func注册，只有建表和改表的规则可以自动修正
+var fixers = map[uint16]map[string]Fixer{
+	110: {
+		"AvailableCharsets":           fixCharset,
+		"AvailableCollates":           fixCollate,
+		"AvailableEngines":            fixEngine,
+		"TableNameLowerCaseRequired":  fixTableNameLowerCase,
+		"TableCommentRequired":        fixTableComment,
+		"ColumnCommentRequired":       fixColumnComment,
+		"PrimaryKeyNameExplicit":      fixPrimaryKeyName,
+		"IndexNameExplicit":           fixIndexName,
+		"IndexNameLowerCaseRequired":  fixIndexNameLowerCase,
+		"IndexNamePrefixRequired":     fixIndexNamePrefix,
+		"UniqueNameExplicit":          fixUniqueName,
+		"UniqueNameLowerCaseRequired": fixUniqueNameLowerCase,
+		"UniqueNamePrefixRequired":    fixUniqueNamePrefix,
+	},
+	120: {
+		"AvailableCharsets":           fixCharset,
+		"AvailableCollates":           fixCollate,
+		"AvailableEngines":            fixEngine,
+		"ColumnCommentRequired":       fixColumnComment,
+		"IndexNameExplicit":           fixIndexName,
+		"IndexNameLowerCaseRequired":  fixIndexNameLowerCase,
+		"IndexNamePrefixRequired":     fixIndexNamePrefix,
+		"UniqueNameExplicit":          fixUniqueName,
+		"UniqueNameLowerCaseRequired": fixUniqueNameLowerCase,
+		"UniqueNamePrefixRequired":    fixUniqueNamePrefix,
+	},
+}
+
+// RegisterFixer 注册自动修正函数，gid与规则的vldr_group对应，name与规则的func对应
+func RegisterFixer(gid uint16, name string, fn Fixer) error {
+	if name == "" || fn == nil {
+		return fmt.Errorf("错误代码: 1500, 错误信息: 修正函数`%s`没有实现。", name)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if _, ok := fixers[gid][name]; ok {
+		return fmt.Errorf("错误代码: 1500, 错误信息: 规则组%d的修正函数`%s`已经注册。", gid, name)
+	}
+	if fixers[gid] == nil {
+		fixers[gid] = map[string]Fixer{}
+	}
+	fixers[gid][name] = fn
+	return nil
+}
+
+// fixerOf 按规则组和名称查找修正函数
+func fixerOf(gid uint16, name string) Fixer {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return fixers[gid][name]
+}
+
+// fixGroup 语句对应的规则组，不能自动修正的语句返回0
+func fixGroup(node ast.StmtNode) uint16 {
+	switch node.(type) {
+	case *ast.CreateTableStmt:
+		return 110
+	case *ast.AlterTableStmt:
+		return 120
+	}
+	return 0
+}
+
+// Autofix 自动修正工单内容中能够机械修正的问题，返回修正之后的脚本和所做的修正，
+// 上下文中需要填充工单和元数据，rules是全部规则，按上下文的规则配置覆盖之后使用。
+// 修正过的语句重新生成SQL，并保留语句前面的注释，其他内容保持原样
+func Autofix(ctx *Context, rules []*models.Rule) (content string, fixes []*models.Clause, err error) {
+	content = ctx.Ticket.Content
+	nodes, err := Parse(content)
+	if err != nil {
+		return
+	}
+	ctx.Stmts = make([]*models.Statement, len(nodes))
+	for i, node := range nodes {
+		ctx.Stmts[i] = &models.Statement{
+			Sequence:   uint16(i + 1),
+			StmtNode:   node,
+			Violations: &models.Violations{},
+		}
+	}
+	ctx.Simulate()
+
+	before := make([]string, len(nodes))
+	for i, node := range nodes {
+		before[i] = restore(node)
+	}
+
+	L := ctx.Profile.Apply(rules)
+	sorted := make([]*models.Rule, len(L))
+	copy(sorted, L)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	for _, s := range ctx.Stmts {
+		gid := fixGroup(s.StmtNode)
+		if gid == 0 {
+			continue
+		}
+		for _, r := range sorted {
+			if r.VldrGroup != gid || r.Bitwise&1 != 1 {
+				continue
+			}
+			fn := fixerOf(gid, r.Func)
+			if fn == nil {
+				continue
+			}
+			// 被豁免的规则不做修正
+			if _, _, ok := ctx.waive(s, r); ok {
+				continue
+			}
+			fn(ctx, s, r)
+		}
+	}
+
+	var sb strings.Builder
+	offset := 0
+	for i, s := range ctx.Stmts {
+		text := strings.TrimSpace(s.StmtNode.Text())
+		pos := strings.Index(content[offset:], text)
+		if text == "" || pos < 0 {
+			continue
+		}
+		start := offset + pos
+		sb.WriteString(content[offset:start])
+		if after := restore(s.StmtNode); after != "" && after != before[i] {
+			sb.WriteString(leadingComments(text))
+			sb.WriteString(after)
+		} else {
+			sb.WriteString(text)
+		}
+		offset = start + len(text)
+	}
+	sb.WriteString(content[offset:])
+
+	Locate(content, ctx.Stmts)
+	for _, s := range ctx.Stmts {
+		fixes = append(fixes, s.Violations.Clauses()...)
+	}
+	content = sb.String()
+	return
+}
+
+// restore 重新生成语句的SQL，失败时返回空
+func restore(node ast.Node) string {
+	var sb strings.Builder
+	if err := node.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return ""
+	}
+	return sb.String()
+}
+
+// leadingComments 语句前面的注释，重新生成SQL时注释会丢失，其中可能有内联豁免
+func leadingComments(text string) string {
+	rest := text
+	for {
+		trimmed := strings.TrimLeft(rest, " \t\r\n")
+		switch {
+		case strings.HasPrefix(trimmed, "/*"):
+			end := strings.Index(trimmed, "*/")
+			if end < 0 {
+				return text[:len(text)-len(rest)]
+			}
+			rest = trimmed[end+2:]
+		case strings.HasPrefix(trimmed, "--"), strings.HasPrefix(trimmed, "#"):
+			end := strings.Index(trimmed, "\n")
+			if end < 0 {
+				return text[:len(text)-len(rest)]
+			}
+			rest = trimmed[end+1:]
+		default:
+			return text[:len(text)-len(trimmed)]
+		}
+	}
+}
+
+// fixed 记录一处修正
+func fixed(s *models.Statement, r *models.Rule, object, fix string) {
+	s.Violations.Append(&models.Clause{
+		Rule:        r.Name,
+		Level:       r.Level,
+		Description: r.Description,
+		Object:      object,
+		Fix:         fix,
+	})
+}
+
+// tableOptions 语句中的表选项，建表语句中缺少的选项可以补充，
+// 改表语句只修正已经出现的选项
+func tableOptions(node ast.StmtNode) (options *[]*ast.TableOption, add bool) {
+	switch x := node.(type) {
+	case *ast.CreateTableStmt:
+		// CREATE ... LIKE ... 或者 CREATE ... SELECT ...
+		if x.ReferTable != nil || x.Select != nil {
+			return nil, false
+		}
+		return &x.Options, true
+	case *ast.AlterTableStmt:
+		for _, spec := range x.Specs {
+			if spec.Tp == ast.AlterTableOption {
+				return &spec.Options, false
+			}
+		}
+	}
+	return nil, false
+}
+
+// columnDefs 语句中新定义的列
+func columnDefs(node ast.StmtNode) []*ast.ColumnDef {
+	switch x := node.(type) {
+	case *ast.CreateTableStmt:
+		return x.Cols
+	case *ast.AlterTableStmt:
+		L := []*ast.ColumnDef{}
+		for _, spec := range x.Specs {
+			switch spec.Tp {
+			case ast.AlterTableAddColumns, ast.AlterTableChangeColumn, ast.AlterTableModifyColumn:
+				L = append(L, spec.NewColumns...)
+			}
+		}
+		return L
+	}
+	return nil
+}
+
+// constraints 语句中新定义的索引和约束
+func constraints(node ast.StmtNode) []*ast.Constraint {
+	switch x := node.(type) {
+	case *ast.CreateTableStmt:
+		return x.Constraints
+	case *ast.AlterTableStmt:
+		L := []*ast.Constraint{}
+		for _, spec := range x.Specs {
+			if spec.Tp == ast.AlterTableAddConstraint && spec.Constraint != nil {
+				L = append(L, spec.Constraint)
+			}
+		}
+		return L
+	}
+	return nil
+}
+
+// usedNames 表上已经使用的索引名，包括语句中定义的和改表之前已经存在的，不区分大小写
+func usedNames(ctx *Context, s *models.Statement) map[string]bool {
+	used := map[string]bool{}
+	for _, c := range constraints(s.StmtNode) {
+		if c.Name != "" {
+			used[strings.ToLower(c.Name)] = true
+		}
+	}
+	if at, ok := s.StmtNode.(*ast.AlterTableStmt); ok {
+		database := at.Table.Schema.O
+		if database == "" {
+			database = ctx.Ticket.Database
+		}
+		if table := ctx.SchemaOf(s).Table(database, at.Table.Name.O); table != nil {
+			for name := range table.Indexes {
+				used[strings.ToLower(name)] = true
+			}
+		}
+	}
+	return used
+}
+
+// ruleValues 规则的可选值列表
+func ruleValues(r *models.Rule) []string {
+	var values []string
+	json.Unmarshal([]byte(r.Values), &values)
+	return values
+}
+
+// contains 不区分大小写的包含判断
+func contains(L []string, value string) bool {
+	for _, elem := range L {
+		if strings.EqualFold(elem, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// fixOption 将表选项修正为指定的值，选项的值已经允许时不做修改
+func fixOption(s *models.Statement, r *models.Rule, tp ast.TableOptionType, allowed []string, value, fix string) {
+	options, add := tableOptions(s.StmtNode)
+	if options == nil || value == "" {
+		return
+	}
+	for _, opt := range *options {
+		if opt.Tp != tp {
+			continue
+		}
+		if contains(allowed, opt.StrValue) {
+			return
+		}
+		fixed(s, r, opt.StrValue, fix)
+		opt.StrValue = value
+		return
+	}
+	if add {
+		fixed(s, r, "", fix)
+		*options = append(*options, &ast.TableOption{Tp: tp, StrValue: value})
+	}
+}
+
+// optionValue 表选项的值，没有该选项时返回空
+func optionValue(node ast.StmtNode, tp ast.TableOptionType) string {
+	if options, _ := tableOptions(node); options != nil {
+		for _, opt := range *options {
+			if opt.Tp == tp {
+				return opt.StrValue
+			}
+		}
+	}
+	return ""
+}
+
+// fixCharset 使用第一个允许的字符集
+// RULE: CTB-L2-001, MTB-L2-001
+func fixCharset(ctx *Context, s *models.Statement, r *models.Rule) {
+	if charsets := ruleValues(r); len(charsets) > 0 {
+		fixOption(s, r, ast.TableOptionCharset, charsets, charsets[0], fmt.Sprintf("DEFAULT CHARACTER SET %s", charsets[0]))
+	}
+}
+
+// fixCollate 使用第一个与字符集匹配的排序规则
+// RULE: CTB-L2-002, MTB-L2-002
+func fixCollate(ctx *Context, s *models.Statement, r *models.Rule) {
+	cs := optionValue(s.StmtNode, ast.TableOptionCharset)
+	for _, collate := range ruleValues(r) {
+		if cs == "" || charset.ValidCharsetAndCollation(cs, collate) {
+			fixOption(s, r, ast.TableOptionCollate, ruleValues(r), collate, fmt.Sprintf("DEFAULT COLLATE %s", collate))
+			return
+		}
+	}
+}
+
+// fixEngine 使用第一个允许的存储引擎
+// RULE: CTB-L2-004, MTB-L2-004
+func fixEngine(ctx *Context, s *models.Statement, r *models.Rule) {
+	if engines := ruleValues(r); len(engines) > 0 {
+		fixOption(s, r, ast.TableOptionEngine, engines, engines[0], fmt.Sprintf("ENGINE = %s", engines[0]))
+	}
+}
+
+// fixTableComment 为表补充占位的注释
+// RULE: CTB-L2-008
+func fixTableComment(ctx *Context, s *models.Statement, r *models.Rule) {
+	ct := s.StmtNode.(*ast.CreateTableStmt)
+	options, add := tableOptions(ct)
+	if options == nil {
+		return
+	}
+	fix := fmt.Sprintf("COMMENT '%s'", Placeholder)
+	for _, opt := range *options {
+		if opt.Tp != ast.TableOptionComment {
+			continue
+		}
+		if strings.TrimSpace(opt.StrValue) == "" {
+			fixed(s, r, ct.Table.Name.O, fix)
+			opt.StrValue = Placeholder
+		}
+		return
+	}
+	if add {
+		fixed(s, r, ct.Table.Name.O, fix)
+		*options = append(*options, &ast.TableOption{Tp: ast.TableOptionComment, StrValue: Placeholder})
+	}
+}
+
+// fixTableNameLowerCase 表名改为小写，工单中后续语句对该表的引用一起修改
+// RULE: CTB-L2-006
+func fixTableNameLowerCase(ctx *Context, s *models.Statement, r *models.Rule) {
+	ct := s.StmtNode.(*ast.CreateTableStmt)
+	from := ct.Table.Name.O
+	to := strings.ToLower(from)
+	if from == to || Match(r, to) != nil {
+		return
+	}
+	database := ct.Table.Schema.O
+	if database == "" {
+		database = ctx.Ticket.Database
+	}
+	fix := fmt.Sprintf("`%s` -> `%s`", from, to)
+	fixed(s, r, from, fix)
+	ct.Table.Name = model.NewCIStr(to)
+
+	for _, other := range ctx.Stmts {
+		if other.Sequence <= s.Sequence || other.StmtNode == nil {
+			continue
+		}
+		v := &tableRenamer{current: ctx.Ticket.Database, database: database, from: from, to: to}
+		other.StmtNode.Accept(v)
+		if v.renamed {
+			fixed(other, r, from, fix)
+		}
+	}
+}
+
+// tableRenamer 修改语句中引用的表名，表名区分大小写
+type tableRenamer struct {
+	current  string
+	database string
+	from     string
+	to       string
+	renamed  bool
+}
+
+// Enter 实现ast.Visitor接口
+func (v *tableRenamer) Enter(n ast.Node) (ast.Node, bool) {
+	if tn, ok := n.(*ast.TableName); ok && tn.Name.O == v.from {
+		database := tn.Schema.O
+		if database == "" {
+			database = v.current
+		}
+		if strings.EqualFold(database, v.database) {
+			tn.Name = model.NewCIStr(v.to)
+			v.renamed = true
+		}
+	}
+	return n, false
+}
+
+// Leave 实现ast.Visitor接口
+func (v *tableRenamer) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
+
+// fixColumnComment 为新定义的列补充占位的注释
+// RULE: CTB-L2-016, MTB-L2-009
+func fixColumnComment(ctx *Context, s *models.Statement, r *models.Rule) {
+	fix := fmt.Sprintf("COMMENT '%s'", Placeholder)
+	for _, col := range columnDefs(s.StmtNode) {
+		var option *ast.ColumnOption
+		for _, op := range col.Options {
+			if op.Tp == ast.ColumnOptionComment {
+				option = op
+				break
+			}
+		}
+		if option != nil {
+			if comment, ok := option.Expr.(ast.ValueExpr); ok {
+				if value, ok := comment.GetValue().(string); ok && strings.TrimSpace(value) != "" {
+					continue
+				}
+			}
+			option.Expr = ast.NewValueExpr(Placeholder)
+		} else {
+			col.Options = append(col.Options, &ast.ColumnOption{
+				Tp:   ast.ColumnOptionComment,
+				Expr: ast.NewValueExpr(Placeholder),
+			})
+		}
+		fixed(s, r, col.Name.Name.O, fix)
+	}
+}
+
+// fixPrimaryKeyName 主键命名为pk_表名
+// RULE: CTB-L2-027
+func fixPrimaryKeyName(ctx *Context, s *models.Statement, r *models.Rule) {
+	ct := s.StmtNode.(*ast.CreateTableStmt)
+	for _, c := range ct.Constraints {
+		if c.Tp != ast.ConstraintPrimaryKey || strings.TrimSpace(c.Name) != "" {
+			continue
+		}
+		c.Name = "pk_" + strings.ToLower(ct.Table.Name.O)
+		fixed(s, r, ct.Table.Name.O, fmt.Sprintf("PRIMARY KEY `%s`", c.Name))
+	}
+}
+
+// isIndex 普通索引
+func isIndex(c *ast.Constraint) bool {
+	return c.Tp == ast.ConstraintIndex || c.Tp == ast.ConstraintKey
+}
+
+// isUnique 唯一索引
+func isUnique(c *ast.Constraint) bool {
+	return c.Tp == ast.ConstraintUniq || c.Tp == ast.ConstraintUniqKey || c.Tp == ast.ConstraintUniqIndex
+}
+
+// nameIndexes 为没有名称的索引命名，名称由前缀和索引的列组成
+func nameIndexes(ctx *Context, s *models.Statement, r *models.Rule, match func(*ast.Constraint) bool, prefix string) {
+	used := usedNames(ctx, s)
+	for _, c := range constraints(s.StmtNode) {
+		if !match(c) || strings.TrimSpace(c.Name) != "" {
+			continue
+		}
+		columns := []string{}
+		for _, key := range c.Keys {
+			if key.Column != nil {
+				columns = append(columns, strings.ToLower(key.Column.Name.O))
+			}
+		}
+		name := prefix + strings.Join(columns, "_")
+		for i := 2; used[name]; i++ {
+			name = prefix + strings.Join(columns, "_") + "_" + strconv.Itoa(i)
+		}
+		used[name] = true
+		c.Name = name
+		fixed(s, r, strings.Join(columns, ","), fmt.Sprintf("`%s`", name))
+	}
+}
+
+// renameIndexes 修改不符合规则的索引名，rename返回空时表示无法修正
+func renameIndexes(ctx *Context, s *models.Statement, r *models.Rule, match func(*ast.Constraint) bool, rename func(name string, used map[string]bool) string) {
+	used := usedNames(ctx, s)
+	for _, c := range constraints(s.StmtNode) {
+		name := strings.TrimSpace(c.Name)
+		if !match(c) || name == "" || Match(r, name) == nil {
+			continue
+		}
+		to := rename(name, used)
+		if to == "" {
+			continue
+		}
+		used[strings.ToLower(to)] = true
+		c.Name = to
+		fixed(s, r, name, fmt.Sprintf("`%s` -> `%s`", name, to))
+	}
+}
+
+// lowerName 名称改为小写，改为小写之后仍然不符合规则时无法修正
+func lowerName(r *models.Rule) func(string, map[string]bool) string {
+	return func(name string, used map[string]bool) string {
+		to := strings.ToLower(name)
+		if Match(r, to) != nil {
+			return ""
+		}
+		return to
+	}
+}
+
+// prefixName 按前缀规则生成名称，优先在原名称前加上前缀，否则使用前缀加上最小的可用序号
+func prefixName(r *models.Rule) func(string, map[string]bool) string {
+	return func(name string, used map[string]bool) string {
+		re, err := regexp.Compile(r.Values)
+		if err != nil {
+			return ""
+		}
+		prefix := literalPrefix(r.Values)
+		if prefix == "" {
+			return ""
+		}
+		if to := prefix + name; re.MatchString(to) && !used[strings.ToLower(to)] {
+			return to
+		}
+		for i := 1; i < 1000; i++ {
+			if to := prefix + strconv.Itoa(i); re.MatchString(to) && !used[strings.ToLower(to)] {
+				return to
+			}
+		}
+		return ""
+	}
+}
+
+// literalPrefix 正则表达式开头的字面前缀，例如：^index_[1-9][0-9]*$的前缀是index_
+func literalPrefix(pattern string) string {
+	if !strings.HasPrefix(pattern, "^") {
+		return ""
+	}
+	prefix := []rune{}
+	for _, c := range pattern[1:] {
+		if c != '_' && (c < '0' || c > '9') && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			break
+		}
+		prefix = append(prefix, c)
+	}
+	return string(prefix)
+}
+
+// fixIndexName 为没有名称的索引命名
+// RULE: CTB-L2-032, MTB-L2-014
+func fixIndexName(ctx *Context, s *models.Statement, r *models.Rule) {
+	nameIndexes(ctx, s, r, isIndex, "idx_")
+}
+
+// fixUniqueName 为没有名称的唯一索引命名
+// RULE: CTB-L2-037, MTB-L2-019
+func fixUniqueName(ctx *Context, s *models.Statement, r *models.Rule) {
+	nameIndexes(ctx, s, r, isUnique, "uk_")
+}
+
+// fixIndexNameLowerCase 索引名改为小写
+// RULE: CTB-L2-034, MTB-L2-016
+func fixIndexNameLowerCase(ctx *Context, s *models.Statement, r *models.Rule) {
+	renameIndexes(ctx, s, r, isIndex, lowerName(r))
+}
+
+// fixUniqueNameLowerCase 唯一索引名改为小写
+// RULE: CTB-L2-039, MTB-L2-021
+func fixUniqueNameLowerCase(ctx *Context, s *models.Statement, r *models.Rule) {
+	renameIndexes(ctx, s, r, isUnique, lowerName(r))
+}
+
+// fixIndexNamePrefix 索引名改为符合前缀规则的名称
+// RULE: CTB-L2-036, MTB-L2-018
+func fixIndexNamePrefix(ctx *Context, s *models.Statement, r *models.Rule) {
+	renameIndexes(ctx, s, r, isIndex, prefixName(r))
+}
+
+// fixUniqueNamePrefix 唯一索引名改为符合前缀规则的名称
+// RULE: CTB-L2-041, MTB-L2-023
+func fixUniqueNamePrefix(ctx *Context, s *models.Statement, r *models.Rule) {
+	renameIndexes(ctx, s, r, isUnique, prefixName(r))
+}
@@ -0,0 +1,95 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/go-xorm/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mia0x75/halo/models"
+)
+
+func TestAutofix(t *testing.T) {
+	rules := []*models.Rule{
+		{Name: "CTB-L2-001", VldrGroup: 110, Func: "AvailableCharsets", Values: `["utf8mb4"]`, Bitwise: 7, Level: 2},
+		{Name: "CTB-L2-002", VldrGroup: 110, Func: "AvailableCollates", Values: `["utf8_bin", "utf8mb4_bin"]`, Bitwise: 7, Level: 2},
+		{Name: "CTB-L2-004", VldrGroup: 110, Func: "AvailableEngines", Values: `["innodb"]`, Bitwise: 7, Level: 2},
+		{Name: "CTB-L2-006", VldrGroup: 110, Func: "TableNameLowerCaseRequired", Values: `^[_a-z0-9]+$`, Bitwise: 7, Level: 2},
+		{Name: "CTB-L2-008", VldrGroup: 110, Func: "TableCommentRequired", Bitwise: 7, Level: 2},
+		{Name: "CTB-L2-016", VldrGroup: 110, Func: "ColumnCommentRequired", Bitwise: 7, Level: 2},
+		{Name: "CTB-L2-027", VldrGroup: 110, Func: "PrimaryKeyNameExplicit", Bitwise: 7, Level: 2},
+		{Name: "CTB-L2-032", VldrGroup: 110, Func: "IndexNameExplicit", Bitwise: 7, Level: 2},
+		{Name: "CTB-L2-036", VldrGroup: 110, Func: "IndexNamePrefixRequired", Values: `^index_[1-9][0-9]*$`, Bitwise: 7, Level: 2},
+		{Name: "MTB-L2-009", VldrGroup: 120, Func: "ColumnCommentRequired", Bitwise: 7, Level: 2},
+		{Name: "MTB-L2-016", VldrGroup: 120, Func: "IndexNameLowerCaseRequired", Values: `^[_a-z0-9]+$`, Bitwise: 7, Level: 2},
+		{Name: "MTB-L2-018", VldrGroup: 120, Func: "IndexNamePrefixRequired", Values: `^index_[1-9][0-9]*$`, Bitwise: 7, Level: 2},
+		// 没有启用的规则不做修正
+		{Name: "MTB-L2-004", VldrGroup: 120, Func: "AvailableEngines", Values: `["innodb"]`, Bitwise: 6, Level: 2},
+	}
+	content := "-- 用户表\n" +
+		"CREATE TABLE Users (id INT, name VARCHAR(10) COMMENT '姓名', PRIMARY KEY (id), KEY (name)) ENGINE=MyISAM;\n" +
+		"/* halo:ignore MTB-L2-009 */ ALTER TABLE Users ADD COLUMN c1 INT, ENGINE=MyISAM;\n" +
+		"ALTER TABLE t2 ADD INDEX Idx_C1 (c1), ADD COLUMN c2 INT;\n" +
+		"DROP TABLE t3;"
+
+	ctx := &Context{
+		Ticket: &models.Ticket{Database: "db1", Content: content},
+		Databases: []models.Database{
+			{Name: "db1"},
+		},
+		Tables: map[string][]*core.Table{
+			"db1": {
+				{Name: "t2", Indexes: map[string]*core.Index{"index_1": {Name: "index_1"}}},
+			},
+		},
+	}
+	fixed, fixes, err := Autofix(ctx, rules)
+	assert.NoError(t, err)
+	assert.Equal(t, "-- 用户表\n"+
+		"CREATE TABLE `users` (`id` INT COMMENT 'TODO',`name` VARCHAR(10) COMMENT '姓名',PRIMARY KEY `pk_users`(`id`),INDEX `index_1`(`name`)) "+
+		"ENGINE = innodb DEFAULT CHARACTER SET = UTF8MB4 DEFAULT COLLATE = UTF8MB4_BIN COMMENT = 'TODO';\n"+
+		"/* halo:ignore MTB-L2-009 */ ALTER TABLE `users` ADD COLUMN `c1` INT, ENGINE = MyISAM;\n"+
+		"ALTER TABLE `t2` ADD INDEX `index_2`(`c1`), ADD COLUMN `c2` INT COMMENT 'TODO';\n"+
+		"DROP TABLE t3;", fixed)
+
+	L := map[string]*models.Clause{}
+	for _, c := range fixes {
+		if _, ok := L[c.Rule+" "+c.Object]; !ok {
+			L[c.Rule+" "+c.Object] = c
+		}
+	}
+	assert.Equal(t, 13, len(fixes))
+	assert.Equal(t, "`Users` -> `users`", L["CTB-L2-006 Users"].Fix)
+	assert.Equal(t, 2, L["CTB-L2-006 Users"].Line)
+	assert.Equal(t, 14, L["CTB-L2-006 Users"].Column)
+	// 后续语句对该表的引用一起修改
+	assert.Equal(t, "CTB-L2-006", fixes[9].Rule)
+	assert.Equal(t, 3, fixes[9].Line)
+	assert.Equal(t, 42, fixes[9].Column)
+	assert.Equal(t, "ENGINE = innodb", L["CTB-L2-004 MyISAM"].Fix)
+	assert.Equal(t, "`idx_name`", L["CTB-L2-032 name"].Fix)
+	assert.Equal(t, "`idx_name` -> `index_1`", L["CTB-L2-036 idx_name"].Fix)
+	assert.Equal(t, "`Idx_C1` -> `idx_c1`", L["MTB-L2-016 Idx_C1"].Fix)
+	assert.Equal(t, "`idx_c1` -> `index_2`", L["MTB-L2-018 idx_c1"].Fix)
+	assert.Equal(t, 4, L["MTB-L2-018 idx_c1"].Line)
+	assert.Equal(t, "COMMENT 'TODO'", L["MTB-L2-009 c2"].Fix)
+	// 被豁免的规则没有修正
+	assert.Nil(t, L["MTB-L2-009 c1"])
+
+	// 修正之后的脚本仍然可以解析
+	_, err = Parse(fixed)
+	assert.NoError(t, err)
+}
+
+func TestPrefixName(t *testing.T) {
+	r := &models.Rule{Values: `^index_[1-9][0-9]*$`}
+	assert.Equal(t, "index_2", prefixName(r)("idx_c1", map[string]bool{"index_1": true}))
+	r = &models.Rule{Values: `^pk_[_a-zA-Z0-9]+$`}
+	assert.Equal(t, "pk_id", prefixName(r)("id", map[string]bool{}))
+	// 没有字面前缀时无法修正
+	r = &models.Rule{Values: `^(idx|index)_\w+$`}
+	assert.Equal(t, "", prefixName(r)("c1", map[string]bool{}))
+
+	assert.Equal(t, "-- a\n/* b */ ", leadingComments("-- a\n/* b */ CREATE TABLE t1 (id INT)"))
+	assert.Equal(t, "", leadingComments("CREATE TABLE t1 (id INT)"))
+}
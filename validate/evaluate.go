@@ -0,0 +1,380 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-xorm/core"
+	"github.com/mia0x75/parser/ast"
+	"github.com/mia0x75/parser/format"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mia0x75/halo/models"
+)
+
+// 声明式规则，规则的Func为Evaluate，Path为路径表达式，不需要为规则编写代码。
+//
+// 路径表达式以根对象开始，后面是以点号分隔的字段：
+//   - 根对象可以是语法树的语句类型，可以省略Stmt后缀，例如CreateTable对应CREATE TABLE语句；
+//     也可以是Table，表示语句访问的表在执行之前的结构
+//   - 字段使用结构体的导出字段或者没有参数的方法，不区分大小写，例如Table.Columns.Name
+//   - 数组使用[*]展开全部元素，使用[n]选取一个元素，没有下标时默认展开
+//   - 常用字段有别名，例如Columns对应Cols，Type对应Tp，因此可以写CreateTable.Columns[*].Type
+//
+// 比较符与已有规则一致：
+//   - in / not-in 值为JSON数组，不区分大小写
+//   - regexp / not-regexp 值为正则表达式，not-regexp表示不允许匹配
+//   - lte / gte 值为数字，字符串比较长度
+//   - exists 路径至少有一个值，not-exists 路径没有任何值
+//
+// 每一个不满足条件的值都会产生一条违规，消息中的%s替换为该值。
+
+// 字段的别名，按顺序查找
+var aliases = map[string][]string{
+	"columns": {"Cols", "NewColumns", "Columns"},
+	"type":    {"Tp"},
+	"table":   {"Table", "TableName"},
+}
+
+// operand 路径表达式取到的一个值
+type operand struct {
+	text    string
+	number  float64
+	numeric bool
+}
+
+// segment 路径表达式中的一段
+type segment struct {
+	name  string
+	index int // -1表示展开全部元素
+}
+
+// parsePath 解析路径表达式，返回根对象和后续的字段
+func parsePath(path string) (root string, segs []segment, err error) {
+	parts := strings.Split(strings.TrimSpace(path), ".")
+	for i, part := range parts {
+		seg := segment{name: part, index: -1}
+		if n := strings.Index(part, "["); n >= 0 {
+			if !strings.HasSuffix(part, "]") {
+				err = fmt.Errorf("`%s`不是一个有效的路径表达式。", path)
+				return
+			}
+			seg.name = part[:n]
+			if idx := part[n+1 : len(part)-1]; idx != "*" {
+				if seg.index, err = strconv.Atoi(idx); err != nil || seg.index < 0 {
+					err = fmt.Errorf("`%s`不是一个有效的路径表达式。", path)
+					return
+				}
+			}
+		}
+		if seg.name == "" {
+			err = fmt.Errorf("`%s`不是一个有效的路径表达式。", path)
+			return
+		}
+		if i == 0 {
+			root = seg.name
+			continue
+		}
+		segs = append(segs, seg)
+	}
+	return
+}
+
+// CheckPath 检查声明式规则的路径表达式，修改规则时使用
+func CheckPath(path string) error {
+	_, _, err := parsePath(path)
+	return err
+}
+
+// Evaluate 声明式规则的审核入口，所有规则组共用
+func (v *vldr) Evaluate(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	root, segs, err := parsePath(r.Path)
+	if err != nil {
+		log.Warnf("[W] RULE: %s, %s", r.Name, err.Error())
+		return
+	}
+
+	var roots []reflect.Value
+	if strings.EqualFold(root, "Table") {
+		for _, table := range v.targetTables(s.StmtNode) {
+			roots = append(roots, reflect.ValueOf(table))
+		}
+	} else {
+		name := reflect.TypeOf(s.StmtNode).Elem().Name()
+		if !strings.EqualFold(name, root) && !strings.EqualFold(name, root+"Stmt") {
+			// 规则不适用于当前语句
+			return
+		}
+		roots = append(roots, reflect.ValueOf(s.StmtNode))
+	}
+
+	operands := []operand{}
+	for _, elem := range roots {
+		operands = append(operands, walkPath(elem, segs)...)
+	}
+
+	for _, text := range compare(r, operands) {
+		description := r.Message
+		if strings.Contains(description, "%") {
+			description = fmt.Sprintf(r.Message, text)
+		}
+		c := &models.Clause{
+			Description: description,
+			Level:       r.Level,
+		}
+		s.Violations.Append(c)
+	}
+}
+
+// compare 返回不满足规则的值
+func compare(r *models.Rule, operands []operand) (failed []string) {
+	switch r.Operator {
+	case "exists":
+		if len(operands) == 0 {
+			failed = append(failed, r.Path)
+		}
+	case "not-exists":
+		for _, op := range operands {
+			failed = append(failed, op.text)
+		}
+	case "in", "not-in":
+		values := []string{}
+		if err := json.Unmarshal([]byte(r.Values), &values); err != nil {
+			log.Warnf("[W] RULE: %s, `%s`不是一个有效的JSON数组。", r.Name, r.Values)
+			return
+		}
+		for _, op := range operands {
+			found := false
+			for _, value := range values {
+				if strings.EqualFold(op.text, value) {
+					found = true
+					break
+				}
+			}
+			if found != (r.Operator == "in") {
+				failed = append(failed, op.text)
+			}
+		}
+	case "regexp", "not-regexp":
+		re, err := regexp.Compile(r.Values)
+		if err != nil {
+			log.Warnf("[W] RULE: %s, `%s`不是一个有的效正则表达式。", r.Name, r.Values)
+			return
+		}
+		for _, op := range operands {
+			if re.MatchString(op.text) != (r.Operator == "regexp") {
+				failed = append(failed, op.text)
+			}
+		}
+	case "lte", "gte":
+		threshold, err := strconv.ParseFloat(r.Values, 64)
+		if err != nil {
+			log.Warnf("[W] RULE: %s, `%s`不是一个有效的数字。", r.Name, r.Values)
+			return
+		}
+		for _, op := range operands {
+			n := op.number
+			if !op.numeric {
+				n = float64(utf8.RuneCountInString(op.text))
+			}
+			if (r.Operator == "lte" && n > threshold) || (r.Operator == "gte" && n < threshold) {
+				failed = append(failed, op.text)
+			}
+		}
+	default:
+		log.Warnf("[W] RULE: %s, 不支持的比较符`%s`。", r.Name, r.Operator)
+	}
+	return
+}
+
+// walkPath 沿着路径取值，数组展开，空指针忽略
+func walkPath(value reflect.Value, segs []segment) []operand {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if len(segs) == 0 {
+			if op, ok := leaf(value); ok {
+				return []operand{op}
+			}
+		}
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		// 没有下标的数组默认展开
+		return walkSlice(value, -1, segs)
+	case reflect.Map:
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		L := []operand{}
+		for _, key := range keys {
+			L = append(L, walkPath(value.MapIndex(key), segs)...)
+		}
+		return L
+	}
+
+	if len(segs) == 0 {
+		if op, ok := leaf(value); ok {
+			return []operand{op}
+		}
+		return nil
+	}
+
+	seg := segs[0]
+	next, ok := field(value, seg.name)
+	if !ok {
+		return nil
+	}
+	for next.Kind() == reflect.Ptr || next.Kind() == reflect.Interface {
+		if next.IsNil() {
+			return nil
+		}
+		if k := next.Elem().Kind(); k != reflect.Slice && k != reflect.Array {
+			break
+		}
+		next = next.Elem()
+	}
+	if next.Kind() == reflect.Slice || next.Kind() == reflect.Array {
+		return walkSlice(next, seg.index, segs[1:])
+	}
+	return walkPath(next, segs[1:])
+}
+
+func walkSlice(value reflect.Value, index int, segs []segment) []operand {
+	if index >= 0 {
+		if index >= value.Len() {
+			return nil
+		}
+		return walkPath(value.Index(index), segs)
+	}
+	L := []operand{}
+	for i := 0; i < value.Len(); i++ {
+		L = append(L, walkPath(value.Index(i), segs)...)
+	}
+	return L
+}
+
+// field 按名称查找字段或者没有参数的方法，不区分大小写，找不到时使用别名
+func field(value reflect.Value, name string) (reflect.Value, bool) {
+	names := append([]string{name}, aliases[strings.ToLower(name)]...)
+	for _, name := range names {
+		if value.Kind() == reflect.Struct {
+			if f := value.FieldByNameFunc(func(n string) bool {
+				return strings.EqualFold(n, name)
+			}); f.IsValid() && f.CanInterface() {
+				return f, true
+			}
+		}
+		if m, ok := method(value, name); ok {
+			return m, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func method(value reflect.Value, name string) (reflect.Value, bool) {
+	candidates := []reflect.Value{value}
+	if value.CanAddr() {
+		candidates = append(candidates, value.Addr())
+	}
+	for _, elem := range candidates {
+		t := elem.Type()
+		for i := 0; i < t.NumMethod(); i++ {
+			m := t.Method(i)
+			if !strings.EqualFold(m.Name, name) {
+				continue
+			}
+			// 只使用没有参数、只有一个返回值的方法
+			if m.Type.NumIn() != 1 || m.Type.NumOut() != 1 {
+				continue
+			}
+			return elem.Method(i).Call(nil)[0], true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// leaf 把路径末端的值转换为可以比较的值
+func leaf(value reflect.Value) (operand, bool) {
+	if !value.IsValid() || !value.CanInterface() {
+		return operand{}, false
+	}
+	if (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && value.IsNil() {
+		return operand{}, false
+	}
+	switch x := value.Interface().(type) {
+	case ast.Node:
+		// 名称不加反引号，方便比较
+		var sb strings.Builder
+		if err := x.Restore(format.NewRestoreCtx(format.RestoreStringSingleQuotes|format.RestoreKeyWordUppercase, &sb)); err != nil {
+			return operand{}, false
+		}
+		return operand{text: sb.String()}, true
+	case interface{ CompactStr() string }:
+		// 列的类型，例如varchar(10)
+		return operand{text: x.CompactStr()}, true
+	case core.SQLType:
+		return operand{text: x.Name}, true
+	case fmt.Stringer:
+		return operand{text: x.String()}, true
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return operand{text: value.String()}, true
+	case reflect.Bool:
+		return operand{text: strconv.FormatBool(value.Bool())}, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := value.Int()
+		return operand{text: strconv.FormatInt(n, 10), number: float64(n), numeric: true}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := value.Uint()
+		return operand{text: strconv.FormatUint(n, 10), number: float64(n), numeric: true}, true
+	case reflect.Float32, reflect.Float64:
+		n := value.Float()
+		return operand{text: strconv.FormatFloat(n, 'f', -1, 64), number: n, numeric: true}, true
+	}
+	return operand{}, false
+}
+
+// targetTables 语句访问的表在执行之前的结构，表不存在时忽略
+func (v *vldr) targetTables(node ast.Node) (tables []*core.Table) {
+	add := func(database, name string) {
+		if table := v.TableInfo(database, name); table != nil {
+			tables = append(tables, table)
+		}
+	}
+	if len(v.Vi) > 0 {
+		for _, vi := range v.Vi {
+			if vi.Table != nil {
+				add(vi.Database, vi.Table.Name)
+			}
+		}
+		return
+	}
+	// DDL语句的目标表在Table或者Tables字段
+	value := reflect.ValueOf(node).Elem()
+	if f := value.FieldByName("Table"); f.IsValid() && f.CanInterface() {
+		if tn, ok := f.Interface().(*ast.TableName); ok && tn != nil {
+			add(tn.Schema.O, tn.Name.O)
+		}
+	}
+	if f := value.FieldByName("Tables"); f.IsValid() && f.CanInterface() {
+		if L, ok := f.Interface().([]*ast.TableName); ok {
+			for _, tn := range L {
+				add(tn.Schema.O, tn.Name.O)
+			}
+		}
+	}
+	return
+}
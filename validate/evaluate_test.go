@@ -0,0 +1,89 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/go-xorm/core"
+	"github.com/mia0x75/parser"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mia0x75/halo/models"
+)
+
+func TestEvaluate(t *testing.T) {
+	p := parser.New()
+	sql := "CREATE TABLE t1 (id INT NOT NULL, c1 ENUM('a','b'), very_long_column_name VARCHAR(10), PRIMARY KEY (id)) ENGINE=MyISAM;" +
+		"UPDATE t1 SET c1 = 'a' WHERE id = 1;"
+	nodes, _, err := p.Parse(sql, "", "")
+	assert.NoError(t, err)
+
+	stmts := []*models.Statement{}
+	for i, node := range nodes {
+		stmts = append(stmts, &models.Statement{
+			Sequence:   uint16(i + 1),
+			StmtNode:   node,
+			Violations: &models.Violations{},
+		})
+	}
+	ctx := &Context{
+		Stmts:     stmts,
+		Ticket:    &models.Ticket{Database: "db1"},
+		Databases: []models.Database{{Name: "db1"}},
+		Tables:    map[string][]*core.Table{},
+	}
+	ctx.Simulate()
+
+	cases := []struct {
+		stmt     int
+		path     string
+		operator string
+		values   string
+		failed   []string
+	}{
+		{0, "CreateTable.Columns[*].Type", "not-regexp", `^(enum|set)\(`, []string{"enum('a','b')"}},
+		{0, "CreateTable.Columns[*].Name", "lte", "10", []string{"very_long_column_name"}},
+		{0, "CreateTable.Columns[1].Name", "in", `["C1"]`, nil},
+		{0, "CreateTableStmt.Table.Name", "regexp", "^t[0-9]+$", nil},
+		{0, "CreateTable.Constraints", "exists", "", nil},
+		{0, "CreateTable.Partition", "exists", "", []string{"CreateTable.Partition"}},
+		{0, "CreateTable.Options[*].StrValue", "not-in", `["MyISAM"]`, []string{"MyISAM"}},
+		{0, "AlterTable.Specs", "exists", "", nil},
+		// 更新之前表已经由前一条语句创建
+		{1, "Table.Columns.Name", "not-in", `["very_long_column_name"]`, []string{"very_long_column_name"}},
+		{1, "Table.Columns[0].SQLType", "in", `["INT"]`, nil},
+	}
+	for _, c := range cases {
+		s := stmts[c.stmt]
+		s.Violations = &models.Violations{}
+		v := &vldr{Ctx: ctx}
+		v.Seek(s)
+		v.Walk(s.StmtNode)
+		r := &models.Rule{
+			Name:     "TST-L2-001",
+			Func:     "Evaluate",
+			Path:     c.path,
+			Operator: c.operator,
+			Values:   c.values,
+			Message:  "%s",
+			Level:    2,
+		}
+		v.Evaluate(s, r)
+		failed := []string{}
+		for _, clause := range s.Violations.Clauses() {
+			failed = append(failed, clause.Description)
+		}
+		if c.failed == nil {
+			assert.Empty(t, failed, c.path)
+		} else {
+			assert.Equal(t, c.failed, failed, c.path)
+		}
+	}
+}
+
+func TestCheckPath(t *testing.T) {
+	assert.NoError(t, CheckPath("CreateTable.Columns[*].Type"))
+	assert.NoError(t, CheckPath("Table.Indices[0].Name"))
+	assert.Error(t, CheckPath(""))
+	assert.Error(t, CheckPath("CreateTable.Columns[x].Type"))
+	assert.Error(t, CheckPath("CreateTable..Type"))
+}
@@ -0,0 +1,222 @@
+package validate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mia0x75/halo/models"
+)
+
+// 规则测试用例的文件名，每个目录可以有自己的结构快照，没有时使用上级目录的快照
+const (
+	FixtureSnapshot = "schema.json" // halocli snapshot生成的JSON快照
+	FixtureDump     = "schema.sql"  // mysqldump --no-data生成的结构
+)
+
+// 测试用例中的指令，写在SQL注释中，例如：
+//
+//	-- database: db1
+//	-- version: 5.7.30
+//	-- expect: CTB-L2-006, CTB-L2-008
+//	-- absent: CTB-L2-001
+var directivePattern = regexp.MustCompile(`(?m)^\s*(?:--|#)\s*(database|version|expect|absent)\s*:\s*(.*?)\s*$`)
+
+// Fixture 规则测试用例，一个SQL文件就是一个用例，不需要连接任何数据库
+type Fixture struct {
+	File     string   // SQL文件的路径
+	Content  string   // SQL文件的内容
+	Database string   // 脚本的默认数据库
+	Version  string   // 目标群集的版本，覆盖结构文件中的版本
+	Expect   []string // 必须报告的规则
+	Absent   []string // 不能报告的规则
+	Schema   string   // 结构快照或者mysqldump文件的路径，为空时表示没有任何结构
+}
+
+// FixtureResult 测试用例的执行结果
+type FixtureResult struct {
+	Fixture    *Fixture
+	Reported   []string // 报告的规则，被豁免的规则不计算在内
+	Missing    []string // 期望报告但是没有报告的规则
+	Unexpected []string // 不期望报告但是报告了的规则
+	Err        error    // 用例本身的错误，例如语法错误或者结构文件无法读取
+}
+
+// Passed 用例是否通过
+func (r *FixtureResult) Passed() bool {
+	return r.Err == nil && len(r.Missing) == 0 && len(r.Unexpected) == 0
+}
+
+// ParseFixture 解析测试用例中的指令
+func ParseFixture(file string, content string) *Fixture {
+	f := &Fixture{
+		File:    file,
+		Content: content,
+	}
+	for _, match := range directivePattern.FindAllStringSubmatch(content, -1) {
+		switch match[1] {
+		case "database":
+			f.Database = match[2]
+		case "version":
+			f.Version = match[2]
+		case "expect":
+			f.Expect = append(f.Expect, ruleNames(match[2])...)
+		case "absent":
+			f.Absent = append(f.Absent, ruleNames(match[2])...)
+		}
+	}
+	return f
+}
+
+// ruleNames 逗号分隔的规则名称
+func ruleNames(s string) []string {
+	L := []string{}
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.ToUpper(strings.TrimSpace(name)); name != "" {
+			L = append(L, name)
+		}
+	}
+	return L
+}
+
+// LoadFixtures 递归读取目录中的测试用例，按路径排序
+func LoadFixtures(root string) ([]*Fixture, error) {
+	fixtures := []*Fixture{}
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(info.Name(), ".sql") || info.Name() == FixtureDump {
+			return nil
+		}
+		bs, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		f := ParseFixture(path, string(bs))
+		f.Schema = schemaOf(root, filepath.Dir(path))
+		fixtures = append(fixtures, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(fixtures, func(i, j int) bool {
+		return fixtures[i].File < fixtures[j].File
+	})
+	return fixtures, nil
+}
+
+// schemaOf 从用例所在的目录向上查找结构文件，直到根目录为止
+func schemaOf(root string, dir string) string {
+	root = filepath.Clean(root)
+	for {
+		for _, name := range []string{FixtureSnapshot, FixtureDump} {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return path
+			}
+		}
+		if dir == root || !strings.HasPrefix(dir, root) {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Run 使用审核引擎执行测试用例，规则来自规则缓存
+func (f *Fixture) Run() *FixtureResult {
+	result := &FixtureResult{
+		Fixture: f,
+	}
+
+	// 没有结构文件时相当于空的mysqldump文件
+	var p Provider = ParseDump("", f.Database)
+	switch {
+	case strings.HasSuffix(f.Schema, ".json"):
+		if p, result.Err = NewSnapshotProvider(f.Schema); result.Err != nil {
+			return result
+		}
+	case f.Schema != "":
+		if p, result.Err = NewDumpProvider(f.Schema, f.Database); result.Err != nil {
+			return result
+		}
+	}
+	ctx := &Context{
+		Ticket: &models.Ticket{Database: f.Database, Content: f.Content},
+	}
+	if result.Err = ctx.Load(p); result.Err != nil {
+		return result
+	}
+	if f.Version != "" {
+		if ctx.Version = ParseVersion(f.Version, ""); ctx.Version == nil {
+			result.Err = fmt.Errorf("错误代码: 1500, 错误信息: 无法识别的版本`%s`。", f.Version)
+			return result
+		}
+	}
+
+	nodes, err := Parse(f.Content)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	for i, node := range nodes {
+		ctx.Stmts = append(ctx.Stmts, &models.Statement{
+			Sequence:   uint16(i + 1),
+			Content:    strings.TrimSpace(node.Text()),
+			StmtNode:   node,
+			Violations: &models.Violations{},
+		})
+	}
+	RunContext(ctx)
+
+	reported := map[string]bool{}
+	for _, s := range ctx.Stmts {
+		for _, c := range s.Violations.Clauses() {
+			if c.Rule != "" && !c.Waived {
+				reported[c.Rule] = true
+			}
+		}
+	}
+	for name := range reported {
+		result.Reported = append(result.Reported, name)
+	}
+	sort.Strings(result.Reported)
+	for _, name := range f.Expect {
+		if !reported[name] {
+			result.Missing = append(result.Missing, name)
+		}
+	}
+	for _, name := range f.Absent {
+		if reported[name] {
+			result.Unexpected = append(result.Unexpected, name)
+		}
+	}
+	return result
+}
+
+// String 测试结果的一行摘要
+func (r *FixtureResult) String() string {
+	switch {
+	case r.Err != nil:
+		return fmt.Sprintf("ERROR %s: %s", r.Fixture.File, r.Err.Error())
+	case r.Passed():
+		return fmt.Sprintf("PASS  %s", r.Fixture.File)
+	}
+	L := []string{}
+	if len(r.Missing) > 0 {
+		L = append(L, fmt.Sprintf("missing %s", strings.Join(r.Missing, ", ")))
+	}
+	if len(r.Unexpected) > 0 {
+		L = append(L, fmt.Sprintf("unexpected %s", strings.Join(r.Unexpected, ", ")))
+	}
+	return fmt.Sprintf("FAIL  %s: %s", r.Fixture.File, strings.Join(L, "; "))
+}
@@ -0,0 +1,55 @@
+package validate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mia0x75/halo/caches"
+	"github.com/mia0x75/halo/models"
+)
+
+func TestParseFixture(t *testing.T) {
+	f := ParseFixture("t.sql", "-- database: db1\n# expect: ctb-l2-006, CTB-L2-008\n-- expect: CTB-L2-016\n"+
+		"  -- absent: CTB-L2-001\nCREATE TABLE t1 (id INT) COMMENT '-- expect: CTB-L2-999'")
+	assert.Equal(t, "db1", f.Database)
+	assert.Equal(t, []string{"CTB-L2-006", "CTB-L2-008", "CTB-L2-016"}, f.Expect)
+	assert.Equal(t, []string{"CTB-L2-001"}, f.Absent)
+}
+
+func TestFixtures(t *testing.T) {
+	bs, err := ioutil.ReadFile(filepath.Join("testdata", "rules.json"))
+	assert.NoError(t, err)
+	rules := []*models.Rule{}
+	assert.NoError(t, json.Unmarshal(bs, &rules))
+
+	caches.RulesMap.Lock()
+	origin := caches.RulesMap.M
+	caches.RulesMap.M = rules
+	caches.RulesMap.Unlock()
+	defer func() {
+		caches.RulesMap.Lock()
+		caches.RulesMap.M = origin
+		caches.RulesMap.Unlock()
+	}()
+
+	fixtures, err := LoadFixtures(filepath.Join("testdata", "fixtures"))
+	assert.NoError(t, err)
+	assert.Equal(t, 8, len(fixtures))
+	for _, f := range fixtures {
+		assert.Equal(t, filepath.Join("testdata", "fixtures", FixtureDump), f.Schema)
+		result := f.Run()
+		assert.True(t, result.Passed(), result.String())
+	}
+
+	// 期望不成立时报告缺少和多余的规则
+	f := ParseFixture("t.sql", "-- expect: CTB-L2-001\n-- absent: CTB-L2-006\nCREATE TABLE T1 (id INT) DEFAULT CHARSET=utf8mb4")
+	result := f.Run()
+	assert.False(t, result.Passed())
+	assert.Equal(t, []string{"CTB-L2-001"}, result.Missing)
+	assert.Equal(t, []string{"CTB-L2-006"}, result.Unexpected)
+	assert.Equal(t, "FAIL  t.sql: missing CTB-L2-001; unexpected CTB-L2-006", result.String())
+}
@@ -0,0 +1,158 @@
+package validate
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mia0x75/parser/ast"
+
+	"github.com/mia0x75/halo/models"
+)
+
+// Locate 根据工单的原始内容计算每个描述的行和列，有违反规则的对象时定位到对象在语句中第一次出现的位置，
+// 否则定位到语句的开始
+func Locate(content string, stmts []*models.Statement) {
+	for s, l := range locateStatements(content, stmts) {
+		if s.Violations == nil {
+			continue
+		}
+		for _, c := range s.Violations.Clauses() {
+			pos := l.start
+			if i := indexObject(l.text, c.Object); i >= 0 {
+				pos += i
+			}
+			c.Line, c.Column = position(content, pos)
+		}
+	}
+}
+
+// Lines 每条语句在工单原始内容中的起始行，与Locate使用相同的定位方法
+func Lines(content string, stmts []*models.Statement) map[*models.Statement]int {
+	lines := make(map[*models.Statement]int, len(stmts))
+	for s, l := range locateStatements(content, stmts) {
+		lines[s], _ = position(content, l.start)
+	}
+	return lines
+}
+
+// location 语句在工单原始内容中的起始偏移量和原始文本，文本经过了格式化无法找到时为空
+type location struct {
+	start int
+	text  string
+}
+
+// locateStatements 按照顺序在工单的原始内容中查找每条语句，相同的语句依次对应各自的位置
+func locateStatements(content string, stmts []*models.Statement) map[*models.Statement]location {
+	L := make([]*models.Statement, len(stmts))
+	copy(L, stmts)
+	sort.SliceStable(L, func(i, j int) bool {
+		return L[i].Sequence < L[j].Sequence
+	})
+
+	locations := make(map[*models.Statement]location, len(L))
+	offset := 0
+	for _, s := range L {
+		text := s.Content
+		if s.StmtNode != nil && strings.TrimSpace(s.StmtNode.Text()) != "" {
+			text = strings.TrimSpace(s.StmtNode.Text())
+		}
+		start := offset
+		if pos := strings.Index(content[offset:], text); pos >= 0 {
+			start = offset + pos
+			offset = start + len(text)
+		} else {
+			// 语句的文本经过了格式化，只能定位到上一条语句之后
+			text = ""
+		}
+		locations[s] = location{start: start, text: text}
+	}
+	return locations
+}
+
+// indexObject 对象名称在语句中第一次出现的位置，名称不区分大小写，不存在时返回-1
+func indexObject(text string, object string) int {
+	if text == "" || object == "" {
+		return -1
+	}
+	re, err := regexp.Compile(`(?i)(?:^|[^\w$])(` + regexp.QuoteMeta(object) + `)(?:$|[^\w$])`)
+	if err != nil {
+		return -1
+	}
+	loc := re.FindStringSubmatchIndex(text)
+	if loc == nil {
+		return -1
+	}
+	return loc[2]
+}
+
+// position 偏移量对应的行和列，都从1开始，列按照字符计算
+func position(content string, offset int) (int, int) {
+	if offset > len(content) {
+		offset = len(content)
+	}
+	line := strings.Count(content[:offset], "\n") + 1
+	column := utf8.RuneCountInString(content[strings.LastIndex(content[:offset], "\n")+1:offset]) + 1
+	return line, column
+}
+
+// targetOf 语句的目标对象，规则函数没有指定违反规则的对象时使用
+func targetOf(node ast.StmtNode) string {
+	switch x := node.(type) {
+	case nil:
+		return ""
+	case *ast.CreateDatabaseStmt:
+		return x.Name
+	case *ast.AlterDatabaseStmt:
+		return x.Name
+	case *ast.DropDatabaseStmt:
+		return x.Name
+	}
+	// 存储程序、触发器和事件
+	if v := reflect.Indirect(reflect.ValueOf(node)); v.Kind() == reflect.Struct {
+		if f := v.FieldByName("Name"); f.IsValid() {
+			if name, ok := f.Interface().(*ProgramName); ok && name != nil {
+				return name.Name
+			}
+		}
+	}
+	if L := tablesOf(node, ""); len(L) > 0 {
+		return L[0][1]
+	}
+	return ""
+}
+
+// tablesOf 语句中引用的全部表，没有指定数据库时使用工单的目标数据库
+func tablesOf(node ast.StmtNode, current string) [][2]string {
+	if node == nil {
+		return nil
+	}
+	tv := &tableVisitor{current: current}
+	node.Accept(tv)
+	return tv.tables
+}
+
+// tableVisitor 收集语句中出现的表名
+type tableVisitor struct {
+	current string
+	tables  [][2]string
+}
+
+// Enter 实现ast.Visitor接口
+func (tv *tableVisitor) Enter(n ast.Node) (ast.Node, bool) {
+	if tn, ok := n.(*ast.TableName); ok {
+		database := tn.Schema.O
+		if database == "" {
+			database = tv.current
+		}
+		tv.tables = append(tv.tables, [2]string{database, tn.Name.O})
+	}
+	return n, false
+}
+
+// Leave 实现ast.Visitor接口
+func (tv *tableVisitor) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
@@ -0,0 +1,107 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mia0x75/halo/models"
+)
+
+func TestLocate(t *testing.T) {
+	content := "-- 建表\nCREATE TABLE t1 (\n  id INT,\n  `Name` VARCHAR(10)\n);\n\n  ALTER TABLE t1 ADD COLUMN c1 INT;\nDROP TABLE t2;"
+	nodes, err := Parse(content)
+	assert.NoError(t, err)
+	stmts := []*models.Statement{}
+	for i, node := range nodes {
+		stmts = append(stmts, &models.Statement{
+			Sequence:   uint16(i + 1),
+			StmtNode:   node,
+			Violations: &models.Violations{},
+		})
+	}
+
+	v := &customVldr{}
+	v.SetContext(&Context{Ticket: &models.Ticket{Database: "db1", Content: content}})
+	fn := func(object string) func(ctx *Context, s *models.Statement, r *models.Rule) {
+		return func(ctx *Context, s *models.Statement, r *models.Rule) {
+			s.Violations.Append(&models.Clause{Description: r.Message, Level: r.Level, Object: object})
+		}
+	}
+	assert.NoError(t, RegisterRule("LocateColumn", fn("name")))
+	assert.NoError(t, RegisterRule("LocateTarget", fn("")))
+	defer func() {
+		mutex.Lock()
+		delete(rules, "LocateColumn")
+		delete(rules, "LocateTarget")
+		mutex.Unlock()
+	}()
+
+	assert.NoError(t, Call(v, "LocateColumn", stmts[0], &models.Rule{Name: "CTB-L2-010", Level: 2}))
+	assert.NoError(t, Call(v, "LocateTarget", stmts[1], &models.Rule{Name: "MTB-L2-001", Level: 1}))
+	assert.NoError(t, Call(v, "LocateTarget", stmts[2], &models.Rule{Name: "DTB-L2-001", Level: 1}))
+	Locate(content, stmts)
+
+	// 对象名称不区分大小写，定位到对象第一次出现的位置
+	c := stmts[0].Violations.Clauses()[0]
+	assert.Equal(t, "CTB-L2-010", c.Rule)
+	assert.Equal(t, "name", c.Object)
+	assert.Equal(t, 4, c.Line)
+	assert.Equal(t, 4, c.Column)
+
+	// 规则没有指定对象时使用语句的目标对象
+	c = stmts[1].Violations.Clauses()[0]
+	assert.Equal(t, "MTB-L2-001", c.Rule)
+	assert.Equal(t, "t1", c.Object)
+	assert.Equal(t, 7, c.Line)
+	assert.Equal(t, 15, c.Column)
+
+	c = stmts[2].Violations.Clauses()[0]
+	assert.Equal(t, "t2", c.Object)
+	assert.Equal(t, 8, c.Line)
+	assert.Equal(t, 12, c.Column)
+
+	report := stmts[0].Violations.Marshal()
+	clauses, err := models.ParseReport(report)
+	assert.NoError(t, err)
+	assert.Equal(t, stmts[0].Violations.Clauses(), clauses)
+}
+
+func TestLines(t *testing.T) {
+	// 相同的语句依次定位到各自的位置
+	content := "INSERT INTO t1 VALUES (1);\nINSERT INTO t1 VALUES (1);\n\n  INSERT INTO t1 VALUES (1);"
+	nodes, err := Parse(content)
+	assert.NoError(t, err)
+	stmts := []*models.Statement{}
+	for i, node := range nodes {
+		stmts = append(stmts, &models.Statement{
+			Sequence:   uint16(i + 1),
+			StmtNode:   node,
+			Violations: &models.Violations{},
+		})
+		stmts[i].Violations.Append(&models.Clause{Description: "duplicate", Level: 2})
+	}
+	lines := Lines(content, stmts)
+	assert.Equal(t, 1, lines[stmts[0]])
+	assert.Equal(t, 2, lines[stmts[1]])
+	assert.Equal(t, 4, lines[stmts[2]])
+
+	Locate(content, stmts)
+	assert.Equal(t, 2, stmts[1].Violations.Clauses()[0].Line)
+	c := stmts[2].Violations.Clauses()[0]
+	assert.Equal(t, 4, c.Line)
+	assert.Equal(t, 3, c.Column)
+}
+
+func TestTargetOf(t *testing.T) {
+	nodes, err := Parse("CREATE DATABASE db2;" +
+		"CREATE INDEX idx_c1 ON db1.t1 (c1);" +
+		"CREATE PROCEDURE p1() BEGIN SELECT 1; END;" +
+		"SET NAMES utf8mb4")
+	assert.NoError(t, err)
+	assert.Equal(t, "db2", targetOf(nodes[0]))
+	assert.Equal(t, "t1", targetOf(nodes[1]))
+	assert.Equal(t, "p1", targetOf(nodes[2]))
+	assert.Equal(t, "", targetOf(nodes[3]))
+	assert.Equal(t, "", targetOf(nil))
+}
@@ -0,0 +1,475 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-xorm/core"
+	"github.com/mia0x75/parser/ast"
+
+	"github.com/mia0x75/halo/models"
+)
+
+// 在线DDL的算法，代价依次增加
+const (
+	AlgorithmInstant = "INSTANT" // 只修改元数据
+	AlgorithmInplace = "INPLACE" // 在原表上修改，可能需要重建表
+	AlgorithmCopy    = "COPY"    // 复制到新表
+)
+
+// 在线DDL期间的锁，限制依次增加
+const (
+	LockNone      = "NONE"      // 允许并发读写
+	LockShared    = "SHARED"    // 允许并发读，阻塞写
+	LockExclusive = "EXCLUSIVE" // 阻塞读写
+)
+
+var (
+	algorithmRank = map[string]int{AlgorithmInstant: 0, AlgorithmInplace: 1, AlgorithmCopy: 2}
+	lockRank      = map[string]int{LockNone: 0, LockShared: 1, LockExclusive: 2}
+)
+
+// defaultVersion 版本未知时按MySQL 5.6预测，结果偏保守
+var defaultVersion = &ServerVersion{Flavor: FlavorMySQL, Major: 5, Minor: 6}
+
+// SpecPrediction 单个ALTER操作的预测
+type SpecPrediction struct {
+	Spec      string // 操作的SQL
+	Algorithm string
+	Lock      string
+	Rebuild   bool // 是否重建表
+}
+
+// Prediction ALTER TABLE语句的在线DDL预测，整条语句取代价最高的算法和最严格的锁
+type Prediction struct {
+	Database  string
+	Table     string
+	Algorithm string
+	Lock      string
+	Rebuild   bool
+	Specs     []*SpecPrediction
+	Stat      *models.TableStat // 目标表的统计信息，未知时为空
+}
+
+// BlocksDML 执行期间是否阻塞并发的DML
+func (p *Prediction) BlocksDML() bool {
+	return p.Lock != LockNone
+}
+
+// String 预测结果的描述，写入语句的审核报告
+func (p *Prediction) String() string {
+	rebuild := "不需要重建表"
+	if p.Rebuild {
+		rebuild = "需要重建表"
+	}
+	dml := "不阻塞并发DML"
+	if p.BlocksDML() {
+		dml = "阻塞并发DML"
+	}
+	s := fmt.Sprintf("在线DDL预测：算法%s，锁%s，%s，%s", p.Algorithm, p.Lock, rebuild, dml)
+	if p.Stat != nil {
+		s += fmt.Sprintf("，目标表约%d行、%dMB", p.Stat.Rows, (p.Stat.DataLength+p.Stat.IndexLength)>>20)
+	}
+	return s + "。"
+}
+
+// merge 合并单个操作的预测
+func (p *Prediction) merge(sp *SpecPrediction) {
+	p.Specs = append(p.Specs, sp)
+	if algorithmRank[sp.Algorithm] > algorithmRank[p.Algorithm] {
+		p.Algorithm = sp.Algorithm
+	}
+	if lockRank[sp.Lock] > lockRank[p.Lock] {
+		p.Lock = sp.Lock
+	}
+	p.Rebuild = p.Rebuild || sp.Rebuild
+}
+
+// onlineFeatures 不同版本支持的在线DDL特性
+type onlineFeatures struct {
+	inplace         bool // 支持INPLACE，5.6开始
+	inplaceVarchar  bool // 原地扩展VARCHAR的长度
+	renameIndex     bool // RENAME INDEX
+	instantAdd      bool // 在表的末尾瞬时增加列
+	instantAnywhere bool // 在任意位置瞬时增加列
+	instantDrop     bool // 瞬时删除列
+	instantRename   bool // 瞬时重命名列
+}
+
+// featuresOf 版本对应的在线DDL特性
+func featuresOf(v *ServerVersion) onlineFeatures {
+	if v.Flavor == FlavorMariaDB {
+		return onlineFeatures{
+			inplace:         v.AtLeast("10.0.0"),
+			inplaceVarchar:  v.AtLeast("10.2.2"),
+			renameIndex:     v.AtLeast("10.5.2"),
+			instantAdd:      v.AtLeast("10.3.2"),
+			instantAnywhere: v.AtLeast("10.4.0"),
+			instantDrop:     v.AtLeast("10.4.0"),
+		}
+	}
+	return onlineFeatures{
+		inplace:         v.AtLeast("5.6.0"),
+		inplaceVarchar:  v.AtLeast("5.7.0"),
+		renameIndex:     v.AtLeast("5.7.0"),
+		instantAdd:      v.AtLeast("8.0.12"),
+		instantAnywhere: v.AtLeast("8.0.29"),
+		instantDrop:     v.AtLeast("8.0.29"),
+		instantRename:   v.AtLeast("8.0.28"),
+	}
+}
+
+// PredictAlter 预测ALTER TABLE的算法、锁和是否重建表，table是语句执行之前的表结构，未知时为nil，
+// 预测参照InnoDB的在线DDL文档，无法判断的操作按COPY处理
+func PredictAlter(at *ast.AlterTableStmt, table *core.Table, version *ServerVersion) *Prediction {
+	if version == nil {
+		version = defaultVersion
+	}
+	p := &Prediction{
+		Table:     at.Table.Name.O,
+		Algorithm: AlgorithmInstant,
+		Lock:      LockNone,
+	}
+
+	algorithm := ast.AlterAlgorithmDefault
+	lock := ast.LockTypeDefault
+	addsPrimaryKey := false
+	for _, spec := range at.Specs {
+		switch spec.Tp {
+		case ast.AlterTableAlgorithm:
+			algorithm = spec.Algorithm
+		case ast.AlterTableLock:
+			lock = spec.LockType
+		case ast.AlterTableAddConstraint:
+			if spec.Constraint != nil && spec.Constraint.Tp == ast.ConstraintPrimaryKey {
+				addsPrimaryKey = true
+			}
+		}
+	}
+
+	for _, spec := range at.Specs {
+		if spec.Tp == ast.AlterTableAlgorithm || spec.Tp == ast.AlterTableLock {
+			continue
+		}
+		var sp *SpecPrediction
+		if version.Flavor == FlavorTiDB {
+			sp = predictTiDB(spec, table)
+		} else {
+			sp = predictSpec(spec, table, version, addsPrimaryKey)
+		}
+		// 显式指定的算法和锁，指定的算法低于预测时语句会报错，这里不做处理
+		switch {
+		case algorithm == ast.AlterAlgorithmCopy:
+			sp.Algorithm, sp.Rebuild = AlgorithmCopy, true
+		case algorithm == ast.AlterAlgorithmInplace && sp.Algorithm == AlgorithmInstant:
+			sp.Algorithm = AlgorithmInplace
+		}
+		if sp.Algorithm == AlgorithmCopy && lockRank[sp.Lock] < lockRank[LockShared] {
+			sp.Lock = LockShared
+		}
+		switch lock {
+		case ast.LockTypeShared:
+			if lockRank[sp.Lock] < lockRank[LockShared] {
+				sp.Lock = LockShared
+			}
+		case ast.LockTypeExclusive:
+			sp.Lock = LockExclusive
+		}
+		p.merge(sp)
+	}
+	return p
+}
+
+// predictSpec 预测MySQL和MariaDB上单个操作的算法
+func predictSpec(spec *ast.AlterTableSpec, table *core.Table, v *ServerVersion, addsPrimaryKey bool) *SpecPrediction {
+	sp := &SpecPrediction{
+		Spec:      restore(spec),
+		Algorithm: AlgorithmCopy,
+		Lock:      LockShared,
+		Rebuild:   true,
+	}
+	set := func(algorithm, lock string, rebuild bool) *SpecPrediction {
+		sp.Algorithm, sp.Lock, sp.Rebuild = algorithm, lock, rebuild
+		return sp
+	}
+	f := featuresOf(v)
+	if !f.inplace {
+		return sp
+	}
+
+	switch spec.Tp {
+	case ast.AlterTableAddColumns:
+		set(AlgorithmInstant, LockNone, false)
+		for _, def := range spec.NewColumns {
+			col := predictAddColumn(def, spec.Position, f)
+			if algorithmRank[col.Algorithm] > algorithmRank[sp.Algorithm] {
+				sp.Algorithm = col.Algorithm
+			}
+			if lockRank[col.Lock] > lockRank[sp.Lock] {
+				sp.Lock = col.Lock
+			}
+			sp.Rebuild = sp.Rebuild || col.Rebuild
+		}
+		return sp
+	case ast.AlterTableDropColumn:
+		if f.instantDrop {
+			return set(AlgorithmInstant, LockNone, false)
+		}
+		return set(AlgorithmInplace, LockNone, true)
+	case ast.AlterTableModifyColumn, ast.AlterTableChangeColumn:
+		if len(spec.NewColumns) == 0 {
+			return sp
+		}
+		def := spec.NewColumns[0]
+		name := def.Name.Name.O
+		if spec.Tp == ast.AlterTableChangeColumn && spec.OldColumnName != nil {
+			name = spec.OldColumnName.Name.O
+		}
+		var old *core.Column
+		if table != nil {
+			old = table.GetColumn(name)
+		}
+		return predictColumn(sp, old, def, spec.Position, table, f, name != def.Name.Name.O)
+	case ast.AlterTableAlterColumn:
+		// SET DEFAULT和DROP DEFAULT只修改元数据
+		if f.instantAdd {
+			return set(AlgorithmInstant, LockNone, false)
+		}
+		return set(AlgorithmInplace, LockNone, false)
+	case ast.AlterTableAddConstraint:
+		if spec.Constraint == nil {
+			return sp
+		}
+		switch spec.Constraint.Tp {
+		case ast.ConstraintPrimaryKey:
+			return set(AlgorithmInplace, LockNone, true)
+		case ast.ConstraintKey, ast.ConstraintIndex, ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
+			return set(AlgorithmInplace, LockNone, false)
+		case ast.ConstraintFulltext:
+			// 第一个全文索引需要增加FTS_DOC_ID列，会重建表，模拟的结构不区分全文索引，按重建表预测
+			return set(AlgorithmInplace, LockShared, true)
+		case ast.ConstraintForeignKey:
+			// 只有关闭foreign_key_checks时才能INPLACE，默认是开启的
+			return sp
+		}
+	case ast.AlterTableDropIndex, ast.AlterTableDropForeignKey:
+		return set(AlgorithmInplace, LockNone, false)
+	case ast.AlterTableDropPrimaryKey:
+		// 只有在同一条语句中增加新的主键时才能INPLACE
+		if addsPrimaryKey {
+			return set(AlgorithmInplace, LockNone, true)
+		}
+		return sp
+	case ast.AlterTableRenameIndex:
+		if f.renameIndex {
+			return set(AlgorithmInplace, LockNone, false)
+		}
+	case ast.AlterTableRenameTable:
+		if f.instantAdd {
+			return set(AlgorithmInstant, LockNone, false)
+		}
+		return set(AlgorithmInplace, LockNone, false)
+	case ast.AlterTableOption:
+		return predictOptions(sp, spec.Options, table)
+	case ast.AlterTableForce:
+		return set(AlgorithmInplace, LockNone, true)
+	case ast.AlterTableAddPartitions, ast.AlterTableCoalescePartitions, ast.AlterTableDropPartition, ast.AlterTableTruncatePartition:
+		return set(AlgorithmInplace, LockShared, false)
+	}
+	return sp
+}
+
+// predictAddColumn 增加单个列
+func predictAddColumn(def *ast.ColumnDef, pos *ast.ColumnPosition, f onlineFeatures) *SpecPrediction {
+	sp := &SpecPrediction{
+		Algorithm: AlgorithmInplace,
+		Lock:      LockNone,
+		Rebuild:   true,
+	}
+	virtual := false
+	for _, op := range def.Options {
+		switch op.Tp {
+		case ast.ColumnOptionAutoIncrement:
+			// 增加自增列需要重建表，期间不允许并发DML
+			sp.Lock = LockShared
+			return sp
+		case ast.ColumnOptionGenerated:
+			if op.Stored {
+				sp.Algorithm, sp.Lock = AlgorithmCopy, LockShared
+				return sp
+			}
+			virtual = true
+		}
+	}
+	last := pos == nil || pos.Tp == ast.ColumnPositionNone
+	switch {
+	case f.instantAdd && (last || f.instantAnywhere):
+		sp.Algorithm, sp.Rebuild = AlgorithmInstant, false
+	case virtual:
+		sp.Rebuild = false
+	}
+	return sp
+}
+
+// predictColumn 修改列，old是修改之前的列，未知时无法判断，按COPY处理
+func predictColumn(sp *SpecPrediction, old *core.Column, def *ast.ColumnDef, pos *ast.ColumnPosition, table *core.Table, f onlineFeatures, renamed bool) *SpecPrediction {
+	if old == nil {
+		return sp
+	}
+	for _, op := range def.Options {
+		if op.Tp == ast.ColumnOptionGenerated && op.Stored {
+			return sp
+		}
+	}
+	col := newColumn(def)
+	charset := ""
+	if table != nil {
+		charset = table.Charset
+	}
+	if def.Tp != nil && def.Tp.Charset != "" {
+		charset = def.Tp.Charset
+	}
+
+	switch {
+	case sameType(old, col):
+	case f.inplaceVarchar && strings.EqualFold(old.SQLType.Name, core.Varchar) && strings.EqualFold(col.SQLType.Name, core.Varchar) &&
+		col.Length >= old.Length && (old.Length*charsetMaxLen(charset) < 256) == (col.Length*charsetMaxLen(charset) < 256):
+		// 长度字节数不变时可以原地扩展VARCHAR
+	default:
+		return sp
+	}
+
+	sp.Algorithm, sp.Lock, sp.Rebuild = AlgorithmInplace, LockNone, false
+	if old.Nullable != col.Nullable || (pos != nil && pos.Tp != ast.ColumnPositionNone) {
+		sp.Rebuild = true
+		return sp
+	}
+	if renamed && f.instantRename {
+		sp.Algorithm = AlgorithmInstant
+	}
+	return sp
+}
+
+// predictOptions 修改表的选项
+func predictOptions(sp *SpecPrediction, options []*ast.TableOption, table *core.Table) *SpecPrediction {
+	sp.Algorithm, sp.Lock, sp.Rebuild = AlgorithmInplace, LockNone, false
+	for _, op := range options {
+		switch op.Tp {
+		case ast.TableOptionEngine:
+			if table == nil || !strings.EqualFold(op.StrValue, table.StoreEngine) {
+				sp.Algorithm, sp.Lock, sp.Rebuild = AlgorithmCopy, LockShared, true
+				return sp
+			}
+			// ENGINE=InnoDB相当于重建表
+			sp.Rebuild = true
+		case ast.TableOptionCharset, ast.TableOptionCollate:
+			// DEFAULT CHARSET只修改元数据，CONVERT TO CHARACTER SET需要复制表，解析器无法区分，按代价高的情况预测
+			current := ""
+			if table != nil {
+				current = table.Charset
+				if op.Tp == ast.TableOptionCollate {
+					current = table.Collate
+				}
+			}
+			if !strings.EqualFold(op.StrValue, current) {
+				sp.Algorithm, sp.Lock, sp.Rebuild = AlgorithmCopy, LockShared, true
+				return sp
+			}
+		case ast.TableOptionRowFormat, ast.TableOptionKeyBlockSize, ast.TableOptionCompression:
+			sp.Rebuild = true
+		}
+	}
+	return sp
+}
+
+// predictTiDB TiDB的DDL都是在线的，只有增加索引和修改列类型需要回填数据
+func predictTiDB(spec *ast.AlterTableSpec, table *core.Table) *SpecPrediction {
+	sp := &SpecPrediction{
+		Spec:      restore(spec),
+		Algorithm: AlgorithmInstant,
+		Lock:      LockNone,
+	}
+	switch spec.Tp {
+	case ast.AlterTableAddConstraint:
+		if spec.Constraint != nil && spec.Constraint.Tp != ast.ConstraintForeignKey {
+			sp.Algorithm = AlgorithmInplace
+		}
+	case ast.AlterTableModifyColumn, ast.AlterTableChangeColumn:
+		if len(spec.NewColumns) == 0 || table == nil {
+			break
+		}
+		name := spec.NewColumns[0].Name.Name.O
+		if spec.Tp == ast.AlterTableChangeColumn && spec.OldColumnName != nil {
+			name = spec.OldColumnName.Name.O
+		}
+		if old := table.GetColumn(name); old == nil || !sameType(old, newColumn(spec.NewColumns[0])) {
+			sp.Algorithm, sp.Rebuild = AlgorithmInplace, true
+		}
+	}
+	return sp
+}
+
+// sameType 列的类型和长度是否相同，新的列没有指定长度时使用默认长度，视为相同
+func sameType(old, col *core.Column) bool {
+	if !strings.EqualFold(old.SQLType.Name, col.SQLType.Name) {
+		return false
+	}
+	return (col.Length <= 0 || col.Length == old.Length) && (col.Length2 <= 0 || col.Length2 == old.Length2)
+}
+
+// charsetMaxLen 字符集中单个字符的最大字节数，未知的字符集按utf8mb4处理
+func charsetMaxLen(charset string) int {
+	switch strings.ToLower(charset) {
+	case "latin1", "ascii", "binary":
+		return 1
+	case "gbk", "gb2312", "big5":
+		return 2
+	case "utf8", "utf8mb3":
+		return 3
+	}
+	return 4
+}
+
+// Predict 预测工单中每条ALTER TABLE语句的在线DDL，表结构使用语句执行之前模拟的结构
+func (ctx *Context) Predict() {
+	ctx.Predictions = make(map[*models.Statement]*Prediction)
+	for _, s := range ctx.Stmts {
+		at, ok := s.StmtNode.(*ast.AlterTableStmt)
+		if !ok {
+			continue
+		}
+		database := at.Table.Schema.O
+		if database == "" && ctx.Ticket != nil {
+			database = ctx.Ticket.Database
+		}
+		p := PredictAlter(at, ctx.SchemaOf(s).Table(database, at.Table.Name.O), ctx.Version)
+		p.Database = database
+		p.Stat = ctx.tableStat(database, at.Table.Name.O)
+		ctx.Predictions[s] = p
+	}
+}
+
+// tableStat 目标群集上表的统计信息
+func (ctx *Context) tableStat(database, table string) *models.TableStat {
+	for i := range ctx.TableStats {
+		if ctx.TableStats[i].Database == database && ctx.TableStats[i].Name == table {
+			return &ctx.TableStats[i]
+		}
+	}
+	return nil
+}
+
+// describePredictions 把在线DDL预测作为消息级别的描述写入语句的报告，不影响审核结果
+func (ctx *Context) describePredictions() {
+	for _, s := range ctx.Stmts {
+		p, ok := ctx.Predictions[s]
+		if !ok || s.Violations == nil {
+			continue
+		}
+		s.Violations.Append(&models.Clause{
+			Description: p.String(),
+			Level:       0,
+			Object:      p.Table,
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/go-xorm/core"
+	"github.com/mia0x75/parser"
+	"github.com/mia0x75/parser/ast"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mia0x75/halo/caches"
+	"github.com/mia0x75/halo/models"
+)
+
+func TestPredictAlter(t *testing.T) {
+	p := parser.New()
+	node, err := p.ParseOneStmt("CREATE TABLE t1 (id INT NOT NULL, c1 VARCHAR(10), c2 INT, PRIMARY KEY (id)) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4", "", "")
+	assert.NoError(t, err)
+	table := newTable(node.(*ast.CreateTableStmt))
+
+	mysql56 := ParseVersion("5.6.40", "")
+	mysql57 := ParseVersion("5.7.30-log", "")
+	mysql80 := ParseVersion("8.0.30", "")
+	cases := []struct {
+		sql       string
+		version   *ServerVersion
+		algorithm string
+		lock      string
+		rebuild   bool
+	}{
+		{"ALTER TABLE t1 ADD COLUMN c3 INT", mysql57, AlgorithmInplace, LockNone, true},
+		{"ALTER TABLE t1 ADD COLUMN c3 INT", mysql80, AlgorithmInstant, LockNone, false},
+		{"ALTER TABLE t1 ADD COLUMN c3 INT FIRST", ParseVersion("8.0.20", ""), AlgorithmInplace, LockNone, true},
+		{"ALTER TABLE t1 ADD COLUMN c3 INT FIRST", mysql80, AlgorithmInstant, LockNone, false},
+		{"ALTER TABLE t1 ADD COLUMN c3 INT, ALGORITHM=INPLACE", mysql80, AlgorithmInplace, LockNone, false},
+		{"ALTER TABLE t1 DROP COLUMN c2", mysql57, AlgorithmInplace, LockNone, true},
+		{"ALTER TABLE t1 ADD INDEX idx_c1 (c1)", mysql57, AlgorithmInplace, LockNone, false},
+		{"ALTER TABLE t1 ADD INDEX idx_c1 (c1), LOCK=EXCLUSIVE", mysql57, AlgorithmInplace, LockExclusive, false},
+		{"ALTER TABLE t1 ADD INDEX idx_c1 (c1)", ParseVersion("5.5.62", ""), AlgorithmCopy, LockShared, true},
+		{"ALTER TABLE t1 MODIFY COLUMN c1 VARCHAR(60)", mysql57, AlgorithmInplace, LockNone, false},
+		{"ALTER TABLE t1 MODIFY COLUMN c1 VARCHAR(100)", mysql57, AlgorithmCopy, LockShared, true},
+		{"ALTER TABLE t1 MODIFY COLUMN c1 VARCHAR(60)", mysql56, AlgorithmCopy, LockShared, true},
+		{"ALTER TABLE t1 MODIFY COLUMN c2 BIGINT", mysql80, AlgorithmCopy, LockShared, true},
+		{"ALTER TABLE t1 MODIFY COLUMN c2 INT NOT NULL", mysql80, AlgorithmInplace, LockNone, true},
+		{"ALTER TABLE t1 CHANGE COLUMN c2 c3 INT", mysql57, AlgorithmInplace, LockNone, false},
+		{"ALTER TABLE t1 CHANGE COLUMN c2 c3 INT", mysql80, AlgorithmInstant, LockNone, false},
+		{"ALTER TABLE t1 MODIFY COLUMN c9 INT", mysql80, AlgorithmCopy, LockShared, true},
+		{"ALTER TABLE t1 DROP PRIMARY KEY", mysql57, AlgorithmCopy, LockShared, true},
+		{"ALTER TABLE t1 DROP PRIMARY KEY, ADD PRIMARY KEY (id, c2)", mysql57, AlgorithmInplace, LockNone, true},
+		{"ALTER TABLE t1 ENGINE=InnoDB", mysql57, AlgorithmInplace, LockNone, true},
+		{"ALTER TABLE t1 ENGINE=MyISAM", mysql57, AlgorithmCopy, LockShared, true},
+		{"ALTER TABLE t1 DEFAULT CHARSET=latin1", mysql57, AlgorithmCopy, LockShared, true},
+		{"ALTER TABLE t1 COMMENT='用户表'", mysql57, AlgorithmInplace, LockNone, false},
+		{"ALTER TABLE t1 ADD COLUMN c3 INT, MODIFY COLUMN c2 BIGINT", mysql80, AlgorithmCopy, LockShared, true},
+		// TiDB的DDL都是在线的
+		{"ALTER TABLE t1 MODIFY COLUMN c2 BIGINT", ParseVersion("5.7.25-TiDB-v4.0.0", ""), AlgorithmInplace, LockNone, true},
+		// 版本未知时按5.6预测
+		{"ALTER TABLE t1 ADD COLUMN c3 INT", nil, AlgorithmInplace, LockNone, true},
+	}
+	for _, c := range cases {
+		node, err := p.ParseOneStmt(c.sql, "", "")
+		if !assert.NoError(t, err, c.sql) {
+			continue
+		}
+		v := ""
+		if c.version != nil {
+			v = c.version.String()
+		}
+		prediction := PredictAlter(node.(*ast.AlterTableStmt), table, c.version)
+		assert.Equal(t, c.algorithm, prediction.Algorithm, "%s %s", v, c.sql)
+		assert.Equal(t, c.lock, prediction.Lock, "%s %s", v, c.sql)
+		assert.Equal(t, c.rebuild, prediction.Rebuild, "%s %s", v, c.sql)
+	}
+}
+
+func TestCopyAlter(t *testing.T) {
+	caches.RulesMap.Lock()
+	origin := caches.RulesMap.M
+	caches.RulesMap.M = []*models.Rule{
+		{Name: "MTB-L2-040", VldrGroup: 120, Func: "CopyAlterMaxRows", Values: "1000000", Bitwise: 7, Level: 2,
+			Message: "表\"%s\"约有%d行，需要复制表的ALTER TABLE会阻塞写入，请控制在%d行以内或者使用在线变更工具。"},
+		{Name: "MTB-L2-041", VldrGroup: 120, Func: "CopyAlterMaxSize", Values: "1024", Bitwise: 7, Level: 1,
+			Message: "表\"%s\"的大小约为%dMB，需要复制表的ALTER TABLE会阻塞写入，请控制在%dMB以内或者使用在线变更工具。"},
+	}
+	caches.RulesMap.Unlock()
+	defer func() {
+		caches.RulesMap.Lock()
+		caches.RulesMap.M = origin
+		caches.RulesMap.Unlock()
+	}()
+
+	nodes, err := Parse("ALTER TABLE t1 MODIFY COLUMN c1 BIGINT;\n" +
+		"ALTER TABLE t1 ADD INDEX idx_c1 (c1);\n" +
+		"ALTER TABLE t2 MODIFY COLUMN c1 BIGINT;")
+	assert.NoError(t, err)
+	stmts := []*models.Statement{}
+	for i, node := range nodes {
+		stmts = append(stmts, &models.Statement{
+			Sequence:   uint16(i + 1),
+			StmtNode:   node,
+			Violations: &models.Violations{},
+		})
+	}
+	table := func(sql string) *core.Table {
+		node, err := parser.New().ParseOneStmt(sql, "", "")
+		assert.NoError(t, err)
+		return newTable(node.(*ast.CreateTableStmt))
+	}
+	ctx := &Context{
+		Ticket:    &models.Ticket{Database: "db1"},
+		Stmts:     stmts,
+		Databases: []models.Database{{Name: "db1"}},
+		Tables: map[string][]*core.Table{
+			"db1": {
+				table("CREATE TABLE t1 (id INT, c1 INT)"),
+				table("CREATE TABLE t2 (id INT, c1 INT)"),
+			},
+		},
+		TableStats: []models.TableStat{
+			{Database: "db1", Name: "t1", Rows: 5000000, DataLength: 2 << 30},
+			{Database: "db1", Name: "t2", Rows: 1000, DataLength: 1 << 20},
+		},
+		Version: ParseVersion("8.0.30", ""),
+	}
+	RunContext(ctx)
+
+	rules := func(s *models.Statement) []string {
+		L := []string{}
+		for _, c := range s.Violations.Clauses() {
+			if c.Rule != "" {
+				L = append(L, c.Rule)
+			}
+		}
+		return L
+	}
+	// 复制大表
+	assert.ElementsMatch(t, []string{"MTB-L2-040", "MTB-L2-041"}, rules(stmts[0]))
+	// 在线增加索引
+	assert.Empty(t, rules(stmts[1]))
+	// 复制小表
+	assert.Empty(t, rules(stmts[2]))
+
+	// 预测结果以消息级别写入报告
+	clauses := stmts[0].Violations.Clauses()
+	description := clauses[len(clauses)-1]
+	assert.Equal(t, uint8(0), description.Level)
+	assert.Equal(t, "在线DDL预测：算法COPY，锁SHARED，需要重建表，阻塞并发DML，目标表约5000000行、2048MB。", description.Description)
+	clauses = stmts[1].Violations.Clauses()
+	assert.Equal(t, "在线DDL预测：算法INPLACE，锁NONE，不需要重建表，不阻塞并发DML，目标表约5000000行、2048MB。", clauses[len(clauses)-1].Description)
+}
@@ -0,0 +1,88 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mia0x75/halo/models"
+)
+
+// EstimateRows 从EXPLAIN FORMAT=JSON的结果中估算语句扫描的行数，
+// 多表关联时以扫描行数最多的表为准
+func EstimateRows(plan string) (rows int64, err error) {
+	var root interface{}
+	if err = json.Unmarshal([]byte(plan), &root); err != nil {
+		return
+	}
+	found := false
+	var walk func(node interface{})
+	walk = func(node interface{}) {
+		switch x := node.(type) {
+		case map[string]interface{}:
+			if table, ok := x["table"].(map[string]interface{}); ok {
+				// 5.7以后是rows_examined_per_scan，5.6是rows
+				for _, key := range []string{"rows_examined_per_scan", "rows"} {
+					if n, ok := table[key].(float64); ok {
+						found = true
+						if int64(n) > rows {
+							rows = int64(n)
+						}
+						break
+					}
+				}
+			}
+			for _, elem := range x {
+				walk(elem)
+			}
+		case []interface{}:
+			for _, elem := range x {
+				walk(elem)
+			}
+		}
+	}
+	walk(root)
+	if !found {
+		err = fmt.Errorf("No row estimate in plan")
+	}
+	return
+}
+
+// explain 在目标群集上获取语句的执行计划，原始结果保存到Statement.Plan，
+// 同一条语句只获取一次，离线审核或者获取失败时返回false
+func (v *vldr) explain(s *models.Statement) (int64, bool) {
+	if s.Plan == "" {
+		if v.Ctx.Cluster == nil || v.Ctx.Passwd == nil {
+			return 0, false
+		}
+		plan, err := v.Ctx.Cluster.Explain(v.Ctx.Ticket.Database, v.Ctx.Passwd, s.Content)
+		if err != nil {
+			// 目标表在工单前面的语句中才创建时，执行计划无法获取
+			log.Warnf("[W] Failed to explain statement %d, err: %s", s.Sequence, err.Error())
+			return 0, false
+		}
+		s.Plan = plan
+	}
+	rows, err := EstimateRows(s.Plan)
+	if err != nil {
+		return 0, false
+	}
+	return rows, true
+}
+
+// maxAllowedRows 语句预计影响的行数不能超过规则的阈值
+func (v *vldr) maxAllowedRows(s *models.Statement, r *models.Rule) {
+	threshold, err := strconv.ParseInt(r.Values, 10, 64)
+	if err != nil {
+		return
+	}
+	if rows, ok := v.explain(s); ok && rows > threshold {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, threshold),
+			Level:       r.Level,
+		}
+		s.Violations.Append(c)
+	}
+}
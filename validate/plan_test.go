@@ -0,0 +1,35 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateRows(t *testing.T) {
+	// 单表UPDATE
+	plan := `{"query_block": {"select_id": 1, "table": {"update": true, "table_name": "t1", "access_type": "ALL", "rows_examined_per_scan": 1200, "filtered": "100.00"}}}`
+	rows, err := EstimateRows(plan)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1200), rows)
+
+	// 多表DELETE，以扫描行数最多的表为准
+	plan = `{"query_block": {"select_id": 1, "nested_loop": [
+		{"table": {"table_name": "a", "access_type": "ALL", "rows_examined_per_scan": 30}},
+		{"table": {"delete": true, "table_name": "b", "access_type": "ref", "rows_examined_per_scan": 800}}
+	]}}`
+	rows, err = EstimateRows(plan)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(800), rows)
+
+	// 5.6的格式
+	rows, err = EstimateRows(`{"query_block": {"select_id": 1, "table": {"table_name": "t1", "rows": 42}}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), rows)
+
+	// 没有行数估算
+	_, err = EstimateRows(`{"query_block": {"select_id": 1, "message": "No tables used"}}`)
+	assert.Error(t, err)
+	_, err = EstimateRows("")
+	assert.Error(t, err)
+}
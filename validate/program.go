@@ -0,0 +1,807 @@
+package validate
+
+import (
+	"strings"
+
+	"github.com/mia0x75/parser"
+	"github.com/mia0x75/parser/ast"
+	"github.com/mia0x75/parser/format"
+)
+
+// 接口确认，如果有报错表示没有实现接口的全部方法
+var (
+	_ ast.StmtNode = &CreateFunctionStmt{}
+	_ ast.StmtNode = &AlterFunctionStmt{}
+	_ ast.StmtNode = &DropFunctionStmt{}
+	_ ast.StmtNode = &CreateProcedureStmt{}
+	_ ast.StmtNode = &AlterProcedureStmt{}
+	_ ast.StmtNode = &DropProcedureStmt{}
+	_ ast.StmtNode = &CreateTriggerStmt{}
+	_ ast.StmtNode = &DropTriggerStmt{}
+	_ ast.StmtNode = &CreateEventStmt{}
+	_ ast.StmtNode = &AlterEventStmt{}
+	_ ast.StmtNode = &DropEventStmt{}
+)
+
+// 存储程序的类型
+const (
+	ProgramFunction  = "FUNCTION"
+	ProgramProcedure = "PROCEDURE"
+)
+
+// Parse 拆分并解析脚本，解析器不支持的存储程序语句（函数、存储过程、触发器和事件）
+// 由ParseProgram解析，其余语句仍然交给解析器
+func Parse(content string) ([]ast.StmtNode, error) {
+	p := parser.New()
+	stmts := []ast.StmtNode{}
+	for _, sql := range Split(content) {
+		nodes, err := parse(p, sql)
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, nodes...)
+	}
+	return stmts, nil
+}
+
+// parse 解析单条语句
+func parse(p *parser.Parser, sql string) ([]ast.StmtNode, error) {
+	if node, ok := ParseProgram(sql); ok {
+		return []ast.StmtNode{node}, nil
+	}
+	nodes, _, err := p.Parse(sql, "", "")
+	return nodes, err
+}
+
+// ProgramName 存储程序的名称
+type ProgramName struct {
+	Schema string // 所属的数据库，没有指定时为空
+	Name   string
+}
+
+// programStmt 存储程序语句的公共部分，只解析审核需要的内容，原始文本原样保留用于执行
+type programStmt struct {
+	ast.StmtNode // 仅用于满足ast.StmtNode接口中未导出的方法，不能直接调用
+
+	text string
+}
+
+// Restore 存储程序语句原样输出
+func (n *programStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WritePlain(n.text)
+	return nil
+}
+
+// Text 原始文本
+func (n *programStmt) Text() string {
+	return n.text
+}
+
+// SetText 设置原始文本
+func (n *programStmt) SetText(text string) {
+	n.text = text
+}
+
+// CreateFunctionStmt CREATE FUNCTION语句
+type CreateFunctionStmt struct {
+	programStmt
+
+	IfNotExists bool
+	Name        *ProgramName
+	Body        string // 函数体
+}
+
+// Accept 实现ast.Node接口
+func (n *CreateFunctionStmt) Accept(v ast.Visitor) (ast.Node, bool) {
+	node, _ := v.Enter(n)
+	return v.Leave(node)
+}
+
+// AlterFunctionStmt ALTER FUNCTION语句，只能修改函数的特性
+type AlterFunctionStmt struct {
+	programStmt
+
+	Name *ProgramName
+}
+
+// Accept 实现ast.Node接口
+func (n *AlterFunctionStmt) Accept(v ast.Visitor) (ast.Node, bool) {
+	node, _ := v.Enter(n)
+	return v.Leave(node)
+}
+
+// DropFunctionStmt DROP FUNCTION语句
+type DropFunctionStmt struct {
+	programStmt
+
+	IfExists bool
+	Name     *ProgramName
+}
+
+// Accept 实现ast.Node接口
+func (n *DropFunctionStmt) Accept(v ast.Visitor) (ast.Node, bool) {
+	node, _ := v.Enter(n)
+	return v.Leave(node)
+}
+
+// CreateProcedureStmt CREATE PROCEDURE语句
+type CreateProcedureStmt struct {
+	programStmt
+
+	IfNotExists bool
+	Name        *ProgramName
+	Body        string // 存储过程体
+}
+
+// Accept 实现ast.Node接口
+func (n *CreateProcedureStmt) Accept(v ast.Visitor) (ast.Node, bool) {
+	node, _ := v.Enter(n)
+	return v.Leave(node)
+}
+
+// AlterProcedureStmt ALTER PROCEDURE语句，只能修改存储过程的特性
+type AlterProcedureStmt struct {
+	programStmt
+
+	Name *ProgramName
+}
+
+// Accept 实现ast.Node接口
+func (n *AlterProcedureStmt) Accept(v ast.Visitor) (ast.Node, bool) {
+	node, _ := v.Enter(n)
+	return v.Leave(node)
+}
+
+// DropProcedureStmt DROP PROCEDURE语句
+type DropProcedureStmt struct {
+	programStmt
+
+	IfExists bool
+	Name     *ProgramName
+}
+
+// Accept 实现ast.Node接口
+func (n *DropProcedureStmt) Accept(v ast.Visitor) (ast.Node, bool) {
+	node, _ := v.Enter(n)
+	return v.Leave(node)
+}
+
+// CreateTriggerStmt CREATE TRIGGER语句
+type CreateTriggerStmt struct {
+	programStmt
+
+	IfNotExists bool
+	Name        *ProgramName
+	Timing      string       // BEFORE或者AFTER
+	Event       string       // INSERT、UPDATE或者DELETE
+	Table       *ProgramName // 触发器所在的表，只能和触发器在同一个库
+	Body        string       // 触发器的语句
+}
+
+// Accept 实现ast.Node接口
+func (n *CreateTriggerStmt) Accept(v ast.Visitor) (ast.Node, bool) {
+	node, _ := v.Enter(n)
+	return v.Leave(node)
+}
+
+// DropTriggerStmt DROP TRIGGER语句
+type DropTriggerStmt struct {
+	programStmt
+
+	IfExists bool
+	Name     *ProgramName
+}
+
+// Accept 实现ast.Node接口
+func (n *DropTriggerStmt) Accept(v ast.Visitor) (ast.Node, bool) {
+	node, _ := v.Enter(n)
+	return v.Leave(node)
+}
+
+// CreateEventStmt CREATE EVENT语句
+type CreateEventStmt struct {
+	programStmt
+
+	IfNotExists bool
+	Name        *ProgramName
+	Body        string // DO之后的语句
+}
+
+// Accept 实现ast.Node接口
+func (n *CreateEventStmt) Accept(v ast.Visitor) (ast.Node, bool) {
+	node, _ := v.Enter(n)
+	return v.Leave(node)
+}
+
+// AlterEventStmt ALTER EVENT语句
+type AlterEventStmt struct {
+	programStmt
+
+	Name    *ProgramName
+	NewName *ProgramName // RENAME TO的新名称，没有重命名时为nil
+	Body    string       // DO之后的语句，没有修改时为空
+}
+
+// Accept 实现ast.Node接口
+func (n *AlterEventStmt) Accept(v ast.Visitor) (ast.Node, bool) {
+	node, _ := v.Enter(n)
+	return v.Leave(node)
+}
+
+// DropEventStmt DROP EVENT语句
+type DropEventStmt struct {
+	programStmt
+
+	IfExists bool
+	Name     *ProgramName
+}
+
+// Accept 实现ast.Node接口
+func (n *DropEventStmt) Accept(v ast.Visitor) (ast.Node, bool) {
+	node, _ := v.Enter(n)
+	return v.Leave(node)
+}
+
+// ParseProgram 解析存储程序语句，不是存储程序语句时返回false
+func ParseProgram(sql string) (ast.StmtNode, bool) {
+	p := &programParser{
+		sql:  sql,
+		toks: tokenize(sql),
+	}
+
+	var node ast.StmtNode
+	switch {
+	case p.accept("CREATE"):
+		p.accept("OR", "REPLACE")
+		p.skipDefiner()
+		p.accept("AGGREGATE")
+		switch {
+		case p.accept("FUNCTION"):
+			node = p.createFunction()
+		case p.accept("PROCEDURE"):
+			node = p.createProcedure()
+		case p.accept("TRIGGER"):
+			node = p.createTrigger()
+		case p.accept("EVENT"):
+			node = p.createEvent()
+		}
+	case p.accept("ALTER"):
+		p.skipDefiner()
+		switch {
+		case p.accept("FUNCTION"):
+			node = p.alterFunction()
+		case p.accept("PROCEDURE"):
+			node = p.alterProcedure()
+		case p.accept("EVENT"):
+			node = p.alterEvent()
+		}
+	case p.accept("DROP"):
+		switch {
+		case p.accept("FUNCTION"):
+			node = p.dropFunction()
+		case p.accept("PROCEDURE"):
+			node = p.dropProcedure()
+		case p.accept("TRIGGER"):
+			node = p.dropTrigger()
+		case p.accept("EVENT"):
+			node = p.dropEvent()
+		}
+	}
+	if node == nil {
+		return nil, false
+	}
+	node.SetText(sql)
+	return node, true
+}
+
+func (p *programParser) createFunction() ast.StmtNode {
+	n := &CreateFunctionStmt{}
+	n.IfNotExists = p.accept("IF", "NOT", "EXISTS")
+	if n.Name = p.name(); n.Name == nil {
+		return nil
+	}
+	p.skipParens()
+	if p.accept("RETURNS") {
+		p.skipType()
+	}
+	p.skipCharacteristics()
+	n.Body = p.rest()
+	return n
+}
+
+func (p *programParser) alterFunction() ast.StmtNode {
+	n := &AlterFunctionStmt{}
+	if n.Name = p.name(); n.Name == nil {
+		return nil
+	}
+	return n
+}
+
+func (p *programParser) dropFunction() ast.StmtNode {
+	n := &DropFunctionStmt{}
+	n.IfExists = p.accept("IF", "EXISTS")
+	if n.Name = p.name(); n.Name == nil {
+		return nil
+	}
+	return n
+}
+
+func (p *programParser) createProcedure() ast.StmtNode {
+	n := &CreateProcedureStmt{}
+	n.IfNotExists = p.accept("IF", "NOT", "EXISTS")
+	if n.Name = p.name(); n.Name == nil {
+		return nil
+	}
+	p.skipParens()
+	p.skipCharacteristics()
+	n.Body = p.rest()
+	return n
+}
+
+func (p *programParser) alterProcedure() ast.StmtNode {
+	n := &AlterProcedureStmt{}
+	if n.Name = p.name(); n.Name == nil {
+		return nil
+	}
+	return n
+}
+
+func (p *programParser) dropProcedure() ast.StmtNode {
+	n := &DropProcedureStmt{}
+	n.IfExists = p.accept("IF", "EXISTS")
+	if n.Name = p.name(); n.Name == nil {
+		return nil
+	}
+	return n
+}
+
+func (p *programParser) createTrigger() ast.StmtNode {
+	n := &CreateTriggerStmt{}
+	n.IfNotExists = p.accept("IF", "NOT", "EXISTS")
+	if n.Name = p.name(); n.Name == nil {
+		return nil
+	}
+	if n.Timing = p.choose("BEFORE", "AFTER"); n.Timing == "" {
+		return nil
+	}
+	if n.Event = p.choose("INSERT", "UPDATE", "DELETE"); n.Event == "" {
+		return nil
+	}
+	if !p.accept("ON") {
+		return nil
+	}
+	if n.Table = p.name(); n.Table == nil {
+		return nil
+	}
+	if !p.accept("FOR", "EACH", "ROW") {
+		return nil
+	}
+	if p.accept("FOLLOWS") || p.accept("PRECEDES") {
+		p.ident()
+	}
+	n.Body = p.rest()
+	return n
+}
+
+func (p *programParser) dropTrigger() ast.StmtNode {
+	n := &DropTriggerStmt{}
+	n.IfExists = p.accept("IF", "EXISTS")
+	if n.Name = p.name(); n.Name == nil {
+		return nil
+	}
+	return n
+}
+
+func (p *programParser) createEvent() ast.StmtNode {
+	n := &CreateEventStmt{}
+	n.IfNotExists = p.accept("IF", "NOT", "EXISTS")
+	if n.Name = p.name(); n.Name == nil {
+		return nil
+	}
+	// 调度、完成后是否保留、状态和注释都不需要审核，直接跳到DO
+	p.skipTo("DO")
+	if n.Body = p.rest(); n.Body == "" {
+		return nil
+	}
+	return n
+}
+
+func (p *programParser) alterEvent() ast.StmtNode {
+	n := &AlterEventStmt{}
+	if n.Name = p.name(); n.Name == nil {
+		return nil
+	}
+	for p.i < len(p.toks) {
+		switch {
+		case p.accept("RENAME", "TO"):
+			if n.NewName = p.name(); n.NewName == nil {
+				return nil
+			}
+		case p.accept("DO"):
+			n.Body = p.rest()
+			return n
+		default:
+			p.i++
+		}
+	}
+	return n
+}
+
+func (p *programParser) dropEvent() ast.StmtNode {
+	n := &DropEventStmt{}
+	n.IfExists = p.accept("IF", "EXISTS")
+	if n.Name = p.name(); n.Name == nil {
+		return nil
+	}
+	return n
+}
+
+// 词法单元的类型
+const (
+	tokWord   = iota // 关键字或者标识符
+	tokIdent         // 反引号包围的标识符
+	tokString        // 单引号或者双引号包围的字符串
+	tokSymbol        // 其他符号
+)
+
+// token 词法单元
+type token struct {
+	kind int
+	text string // 去掉引号之后的内容
+	pos  int    // 在原始文本中的起始位置
+}
+
+// is 是否是指定的关键字之一
+func (t token) is(words ...string) bool {
+	if t.kind != tokWord {
+		return false
+	}
+	for _, word := range words {
+		if strings.EqualFold(t.text, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c == '$' || c >= 0x80 ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// tokenize 简单的词法分析，跳过空白和注释，/*!40101 ... */这种带版本的注释按照正常内容处理
+func tokenize(sql string) []token {
+	toks := []token{}
+	n := len(sql)
+	for i := 0; i < n; {
+		c := sql[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case c == '#' || (c == '-' && strings.HasPrefix(sql[i:], "--") &&
+			(i+2 == n || sql[i+2] == ' ' || sql[i+2] == '\t' || sql[i+2] == '\n' || sql[i+2] == '\r')):
+			if j := strings.IndexByte(sql[i:], '\n'); j >= 0 {
+				i += j + 1
+			} else {
+				i = n
+			}
+		case strings.HasPrefix(sql[i:], "/*!"):
+			i += 3
+			for i < n && sql[i] >= '0' && sql[i] <= '9' {
+				i++
+			}
+		case strings.HasPrefix(sql[i:], "/*"):
+			if j := strings.Index(sql[i+2:], "*/"); j >= 0 {
+				i += j + 4
+			} else {
+				i = n
+			}
+		case strings.HasPrefix(sql[i:], "*/"):
+			// 带版本的注释的结束部分
+			i += 2
+		case c == '\'' || c == '"' || c == '`':
+			var sb strings.Builder
+			j := i + 1
+			for j < n {
+				if sql[j] == '\\' && c != '`' && j+1 < n {
+					sb.WriteByte(sql[j+1])
+					j += 2
+					continue
+				}
+				if sql[j] == c {
+					if j+1 < n && sql[j+1] == c {
+						sb.WriteByte(c)
+						j += 2
+						continue
+					}
+					break
+				}
+				sb.WriteByte(sql[j])
+				j++
+			}
+			kind := tokString
+			if c == '`' {
+				kind = tokIdent
+			}
+			toks = append(toks, token{kind: kind, text: sb.String(), pos: i})
+			i = j + 1
+		case isWordChar(c):
+			j := i
+			for j < n && isWordChar(sql[j]) {
+				j++
+			}
+			toks = append(toks, token{kind: tokWord, text: sql[i:j], pos: i})
+			i = j
+		default:
+			toks = append(toks, token{kind: tokSymbol, text: sql[i : i+1], pos: i})
+			i++
+		}
+	}
+	return toks
+}
+
+// programParser 存储程序语句头部的解析
+type programParser struct {
+	sql  string
+	toks []token
+	i    int
+}
+
+// peek 当前的词法单元，已经结束时返回一个空的符号
+func (p *programParser) peek() token {
+	if p.i < len(p.toks) {
+		return p.toks[p.i]
+	}
+	return token{kind: tokSymbol, pos: len(p.sql)}
+}
+
+// accept 当前位置是指定的关键字序列时消费掉并返回true
+func (p *programParser) accept(words ...string) bool {
+	if p.i+len(words) > len(p.toks) {
+		return false
+	}
+	for k, word := range words {
+		if !p.toks[p.i+k].is(word) {
+			return false
+		}
+	}
+	p.i += len(words)
+	return true
+}
+
+// choose 当前位置是指定的关键字之一时消费掉并返回这个关键字，否则返回空
+func (p *programParser) choose(words ...string) string {
+	for _, word := range words {
+		if p.accept(word) {
+			return word
+		}
+	}
+	return ""
+}
+
+// symbol 当前位置是指定的符号时消费掉并返回true
+func (p *programParser) symbol(s string) bool {
+	if t := p.peek(); t.kind == tokSymbol && t.text == s && p.i < len(p.toks) {
+		p.i++
+		return true
+	}
+	return false
+}
+
+// ident 标识符
+func (p *programParser) ident() (string, bool) {
+	t := p.peek()
+	if p.i >= len(p.toks) || (t.kind != tokWord && t.kind != tokIdent) {
+		return "", false
+	}
+	p.i++
+	return t.text, true
+}
+
+// name 可能带有数据库的名称
+func (p *programParser) name() *ProgramName {
+	first, ok := p.ident()
+	if !ok {
+		return nil
+	}
+	if !p.symbol(".") {
+		return &ProgramName{Name: first}
+	}
+	second, ok := p.ident()
+	if !ok {
+		return nil
+	}
+	return &ProgramName{Schema: first, Name: second}
+}
+
+// skipDefiner 跳过DEFINER = user
+func (p *programParser) skipDefiner() {
+	if !p.accept("DEFINER") {
+		return
+	}
+	p.symbol("=")
+	if p.accept("CURRENT_USER") {
+		if p.symbol("(") {
+			p.symbol(")")
+		}
+		return
+	}
+	p.i++
+	if p.symbol("@") {
+		p.i++
+	}
+}
+
+// skipParens 跳过一对括号及其中的内容
+func (p *programParser) skipParens() {
+	if !p.symbol("(") {
+		return
+	}
+	depth := 1
+	for p.i < len(p.toks) && depth > 0 {
+		t := p.toks[p.i]
+		if t.kind == tokSymbol && t.text == "(" {
+			depth++
+		}
+		if t.kind == tokSymbol && t.text == ")" {
+			depth--
+		}
+		p.i++
+	}
+}
+
+// skipType 跳过数据类型
+func (p *programParser) skipType() {
+	p.i++
+	p.skipParens()
+	for {
+		switch {
+		case p.accept("UNSIGNED"), p.accept("SIGNED"), p.accept("ZEROFILL"), p.accept("BINARY"):
+		case p.accept("CHARSET"), p.accept("CHARACTER", "SET"), p.accept("COLLATE"):
+			p.i++
+		default:
+			return
+		}
+	}
+}
+
+// skipCharacteristics 跳过函数和存储过程的特性
+func (p *programParser) skipCharacteristics() {
+	for {
+		switch {
+		case p.accept("COMMENT"):
+			p.i++
+		case p.accept("LANGUAGE", "SQL"),
+			p.accept("NOT", "DETERMINISTIC"),
+			p.accept("DETERMINISTIC"),
+			p.accept("CONTAINS", "SQL"),
+			p.accept("NO", "SQL"),
+			p.accept("READS", "SQL", "DATA"),
+			p.accept("MODIFIES", "SQL", "DATA"),
+			p.accept("SQL", "SECURITY", "DEFINER"),
+			p.accept("SQL", "SECURITY", "INVOKER"):
+		default:
+			return
+		}
+	}
+}
+
+// skipTo 跳过指定的关键字以及之前的全部内容
+func (p *programParser) skipTo(word string) {
+	for p.i < len(p.toks) && !p.accept(word) {
+		p.i++
+	}
+}
+
+// rest 当前位置之后的全部原始文本
+func (p *programParser) rest() string {
+	if p.i >= len(p.toks) {
+		return ""
+	}
+	return strings.TrimSpace(p.sql[p.toks[p.i].pos:])
+}
+
+// programBody 将存储程序的语句拆分为单条语句，去掉复合语句和流程控制语句的头部，
+// 例如IF ... THEN DELETE ...中只保留DELETE语句，剩下的END IF等语句由调用方在解析时跳过
+func programBody(body string) []string {
+	stmts := []string{}
+	for _, sql := range Split(body) {
+		if stmt := stripControl(sql); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+	}
+	return stmts
+}
+
+// stripControl 去掉语句前面的标签、BEGIN以及IF、CASE、WHILE等流程控制的头部
+func stripControl(sql string) string {
+	p := &programParser{
+		sql:  sql,
+		toks: tokenize(sql),
+	}
+	for p.i < len(p.toks) {
+		// 标签
+		if p.i+1 < len(p.toks) && p.toks[p.i+1].kind == tokSymbol && p.toks[p.i+1].text == ":" {
+			p.i += 2
+			continue
+		}
+		switch {
+		case p.accept("BEGIN"), p.accept("ELSE"), p.accept("LOOP"), p.accept("REPEAT"):
+		case p.accept("IF"), p.accept("ELSEIF"), p.accept("CASE"), p.accept("WHEN"):
+			p.skipTo("THEN")
+		case p.accept("WHILE"):
+			p.skipTo("DO")
+		default:
+			return p.rest()
+		}
+	}
+	return ""
+}
+
+// blockTracker 拆分脚本时统计存储程序中还没有结束的BEGIN ... END等复合语句，
+// 没有使用DELIMITER时，复合语句没有结束之前遇到的分号属于存储程序内部。
+// 每次只扫描新追加的部分，大脚本中的长语句不会被反复扫描
+type blockTracker struct {
+	p       programParser
+	checked bool // 已经根据语句的开头判断过是否是存储程序
+	program bool // 语句是存储程序
+	depth   int  // 还没有结束的复合语句的层数
+}
+
+// feed 追加语句的一部分，返回追加之后是否还有没有结束的复合语句。
+// 追加的部分必须从词法单元的边界开始，Split在分隔符处追加，满足这个要求
+func (b *blockTracker) feed(sql string) bool {
+	b.p.toks = append(b.p.toks, tokenize(sql)...)
+	if !b.checked {
+		b.checked = true
+		b.program = b.p.program()
+	}
+	if !b.program {
+		return false
+	}
+
+	p := &b.p
+	for ; p.i < len(p.toks); p.i++ {
+		t := p.toks[p.i]
+		prev := p.toks[p.i-1]
+		next := token{kind: tokSymbol}
+		if p.i+1 < len(p.toks) {
+			next = p.toks[p.i+1]
+		}
+		switch {
+		case t.is("END"):
+			b.depth--
+			// END IF、END LOOP等结束语句后面的关键字不能再计算一次
+			if next.is("IF", "CASE", "LOOP", "WHILE", "REPEAT") {
+				p.i++
+			}
+		case t.is("BEGIN", "CASE", "LOOP"):
+			b.depth++
+		case t.is("IF", "WHILE", "REPEAT"):
+			// IF()和REPEAT()函数，名称前面的IF [NOT] EXISTS不是复合语句
+			if next.kind == tokSymbol && next.text == "(" {
+				continue
+			}
+			if prev.is("FUNCTION", "PROCEDURE", "TRIGGER", "EVENT") {
+				continue
+			}
+			b.depth++
+		}
+	}
+	return b.depth > 0
+}
+
+// program 语句的开头是否是CREATE或者ALTER存储程序，是的时候停在程序类型的位置
+func (p *programParser) program() bool {
+	switch {
+	case p.accept("CREATE"):
+		p.accept("OR", "REPLACE")
+		p.skipDefiner()
+		p.accept("AGGREGATE")
+	case p.accept("ALTER"):
+		p.skipDefiner()
+	default:
+		return false
+	}
+	return p.peek().is("FUNCTION", "PROCEDURE", "TRIGGER", "EVENT")
+}
@@ -0,0 +1,172 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProgram(t *testing.T) {
+	script := "CREATE DEFINER=`root`@`%` FUNCTION `db1`.`fn_add`(a INT, b INT) RETURNS int(11)\n" +
+		"    DETERMINISTIC\n" +
+		"BEGIN\n" +
+		"  DECLARE c INT;\n" +
+		"  SET c = a + b;\n" +
+		"  IF c > 0 THEN\n" +
+		"    RETURN c;\n" +
+		"  END IF;\n" +
+		"  RETURN 0;\n" +
+		"END;\n" +
+		"DROP FUNCTION IF EXISTS fn_sub;\n"
+
+	stmts := Split(script)
+	if !assert.Len(t, stmts, 2) {
+		return
+	}
+
+	node, ok := ParseProgram(stmts[0])
+	if assert.True(t, ok) {
+		cf, ok := node.(*CreateFunctionStmt)
+		if assert.True(t, ok) {
+			assert.Equal(t, "db1", cf.Name.Schema)
+			assert.Equal(t, "fn_add", cf.Name.Name)
+			assert.Equal(t, stmts[0], cf.Text())
+		}
+	}
+
+	node, ok = ParseProgram(stmts[1])
+	if assert.True(t, ok) {
+		df, ok := node.(*DropFunctionStmt)
+		if assert.True(t, ok) {
+			assert.True(t, df.IfExists)
+			assert.Equal(t, "", df.Name.Schema)
+			assert.Equal(t, "fn_sub", df.Name.Name)
+		}
+	}
+
+	_, ok = ParseProgram("CREATE TABLE t1 (id INT)")
+	assert.False(t, ok)
+}
+
+func TestParseTrigger(t *testing.T) {
+	script := "/*!50003 CREATE*/ /*!50017 DEFINER=`root`@`localhost`*/ /*!50003 TRIGGER tg_t1_bi BEFORE INSERT ON `t1`\n" +
+		"FOR EACH ROW BEGIN\n" +
+		"  IF NOT NEW.name THEN\n" +
+		"    SET NEW.name = 'x';\n" +
+		"  END IF;\n" +
+		"END */;\n" +
+		"DROP TRIGGER IF EXISTS db1.tg_t1_bi;\n"
+
+	stmts := Split(script)
+	if !assert.Len(t, stmts, 2) {
+		return
+	}
+
+	node, ok := ParseProgram(stmts[0])
+	if assert.True(t, ok) {
+		ct, ok := node.(*CreateTriggerStmt)
+		if assert.True(t, ok) {
+			assert.Equal(t, "tg_t1_bi", ct.Name.Name)
+			assert.Equal(t, "BEFORE", ct.Timing)
+			assert.Equal(t, "INSERT", ct.Event)
+			assert.Equal(t, "t1", ct.Table.Name)
+		}
+	}
+
+	node, ok = ParseProgram(stmts[1])
+	if assert.True(t, ok) {
+		dt, ok := node.(*DropTriggerStmt)
+		if assert.True(t, ok) {
+			assert.True(t, dt.IfExists)
+			assert.Equal(t, "db1", dt.Name.Schema)
+			assert.Equal(t, "tg_t1_bi", dt.Name.Name)
+		}
+	}
+}
+
+func TestParseEvent(t *testing.T) {
+	script := "CREATE EVENT IF NOT EXISTS ev_purge ON SCHEDULE EVERY 1 DAY COMMENT 'purge' DO\n" +
+		"BEGIN\n" +
+		"  DECLARE n INT DEFAULT 0;\n" +
+		"  IF NOT EXISTS (SELECT 1 FROM t2) THEN\n" +
+		"    DELETE FROM t1;\n" +
+		"  ELSE\n" +
+		"    UPDATE t1 SET c = 1 WHERE id = 1;\n" +
+		"  END IF;\n" +
+		"END;\n" +
+		"ALTER EVENT ev_purge RENAME TO db1.ev_clean;\n"
+
+	stmts := Split(script)
+	if !assert.Len(t, stmts, 2) {
+		return
+	}
+
+	node, ok := ParseProgram(stmts[0])
+	if assert.True(t, ok) {
+		ce, ok := node.(*CreateEventStmt)
+		if assert.True(t, ok) {
+			assert.True(t, ce.IfNotExists)
+			assert.Equal(t, "ev_purge", ce.Name.Name)
+			assert.Equal(t, []string{
+				"DECLARE n INT DEFAULT 0",
+				"DELETE FROM t1",
+				"UPDATE t1 SET c = 1 WHERE id = 1",
+				"END IF",
+				"END",
+			}, programBody(ce.Body))
+		}
+	}
+
+	node, ok = ParseProgram(stmts[1])
+	if assert.True(t, ok) {
+		ae, ok := node.(*AlterEventStmt)
+		if assert.True(t, ok) {
+			assert.Equal(t, "ev_purge", ae.Name.Name)
+			assert.Equal(t, "db1", ae.NewName.Schema)
+			assert.Equal(t, "ev_clean", ae.NewName.Name)
+			assert.Equal(t, "", ae.Body)
+		}
+	}
+}
+
+func TestParseProcedure(t *testing.T) {
+	script := "DELIMITER $$\n" +
+		"CREATE PROCEDURE sp_purge(IN p_id INT)\n" +
+		"  MODIFIES SQL DATA\n" +
+		"BEGIN\n" +
+		"  WHILE p_id > 0 DO\n" +
+		"    DELETE FROM t1;\n" +
+		"    SET p_id = p_id - 1;\n" +
+		"  END WHILE;\n" +
+		"END$$\n" +
+		"DELIMITER ;\n" +
+		"DROP PROCEDURE sp_purge;\n"
+
+	stmts := Split(script)
+	if !assert.Len(t, stmts, 2) {
+		return
+	}
+
+	node, ok := ParseProgram(stmts[0])
+	if assert.True(t, ok) {
+		cp, ok := node.(*CreateProcedureStmt)
+		if assert.True(t, ok) {
+			assert.Equal(t, "sp_purge", cp.Name.Name)
+			assert.Equal(t, []string{
+				"DELETE FROM t1",
+				"SET p_id = p_id - 1",
+				"END WHILE",
+				"END",
+			}, programBody(cp.Body))
+		}
+	}
+
+	node, ok = ParseProgram(stmts[1])
+	if assert.True(t, ok) {
+		dp, ok := node.(*DropProcedureStmt)
+		if assert.True(t, ok) {
+			assert.False(t, dp.IfExists)
+			assert.Equal(t, "sp_purge", dp.Name.Name)
+		}
+	}
+}
@@ -0,0 +1,378 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/go-xorm/core"
+	"github.com/mia0x75/parser"
+	"github.com/mia0x75/parser/ast"
+
+	"github.com/mia0x75/halo/g"
+	"github.com/mia0x75/halo/models"
+	"github.com/mia0x75/halo/tools"
+)
+
+// 接口确认，如果有报错表示没有实现接口的全部方法
+var (
+	_ Provider = &ClusterProvider{}
+	_ Provider = &DumpProvider{}
+	_ Provider = &SnapshotProvider{}
+)
+
+// Provider 元数据的来源，用于填充审核上下文中的库表结构
+type Provider interface {
+	Databases() ([]models.Database, error)     // 全部用户数据库
+	Tables() (map[string][]*core.Table, error) // 全部表结构，键为数据库名称
+	Routines() ([]models.Routine, error)       // 全部存储函数和存储过程
+	Triggers() ([]models.Trigger, error)       // 全部触发器
+	Events() ([]models.Event, error)           // 全部事件
+	Variables() (map[string]string, error)     // 审核需要的全局变量
+	TableStats() ([]models.TableStat, error)   // 全部表的行数和大小
+}
+
+// LoadError 读取元数据时失败的部分，其他部分已经正常填充
+type LoadError struct {
+	Parts []string // 失败的部分和原因
+}
+
+// Error 实现error接口
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("错误代码: 1500, 错误信息: 读取元数据失败，%s。", strings.Join(e.Parts, "; "))
+}
+
+// Load 从元数据的来源填充审核上下文，每一部分单独读取，某一部分失败时不影响其他部分，
+// 全部失败的部分通过LoadError返回
+func (ctx *Context) Load(p Provider) error {
+	e := &LoadError{}
+	collect := func(part string, err error) {
+		if err != nil {
+			e.Parts = append(e.Parts, fmt.Sprintf("%s: %s", part, err.Error()))
+		}
+	}
+	var err error
+	ctx.Databases, err = p.Databases()
+	collect("数据库", err)
+	ctx.Tables, err = p.Tables()
+	collect("表结构", err)
+	ctx.Routines, err = p.Routines()
+	collect("存储函数和存储过程", err)
+	ctx.Triggers, err = p.Triggers()
+	collect("触发器", err)
+	ctx.Events, err = p.Events()
+	collect("事件", err)
+	ctx.Variables, err = p.Variables()
+	collect("全局变量", err)
+	ctx.TableStats, err = p.TableStats()
+	collect("表的统计信息", err)
+	ctx.Version = ctx.version()
+	if len(e.Parts) > 0 {
+		return e
+	}
+	return nil
+}
+
+// DescribeLoadError 元数据不完整时依赖库表结构的规则可能误报或者漏报，
+// 作为语句级别的描述写入每一条语句的报告
+func (ctx *Context) DescribeLoadError(err error) {
+	description := err.Error()
+	if e, ok := err.(*LoadError); ok {
+		description = fmt.Sprintf("元数据读取不完整，审核结果可能不准确，%s。", strings.Join(e.Parts, "; "))
+	}
+	for _, s := range ctx.Stmts {
+		if s.Violations == nil {
+			continue
+		}
+		s.Violations.Append(&models.Clause{
+			Description: description,
+			Level:       2,
+		})
+	}
+}
+
+// variables 审核规则需要读取的全局变量
+var variables = []string{"event_scheduler", "version", "version_comment"}
+
+// ClusterProvider 从在线的群集读取元数据
+type ClusterProvider struct {
+	Cluster *models.Cluster
+	Passwd  func(c *models.Cluster) []byte
+}
+
+// NewClusterProvider 使用系统配置的密钥解密群集的密码
+func NewClusterProvider(cluster *models.Cluster) *ClusterProvider {
+	return &ClusterProvider{
+		Cluster: cluster,
+		Passwd: func(c *models.Cluster) []byte {
+			bs, _ := tools.DecryptAES(c.Password, g.Config().Secret.Crypto)
+			return bs
+		},
+	}
+}
+
+// Databases 群集上全部的用户数据库
+func (p *ClusterProvider) Databases() ([]models.Database, error) {
+	return p.Cluster.Databases(p.Passwd)
+}
+
+// Tables 群集上全部的表结构
+func (p *ClusterProvider) Tables() (map[string][]*core.Table, error) {
+	return p.Cluster.Metadata("*", p.Passwd)
+}
+
+// Routines 群集上全部的存储函数和存储过程
+func (p *ClusterProvider) Routines() ([]models.Routine, error) {
+	return p.Cluster.Routines(p.Passwd)
+}
+
+// Triggers 群集上全部的触发器
+func (p *ClusterProvider) Triggers() ([]models.Trigger, error) {
+	return p.Cluster.Triggers(p.Passwd)
+}
+
+// Events 群集上全部的事件
+func (p *ClusterProvider) Events() ([]models.Event, error) {
+	return p.Cluster.Events(p.Passwd)
+}
+
+// Variables 群集上审核需要的全局变量
+func (p *ClusterProvider) Variables() (map[string]string, error) {
+	return p.Cluster.Variables(p.Passwd, variables...)
+}
+
+// TableStats 群集上全部表的行数和大小
+func (p *ClusterProvider) TableStats() ([]models.TableStat, error) {
+	return p.Cluster.TableStats(p.Passwd)
+}
+
+// DumpProvider 从mysqldump --no-data导出的文件读取元数据，
+// 文件中的语句依次作用在一个空的结构上，不需要连接群集
+type DumpProvider struct {
+	schema    *Schema
+	variables map[string]string
+}
+
+// 导出文件头部记录的服务器版本，例如：-- Server version	5.7.30-log
+var dumpVersionPattern = regexp.MustCompile(`(?m)^--\s*Server version\s+(\S+)`)
+
+// NewDumpProvider 读取并重放导出文件，database是导出文件中没有USE语句时表所属的数据库
+func NewDumpProvider(path string, database string) (*DumpProvider, error) {
+	bs, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDump(string(bs), database), nil
+}
+
+// ParseDump 重放导出的内容，不能解析的语句（比如视图）直接跳过，
+// 使用--routines和--events导出的存储程序、事件以及默认导出的触发器同样会被记录
+func ParseDump(content string, database string) *DumpProvider {
+	schema := NewSchema(nil, nil)
+	if database != "" {
+		schema.Databases = append(schema.Databases, models.Database{Name: database})
+	}
+
+	p := parser.New()
+	current := database
+	for _, sql := range Split(content) {
+		nodes, err := parse(p, sql)
+		if err != nil {
+			continue
+		}
+		for _, node := range nodes {
+			if use, ok := node.(*ast.UseStmt); ok {
+				current = use.DBName
+				continue
+			}
+			if next := schema.Apply(node, current); next != nil {
+				schema = next
+			}
+		}
+	}
+
+	variables := map[string]string{}
+	if match := dumpVersionPattern.FindStringSubmatch(content); match != nil {
+		variables["version"] = match[1]
+	}
+	return &DumpProvider{
+		schema:    schema,
+		variables: variables,
+	}
+}
+
+// Databases 导出文件中的数据库
+func (p *DumpProvider) Databases() ([]models.Database, error) {
+	return p.schema.Databases, nil
+}
+
+// Tables 导出文件中的表结构
+func (p *DumpProvider) Tables() (map[string][]*core.Table, error) {
+	return p.schema.Tables, nil
+}
+
+// Routines 导出文件中的存储函数和存储过程
+func (p *DumpProvider) Routines() ([]models.Routine, error) {
+	return p.schema.Routines, nil
+}
+
+// Triggers 导出文件中的触发器
+func (p *DumpProvider) Triggers() ([]models.Trigger, error) {
+	return p.schema.Triggers, nil
+}
+
+// Events 导出文件中的事件
+func (p *DumpProvider) Events() ([]models.Event, error) {
+	return p.schema.Events, nil
+}
+
+// Variables 导出文件中只有头部记录的服务器版本
+func (p *DumpProvider) Variables() (map[string]string, error) {
+	return p.variables, nil
+}
+
+// TableStats 导出文件中没有表的统计信息
+func (p *DumpProvider) TableStats() ([]models.TableStat, error) {
+	return nil, nil
+}
+
+// Snapshot 群集结构的快照，以JSON格式保存，表结构使用models.Table重新封包
+type Snapshot struct {
+	Databases  []models.Database
+	Tables     map[string][]*models.Table
+	Routines   []models.Routine
+	Triggers   []models.Trigger
+	Events     []models.Event
+	Variables  map[string]string
+	TableStats []models.TableStat
+}
+
+// TakeSnapshot 从任意的元数据来源生成快照
+func TakeSnapshot(p Provider) (snapshot *Snapshot, err error) {
+	snapshot = &Snapshot{
+		Tables: make(map[string][]*models.Table),
+	}
+	if snapshot.Databases, err = p.Databases(); err != nil {
+		return nil, err
+	}
+	if snapshot.Routines, err = p.Routines(); err != nil {
+		return nil, err
+	}
+	if snapshot.Triggers, err = p.Triggers(); err != nil {
+		return nil, err
+	}
+	if snapshot.Events, err = p.Events(); err != nil {
+		return nil, err
+	}
+	if snapshot.Variables, err = p.Variables(); err != nil {
+		return nil, err
+	}
+	if snapshot.TableStats, err = p.TableStats(); err != nil {
+		return nil, err
+	}
+	tables, err := p.Tables()
+	if err != nil {
+		return nil, err
+	}
+	for database, elems := range tables {
+		for _, t := range elems {
+			snapshot.Tables[database] = append(snapshot.Tables[database], &models.Table{
+				Name:          t.Name,
+				Columns:       t.Columns(),
+				Indexes:       t.Indexes,
+				PrimaryKeys:   t.PrimaryKeys,
+				AutoIncrement: t.AutoIncrement,
+				Created:       t.Created,
+				Updated:       t.Updated,
+				Deleted:       t.Deleted,
+				Version:       t.Version,
+				StoreEngine:   t.StoreEngine,
+				Charset:       t.Charset,
+				Collate:       t.Collate,
+				Comment:       t.Comment,
+			})
+		}
+	}
+	return
+}
+
+// SnapshotProvider 从JSON格式的快照读取元数据
+type SnapshotProvider struct {
+	snapshot *Snapshot
+}
+
+// NewSnapshotProvider 读取快照文件
+func NewSnapshotProvider(path string) (*SnapshotProvider, error) {
+	bs, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(bs, snapshot); err != nil {
+		return nil, err
+	}
+	return &SnapshotProvider{
+		snapshot: snapshot,
+	}, nil
+}
+
+// Databases 快照中的数据库
+func (p *SnapshotProvider) Databases() ([]models.Database, error) {
+	return p.snapshot.Databases, nil
+}
+
+// Routines 快照中的存储函数和存储过程
+func (p *SnapshotProvider) Routines() ([]models.Routine, error) {
+	return p.snapshot.Routines, nil
+}
+
+// Triggers 快照中的触发器
+func (p *SnapshotProvider) Triggers() ([]models.Trigger, error) {
+	return p.snapshot.Triggers, nil
+}
+
+// Events 快照中的事件
+func (p *SnapshotProvider) Events() ([]models.Event, error) {
+	return p.snapshot.Events, nil
+}
+
+// Variables 快照中的全局变量
+func (p *SnapshotProvider) Variables() (map[string]string, error) {
+	if p.snapshot.Variables == nil {
+		return map[string]string{}, nil
+	}
+	return p.snapshot.Variables, nil
+}
+
+// TableStats 快照中表的行数和大小
+func (p *SnapshotProvider) TableStats() ([]models.TableStat, error) {
+	return p.snapshot.TableStats, nil
+}
+
+// Tables 快照中的表结构，还原为审核规则使用的core.Table
+func (p *SnapshotProvider) Tables() (map[string][]*core.Table, error) {
+	tables := make(map[string][]*core.Table, len(p.snapshot.Tables))
+	for database, elems := range p.snapshot.Tables {
+		for _, elem := range elems {
+			table := core.NewEmptyTable()
+			table.Name = elem.Name
+			table.StoreEngine = elem.StoreEngine
+			table.Charset = elem.Charset
+			table.Collate = elem.Collate
+			table.Comment = elem.Comment
+			for _, col := range elem.Columns {
+				if col.Indexes == nil {
+					col.Indexes = make(map[string]int)
+				}
+				table.AddColumn(col)
+			}
+			for _, index := range elem.Indexes {
+				table.AddIndex(index)
+			}
+			tables[database] = append(tables[database], table)
+		}
+	}
+	return tables, nil
+}
@@ -0,0 +1,79 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-xorm/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mia0x75/halo/models"
+)
+
+func TestParseDump(t *testing.T) {
+	dump := "-- MySQL dump 10.13\n" +
+		"/*!40101 SET NAMES utf8mb4 */;\n" +
+		"CREATE DATABASE /*!32312 IF NOT EXISTS*/ `db1` /*!40100 DEFAULT CHARACTER SET utf8mb4 */;\n" +
+		"USE `db1`;\n" +
+		"DROP TABLE IF EXISTS `t1`;\n" +
+		"CREATE TABLE `t1` (\n" +
+		"  `id` int(11) NOT NULL AUTO_INCREMENT,\n" +
+		"  `name` varchar(64) NOT NULL DEFAULT ';' COMMENT 'name',\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  KEY `idx_name` (`name`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n"
+
+	p := ParseDump(dump, "")
+	databases, _ := p.Databases()
+	if assert.Len(t, databases, 1) {
+		assert.Equal(t, "db1", databases[0].Name)
+		assert.Equal(t, "utf8mb4", databases[0].Charset)
+	}
+
+	tables, _ := p.Tables()
+	if assert.Len(t, tables["db1"], 1) {
+		ti := tables["db1"][0]
+		assert.Equal(t, "t1", ti.Name)
+		assert.Equal(t, "InnoDB", ti.StoreEngine)
+		assert.Equal(t, []string{"id"}, ti.PrimaryKeys)
+		assert.Contains(t, ti.Indexes, "idx_name")
+		assert.False(t, ti.GetColumn("name").Nullable)
+	}
+}
+
+// brokenProvider 表结构和事件读取失败，其他部分来自导出文件
+type brokenProvider struct {
+	*DumpProvider
+}
+
+func (p *brokenProvider) Tables() (map[string][]*core.Table, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (p *brokenProvider) Events() ([]models.Event, error) {
+	return nil, errors.New("access denied")
+}
+
+func TestLoad(t *testing.T) {
+	p := &brokenProvider{ParseDump("CREATE DATABASE `db1`;\nUSE `db1`;\nCREATE TABLE `t1` (`id` int(11));\n", "")}
+	s := &models.Statement{Violations: &models.Violations{}}
+	ctx := &Context{Stmts: []*models.Statement{s}}
+	err := ctx.Load(p)
+	if assert.IsType(t, &LoadError{}, err) {
+		assert.Len(t, err.(*LoadError).Parts, 2)
+		assert.Contains(t, err.Error(), "connection refused")
+		assert.Contains(t, err.Error(), "access denied")
+	}
+	// 失败的部分不影响其他部分
+	assert.Len(t, ctx.Databases, 1)
+	assert.Nil(t, ctx.Tables)
+
+	ctx.DescribeLoadError(err)
+	clauses := s.Violations.Clauses()
+	if assert.Len(t, clauses, 1) {
+		assert.Equal(t, uint8(2), clauses[0].Level)
+		assert.Contains(t, clauses[0].Description, "表结构: connection refused")
+	}
+
+	assert.NoError(t, (&Context{}).Load(ParseDump("", "")))
+}
@@ -0,0 +1,150 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mia0x75/halo/models"
+)
+
+// Base 规则组的基础实现，自定义规则组嵌入该结构体即可获得规则加载、上下文和元数据查询，
+// 只需要实现Validate方法
+type Base = vldr
+
+// RuleFunc 注册的规则函数，ctx是当前工单的审核上下文，s是正在审核的语句，
+// 语句执行之前的结构可以通过ctx.SchemaOf(s)获得
+type RuleFunc func(ctx *Context, s *models.Statement, r *models.Rule)
+
+// 规则组上规则方法的签名，MiscVldr的规则方法只有规则参数
+var (
+	ruleMethod = reflect.TypeOf(func(*models.Statement, *models.Rule) {})
+	miscMethod = reflect.TypeOf(func(*models.Rule) {})
+)
+
+var (
+	mutex sync.RWMutex
+	rules = map[string]RuleFunc{} // 注册的规则函数，键与规则的func对应
+)
+
+// 相当于注册表，保存的是各个验证组的构造函数，每次审核都会重新生成验证器，
+// 避免多个工单同时审核时共享语法树和上下文
+var registry = map[uint16]func() Validator{
+	100: func() Validator { return &DatabaseCreateVldr{} },
+	101: func() Validator { return &DatabaseAlterVldr{} },
+	102: func() Validator { return &DatabaseDropVldr{} },
+	110: func() Validator { return &TableCreateVldr{} },
+	120: func() Validator { return &TableAlterVldr{} },
+	130: func() Validator { return &TableRenameVldr{} },
+	140: func() Validator { return &TableDropVldr{} },
+	150: func() Validator { return &IndexCreateVldr{} },
+	151: func() Validator { return &IndexDropVldr{} },
+	160: func() Validator { return &InsertVldr{} },
+	161: func() Validator { return &ReplaceVldr{} },
+	162: func() Validator { return &UpdateVldr{} },
+	163: func() Validator { return &DeleteVldr{} },
+	170: func() Validator { return &SelectVldr{} },
+	180: func() Validator { return &ViewCreateVldr{} },
+	181: func() Validator { return &ViewAlterVldr{} },
+	182: func() Validator { return &ViewDropVldr{} },
+	190: func() Validator { return &FuncCreateVldr{} },
+	191: func() Validator { return &FuncAlterVldr{} },
+	192: func() Validator { return &FuncDropVldr{} },
+	200: func() Validator { return &TriggerCreateVldr{} },
+	201: func() Validator { return &TriggerAlterVldr{} },
+	202: func() Validator { return &TriggerDropVldr{} },
+	210: func() Validator { return &EventCreateVldr{} },
+	211: func() Validator { return &EventAlterVldr{} },
+	212: func() Validator { return &EventDropVldr{} },
+	220: func() Validator { return &ProcCreateVldr{} },
+	221: func() Validator { return &ProcAlterVldr{} },
+	222: func() Validator { return &ProcDropVldr{} },
+	230: func() Validator { return &MiscVldr{} },
+	240: func() Validator { return &VersionVldr{} },
+}
+
+// RegisterGroup 注册规则组，gid与规则的vldr_group对应，已经注册过的规则组不能覆盖
+func RegisterGroup(gid uint16, factory func() Validator) error {
+	if factory == nil {
+		return fmt.Errorf("错误代码: 1500, 错误信息: 规则组%d没有构造函数。", gid)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if _, ok := registry[gid]; ok {
+		return fmt.Errorf("错误代码: 1500, 错误信息: 规则组%d已经注册。", gid)
+	}
+	registry[gid] = factory
+	return nil
+}
+
+// RegisterRule 注册规则函数，name与规则的func对应，优先于规则组上的同名方法，
+// 同一个规则函数可以被不同规则组的规则使用
+func RegisterRule(name string, fn RuleFunc) error {
+	if name == "" || fn == nil {
+		return fmt.Errorf("错误代码: 1500, 错误信息: 规则函数`%s`没有实现。", name)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if _, ok := rules[name]; ok {
+		return fmt.Errorf("错误代码: 1500, 错误信息: 规则函数`%s`已经注册。", name)
+	}
+	rules[name] = fn
+	return nil
+}
+
+// Verify 检查每一条规则都有实现，服务启动和规则变更时调用，
+// 规则组没有注册或者规则函数找不到时返回错误，而不是在审核时静默跳过
+func Verify(L []*models.Rule) error {
+	missing := []string{}
+	for _, r := range L {
+		if reason := unresolved(r); reason != "" {
+			missing = append(missing, fmt.Sprintf("%s(%s)", r.Name, reason))
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("错误代码: 1500, 错误信息: 以下规则没有实现，%s。", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Resolved 规则是否有实现，缓存加载规则时关闭没有实现的规则
+func Resolved(r *models.Rule) bool {
+	return unresolved(r) == ""
+}
+
+// unresolved 返回规则没有实现的原因，有实现时返回空串
+func unresolved(r *models.Rule) string {
+	if ruleFunc(r.Func) != nil {
+		return ""
+	}
+	factory, ok := groups()[r.VldrGroup]
+	if !ok {
+		return fmt.Sprintf("规则组%d没有注册", r.VldrGroup)
+	}
+	m := reflect.ValueOf(factory()).MethodByName(r.Func)
+	if !m.IsValid() || (m.Type() != ruleMethod && m.Type() != miscMethod) {
+		return fmt.Sprintf("规则函数%s没有实现", r.Func)
+	}
+	return ""
+}
+
+// groups 注册表的快照，审核期间注册新的规则组不影响正在进行的审核
+func groups() map[uint16]func() Validator {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	m := make(map[uint16]func() Validator, len(registry))
+	for gid, factory := range registry {
+		m[gid] = factory
+	}
+	return m
+}
+
+// ruleFunc 按名称查找注册的规则函数
+func ruleFunc(name string) RuleFunc {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return rules[name]
+}
@@ -0,0 +1,86 @@
+package validate
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mia0x75/halo/models"
+)
+
+// customVldr 自定义的规则组，只需要嵌入Base并实现Validate
+type customVldr struct {
+	Base
+}
+
+func (v *customVldr) Enabled() bool {
+	return true
+}
+
+func (v *customVldr) Validate(wg *sync.WaitGroup) {
+	defer wg.Done()
+	for _, s := range v.Ctx.Stmts {
+		for _, r := range v.Rules {
+			Call(v, r.Func, s, r)
+		}
+	}
+}
+
+func (v *customVldr) CustomMethod(s *models.Statement, r *models.Rule) {
+	s.Violations.Append(&models.Clause{Description: r.Message, Level: r.Level})
+}
+
+func TestRegister(t *testing.T) {
+	const gid = 900
+	defer func() {
+		mutex.Lock()
+		delete(registry, gid)
+		delete(rules, "CustomRuleFunc")
+		mutex.Unlock()
+	}()
+
+	// 规则组
+	assert.Error(t, RegisterGroup(gid, nil))
+	assert.NoError(t, RegisterGroup(gid, func() Validator { return &customVldr{} }))
+	assert.Error(t, RegisterGroup(gid, func() Validator { return &customVldr{} }))
+	assert.Error(t, RegisterGroup(110, func() Validator { return &customVldr{} }))
+
+	// 规则函数
+	fn := func(ctx *Context, s *models.Statement, r *models.Rule) {
+		s.Violations.Append(&models.Clause{Description: ctx.Ticket.Database, Level: r.Level})
+	}
+	assert.Error(t, RegisterRule("", fn))
+	assert.Error(t, RegisterRule("CustomRuleFunc", nil))
+	assert.NoError(t, RegisterRule("CustomRuleFunc", fn))
+	assert.Error(t, RegisterRule("CustomRuleFunc", fn))
+
+	// 检查规则的实现
+	L := []*models.Rule{
+		{Name: "CUS-L1-001", VldrGroup: gid, Func: "CustomMethod"},
+		{Name: "CUS-L1-002", VldrGroup: gid, Func: "CustomRuleFunc"},
+		{Name: "CUS-L1-003", VldrGroup: 230, Func: "LockTableProhibited"},
+	}
+	assert.NoError(t, Verify(L))
+	err := Verify(append(L,
+		&models.Rule{Name: "CUS-L1-004", VldrGroup: gid, Func: "NoSuchMethod"},
+		&models.Rule{Name: "CUS-L1-005", VldrGroup: 901, Func: "CustomMethod"},
+	))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "CUS-L1-004")
+	assert.Contains(t, err.Error(), "CUS-L1-005")
+	assert.True(t, Resolved(L[1]))
+	assert.False(t, Resolved(&models.Rule{Name: "CUS-L1-004", VldrGroup: gid, Func: "NoSuchMethod"}))
+
+	// 注册的规则函数优先于规则组上的方法
+	v := &customVldr{}
+	v.SetContext(&Context{Ticket: &models.Ticket{Database: "sample"}})
+	s := &models.Statement{Violations: &models.Violations{}}
+	assert.NoError(t, Call(v, "CustomRuleFunc", s, &models.Rule{Level: 2}))
+	assert.NoError(t, Call(v, "CustomMethod", s, &models.Rule{Message: "custom", Level: 1}))
+	assert.Error(t, Call(v, "NoSuchMethod", s, &models.Rule{}))
+	clauses := s.Violations.Clauses()
+	assert.Equal(t, 2, len(clauses))
+	assert.Equal(t, "sample", clauses[0].Description)
+	assert.Equal(t, "custom", clauses[1].Description)
+}
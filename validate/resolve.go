@@ -0,0 +1,362 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-xorm/core"
+	"github.com/mia0x75/parser/ast"
+
+	"github.com/mia0x75/halo/models"
+)
+
+// Source FROM子句中的一个数据源，可以是实体表，也可以是派生表
+type Source struct {
+	Database string
+	Name     string         // 实体表的表名，派生表为空
+	Alias    string         // 别名，没有别名时为空
+	Table    *core.Table    // 实体表的结构，表不存在或者是派生表时为nil
+	Columns  []*core.Column // 数据源能提供的全部列
+	Complete bool           // Columns是否完整，表不存在或者派生表中有无法展开的*时为false
+}
+
+// match 限定名是否指向当前数据源，有别名时只能使用别名
+func (src *Source) match(database, table string) bool {
+	if src.Alias != "" {
+		return database == "" && strings.EqualFold(src.Alias, table)
+	}
+	if database != "" && database != src.Database {
+		return false
+	}
+	return src.Name == table
+}
+
+// column 根据列名查找数据源中的列，列名不区分大小写
+func (src *Source) column(name string) *core.Column {
+	for _, col := range src.Columns {
+		if strings.EqualFold(col.Name, name) {
+			return col
+		}
+	}
+	return nil
+}
+
+// Scope 名称解析的作用域，对应一个查询块，子查询可以引用外层查询块中的数据源
+type Scope struct {
+	Parent  *Scope
+	Sources []*Source
+	Aliases []string // 选择列的别名，ORDER BY、GROUP BY和HAVING中可以引用
+}
+
+// ColumnRef 语句中对列的一次引用
+type ColumnRef struct {
+	Name      *ast.ColumnName
+	Source    *Source      // 列所属的数据源，无法解析时为nil
+	Column    *core.Column // 解析到的列，无法解析时为nil
+	Uncertain bool         // 可能的数据源中有结构未知的，无法判断列是否存在
+}
+
+// resolver 遍历DML语句的语法树，记录访问的表以及每一个列引用解析到的列
+type resolver struct {
+	v      *vldr
+	tables []VisitInfo
+	refs   []*ColumnRef
+}
+
+// resolve 解析语句，返回访问的表、列引用以及最外层查询返回的列
+func (v *vldr) resolve(node ast.Node) (tables []VisitInfo, refs []*ColumnRef, fields []*core.Column) {
+	r := &resolver{v: v}
+	switch x := node.(type) {
+	case *ast.SelectStmt, *ast.UnionStmt:
+		fields, _ = r.query(x.(ast.ResultSetNode), nil)
+	case *ast.UpdateStmt:
+		scope := &Scope{}
+		if x.TableRefs != nil {
+			r.from(x.TableRefs.TableRefs, scope)
+		}
+		for _, a := range x.List {
+			r.column(a.Column, scope)
+			r.expr(a.Expr, scope)
+		}
+		r.expr(x.Where, scope)
+		if x.Order != nil {
+			for _, item := range x.Order.Items {
+				r.expr(item.Expr, scope)
+			}
+		}
+	case *ast.DeleteStmt:
+		scope := &Scope{}
+		if x.TableRefs != nil {
+			r.from(x.TableRefs.TableRefs, scope)
+		}
+		r.expr(x.Where, scope)
+		if x.Order != nil {
+			for _, item := range x.Order.Items {
+				r.expr(item.Expr, scope)
+			}
+		}
+	case *ast.InsertStmt:
+		scope := &Scope{}
+		if x.Table != nil {
+			r.from(x.Table.TableRefs, scope)
+		}
+		for _, col := range x.Columns {
+			r.column(col, scope)
+		}
+		for _, list := range x.Lists {
+			for _, e := range list {
+				r.expr(e, scope)
+			}
+		}
+		for _, a := range x.Setlist {
+			r.column(a.Column, scope)
+			r.expr(a.Expr, scope)
+		}
+		for _, a := range x.OnDuplicate {
+			r.column(a.Column, scope)
+			r.expr(a.Expr, scope)
+		}
+		if x.Select != nil {
+			r.query(x.Select, nil)
+		}
+	}
+	return r.tables, r.refs, fields
+}
+
+// query 解析一个查询块，返回查询块输出的列以及这些列是否完整
+func (r *resolver) query(node ast.ResultSetNode, parent *Scope) ([]*core.Column, bool) {
+	switch x := node.(type) {
+	case *ast.UnionStmt:
+		var fields []*core.Column
+		complete := true
+		if x.SelectList != nil {
+			for i, sel := range x.SelectList.Selects {
+				cols, ok := r.query(sel, parent)
+				// UNION的列名以第一个查询为准
+				if i == 0 {
+					fields, complete = cols, ok
+				}
+			}
+		}
+		return fields, complete
+	case *ast.SelectStmt:
+		return r.selectStmt(x, parent)
+	}
+	return nil, false
+}
+
+func (r *resolver) selectStmt(x *ast.SelectStmt, parent *Scope) ([]*core.Column, bool) {
+	scope := &Scope{Parent: parent}
+	if x.From != nil {
+		r.from(x.From.TableRefs, scope)
+	}
+
+	fields := []*core.Column{}
+	complete := true
+	if x.Fields != nil {
+		for _, field := range x.Fields.Fields {
+			if field.WildCard != nil {
+				// SELECT *或者SELECT alias.*，展开对应数据源的全部列
+				for _, src := range scope.Sources {
+					if field.WildCard.Table.O != "" && !src.match(field.WildCard.Schema.O, field.WildCard.Table.O) {
+						continue
+					}
+					fields = append(fields, src.Columns...)
+					complete = complete && src.Complete
+				}
+				continue
+			}
+			r.expr(field.Expr, scope)
+			name := field.AsName.O
+			if name != "" {
+				scope.Aliases = append(scope.Aliases, name)
+			}
+			col := &core.Column{}
+			if cn, ok := field.Expr.(*ast.ColumnNameExpr); ok {
+				if ref := r.refs[len(r.refs)-1]; ref.Column != nil {
+					copied := *ref.Column
+					col = &copied
+				}
+				if name == "" {
+					name = cn.Name.Name.O
+				}
+			}
+			if name == "" {
+				name = strings.TrimSpace(field.Text())
+			}
+			col.Name = name
+			fields = append(fields, col)
+		}
+	}
+
+	r.expr(x.Where, scope)
+	if x.GroupBy != nil {
+		for _, item := range x.GroupBy.Items {
+			r.expr(item.Expr, scope)
+		}
+	}
+	if x.Having != nil {
+		r.expr(x.Having.Expr, scope)
+	}
+	if x.OrderBy != nil {
+		for _, item := range x.OrderBy.Items {
+			r.expr(item.Expr, scope)
+		}
+	}
+	return fields, complete
+}
+
+// from 解析FROM子句，数据源加入到作用域中，ON条件同时解析
+func (r *resolver) from(node ast.ResultSetNode, scope *Scope) {
+	switch x := node.(type) {
+	case *ast.Join:
+		if x.Left != nil {
+			r.from(x.Left, scope)
+		}
+		if x.Right != nil {
+			r.from(x.Right, scope)
+		}
+		if x.On != nil {
+			r.expr(x.On.Expr, scope)
+		}
+		for _, col := range x.Using {
+			r.column(col, scope)
+		}
+	case *ast.TableSource:
+		switch source := x.Source.(type) {
+		case *ast.TableName:
+			src := r.table(source)
+			src.Alias = x.AsName.O
+			scope.Sources = append(scope.Sources, src)
+		case *ast.SelectStmt, *ast.UnionStmt:
+			// 派生表不能引用同一个FROM子句中的其他数据源
+			cols, complete := r.query(source, scope.Parent)
+			scope.Sources = append(scope.Sources, &Source{
+				Alias:    x.AsName.O,
+				Columns:  cols,
+				Complete: complete,
+			})
+		}
+	case *ast.TableName:
+		scope.Sources = append(scope.Sources, r.table(x))
+	}
+}
+
+// table 实体表作为数据源，同时记录访问信息
+func (r *resolver) table(tn *ast.TableName) *Source {
+	database := tn.Schema.O
+	if database == "" {
+		database = r.v.Ctx.Ticket.Database
+	}
+	r.tables = append(r.tables, VisitInfo{
+		Database: database,
+		Table: &TableEntry{
+			Name: tn.Name.O,
+		},
+	})
+	src := &Source{
+		Database: database,
+		Name:     tn.Name.O,
+	}
+	if table := r.v.TableInfo(database, tn.Name.O); table != nil {
+		src.Table = table
+		src.Columns = table.Columns()
+		src.Complete = true
+	}
+	return src
+}
+
+// expr 解析表达式中的全部列引用，子查询使用新的作用域
+func (r *resolver) expr(node ast.ExprNode, scope *Scope) {
+	if node == nil {
+		return
+	}
+	node.Accept(&exprVisitor{r: r, scope: scope})
+}
+
+// column 在作用域中查找列，当前作用域中找不到时到外层查找
+func (r *resolver) column(name *ast.ColumnName, scope *Scope) {
+	ref := &ColumnRef{Name: name}
+	r.refs = append(r.refs, ref)
+
+	for sc := scope; sc != nil; sc = sc.Parent {
+		for _, src := range sc.Sources {
+			if name.Table.O != "" && !src.match(name.Schema.O, name.Table.O) {
+				continue
+			}
+			if col := src.column(name.Name.O); col != nil {
+				ref.Source = src
+				ref.Column = col
+				return
+			}
+			if !src.Complete {
+				ref.Uncertain = true
+			}
+			if name.Table.O != "" {
+				// 限定名已经找到了数据源，不再到外层查找
+				ref.Source = src
+				return
+			}
+		}
+		// 没有限定名时可能是选择列的别名
+		if name.Table.O == "" {
+			for _, alias := range sc.Aliases {
+				if strings.EqualFold(alias, name.Name.O) {
+					ref.Uncertain = true
+					return
+				}
+			}
+		}
+	}
+}
+
+// exprVisitor 表达式的访问器，遇到子查询时交给resolver解析
+type exprVisitor struct {
+	r     *resolver
+	scope *Scope
+}
+
+// Enter 实现ast.Visitor接口
+func (ev *exprVisitor) Enter(n ast.Node) (ast.Node, bool) {
+	switch x := n.(type) {
+	case *ast.ColumnNameExpr:
+		ev.r.column(x.Name, ev.scope)
+		return n, true
+	case *ast.SubqueryExpr:
+		ev.r.query(x.Query, ev.scope)
+		return n, true
+	case *ast.SelectStmt, *ast.UnionStmt:
+		// EXISTS、IN等表达式中直接出现的查询
+		ev.r.query(x.(ast.ResultSetNode), ev.scope)
+		return n, true
+	}
+	return n, false
+}
+
+// Leave 实现ast.Visitor接口
+func (ev *exprVisitor) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
+
+// String 列引用在语句中的写法
+func (ref *ColumnRef) String() string {
+	if ref.Name.Table.O != "" {
+		return fmt.Sprintf("%s.%s", ref.Name.Table.O, ref.Name.Name.O)
+	}
+	return ref.Name.Name.O
+}
+
+// columnExists 语句中引用的列必须存在，数据源结构未知时不报告
+func (v *vldr) columnExists(s *models.Statement, r *models.Rule) {
+	for _, ref := range v.Refs {
+		if ref.Column != nil || ref.Uncertain {
+			continue
+		}
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, ref.String()),
+			Level:       r.Level,
+			Object:      ref.Name.Name.O,
+		}
+		s.Violations.Append(c)
+	}
+}
@@ -0,0 +1,77 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/go-xorm/core"
+	"github.com/mia0x75/parser"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mia0x75/halo/models"
+)
+
+func TestResolve(t *testing.T) {
+	p := parser.New()
+	sql := "CREATE TABLE t1 (id INT NOT NULL, c1 VARCHAR(10), c2 TEXT, PRIMARY KEY (id));" +
+		"CREATE TABLE t2 (id INT NOT NULL, t1_id INT, PRIMARY KEY (id));" +
+		"SELECT a.c1, a.c2, b.id FROM t1 a JOIN t2 b ON a.id = b.t1_id WHERE a.c3 = 1;" +
+		"SELECT x.c1 FROM (SELECT c1 AS c1 FROM t1) x WHERE EXISTS (SELECT 1 FROM t2 WHERE t2.t1_id = x.c9);" +
+		"SELECT * FROM t1 ORDER BY c2;" +
+		"UPDATE t1 SET c4 = 1 WHERE id IN (SELECT t1_id FROM t2);" +
+		"DELETE FROM t3 WHERE c5 = 1;" +
+		"INSERT INTO t2 (id, t1_id, c6) VALUES (1, 2, 3);"
+	nodes, _, err := p.Parse(sql, "", "")
+	assert.NoError(t, err)
+
+	stmts := []*models.Statement{}
+	for i, node := range nodes {
+		stmts = append(stmts, &models.Statement{
+			Sequence: uint16(i + 1),
+			StmtNode: node,
+		})
+	}
+	ctx := &Context{
+		Stmts:     stmts,
+		Ticket:    &models.Ticket{Database: "db1"},
+		Databases: []models.Database{{Name: "db1"}},
+		Tables:    map[string][]*core.Table{},
+	}
+	ctx.Simulate()
+
+	// missing 返回结构已知但不存在的列
+	missing := func(s *models.Statement) (names []string) {
+		v := &vldr{Ctx: ctx}
+		v.Seek(s)
+		v.Walk(s.StmtNode)
+		for _, ref := range v.Refs {
+			if ref.Column == nil && !ref.Uncertain {
+				names = append(names, ref.String())
+			}
+		}
+		return
+	}
+
+	// 别名和JOIN
+	assert.Equal(t, []string{"a.c3"}, missing(stmts[2]))
+	// 派生表和关联子查询
+	assert.Equal(t, []string{"x.c9"}, missing(stmts[3]))
+	// 表不存在时无法判断
+	assert.Nil(t, missing(stmts[6]))
+	assert.Equal(t, []string{"c4"}, missing(stmts[5]))
+	assert.Equal(t, []string{"c6"}, missing(stmts[7]))
+
+	// 返回的列，SELECT *展开为表的全部列
+	v := &vldr{Ctx: ctx}
+	v.Seek(stmts[4])
+	v.Walk(stmts[4].StmtNode)
+	if assert.Len(t, v.Fields, 3) {
+		assert.True(t, v.Fields[2].SQLType.IsText())
+	}
+	assert.Nil(t, missing(stmts[4]))
+	v.Seek(stmts[2])
+	v.Walk(stmts[2].StmtNode)
+	if assert.Len(t, v.Fields, 3) {
+		assert.Equal(t, "c2", v.Fields[1].Name)
+		assert.True(t, v.Fields[1].SQLType.IsText())
+	}
+}
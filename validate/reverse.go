@@ -0,0 +1,438 @@
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-xorm/core"
+	"github.com/mia0x75/parser/ast"
+	"github.com/mia0x75/parser/model"
+
+	"github.com/mia0x75/halo/models"
+)
+
+// Reverse 根据语句执行之前的结构生成撤销语句效果的DDL，语句不改变表结构或者不需要撤销时返回空串。
+// 结构来自群集的元数据，其中没有全文索引和空间索引等信息，生成的语句需要人工审核
+func (s *Schema) Reverse(node ast.StmtNode, current string) (string, error) {
+	qualify := func(tn *ast.TableName) (string, string) {
+		database := tn.Schema.O
+		if database == "" {
+			database = current
+		}
+		return database, tn.Name.O
+	}
+
+	switch x := node.(type) {
+	case *ast.CreateTableStmt:
+		database, name := qualify(x.Table)
+		if s.Table(database, name) != nil {
+			// 表已经存在，IF NOT EXISTS不会建表
+			return "", nil
+		}
+		return fmt.Sprintf("DROP TABLE %s;", tableName(database, name)), nil
+	case *ast.CreateIndexStmt:
+		database, name := qualify(x.Table)
+		table := s.Table(database, name)
+		if table == nil {
+			return "", fmt.Errorf("表%s.%s不存在", database, name)
+		}
+		if tableIndex(table, x.IndexName) != nil {
+			return "", nil
+		}
+		return fmt.Sprintf("ALTER TABLE %s DROP INDEX %s;", tableName(database, name), quoteName(x.IndexName)), nil
+	case *ast.DropIndexStmt:
+		database, name := qualify(x.Table)
+		table := s.Table(database, name)
+		if table == nil {
+			return "", fmt.Errorf("表%s.%s不存在", database, name)
+		}
+		if strings.EqualFold(x.IndexName, "PRIMARY") {
+			if len(table.PrimaryKeys) == 0 {
+				return "", fmt.Errorf("表%s.%s没有主键", database, name)
+			}
+			return fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s);", tableName(database, name), quoteNames(table.PrimaryKeys)), nil
+		}
+		index := tableIndex(table, x.IndexName)
+		if index == nil {
+			if x.IfExists {
+				return "", nil
+			}
+			return "", fmt.Errorf("索引%s在表%s.%s中不存在", x.IndexName, database, name)
+		}
+		unique := ""
+		if index.Type == core.UniqueType {
+			unique = "UNIQUE "
+		}
+		return fmt.Sprintf("CREATE %sINDEX %s ON %s (%s);", unique, quoteName(index.Name), tableName(database, name), quoteNames(index.Cols)), nil
+	case *ast.RenameTableStmt:
+		pairs := x.TableToTables
+		if len(pairs) == 0 {
+			pairs = []*ast.TableToTable{{OldTable: x.OldTable, NewTable: x.NewTable}}
+		}
+		// 多个表依次重命名，撤销时按相反的顺序
+		clauses := []string{}
+		for i := len(pairs) - 1; i >= 0; i-- {
+			oldDatabase, oldName := qualify(pairs[i].OldTable)
+			newDatabase, newName := qualify(pairs[i].NewTable)
+			clauses = append(clauses, fmt.Sprintf("%s TO %s", tableName(newDatabase, newName), tableName(oldDatabase, oldName)))
+		}
+		return fmt.Sprintf("RENAME TABLE %s;", strings.Join(clauses, ", ")), nil
+	case *ast.AlterTableStmt:
+		database, name := qualify(x.Table)
+		return s.reverseAlter(x, database, name)
+	}
+	return "", nil
+}
+
+// reverseAlter 逐个生成ALTER操作的撤销操作，后面的操作先撤销
+func (s *Schema) reverseAlter(stmt *ast.AlterTableStmt, database, name string) (string, error) {
+	if s.Table(database, name) == nil {
+		return "", fmt.Errorf("表%s.%s不存在", database, name)
+	}
+	current := s
+	groups := [][]string{}
+	for _, spec := range stmt.Specs {
+		before := current.Table(database, name)
+		if before == nil {
+			return "", fmt.Errorf("表%s.%s不存在", database, name)
+		}
+		// 单独模拟每个操作，得到操作之后的结构
+		single := &ast.AlterTableStmt{
+			Table: &ast.TableName{Schema: model.NewCIStr(database), Name: model.NewCIStr(name)},
+			Specs: []*ast.AlterTableSpec{spec},
+		}
+		next := current.Apply(single, database)
+		if next == nil {
+			next = current
+		}
+		if spec.Tp == ast.AlterTableRenameTable && spec.NewTable != nil {
+			newDatabase := spec.NewTable.Schema.O
+			if newDatabase == "" {
+				newDatabase = database
+			}
+			groups = append(groups, []string{fmt.Sprintf("RENAME TO %s", tableName(database, name))})
+			database, name = newDatabase, spec.NewTable.Name.O
+			current = next
+			continue
+		}
+		after := next.Table(database, name)
+		if after == nil {
+			after = before
+		}
+		clauses, err := reverseSpec(spec, before, after)
+		if err != nil {
+			return "", err
+		}
+		if len(clauses) > 0 {
+			groups = append(groups, clauses)
+		}
+		current = next
+	}
+	if len(groups) == 0 {
+		return "", nil
+	}
+
+	clauses := []string{}
+	for i := len(groups) - 1; i >= 0; i-- {
+		clauses = append(clauses, groups[i]...)
+	}
+	return fmt.Sprintf("ALTER TABLE %s %s;", tableName(database, name), strings.Join(clauses, ", ")), nil
+}
+
+// reverseSpec 单个ALTER操作的撤销操作，before和after是操作前后的表结构
+func reverseSpec(spec *ast.AlterTableSpec, before, after *core.Table) ([]string, error) {
+	clauses := []string{}
+	switch spec.Tp {
+	case ast.AlterTableOption:
+		for _, opt := range spec.Options {
+			switch opt.Tp {
+			case ast.TableOptionEngine:
+				if before.StoreEngine != "" {
+					clauses = append(clauses, fmt.Sprintf("ENGINE = %s", before.StoreEngine))
+				}
+			case ast.TableOptionCharset:
+				if before.Charset != "" {
+					clauses = append(clauses, fmt.Sprintf("DEFAULT CHARACTER SET = %s", before.Charset))
+				}
+			case ast.TableOptionCollate:
+				if before.Collate != "" {
+					clauses = append(clauses, fmt.Sprintf("DEFAULT COLLATE = %s", before.Collate))
+				}
+			case ast.TableOptionComment:
+				clauses = append(clauses, fmt.Sprintf("COMMENT = %s", quoteString(before.Comment)))
+			}
+		}
+	case ast.AlterTableAddColumns:
+		for _, def := range spec.NewColumns {
+			if before.GetColumn(def.Name.Name.O) != nil {
+				continue
+			}
+			clauses = append(clauses, fmt.Sprintf("DROP COLUMN %s", quoteName(def.Name.Name.O)))
+		}
+	case ast.AlterTableDropColumn:
+		col := before.GetColumn(spec.OldColumnName.Name.O)
+		if col == nil {
+			break
+		}
+		clauses = append(clauses, fmt.Sprintf("ADD COLUMN %s%s", columnDefinition(col), columnPosition(before, col.Name)))
+		// 删除列时，包含这一列的主键和索引随之改变或者删除
+		if col.IsPrimaryKey {
+			if len(after.PrimaryKeys) > 0 {
+				clauses = append(clauses, "DROP PRIMARY KEY")
+			}
+			clauses = append(clauses, fmt.Sprintf("ADD PRIMARY KEY (%s)", quoteNames(before.PrimaryKeys)))
+		}
+		for _, index := range sortedIndexes(before) {
+			if !containsName(index.Cols, col.Name) {
+				continue
+			}
+			if tableIndex(after, index.Name) != nil {
+				clauses = append(clauses, fmt.Sprintf("DROP INDEX %s", quoteName(index.Name)))
+			}
+			clauses = append(clauses, addIndexClause(index))
+		}
+	case ast.AlterTableModifyColumn, ast.AlterTableAlterColumn:
+		for _, def := range spec.NewColumns {
+			col := before.GetColumn(def.Name.Name.O)
+			if col == nil {
+				continue
+			}
+			position := ""
+			if spec.Position != nil && spec.Position.Tp != ast.ColumnPositionNone {
+				position = columnPosition(before, col.Name)
+			}
+			clauses = append(clauses, fmt.Sprintf("MODIFY COLUMN %s%s", columnDefinition(col), position))
+		}
+	case ast.AlterTableChangeColumn:
+		col := before.GetColumn(spec.OldColumnName.Name.O)
+		if col == nil || len(spec.NewColumns) == 0 {
+			break
+		}
+		position := ""
+		if spec.Position != nil && spec.Position.Tp != ast.ColumnPositionNone {
+			position = columnPosition(before, col.Name)
+		}
+		clauses = append(clauses, fmt.Sprintf("CHANGE COLUMN %s %s%s", quoteName(spec.NewColumns[0].Name.Name.O), columnDefinition(col), position))
+	case ast.AlterTableAddConstraint:
+		c := spec.Constraint
+		switch c.Tp {
+		case ast.ConstraintPrimaryKey:
+			if len(before.PrimaryKeys) == 0 {
+				clauses = append(clauses, "DROP PRIMARY KEY")
+			}
+		case ast.ConstraintKey, ast.ConstraintIndex, ast.ConstraintFulltext,
+			ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
+			index := constraintIndex(c, core.IndexType)
+			if tableIndex(before, index.Name) == nil {
+				clauses = append(clauses, fmt.Sprintf("DROP INDEX %s", quoteName(index.Name)))
+			}
+		case ast.ConstraintForeignKey:
+			if c.Name == "" {
+				return nil, fmt.Errorf("没有命名的外键无法生成回滚语句")
+			}
+			clauses = append(clauses, fmt.Sprintf("DROP FOREIGN KEY %s", quoteName(c.Name)))
+		}
+	case ast.AlterTableDropPrimaryKey:
+		if len(before.PrimaryKeys) > 0 {
+			clauses = append(clauses, fmt.Sprintf("ADD PRIMARY KEY (%s)", quoteNames(before.PrimaryKeys)))
+		}
+	case ast.AlterTableDropIndex:
+		if index := tableIndex(before, spec.Name); index != nil {
+			clauses = append(clauses, addIndexClause(index))
+		}
+	case ast.AlterTableRenameIndex:
+		clauses = append(clauses, fmt.Sprintf("RENAME INDEX %s TO %s", quoteName(spec.ToKey.O), quoteName(spec.FromKey.O)))
+	case ast.AlterTableLock, ast.AlterTableAlgorithm, ast.AlterTableForce:
+		// 不改变表结构
+	default:
+		return nil, fmt.Errorf("不支持为%s生成回滚语句", restore(spec))
+	}
+	return clauses, nil
+}
+
+// columnDefinition 列的定义，用于恢复被删除或者修改的列
+func columnDefinition(col *core.Column) string {
+	extra := models.GetColumnExtra(col)
+	if extra == nil {
+		extra = &models.ColumnExtra{}
+	}
+	buf := []string{quoteName(col.Name), columnType(col)}
+	buf = append(buf, typeAttributes(extra.ColumnType)...)
+	if col.SQLType.IsText() {
+		if extra.Charset != "" {
+			buf = append(buf, "CHARACTER SET "+extra.Charset)
+		}
+		if extra.Collation != "" {
+			buf = append(buf, "COLLATE "+extra.Collation)
+		}
+	}
+	if extra.Generation != "" {
+		kind := "VIRTUAL"
+		if strings.Contains(strings.ToUpper(extra.Extra), "STORED GENERATED") {
+			kind = "STORED"
+		}
+		buf = append(buf, fmt.Sprintf("GENERATED ALWAYS AS (%s) %s", extra.Generation, kind))
+	}
+	if col.Nullable {
+		buf = append(buf, "NULL")
+	} else {
+		buf = append(buf, "NOT NULL")
+	}
+	if col.Default != "" && extra.Generation == "" {
+		buf = append(buf, "DEFAULT "+columnDefault(col))
+	}
+	// 8.0的EXTRA在ON UPDATE前面还有DEFAULT_GENERATED
+	if i := strings.Index(strings.ToLower(extra.Extra), "on update "); i >= 0 {
+		buf = append(buf, "ON UPDATE "+extra.Extra[i+len("on update "):])
+	}
+	if col.IsAutoIncrement {
+		buf = append(buf, "AUTO_INCREMENT")
+	}
+	if col.Comment != "" {
+		buf = append(buf, "COMMENT "+quoteString(col.Comment))
+	}
+	return strings.Join(buf, " ")
+}
+
+// typeAttributes 完整类型中的UNSIGNED和ZEROFILL
+func typeAttributes(columnType string) []string {
+	_, rest := splitColumnType(columnType)
+	attributes := []string{}
+	for _, word := range strings.Fields(strings.ToUpper(rest)) {
+		if word == "UNSIGNED" || word == "ZEROFILL" {
+			attributes = append(attributes, word)
+		}
+	}
+	return attributes
+}
+
+// splitColumnType 把完整的类型分为类型本身和后面的属性，属性跟在类型的括号后面，
+// ENUM和SET的选项中可能有同样的单词，只看最后一个右括号之后的部分
+func splitColumnType(columnType string) (base, rest string) {
+	if i := strings.LastIndex(columnType, ")"); i >= 0 {
+		return columnType[:i+1], columnType[i+1:]
+	}
+	if i := strings.Index(columnType, " "); i >= 0 {
+		return columnType[:i], columnType[i:]
+	}
+	return columnType, ""
+}
+
+// columnType 有完整的类型时使用其中的类型本身，类型名称转为大写，ENUM和SET的选项保持原样
+func columnType(col *core.Column) string {
+	if extra := models.GetColumnExtra(col); extra != nil && extra.ColumnType != "" {
+		base, _ := splitColumnType(extra.ColumnType)
+		if i := strings.Index(base, "("); i >= 0 {
+			return strings.ToUpper(base[:i]) + base[i:]
+		}
+		return strings.ToUpper(base)
+	}
+	options := col.EnumOptions
+	if col.SQLType.Name == core.Set {
+		options = col.SetOptions
+	}
+	if len(options) > 0 {
+		values := make([]string, len(options))
+		for value, i := range options {
+			if i < len(values) {
+				values[i] = quoteString(value)
+			}
+		}
+		return fmt.Sprintf("%s(%s)", col.SQLType.Name, strings.Join(values, ","))
+	}
+	switch {
+	case col.Length > 0 && col.Length2 > 0:
+		return fmt.Sprintf("%s(%d,%d)", col.SQLType.Name, col.Length, col.Length2)
+	case col.Length > 0:
+		return fmt.Sprintf("%s(%d)", col.SQLType.Name, col.Length)
+	}
+	return col.SQLType.Name
+}
+
+// columnDefault 元数据中文本和时间类型的默认值带有引号，CURRENT_TIMESTAMP需要去掉引号
+func columnDefault(col *core.Column) string {
+	value := col.Default
+	if len(value) < 2 || value[0] != '\'' || value[len(value)-1] != '\'' {
+		return value
+	}
+	value = value[1 : len(value)-1]
+	if col.SQLType.IsTime() && strings.HasPrefix(strings.ToUpper(value), "CURRENT_TIMESTAMP") {
+		return value
+	}
+	return quoteString(value)
+}
+
+// columnPosition 列在原表中的位置
+func columnPosition(table *core.Table, name string) string {
+	prev := ""
+	for _, col := range table.Columns() {
+		if strings.EqualFold(col.Name, name) {
+			if prev == "" {
+				return " FIRST"
+			}
+			return " AFTER " + quoteName(prev)
+		}
+		prev = col.Name
+	}
+	return ""
+}
+
+func addIndexClause(index *core.Index) string {
+	unique := ""
+	if index.Type == core.UniqueType {
+		unique = "UNIQUE "
+	}
+	return fmt.Sprintf("ADD %sINDEX %s (%s)", unique, quoteName(index.Name), quoteNames(index.Cols))
+}
+
+// tableIndex 根据名称获取索引，索引名称不区分大小写
+func tableIndex(table *core.Table, name string) *core.Index {
+	for key, index := range table.Indexes {
+		if strings.EqualFold(key, name) {
+			return index
+		}
+	}
+	return nil
+}
+
+// sortedIndexes 按名称排序的索引，保证生成的语句稳定
+func sortedIndexes(table *core.Table) []*core.Index {
+	indexes := []*core.Index{}
+	for _, index := range table.Indexes {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i].Name < indexes[j].Name
+	})
+	return indexes
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func tableName(database, name string) string {
+	return quoteName(database) + "." + quoteName(name)
+}
+
+func quoteName(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
+
+func quoteNames(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = quoteName(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func quoteString(s string) string {
+	return "'" + strings.Replace(strings.Replace(s, `\`, `\\`, -1), "'", "''", -1) + "'"
+}
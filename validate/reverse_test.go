@@ -0,0 +1,93 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/go-xorm/core"
+	"github.com/mia0x75/parser"
+	"github.com/mia0x75/parser/ast"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mia0x75/halo/models"
+)
+
+func TestReverse(t *testing.T) {
+	p := parser.New()
+	node, err := p.ParseOneStmt("CREATE TABLE t1 (id INT(11) NOT NULL AUTO_INCREMENT, c1 VARCHAR(10) NOT NULL COMMENT 'name', "+
+		"c2 INT(11), c4 INT(10) UNSIGNED ZEROFILL NOT NULL, c5 TIMESTAMP NOT NULL ON UPDATE CURRENT_TIMESTAMP, "+
+		"c6 VARCHAR(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin, c7 INT AS (c4 + 1) STORED, c8 DATETIME(3), c9 BIGINT(20), "+
+		"PRIMARY KEY (id), UNIQUE KEY uk_c1 (c1), KEY idx_c1_c2 (c1, c2)) ENGINE=InnoDB COMMENT 'users'", "", "")
+	assert.NoError(t, err)
+	table := newTable(node.(*ast.CreateTableStmt))
+	table.Name = "t1"
+	table.GetColumn("c2").Default = "0"
+	// 群集元数据中的列，完整的类型和EXTRA来自INFORMATION_SCHEMA.COLUMNS
+	table.GetColumn("c8").Default = "'CURRENT_TIMESTAMP(3)'"
+	models.SetColumnExtra(table.GetColumn("c8"), &models.ColumnExtra{
+		ColumnType: "datetime(3)",
+		Extra:      "DEFAULT_GENERATED on update CURRENT_TIMESTAMP(3)",
+	})
+	models.SetColumnExtra(table.GetColumn("c9"), &models.ColumnExtra{ColumnType: "bigint(20) unsigned"})
+	schema := NewSchema(nil, map[string][]*core.Table{"db1": {table}})
+
+	tests := []struct {
+		sql      string
+		expected string
+	}{
+		{"CREATE TABLE t2 (id INT)", "DROP TABLE `db1`.`t2`;"},
+		{"CREATE TABLE IF NOT EXISTS t1 (id INT)", ""},
+		{"CREATE INDEX idx_c2 ON t1 (c2)", "ALTER TABLE `db1`.`t1` DROP INDEX `idx_c2`;"},
+		{"DROP INDEX uk_c1 ON t1", "CREATE UNIQUE INDEX `uk_c1` ON `db1`.`t1` (`c1`);"},
+		{"DROP INDEX `PRIMARY` ON t1", "ALTER TABLE `db1`.`t1` ADD PRIMARY KEY (`id`);"},
+		{"RENAME TABLE t1 TO t3, db2.t4 TO t5", "RENAME TABLE `db1`.`t5` TO `db2`.`t4`, `db1`.`t3` TO `db1`.`t1`;"},
+		{"ALTER TABLE t1 ADD COLUMN c3 INT, ADD INDEX idx_c3 (c3)",
+			"ALTER TABLE `db1`.`t1` DROP INDEX `idx_c3`, DROP COLUMN `c3`;"},
+		{"ALTER TABLE t1 MODIFY COLUMN c1 VARCHAR(20)",
+			"ALTER TABLE `db1`.`t1` MODIFY COLUMN `c1` VARCHAR(10) NOT NULL COMMENT 'name';"},
+		{"ALTER TABLE t1 CHANGE COLUMN c2 c3 BIGINT FIRST",
+			"ALTER TABLE `db1`.`t1` CHANGE COLUMN `c3` `c2` INT(11) NULL DEFAULT 0 AFTER `c1`;"},
+		{"ALTER TABLE t1 DROP COLUMN c2",
+			"ALTER TABLE `db1`.`t1` ADD COLUMN `c2` INT(11) NULL DEFAULT 0 AFTER `c1`, DROP INDEX `idx_c1_c2`, ADD INDEX `idx_c1_c2` (`c1`, `c2`);"},
+		{"ALTER TABLE t1 DROP INDEX uk_c1, DROP PRIMARY KEY",
+			"ALTER TABLE `db1`.`t1` ADD PRIMARY KEY (`id`), ADD UNIQUE INDEX `uk_c1` (`c1`);"},
+		{"ALTER TABLE t1 COMMENT 'it''s', RENAME TO t2",
+			"ALTER TABLE `db1`.`t2` RENAME TO `db1`.`t1`, COMMENT = 'users';"},
+		{"ALTER TABLE t1 RENAME INDEX uk_c1 TO uk_name", "ALTER TABLE `db1`.`t1` RENAME INDEX `uk_name` TO `uk_c1`;"},
+		{"ALTER TABLE t1 DROP COLUMN c4",
+			"ALTER TABLE `db1`.`t1` ADD COLUMN `c4` INT(10) UNSIGNED ZEROFILL NOT NULL AFTER `c2`;"},
+		{"ALTER TABLE t1 MODIFY COLUMN c5 DATETIME",
+			"ALTER TABLE `db1`.`t1` MODIFY COLUMN `c5` TIMESTAMP NOT NULL ON UPDATE CURRENT_TIMESTAMP();"},
+		{"ALTER TABLE t1 MODIFY COLUMN c6 VARCHAR(30)",
+			"ALTER TABLE `db1`.`t1` MODIFY COLUMN `c6` VARCHAR(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NULL;"},
+		{"ALTER TABLE t1 DROP COLUMN c7",
+			"ALTER TABLE `db1`.`t1` ADD COLUMN `c7` INT(11) GENERATED ALWAYS AS (`c4`+1) STORED NULL AFTER `c6`;"},
+		{"ALTER TABLE t1 MODIFY COLUMN c8 DATETIME",
+			"ALTER TABLE `db1`.`t1` MODIFY COLUMN `c8` DATETIME(3) NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3);"},
+		{"ALTER TABLE t1 MODIFY COLUMN c9 INT",
+			"ALTER TABLE `db1`.`t1` MODIFY COLUMN `c9` BIGINT(20) UNSIGNED NULL;"},
+		{"UPDATE t1 SET c2 = 1", ""},
+	}
+	for _, test := range tests {
+		node, err := p.ParseOneStmt(test.sql, "", "")
+		if !assert.NoError(t, err, test.sql) {
+			continue
+		}
+		actual, err := schema.Reverse(node, "db1")
+		assert.NoError(t, err, test.sql)
+		assert.Equal(t, test.expected, actual, test.sql)
+	}
+
+	// 表不存在或者无法撤销时报错
+	for _, sql := range []string{
+		"ALTER TABLE t9 ADD COLUMN c3 INT",
+		"DROP INDEX idx_c9 ON t1",
+		"ALTER TABLE t1 ADD FOREIGN KEY (c2) REFERENCES t2 (id)",
+	} {
+		node, err := p.ParseOneStmt(sql, "", "")
+		if !assert.NoError(t, err, sql) {
+			continue
+		}
+		_, err = schema.Reverse(node, "db1")
+		assert.Error(t, err, sql)
+	}
+}
@@ -0,0 +1,767 @@
+package validate
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/go-xorm/core"
+	"github.com/mia0x75/parser/ast"
+	"github.com/mia0x75/parser/charset"
+	"github.com/mia0x75/parser/mysql"
+	"github.com/mia0x75/parser/types"
+
+	"github.com/mia0x75/halo/models"
+)
+
+// Schema 审核过程中在内存中模拟的群集结构，工单中的语句按照顺序依次作用在上面，
+// 这样后面的语句可以看到前面的DDL语句产生的效果
+type Schema struct {
+	Databases []models.Database        // 模拟的用户数据库
+	Tables    map[string][]*core.Table // 模拟的表结构，键为数据库名称
+	Routines  []models.Routine         // 模拟的存储函数和存储过程
+	Triggers  []models.Trigger         // 模拟的触发器
+	Events    []models.Event           // 模拟的事件
+}
+
+// NewSchema 以群集的真实结构作为模拟的起点
+func NewSchema(databases []models.Database, tables map[string][]*core.Table) *Schema {
+	s := &Schema{
+		Databases: databases,
+		Tables:    tables,
+	}
+	if s.Tables == nil {
+		s.Tables = make(map[string][]*core.Table)
+	}
+	return s
+}
+
+// Simulate 按照语句的顺序重放工单，记录每一条语句执行之前的结构
+func (ctx *Context) Simulate() {
+	stmts := make([]*models.Statement, len(ctx.Stmts))
+	copy(stmts, ctx.Stmts)
+	sort.SliceStable(stmts, func(i, j int) bool {
+		return stmts[i].Sequence < stmts[j].Sequence
+	})
+
+	current := ctx.initial()
+	ctx.Schemas = make(map[*models.Statement]*Schema, len(stmts))
+	for _, s := range stmts {
+		ctx.Schemas[s] = current
+		if next := current.Apply(s.StmtNode, ctx.Ticket.Database); next != nil {
+			current = next
+		}
+	}
+}
+
+// SchemaOf 获取语句执行之前的结构，没有模拟过的语句使用群集的真实结构
+func (ctx *Context) SchemaOf(s *models.Statement) *Schema {
+	if schema, ok := ctx.Schemas[s]; ok {
+		return schema
+	}
+	return ctx.initial()
+}
+
+// initial 模拟的起点，即群集的真实结构
+func (ctx *Context) initial() *Schema {
+	s := NewSchema(ctx.Databases, ctx.Tables)
+	s.Routines = ctx.Routines
+	s.Triggers = ctx.Triggers
+	s.Events = ctx.Events
+	return s
+}
+
+// Apply 将语句的效果作用到结构上，返回新的结构，原有结构保持不变，
+// 语句不影响结构时返回nil
+func (s *Schema) Apply(node ast.StmtNode, current string) *Schema {
+	qualify := func(tn *ast.TableName) (string, string) {
+		database := tn.Schema.O
+		if database == "" {
+			database = current
+		}
+		return database, tn.Name.O
+	}
+
+	var next *Schema
+	switch x := node.(type) {
+	case *ast.CreateDatabaseStmt:
+		if s.Database(x.Name) != nil {
+			break
+		}
+		next = s.clone()
+		database := models.Database{Name: x.Name}
+		for _, opt := range x.Options {
+			switch opt.Tp {
+			case ast.DatabaseOptionCharset:
+				database.Charset = opt.Value
+			case ast.DatabaseOptionCollate:
+				database.Collate = opt.Value
+			}
+		}
+		next.Databases = append(next.Databases, database)
+	case *ast.AlterDatabaseStmt:
+		name := x.Name
+		if name == "" {
+			name = current
+		}
+		if s.Database(name) == nil {
+			break
+		}
+		next = s.clone()
+		for i := range next.Databases {
+			if next.Databases[i].Name != name {
+				continue
+			}
+			for _, opt := range x.Options {
+				switch opt.Tp {
+				case ast.DatabaseOptionCharset:
+					next.Databases[i].Charset = opt.Value
+				case ast.DatabaseOptionCollate:
+					next.Databases[i].Collate = opt.Value
+				}
+			}
+		}
+	case *ast.DropDatabaseStmt:
+		if s.Database(x.Name) == nil {
+			break
+		}
+		next = s.clone()
+		databases := []models.Database{}
+		for _, database := range next.Databases {
+			if database.Name != x.Name {
+				databases = append(databases, database)
+			}
+		}
+		next.Databases = databases
+		delete(next.Tables, x.Name)
+		// 删除数据库时，其中的存储程序、触发器和事件一并删除
+		routines := []models.Routine{}
+		for _, r := range next.Routines {
+			if r.Database != x.Name {
+				routines = append(routines, r)
+			}
+		}
+		next.Routines = routines
+		triggers := []models.Trigger{}
+		for _, t := range next.Triggers {
+			if t.Database != x.Name {
+				triggers = append(triggers, t)
+			}
+		}
+		next.Triggers = triggers
+		events := []models.Event{}
+		for _, e := range next.Events {
+			if e.Database != x.Name {
+				events = append(events, e)
+			}
+		}
+		next.Events = events
+	case *ast.CreateTableStmt:
+		database, name := qualify(x.Table)
+		if s.Table(database, name) != nil {
+			break
+		}
+		var table *core.Table
+		if x.ReferTable != nil {
+			// CREATE TABLE ... LIKE ...
+			refer := s.Table(qualify(x.ReferTable))
+			if refer == nil {
+				break
+			}
+			table = cloneTable(refer)
+			table.Name = name
+		} else {
+			table = newTable(x)
+			table.Name = name
+		}
+		next = s.clone()
+		next.putTable(database, table)
+	case *ast.AlterTableStmt:
+		database, name := qualify(x.Table)
+		origin := s.Table(database, name)
+		if origin == nil {
+			break
+		}
+		table := cloneTable(origin)
+		for _, spec := range x.Specs {
+			switch spec.Tp {
+			case ast.AlterTableOption:
+				applyTableOptions(table, spec.Options)
+			case ast.AlterTableAddColumns:
+				for _, col := range spec.NewColumns {
+					if table.GetColumn(col.Name.Name.O) != nil {
+						continue
+					}
+					table.AddColumn(newColumn(col))
+					applyColumnKeys(table, col)
+				}
+			case ast.AlterTableDropColumn:
+				table = dropColumn(table, spec.OldColumnName.Name.O)
+			case ast.AlterTableModifyColumn:
+				for _, col := range spec.NewColumns {
+					table = replaceColumn(table, col.Name.Name.O, col)
+				}
+			case ast.AlterTableChangeColumn:
+				for _, col := range spec.NewColumns {
+					table = replaceColumn(table, spec.OldColumnName.Name.O, col)
+				}
+			case ast.AlterTableAddConstraint:
+				applyConstraint(table, spec.Constraint)
+			case ast.AlterTableDropPrimaryKey:
+				for _, pk := range table.PrimaryKeys {
+					if col := table.GetColumn(pk); col != nil {
+						col.IsPrimaryKey = false
+					}
+				}
+				table.PrimaryKeys = []string{}
+			case ast.AlterTableDropIndex:
+				dropIndex(table, spec.Name)
+			case ast.AlterTableRenameIndex:
+				renameIndex(table, spec.FromKey.O, spec.ToKey.O)
+			case ast.AlterTableRenameTable:
+				if spec.NewTable == nil {
+					break
+				}
+				newDatabase, newName := qualify(spec.NewTable)
+				if newDatabase != database {
+					if next == nil {
+						next = s.clone()
+					}
+					next.dropTable(database, name)
+					database = newDatabase
+				}
+				name = newName
+			}
+		}
+		table.Name = name
+		if next == nil {
+			next = s.clone()
+		}
+		next.dropTable(database, origin.Name)
+		next.putTable(database, table)
+	case *ast.RenameTableStmt:
+		oldDatabase, oldName := qualify(x.OldTable)
+		newDatabase, newName := qualify(x.NewTable)
+		origin := s.Table(oldDatabase, oldName)
+		if origin == nil {
+			break
+		}
+		table := cloneTable(origin)
+		table.Name = newName
+		next = s.clone()
+		next.dropTable(oldDatabase, oldName)
+		next.putTable(newDatabase, table)
+		// 同一个库内重命名时触发器跟随表，跨库重命名有触发器的表会报错
+		for i := range next.Triggers {
+			t := &next.Triggers[i]
+			if t.Database == oldDatabase && t.Table == oldName && oldDatabase == newDatabase {
+				t.Table = newName
+			}
+		}
+	case *ast.DropTableStmt:
+		if x.IsView {
+			break
+		}
+		for _, tn := range x.Tables {
+			database, name := qualify(tn)
+			if s.Table(database, name) == nil {
+				continue
+			}
+			if next == nil {
+				next = s.clone()
+			}
+			next.dropTable(database, name)
+			// 删除表时，表上的触发器一并删除
+			triggers := []models.Trigger{}
+			for _, t := range next.Triggers {
+				if t.Database != database || t.Table != name {
+					triggers = append(triggers, t)
+				}
+			}
+			next.Triggers = triggers
+		}
+	case *ast.CreateIndexStmt:
+		database, name := qualify(x.Table)
+		origin := s.Table(database, name)
+		if origin == nil {
+			break
+		}
+		table := cloneTable(origin)
+		index := core.NewIndex(x.IndexName, core.IndexType)
+		if x.Unique {
+			index.Type = core.UniqueType
+		}
+		for _, col := range x.IndexColNames {
+			index.AddColumn(col.Column.Name.O)
+		}
+		addIndex(table, index)
+		next = s.clone()
+		next.dropTable(database, name)
+		next.putTable(database, table)
+	case *ast.DropIndexStmt:
+		database, name := qualify(x.Table)
+		origin := s.Table(database, name)
+		if origin == nil {
+			break
+		}
+		table := cloneTable(origin)
+		dropIndex(table, x.IndexName)
+		next = s.clone()
+		next.dropTable(database, name)
+		next.putTable(database, table)
+	case *CreateFunctionStmt:
+		next = s.createRoutine(qualifyProgram(x.Name, current), x.Name.Name, ProgramFunction)
+	case *DropFunctionStmt:
+		next = s.dropRoutine(qualifyProgram(x.Name, current), x.Name.Name, ProgramFunction)
+	case *CreateProcedureStmt:
+		next = s.createRoutine(qualifyProgram(x.Name, current), x.Name.Name, ProgramProcedure)
+	case *DropProcedureStmt:
+		next = s.dropRoutine(qualifyProgram(x.Name, current), x.Name.Name, ProgramProcedure)
+	case *CreateTriggerStmt:
+		database := qualifyProgram(x.Name, current)
+		if s.Trigger(database, x.Name.Name) != nil {
+			break
+		}
+		next = s.clone()
+		next.Triggers = append(next.Triggers, models.Trigger{
+			Database: database,
+			Name:     x.Name.Name,
+			Table:    x.Table.Name,
+			Timing:   x.Timing,
+			Event:    x.Event,
+		})
+	case *DropTriggerStmt:
+		database := qualifyProgram(x.Name, current)
+		if s.Trigger(database, x.Name.Name) == nil {
+			break
+		}
+		next = s.clone()
+		triggers := []models.Trigger{}
+		for _, t := range next.Triggers {
+			if t.Database == database && strings.EqualFold(t.Name, x.Name.Name) {
+				continue
+			}
+			triggers = append(triggers, t)
+		}
+		next.Triggers = triggers
+	case *CreateEventStmt:
+		database := qualifyProgram(x.Name, current)
+		if s.Event(database, x.Name.Name) != nil {
+			break
+		}
+		next = s.clone()
+		next.Events = append(next.Events, models.Event{
+			Database: database,
+			Name:     x.Name.Name,
+			Status:   "ENABLED",
+		})
+	case *AlterEventStmt:
+		database := qualifyProgram(x.Name, current)
+		if x.NewName == nil || s.Event(database, x.Name.Name) == nil {
+			break
+		}
+		next = s.clone()
+		e := next.Event(database, x.Name.Name)
+		e.Database = qualifyProgram(x.NewName, current)
+		e.Name = x.NewName.Name
+	case *DropEventStmt:
+		database := qualifyProgram(x.Name, current)
+		if s.Event(database, x.Name.Name) == nil {
+			break
+		}
+		next = s.clone()
+		events := []models.Event{}
+		for _, e := range next.Events {
+			if e.Database == database && strings.EqualFold(e.Name, x.Name.Name) {
+				continue
+			}
+			events = append(events, e)
+		}
+		next.Events = events
+	}
+
+	return next
+}
+
+// qualifyProgram 存储程序所属的数据库
+func qualifyProgram(name *ProgramName, current string) string {
+	if name.Schema == "" {
+		return current
+	}
+	return name.Schema
+}
+
+// Routine 根据名称获取模拟的存储函数或者存储过程，名称不区分大小写
+func (s *Schema) Routine(database, name, typ string) *models.Routine {
+	for i := range s.Routines {
+		r := &s.Routines[i]
+		if r.Database == database && r.Type == typ && strings.EqualFold(r.Name, name) {
+			return r
+		}
+	}
+	return nil
+}
+
+// createRoutine 增加存储函数或者存储过程，已经存在时返回nil
+func (s *Schema) createRoutine(database, name, typ string) *Schema {
+	if s.Routine(database, name, typ) != nil {
+		return nil
+	}
+	next := s.clone()
+	next.Routines = append(next.Routines, models.Routine{
+		Database: database,
+		Name:     name,
+		Type:     typ,
+	})
+	return next
+}
+
+// dropRoutine 删除存储函数或者存储过程，不存在时返回nil
+func (s *Schema) dropRoutine(database, name, typ string) *Schema {
+	if s.Routine(database, name, typ) == nil {
+		return nil
+	}
+	next := s.clone()
+	routines := []models.Routine{}
+	for _, r := range next.Routines {
+		if r.Database == database && r.Type == typ && strings.EqualFold(r.Name, name) {
+			continue
+		}
+		routines = append(routines, r)
+	}
+	next.Routines = routines
+	return next
+}
+
+// Trigger 根据名称获取模拟的触发器，触发器的名称在库内唯一
+func (s *Schema) Trigger(database, name string) *models.Trigger {
+	for i := range s.Triggers {
+		t := &s.Triggers[i]
+		if t.Database == database && strings.EqualFold(t.Name, name) {
+			return t
+		}
+	}
+	return nil
+}
+
+// Event 根据名称获取模拟的事件，名称不区分大小写
+func (s *Schema) Event(database, name string) *models.Event {
+	for i := range s.Events {
+		e := &s.Events[i]
+		if e.Database == database && strings.EqualFold(e.Name, name) {
+			return e
+		}
+	}
+	return nil
+}
+
+// Database 根据名称获取模拟的数据库
+func (s *Schema) Database(name string) *models.Database {
+	for i := range s.Databases {
+		if s.Databases[i].Name == name {
+			return &s.Databases[i]
+		}
+	}
+	return nil
+}
+
+// Table 根据名称获取模拟的表
+func (s *Schema) Table(database, name string) *core.Table {
+	for _, table := range s.Tables[database] {
+		if table.Name == name {
+			return table
+		}
+	}
+	return nil
+}
+
+// clone 浅拷贝，修改某个库下的表时只复制这个库的表切片，未修改的表在不同的结构之间共享
+func (s *Schema) clone() *Schema {
+	next := &Schema{
+		Databases: make([]models.Database, len(s.Databases)),
+		Tables:    make(map[string][]*core.Table, len(s.Tables)),
+	}
+	copy(next.Databases, s.Databases)
+	next.Routines = make([]models.Routine, len(s.Routines))
+	copy(next.Routines, s.Routines)
+	next.Triggers = make([]models.Trigger, len(s.Triggers))
+	copy(next.Triggers, s.Triggers)
+	next.Events = make([]models.Event, len(s.Events))
+	copy(next.Events, s.Events)
+	for database, tables := range s.Tables {
+		next.Tables[database] = tables
+	}
+	return next
+}
+
+func (s *Schema) putTable(database string, table *core.Table) {
+	tables := make([]*core.Table, 0, len(s.Tables[database])+1)
+	tables = append(tables, s.Tables[database]...)
+	s.Tables[database] = append(tables, table)
+}
+
+func (s *Schema) dropTable(database, name string) {
+	tables := []*core.Table{}
+	for _, table := range s.Tables[database] {
+		if table.Name != name {
+			tables = append(tables, table)
+		}
+	}
+	s.Tables[database] = tables
+}
+
+// newTable 根据建表语句生成表结构
+func newTable(ct *ast.CreateTableStmt) *core.Table {
+	table := core.NewEmptyTable()
+	applyTableOptions(table, ct.Options)
+	for _, col := range ct.Cols {
+		table.AddColumn(newColumn(col))
+	}
+	for _, col := range ct.Cols {
+		applyColumnKeys(table, col)
+	}
+	for _, c := range ct.Constraints {
+		applyConstraint(table, c)
+	}
+	return table
+}
+
+// newColumn 根据列定义生成列结构
+func newColumn(def *ast.ColumnDef) *core.Column {
+	col := &core.Column{
+		Name:     def.Name.Name.O,
+		Nullable: true,
+		Indexes:  make(map[string]int),
+	}
+	// 与群集的元数据一样保存完整的类型和附加属性，生成回滚语句时需要
+	extra := &models.ColumnExtra{}
+	if def.Tp != nil {
+		col.SQLType = core.SQLType{
+			Name:           strings.ToUpper(types.TypeToStr(def.Tp.Tp, def.Tp.Charset)),
+			DefaultLength:  def.Tp.Flen,
+			DefaultLength2: def.Tp.Decimal,
+		}
+		col.Length = def.Tp.Flen
+		col.Length2 = def.Tp.Decimal
+		extra.ColumnType = def.Tp.InfoSchemaStr()
+		if mysql.HasZerofillFlag(def.Tp.Flag) {
+			extra.ColumnType += " zerofill"
+		}
+		if def.Tp.Charset != charset.CharsetBin {
+			extra.Charset = def.Tp.Charset
+		}
+		if def.Tp.Collate != charset.CollationBin {
+			extra.Collation = def.Tp.Collate
+		}
+	}
+	for _, opt := range def.Options {
+		switch opt.Tp {
+		case ast.ColumnOptionNotNull:
+			col.Nullable = false
+		case ast.ColumnOptionNull:
+			col.Nullable = true
+		case ast.ColumnOptionPrimaryKey:
+			col.IsPrimaryKey = true
+			col.Nullable = false
+		case ast.ColumnOptionAutoIncrement:
+			col.IsAutoIncrement = true
+		case ast.ColumnOptionComment:
+			if e, ok := opt.Expr.(ast.ValueExpr); ok {
+				if comment, ok := e.GetValue().(string); ok {
+					col.Comment = comment
+				}
+			}
+		case ast.ColumnOptionOnUpdate:
+			extra.Extra = "on update " + restore(opt.Expr)
+		case ast.ColumnOptionGenerated:
+			extra.Generation = restore(opt.Expr)
+			if opt.Stored {
+				extra.Extra = "STORED GENERATED"
+			} else {
+				extra.Extra = "VIRTUAL GENERATED"
+			}
+		case ast.ColumnOptionCollate:
+			extra.Collation = opt.StrValue
+		}
+	}
+	models.SetColumnExtra(col, extra)
+	return col
+}
+
+// applyColumnKeys 列定义上的唯一约束
+func applyColumnKeys(table *core.Table, def *ast.ColumnDef) {
+	for _, opt := range def.Options {
+		if opt.Tp != ast.ColumnOptionUniqKey {
+			continue
+		}
+		index := core.NewIndex(def.Name.Name.O, core.UniqueType)
+		index.AddColumn(def.Name.Name.O)
+		addIndex(table, index)
+	}
+}
+
+// applyTableOptions 表选项
+func applyTableOptions(table *core.Table, options []*ast.TableOption) {
+	for _, opt := range options {
+		switch opt.Tp {
+		case ast.TableOptionEngine:
+			table.StoreEngine = opt.StrValue
+		case ast.TableOptionCharset:
+			table.Charset = opt.StrValue
+		case ast.TableOptionCollate:
+			table.Collate = opt.StrValue
+		case ast.TableOptionComment:
+			table.Comment = opt.StrValue
+		}
+	}
+}
+
+// applyConstraint 主键和索引，外键不影响模拟的结构
+func applyConstraint(table *core.Table, c *ast.Constraint) {
+	if c == nil {
+		return
+	}
+	switch c.Tp {
+	case ast.ConstraintPrimaryKey:
+		table.PrimaryKeys = []string{}
+		for _, key := range c.Keys {
+			if col := table.GetColumn(key.Column.Name.O); col != nil {
+				col.IsPrimaryKey = true
+				col.Nullable = false
+				table.PrimaryKeys = append(table.PrimaryKeys, col.Name)
+			}
+		}
+	case ast.ConstraintKey, ast.ConstraintIndex, ast.ConstraintFulltext:
+		addIndex(table, constraintIndex(c, core.IndexType))
+	case ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
+		addIndex(table, constraintIndex(c, core.UniqueType))
+	}
+}
+
+func constraintIndex(c *ast.Constraint, indexType int) *core.Index {
+	name := c.Name
+	if name == "" && len(c.Keys) > 0 {
+		// 没有命名的索引由服务器使用第一列的名称命名
+		name = c.Keys[0].Column.Name.O
+	}
+	index := core.NewIndex(name, indexType)
+	for _, key := range c.Keys {
+		index.AddColumn(key.Column.Name.O)
+	}
+	return index
+}
+
+func addIndex(table *core.Table, index *core.Index) {
+	if index.Name == "" {
+		return
+	}
+	if _, ok := table.Indexes[index.Name]; ok {
+		return
+	}
+	table.AddIndex(index)
+	for _, name := range index.Cols {
+		if col := table.GetColumn(name); col != nil {
+			col.Indexes[index.Name] = index.Type
+		}
+	}
+}
+
+func dropIndex(table *core.Table, name string) {
+	for key, index := range table.Indexes {
+		if !strings.EqualFold(key, name) {
+			continue
+		}
+		for _, colName := range index.Cols {
+			if col := table.GetColumn(colName); col != nil {
+				delete(col.Indexes, key)
+			}
+		}
+		delete(table.Indexes, key)
+	}
+}
+
+func renameIndex(table *core.Table, from, to string) {
+	index, ok := table.Indexes[from]
+	if !ok {
+		return
+	}
+	dropIndex(table, from)
+	renamed := core.NewIndex(to, index.Type)
+	renamed.AddColumn(index.Cols...)
+	addIndex(table, renamed)
+}
+
+// cloneTable 深拷贝一张表，修改表结构之前必须先复制，避免影响之前的语句看到的结构
+func cloneTable(origin *core.Table) *core.Table {
+	return rebuildTable(origin, func(col *core.Column) *core.Column {
+		return col
+	})
+}
+
+// rebuildTable 按照原有的列顺序重新生成表，fn返回nil表示丢弃该列
+func rebuildTable(origin *core.Table, fn func(col *core.Column) *core.Column) *core.Table {
+	table := core.NewEmptyTable()
+	table.Name = origin.Name
+	table.StoreEngine = origin.StoreEngine
+	table.Charset = origin.Charset
+	table.Collate = origin.Collate
+	table.Comment = origin.Comment
+	for _, elem := range origin.Columns() {
+		col := *elem
+		col.Indexes = make(map[string]int, len(elem.Indexes))
+		for k, v := range elem.Indexes {
+			col.Indexes[k] = v
+		}
+		if c := fn(&col); c != nil {
+			table.AddColumn(c)
+		}
+	}
+	for _, elem := range origin.Indexes {
+		index := core.NewIndex(elem.Name, elem.Type)
+		for _, name := range elem.Cols {
+			if table.GetColumn(name) != nil {
+				index.AddColumn(name)
+			}
+		}
+		if len(index.Cols) > 0 {
+			table.AddIndex(index)
+		}
+	}
+	return table
+}
+
+func dropColumn(origin *core.Table, name string) *core.Table {
+	return rebuildTable(origin, func(col *core.Column) *core.Column {
+		if strings.EqualFold(col.Name, name) {
+			return nil
+		}
+		return col
+	})
+}
+
+func replaceColumn(origin *core.Table, name string, def *ast.ColumnDef) *core.Table {
+	if origin.GetColumn(name) == nil {
+		return origin
+	}
+	table := cloneTable(origin)
+	if !strings.EqualFold(name, def.Name.Name.O) {
+		// CHANGE COLUMN修改了列名，索引中的列名同步修改，必须在重建之前修改，否则索引会被丢弃
+		for _, index := range table.Indexes {
+			for i, colName := range index.Cols {
+				if strings.EqualFold(colName, name) {
+					index.Cols[i] = def.Name.Name.O
+				}
+			}
+		}
+	}
+	table = rebuildTable(table, func(col *core.Column) *core.Column {
+		if !strings.EqualFold(col.Name, name) {
+			return col
+		}
+		replaced := newColumn(def)
+		replaced.IsPrimaryKey = replaced.IsPrimaryKey || col.IsPrimaryKey
+		replaced.Indexes = col.Indexes
+		return replaced
+	})
+	applyColumnKeys(table, def)
+	return table
+}
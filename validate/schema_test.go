@@ -0,0 +1,71 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/go-xorm/core"
+	"github.com/mia0x75/parser"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mia0x75/halo/models"
+)
+
+func TestSchemaSimulate(t *testing.T) {
+	p := parser.New()
+	sql := "CREATE TABLE t1 (id INT NOT NULL AUTO_INCREMENT, c1 VARCHAR(10), PRIMARY KEY (id));" +
+		"ALTER TABLE t1 ADD COLUMN c2 INT, ADD INDEX idx_c1 (c1);" +
+		"ALTER TABLE t1 CHANGE COLUMN c1 c3 VARCHAR(20);" +
+		"RENAME TABLE t1 TO t2;" +
+		"DROP TABLE t2;"
+	nodes, _, err := p.Parse(sql, "", "")
+	assert.NoError(t, err)
+
+	stmts := []*models.Statement{}
+	for i, node := range nodes {
+		stmts = append(stmts, &models.Statement{
+			Sequence: uint16(i + 1),
+			StmtNode: node,
+		})
+	}
+	ctx := &Context{
+		Stmts:     stmts,
+		Ticket:    &models.Ticket{Database: "db1"},
+		Databases: []models.Database{{Name: "db1"}},
+		Tables:    map[string][]*core.Table{},
+	}
+	ctx.Simulate()
+
+	// 建表之前表不存在
+	assert.Nil(t, ctx.SchemaOf(stmts[0]).Table("db1", "t1"))
+
+	// 第一次改表之前，表和建表语句中的列可见
+	ti := ctx.SchemaOf(stmts[1]).Table("db1", "t1")
+	if assert.NotNil(t, ti) {
+		assert.NotNil(t, ti.GetColumn("c1"))
+		assert.Nil(t, ti.GetColumn("c2"))
+		assert.Equal(t, []string{"id"}, ti.PrimaryKeys)
+	}
+
+	// 第二次改表之前，新增的列和索引可见
+	ti = ctx.SchemaOf(stmts[2]).Table("db1", "t1")
+	if assert.NotNil(t, ti) {
+		assert.NotNil(t, ti.GetColumn("c2"))
+		assert.Contains(t, ti.Indexes, "idx_c1")
+	}
+
+	// 重命名之前，列已经改名，索引中的列同步修改
+	ti = ctx.SchemaOf(stmts[3]).Table("db1", "t1")
+	if assert.NotNil(t, ti) {
+		assert.Nil(t, ti.GetColumn("c1"))
+		assert.NotNil(t, ti.GetColumn("c3"))
+		assert.Equal(t, []string{"c3"}, ti.Indexes["idx_c1"].Cols)
+	}
+
+	// 删除之前，表已经改名
+	schema := ctx.SchemaOf(stmts[4])
+	assert.Nil(t, schema.Table("db1", "t1"))
+	assert.NotNil(t, schema.Table("db1", "t2"))
+
+	// 模拟不能影响群集的真实结构
+	assert.Empty(t, ctx.Tables["db1"])
+}
@@ -0,0 +1,101 @@
+package validate
+
+import (
+	"strings"
+)
+
+// Split 将脚本拆分为单条语句，支持客户端的DELIMITER指令，
+// 引号和注释中出现的分隔符不会拆分语句，注释会保留在语句中，
+// 没有使用DELIMITER的存储程序按照BEGIN ... END的配对拆分
+func Split(content string) []string {
+	stmts := []string{}
+	delimiter := ";"
+	var sb strings.Builder
+	// 当前语句中已经交给tracker扫描过的长度
+	tracker, fed := &blockTracker{}, 0
+
+	emit := func() {
+		if stmt := strings.TrimSpace(sb.String()); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+		sb.Reset()
+		tracker, fed = &blockTracker{}, 0
+	}
+
+	n := len(content)
+	for i := 0; i < n; {
+		// DELIMITER只能出现在语句的开始位置，并且独占一行
+		if strings.TrimSpace(sb.String()) == "" && (i == 0 || content[i-1] == '\n') {
+			line := content[i:]
+			if pos := strings.IndexByte(line, '\n'); pos >= 0 {
+				line = line[:pos]
+			}
+			fields := strings.Fields(line)
+			if len(fields) == 2 && strings.EqualFold(fields[0], "DELIMITER") {
+				delimiter = fields[1]
+				sb.Reset()
+				tracker, fed = &blockTracker{}, 0
+				i += len(line)
+				continue
+			}
+		}
+
+		c := content[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			// 引号中的内容原样保留
+			j := i + 1
+			for j < n {
+				if content[j] == '\\' && c != '`' {
+					j += 2
+					continue
+				}
+				if content[j] == c {
+					break
+				}
+				j++
+			}
+			if j >= n {
+				j = n - 1
+			}
+			sb.WriteString(content[i : j+1])
+			i = j + 1
+		case c == '#' || (c == '-' && strings.HasPrefix(content[i:], "--") &&
+			(i+2 == n || content[i+2] == ' ' || content[i+2] == '\t' || content[i+2] == '\n' || content[i+2] == '\r')):
+			// 单行注释
+			j := strings.IndexByte(content[i:], '\n')
+			if j < 0 {
+				j = n - i
+			}
+			sb.WriteString(content[i : i+j])
+			i += j
+		case c == '/' && strings.HasPrefix(content[i:], "/*"):
+			// 多行注释，包括/*!40101 ... */这种带版本的注释
+			j := strings.Index(content[i+2:], "*/")
+			if j < 0 {
+				j = n - i - 4
+			}
+			sb.WriteString(content[i : i+j+4])
+			i += j + 4
+		case strings.HasPrefix(content[i:], delimiter):
+			// 没有使用DELIMITER时，存储程序的BEGIN ... END中的分号不拆分语句
+			if delimiter == ";" {
+				open := tracker.feed(sb.String()[fed:])
+				fed = sb.Len()
+				if open {
+					sb.WriteString(delimiter)
+					i += len(delimiter)
+					continue
+				}
+			}
+			emit()
+			i += len(delimiter)
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	emit()
+
+	return stmts
+}
@@ -0,0 +1,6 @@
+CREATE DATABASE db1;
+USE db1;
+CREATE TABLE t1 (
+  id INT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键',
+  PRIMARY KEY (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='测试表';
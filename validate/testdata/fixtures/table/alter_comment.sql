@@ -0,0 +1,5 @@
+-- 新增的列没有注释
+-- database: db1
+-- expect: MTB-L2-009
+-- absent: MTB-L3-002
+ALTER TABLE t1 ADD COLUMN c1 INT;
@@ -0,0 +1,5 @@
+-- 目标表已经存在于结构快照中，缺少注释
+-- database: db1
+-- expect: CTB-L3-002, CTB-L2-008, CTB-L2-016
+-- absent: CTB-L3-001
+CREATE TABLE t1 (id INT) DEFAULT CHARSET=utf8mb4;
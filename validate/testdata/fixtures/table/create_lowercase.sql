@@ -0,0 +1,8 @@
+-- 表名含有大写字母，列和表都有注释
+-- database: db1
+-- expect: CTB-L2-006
+-- absent: CTB-L2-001, CTB-L2-008, CTB-L2-016, CTB-L3-001, CTB-L3-002
+CREATE TABLE Users (
+  id INT UNSIGNED NOT NULL COMMENT '主键',
+  PRIMARY KEY (id)
+) DEFAULT CHARSET=utf8mb4 COMMENT='用户';
@@ -0,0 +1,5 @@
+-- 被内联豁免的规则不计算在内
+-- database: db1
+-- absent: CTB-L2-008
+/* halo:ignore CTB-L2-008 reason="临时表" */
+CREATE TABLE t2 (id INT COMMENT '主键') DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,5 @@
+-- MySQL 5.7不支持基于UCA 9.0.0的排序规则
+-- database: db1
+-- version: 5.7.30-log
+-- expect: VER-L1-001
+CREATE TABLE t2 (id INT COMMENT '主键') DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT '测试';
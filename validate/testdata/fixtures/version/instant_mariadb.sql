@@ -0,0 +1,5 @@
+-- MariaDB 10.3.2开始支持ALGORITHM=INSTANT
+-- database: db1
+-- version: 10.3.22-MariaDB-log
+-- absent: VER-L1-004
+ALTER TABLE t1 ADD COLUMN c2 INT COMMENT '测试', ALGORITHM=INSTANT;
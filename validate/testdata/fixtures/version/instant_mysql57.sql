@@ -0,0 +1,5 @@
+-- MySQL 8.0.12之前不支持ALGORITHM=INSTANT
+-- database: db1
+-- version: 5.7.30-log
+-- expect: VER-L1-004
+ALTER TABLE t1 ADD COLUMN c2 INT COMMENT '测试', ALGORITHM=INSTANT;
@@ -0,0 +1,5 @@
+-- TiDB不支持存储过程
+-- database: db1
+-- version: 5.7.25-TiDB-v4.0.0
+-- expect: VER-L1-005
+CREATE PROCEDURE p1() BEGIN SELECT 1; END;
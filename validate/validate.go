@@ -1,19 +1,19 @@
 package validate
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
+	"runtime"
 	"sync"
 
 	"github.com/go-xorm/core"
 
 	"github.com/mia0x75/halo/caches"
-	"github.com/mia0x75/halo/g"
 	"github.com/mia0x75/halo/models"
-	"github.com/mia0x75/halo/tools"
+	"github.com/mia0x75/parser"
 	"github.com/mia0x75/parser/ast"
+	log "github.com/sirupsen/logrus"
 )
 
 // 接口确认，如果有报错表示没有实现接口的全部方法
@@ -46,80 +46,76 @@ var (
 	_ Validator = &ProcCreateVldr{}
 	_ Validator = &ProcCreateVldr{}
 	_ Validator = &MiscVldr{}
+	_ Validator = &VersionVldr{}
 )
 
 // Context 在不同的验证组中共享内容
 type Context struct {
-	Stmts     []*models.Statement      // 全部等待审核的数据
-	Ticket    *models.Ticket           // 等待审核的工单
-	Cluster   *models.Cluster          // 目标群集
-	Databases []models.Database        // 目标群集所有的用户数据库
-	Tables    map[string][]*core.Table // 目标群集上目标数据库的元数据
+	Stmts       []*models.Statement               // 全部等待审核的数据
+	Ticket      *models.Ticket                    // 等待审核的工单
+	Cluster     *models.Cluster                   // 目标群集
+	Passwd      func(c *models.Cluster) []byte    // 目标群集的密码，获取执行计划时使用
+	Databases   []models.Database                 // 目标群集所有的用户数据库
+	Tables      map[string][]*core.Table          // 目标群集上目标数据库的元数据
+	Routines    []models.Routine                  // 目标群集上的存储函数和存储过程
+	Triggers    []models.Trigger                  // 目标群集上的触发器
+	Events      []models.Event                    // 目标群集上的事件
+	Variables   map[string]string                 // 审核需要的全局变量
+	Schemas     map[*models.Statement]*Schema     // 每条语句执行之前模拟的结构
+	Profile     *models.RuleProfile               // 目标群集和数据库上生效的规则配置，为空时使用规则本身的设置
+	Waivers     []*models.Waiver                  // 对工单有效的规则豁免
+	Version     *ServerVersion                    // 目标群集的版本，未知时版本相关的规则不做检查
+	TableStats  []models.TableStat                // 目标群集上表的行数和大小
+	Predictions map[*models.Statement]*Prediction // 每条ALTER TABLE语句的在线DDL预测
 }
 
-// 相当于注册表
-var registry = map[uint16]Validator{
-	100: &DatabaseCreateVldr{},
-	101: &DatabaseAlterVldr{},
-	102: &DatabaseDropVldr{},
-	110: &TableCreateVldr{},
-	120: &TableAlterVldr{},
-	130: &TableRenameVldr{},
-	140: &TableDropVldr{},
-	150: &IndexCreateVldr{},
-	151: &IndexDropVldr{},
-	160: &InsertVldr{},
-	161: &ReplaceVldr{},
-	162: &UpdateVldr{},
-	163: &DeleteVldr{},
-	170: &SelectVldr{},
-	180: &ViewCreateVldr{},
-	181: &ViewAlterVldr{},
-	182: &ViewDropVldr{},
-	190: &FuncCreateVldr{},
-	191: &FuncAlterVldr{},
-	192: &FuncDropVldr{},
-	200: &TriggerCreateVldr{},
-	201: &TriggerAlterVldr{},
-	202: &TriggerDropVldr{},
-	210: &EventCreateVldr{},
-	211: &EventAlterVldr{},
-	212: &EventDropVldr{},
-	220: &ProcCreateVldr{},
-	221: &ProcAlterVldr{},
-	222: &ProcDropVldr{},
-	230: &MiscVldr{},
-}
+// 全局的审核任务槽位，限制同时执行的验证器数量，所有工单共享
+var workers = make(chan struct{}, runtime.NumCPU()*2)
 
-// Run 调用入口
+// Run 调用入口，元数据来自目标群集
 func Run(stmts []*models.Statement, cluster *models.Cluster, ticket *models.Ticket) {
-	wg := &sync.WaitGroup{}
+	p := NewClusterProvider(cluster)
 	ctx := &Context{
 		Cluster: cluster,
+		Passwd:  p.Passwd,
 		Ticket:  ticket,
 		Stmts:   stmts,
+		Profile: caches.RuleProfilesMap.Resolve(cluster.ClusterID, ticket.Database),
+		Waivers: caches.WaiversMap.Active(ticket.TicketID, cluster.ClusterID),
 	}
-	passwd := func(c *models.Cluster) []byte {
-		bs, _ := tools.DecryptAES(c.Password, g.Config().Secret.Crypto)
-		return bs
-	}
-	var err error
-	if ctx.Tables, err = cluster.Metadata("*", passwd); err != nil {
-		fmt.Println(err)
-	}
-	if ctx.Databases, err = cluster.Databases(passwd); err != nil {
-		fmt.Println(err)
+	if err := ctx.Load(p); err != nil {
+		log.Errorf("[E] 群集(uuid=%s)的元数据读取不完整: %s", cluster.UUID, err.Error())
+		ctx.DescribeLoadError(err)
 	}
+	RunContext(ctx)
+}
 
-	for g, v := range registry {
-		if v.Enabled() {
-			wg.Add(1)
-			v.SetGroup(g)
-			v.SetContext(ctx)
-			go v.Validate(wg)
-		}
+// RunContext 使用已经填充好元数据的上下文进行审核，离线审核时上下文中没有群集
+func RunContext(ctx *Context) {
+	wg := &sync.WaitGroup{}
+	ctx.Simulate()
+	ctx.Predict()
+
+	for gid, factory := range groups() {
+		v := factory()
+		if !v.Enabled() {
+			continue
+		}
+		v.SetGroup(gid)
+		v.SetContext(ctx)
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(v Validator) {
+			defer func() { <-workers }()
+			v.Validate(wg)
+		}(v)
 	}
 	wg.Wait()
+	ctx.describePredictions()
+
+	if ctx.Ticket != nil && ctx.Ticket.Content != "" {
+		Locate(ctx.Ticket.Content, ctx.Stmts)
+	}
 }
 
 // Validator 接口定义
@@ -164,14 +160,28 @@ type IndexEntry struct {
 }
 
 type vldr struct {
-	Rules []*models.Rule // 全部适用的规则
-	Ctx   *Context       // 上下文，保存检测报告？
-	Vi    []VisitInfo
+	Rules  []*models.Rule // 全部适用的规则
+	Ctx    *Context       // 上下文，保存检测报告？
+	Vi     []VisitInfo
+	Refs   []*ColumnRef   // DML语句中全部的列引用
+	Fields []*core.Column // 查询语句返回的列，*已经展开
+	Schema *Schema        // 当前语句执行之前模拟的结构
 }
 
-// SetContext 设置上下文
+// SetContext 设置上下文，在SetGroup之后调用，分组的规则按上下文中的规则配置覆盖
 func (v *vldr) SetContext(ctx *Context) {
 	v.Ctx = ctx
+	v.Rules = ctx.Profile.Apply(v.Rules)
+}
+
+// Seek 定位到语句执行之前的结构，工单中前面的DDL语句对后面的语句可见
+func (v *vldr) Seek(s *models.Statement) {
+	v.Schema = v.Ctx.SchemaOf(s)
+}
+
+// GetContext 当前的审核上下文，注册的规则函数通过它访问上下文
+func (v *vldr) GetContext() *Context {
+	return v.Ctx
 }
 
 // GetRules 设置验证分组，并初始化验证规则
@@ -215,7 +225,11 @@ func (v *vldr) TableInfo(database, table string) *core.Table {
 	if database == "" {
 		database = v.Ctx.Ticket.Database
 	}
-	if tables, ok := v.Ctx.Tables[database]; ok {
+	tables := v.Ctx.Tables
+	if v.Schema != nil {
+		tables = v.Schema.Tables
+	}
+	if tables, ok := tables[database]; ok {
 		for _, elem := range tables {
 			if elem.Name == table {
 				return elem
@@ -225,91 +239,27 @@ func (v *vldr) TableInfo(database, table string) *core.Table {
 	return nil
 }
 
-// Stack LIFO栈的简单实现
-type Stack struct {
-	sync.Mutex // you don't have to do this if you don't want thread safety
-	s          []int
-}
-
-// NewStack 初始化堆栈
-func NewStack() *Stack {
-	return &Stack{
-		s: make([]int, 0),
-	}
-}
-
-// Push 压栈
-func (s *Stack) Push(v int) {
-	s.Lock()
-	defer s.Unlock()
-
-	s.s = append(s.s, v)
-}
-
-// Pop 弹出
-func (s *Stack) Pop() (int, error) {
-	s.Lock()
-	defer s.Unlock()
-
-	l := len(s.s)
-	if l == 0 {
-		return 0, errors.New("Empty Stack")
-	}
-
-	res := s.s[l-1]
-	s.s = s.s[:l-1]
-	return res, nil
-}
-
-// WalkSubquery 递归检查自查询有效性并获取访问信息
-func (v *vldr) WalkSubquery(node ast.SubqueryExpr, stack *Stack) {
-	return
-}
-
 // Walk 语法树分析
 func (v *vldr) Walk(node ast.Node) {
+	// 每条语句的访问信息各自独立，不能累积到下一条语句
+	v.Vi = nil
+	v.Refs = nil
+	v.Fields = nil
 	switch x := node.(type) {
 	case *ast.DeallocateStmt:
 		return
-	case *ast.DeleteStmt:
-		if x.TableRefs != nil {
-			for _, elem := range Walk(v.Ctx, x.TableRefs.TableRefs) {
-				v.appendVisitInfo(elem)
-			}
-		}
+	case *ast.DeleteStmt, *ast.InsertStmt, *ast.SelectStmt, *ast.UnionStmt, *ast.UpdateStmt:
+		v.Vi, v.Refs, v.Fields = v.resolve(x)
 	case *ast.ExecuteStmt:
 		// TODO:
 	case *ast.ExplainStmt:
 		// TODO:
 	case *ast.ExplainForStmt:
 		// TODO:
-	case *ast.InsertStmt:
-		for _, elem := range Walk(v.Ctx, x.Table.TableRefs) {
-			v.appendVisitInfo(elem)
-		}
-		for _, elem := range Walk(v.Ctx, x.Select) {
-			v.appendVisitInfo(elem)
-		}
 	case *ast.LoadDataStmt:
 		// TODO:
 	case *ast.PrepareStmt:
 		// TODO:
-	case *ast.SelectStmt:
-		for _, elem := range Walk(v.Ctx, x) {
-			v.appendVisitInfo(elem)
-		}
-	case *ast.UnionStmt:
-		for _, ss := range x.SelectList.Selects {
-			for _, elem := range Walk(v.Ctx, ss) {
-				v.appendVisitInfo(elem)
-			}
-		}
-	case *ast.UpdateStmt:
-		if x.TableRefs != nil {
-			for _, elem := range Walk(v.Ctx, x.TableRefs.TableRefs) {
-				v.appendVisitInfo(elem)
-			}
-		}
 	case *ast.ShowStmt:
 		// TODO:
 	case *ast.AnalyzeTableStmt:
@@ -457,213 +407,198 @@ func (v *vldr) Walk(node ast.Node) {
 	}
 }
 
-// Walk TODO
-func Walk(ctx *Context, node ast.Node) []VisitInfo { //may have duplicate db name
-	var vi []VisitInfo
+// DatabaseInfo 根据数据库名称获取数据库信息
+func (v *vldr) DatabaseInfo(name string) *models.Database {
+	if name == "" {
+		name = v.Ctx.Ticket.Database
+	}
+	databases := v.Ctx.Databases
+	if v.Schema != nil {
+		databases = v.Schema.Databases
+	}
+	for _, database := range databases {
+		if database.Name == name {
+			return &database
+		}
+	}
+	return nil
+}
 
-	if node == nil {
-		return vi
+// RoutineInfo 根据名称获取存储函数或者存储过程的信息
+func (v *vldr) RoutineInfo(database, name, typ string) *models.Routine {
+	if database == "" {
+		database = v.Ctx.Ticket.Database
 	}
+	return v.current().Routine(database, name, typ)
+}
 
-	switch x := node.(type) {
-	case *ast.TableSource:
-		Walk(ctx, x.Source)
-	case *ast.UnionStmt:
-		if x.SelectList != nil {
-			for _, sel := range x.SelectList.Selects {
-				Walk(ctx, sel)
-			}
-		}
-	case *ast.SelectStmt:
-		if x.From != nil {
-			Walk(ctx, x.From.TableRefs)
-		}
-		if x.GroupBy != nil {
-			for _, item := range x.OrderBy.Items {
-				fmt.Printf("x.GroupBy.Items: %T, %+v\n", item, item)
-			}
-		}
-		if x.OrderBy != nil {
-			for _, item := range x.OrderBy.Items {
-				fmt.Printf("x.OrderBy.Items: %T, %+v\n", item, item)
-			}
+// TriggerInfo 根据名称获取触发器的信息
+func (v *vldr) TriggerInfo(database, name string) *models.Trigger {
+	if database == "" {
+		database = v.Ctx.Ticket.Database
+	}
+	return v.current().Trigger(database, name)
+}
+
+// EventInfo 根据名称获取事件的信息
+func (v *vldr) EventInfo(database, name string) *models.Event {
+	if database == "" {
+		database = v.Ctx.Ticket.Database
+	}
+	return v.current().Event(database, name)
+}
+
+// current 当前语句执行之前的结构，没有定位到语句时使用群集的真实结构
+func (v *vldr) current() *Schema {
+	if v.Schema == nil {
+		return v.Ctx.initial()
+	}
+	return v.Schema
+}
+
+// inspect 使用全部的规则组审核存储程序中的语句，解析器不支持的流程控制语句直接跳过，
+// 存储程序中的语句以当前语句执行之前的结构为起点
+func (v *vldr) inspect(body string) []*models.Statement {
+	schema := v.current()
+	ctx := &Context{
+		Ticket:     v.Ctx.Ticket,
+		Cluster:    v.Ctx.Cluster,
+		Databases:  schema.Databases,
+		Tables:     schema.Tables,
+		Routines:   schema.Routines,
+		Triggers:   schema.Triggers,
+		Events:     schema.Events,
+		Variables:  v.Ctx.Variables,
+		Profile:    v.Ctx.Profile,
+		Waivers:    v.Ctx.Waivers,
+		Version:    v.Ctx.Version,
+		TableStats: v.Ctx.TableStats,
+	}
+	p := parser.New()
+	for _, sql := range programBody(body) {
+		nodes, err := parse(p, sql)
+		if err != nil {
+			continue
+		}
+		for _, node := range nodes {
+			ctx.Stmts = append(ctx.Stmts, &models.Statement{
+				Sequence:   uint16(len(ctx.Stmts) + 1),
+				Content:    sql,
+				StmtNode:   node,
+				Violations: &models.Violations{},
+			})
 		}
-		if x.Where != nil {
-			fmt.Printf("x.Where: %T, %+v", x.Where, x.Where)
-			switch expr := x.Where.(type) {
-			case *ast.AggregateFuncExpr:
-			case *ast.WindowFuncExpr:
-			case *ast.BetweenExpr:
-			case *ast.BinaryOperationExpr:
-				fmt.Printf("ast.BinaryOperationExpr, L:%T, %+v\n", expr.L, expr.L)
-				fmt.Printf("ast.BinaryOperationExpr, R:%T, %+v\n", expr.R, expr.R)
-			case *ast.CaseExpr:
-			case *ast.ColumnNameExpr:
-			case *ast.CompareSubqueryExpr:
-			case *ast.DefaultExpr:
-			case *ast.ExistsSubqueryExpr:
-			case *ast.FuncCallExpr:
-			case *ast.FuncCastExpr:
-			case *ast.IsNullExpr:
-			case *ast.IsTruthExpr:
-			case *ast.ParenthesesExpr:
-			case *ast.PatternInExpr:
-			case *ast.PatternLikeExpr:
-			case *ast.PatternRegexpExpr:
-			case *ast.PositionExpr:
-			case *ast.RowExpr:
-			case *ast.SubqueryExpr:
-			case *ast.UnaryOperationExpr:
-			case *ast.ValuesExpr:
-			case *ast.VariableExpr:
+	}
+	if len(ctx.Stmts) == 0 {
+		return nil
+	}
+
+	ctx.Simulate()
+	ctx.Predict()
+	for gid, factory := range groups() {
+		vd := factory()
+		if !vd.Enabled() {
+			continue
+		}
+		vd.SetGroup(gid)
+		vd.SetContext(ctx)
+		// 已经占用了一个审核任务槽位，这里同步执行，避免槽位耗尽时互相等待
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		vd.Validate(wg)
+	}
+	return ctx.Stmts
+}
+
+// validateBody 使用其他规则组审核存储程序中的语句，发现的问题附加到存储程序的语句上，级别保持不变
+func (v *vldr) validateBody(s *models.Statement, r *models.Rule, name, body string) {
+	if body == "" {
+		return
+	}
+	for _, stmt := range v.inspect(body) {
+		for _, clause := range stmt.Violations.Clauses() {
+			c := &models.Clause{
+				Description: fmt.Sprintf(r.Message, name, stmt.Content, clause.Description),
+				Level:       clause.Level,
+				Rule:        clause.Rule,
+				Object:      clause.Object,
+				Fix:         clause.Fix,
+				Waived:      clause.Waived,
+				WaivedBy:    clause.WaivedBy,
+				Reason:      clause.Reason,
 			}
+			s.Violations.Append(c)
 		}
-	case *ast.TableName:
-		table := &TableEntry{
-			Name: x.Name.O,
-		}
-		database := x.Schema.O
-		if database == "" {
-			database = ctx.Ticket.Database
-		}
-		elem := VisitInfo{
-			Database: database,
-			Table:    table,
-		}
-		vi = append(vi, elem)
-	case *ast.Join:
-		Walk(ctx, x.Left)
-		Walk(ctx, x.Right)
 	}
+}
 
-	return vi
+// targetDatabase 存储程序所属的数据库是否存在
+func (v *vldr) targetDatabase(s *models.Statement, r *models.Rule, name *ProgramName) {
+	database := name.Schema
+	if database == "" {
+		database = v.Ctx.Ticket.Database
+	}
+	if v.DatabaseInfo(database) == nil {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, database),
+			Level:       r.Level,
+			Object:      database,
+		}
+		s.Violations.Append(c)
+	}
 }
 
-// func WalkExpr(s ast.ExprNode) (L []TableInfo) {
-// 	nodes := []ast.ExprNode{}
-// 	switch s.(type) {
-// 	case *ast.BetweenExpr:
-// 		expr := s.(*ast.BetweenExpr)
-// 		nodes = []ast.ExprNode{expr.Left, expr.Right}
-// 	case *ast.BinaryOperationExpr:
-// 		expr := s.(*ast.BinaryOperationExpr)
-// 		nodes = []ast.ExprNode{expr.L, expr.R}
-// 	case *ast.CaseExpr:
-// 		expr := s.(*ast.CaseExpr)
-// 		nodes = []ast.ExprNode{expr.Value, expr.ElseClause}
-// 		for _, w := range expr.WhenClauses {
-// 			nodes = append(nodes, w.Expr)
-// 			nodes = append(nodes, w.Result)
-// 		}
-// 	case *ast.ColumnNameExpr:
-// 		// expr := s.(*ast.ColumnNameExpr)
-// 		// fmt.Printf("*ast.ColumnNameExpr: %T, %+v\n", expr, expr)
-// 	case *ast.DefaultExpr:
-// 		// expr := s.(*ast.DefaultExpr)
-// 		// fmt.Printf("*ast.DefaultExpr: %T, %+v\n", expr, expr)
-// 	case *ast.CompareSubqueryExpr:
-// 		expr := s.(*ast.CompareSubqueryExpr)
-// 		nodes = []ast.ExprNode{expr.L, expr.R}
-// 	case *ast.ExistsSubqueryExpr:
-// 		expr := s.(*ast.ExistsSubqueryExpr)
-// 		nodes = []ast.ExprNode{expr.Sel}
-// 	case *ast.IsNullExpr:
-// 		expr := s.(*ast.IsNullExpr)
-// 		nodes = []ast.ExprNode{expr.Expr}
-// 	case *ast.IsTruthExpr:
-// 		expr := s.(*ast.IsTruthExpr)
-// 		nodes = []ast.ExprNode{expr.Expr}
-// 	case *ast.ParenthesesExpr:
-// 		expr := s.(*ast.ParenthesesExpr)
-// 		nodes = []ast.ExprNode{expr.Expr}
-// 	case *ast.PatternInExpr:
-// 		expr := s.(*ast.PatternInExpr)
-// 		nodes = []ast.ExprNode{expr.Expr}
-// 		if expr.Sel != nil {
-// 			nodes = append(nodes, expr.Sel)
-// 		}
-// 		nodes = append(nodes, expr.List...)
-// 	case *ast.PatternLikeExpr:
-// 		expr := s.(*ast.PatternLikeExpr)
-// 		nodes = []ast.ExprNode{expr.Expr, expr.Pattern}
-// 	case *ast.PatternRegexpExpr:
-// 		expr := s.(*ast.PatternRegexpExpr)
-// 		nodes = []ast.ExprNode{expr.Expr, expr.Pattern}
-// 	case *ast.PositionExpr:
-// 		expr := s.(*ast.PositionExpr)
-// 		nodes = []ast.ExprNode{expr.P}
-// 	case *ast.RowExpr:
-// 		expr := s.(*ast.RowExpr)
-// 		nodes = append(nodes, expr.Values...)
-// 	case *ast.SubqueryExpr:
-// 		expr := s.(*ast.SubqueryExpr)
-// 		stmt := expr.Query.(*ast.SelectStmt)
-// 		L = append(L, WalkJoin(stmt.From.TableRefs)...)
-// 		nodes = []ast.ExprNode{stmt.Where}
-// 	case *ast.UnaryOperationExpr:
-// 		expr := s.(*ast.UnaryOperationExpr)
-// 		nodes = []ast.ExprNode{expr.V}
-// 	case *ast.ValuesExpr:
-// 		expr := s.(*ast.ValuesExpr)
-// 		L = append(L, TableInfo{expr.Column.Name.Schema.O, expr.Column.Name.Table.O, ""})
-// 	case *ast.VariableExpr:
-// 		expr := s.(*ast.VariableExpr)
-// 		nodes = []ast.ExprNode{expr.Value}
-// 	}
-
-// 	for _, node := range nodes {
-// 		if node == nil {
-// 			continue
-// 		}
-// 		L = append(L, WalkExpr(node)...)
-// 	}
-
-// 	return
-// }
-
-// func WalkJoin(s *ast.Join) (L []TableInfo) {
-// 	nodes := []ast.ResultSetNode{s.Left, s.Right}
-// 	for _, node := range nodes {
-// 		if node == nil {
-// 			continue
-// 		}
-// 		switch node.(type) {
-// 		case *ast.TableSource:
-// 			ts := node.(*ast.TableSource)
-// 			switch ts.Source.(type) {
-// 			case *ast.TableName:
-// 				tn := ts.Source.(*ast.TableName)
-// 				L = append(L, TableInfo{Schema: tn.Schema.O, Name: tn.Name.O})
-// 			}
-// 		case *ast.Join:
-// 			L = append(L, WalkJoin(node.(*ast.Join))...)
-// 		}
-// 	}
-// 	return
-// }
+// Call 调用规则函数，注册的规则函数优先，其次是规则组上的同名方法，
+// 规则函数中的panic会被记录下来，不影响其他规则的审核。
+// 规则产生的描述记录规则名称和违反规则的对象，规则在语句上被豁免时照常审核，产生的描述标记为已豁免
+func Call(object interface{}, method string, params ...interface{}) (err error) {
+	vd, ok := object.(interface{ GetContext() *Context })
+	if !ok || vd.GetContext() == nil || len(params) != 2 {
+		return call(object, method, params...)
+	}
+	s, _ := params[0].(*models.Statement)
+	r, _ := params[1].(*models.Rule)
+	if s == nil || s.Violations == nil || r == nil {
+		return call(object, method, params...)
+	}
 
-// DatabaseInfo 根据数据库名称获取数据库信息
-func (v *vldr) DatabaseInfo(name string) *models.Database {
-	if name == "" {
-		name = v.Ctx.Ticket.Database
+	clauses := s.Violations.Capture(func() {
+		err = call(object, method, params...)
+	})
+	if len(clauses) == 0 {
+		return
 	}
-	for _, database := range v.Ctx.Databases {
-		if database.Name == name {
-			return &database
-		}
+	s.Violations.Stamp(clauses, r.Name, targetOf(s.StmtNode))
+	if by, reason, waived := vd.GetContext().waive(s, r); waived {
+		s.Violations.Waive(clauses, by, reason)
 	}
-	return nil
+	return
 }
 
-// Call 方法反射
-func Call(object interface{}, method string, params ...interface{}) error {
+// call 按照名称调用规则函数
+func call(object interface{}, method string, params ...interface{}) (err error) {
 	defer func() {
-		if err := recover(); err != nil {
-			fmt.Printf("错误代码: 1500, 错误信息: %s\n", err)
-			return
+		if e := recover(); e != nil {
+			err = fmt.Errorf("错误代码: 1500, 错误信息: 规则函数`%s`执行失败，%v", method, e)
+			log.Errorf("[E] %s", err.Error())
 		}
 	}()
 
+	if fn := ruleFunc(method); fn != nil {
+		vd, ok := object.(interface{ GetContext() *Context })
+		if !ok || len(params) == 0 || len(params) > 2 {
+			return fmt.Errorf("Couldn't call rule `%s` on `%T`", method, object)
+		}
+		// MiscVldr的规则只有规则参数，没有语句
+		var s *models.Statement
+		if len(params) == 2 {
+			s, _ = params[0].(*models.Statement)
+		}
+		r, _ := params[len(params)-1].(*models.Rule)
+		fn(vd.GetContext(), s, r)
+		return nil
+	}
+
 	v := reflect.ValueOf(object)
 	// Check if the passed interface is a pointer
 	if v.Type().Kind() != reflect.Ptr {
@@ -675,14 +610,9 @@ func Call(object interface{}, method string, params ...interface{}) error {
 	if !f.IsValid() {
 		return fmt.Errorf("Couldn't find method `%s` in interface `%s`, is it exported?", method, v.Type())
 	}
-	// 'dereference' with Elem() and get the field by name
-	// f := ValueIface.Elem().FieldByName(FieldName)
-	// if !f.IsValid() {
-	// 	return fmt.Errorf("Interface `%s` does not have the field `%s`", v.Type(), f)
-	// }
 	// 判断函数参数和传入的参数是否相等
 	if len(params) != f.Type().NumIn() {
-		return fmt.Errorf("")
+		return fmt.Errorf("Method `%s` in interface `%s` expects %d arguments", method, v.Type(), f.Type().NumIn())
 	}
 	if f.Type().NumIn() == 0 {
 		f.Call(nil)
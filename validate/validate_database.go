@@ -37,6 +37,7 @@ func (v *DatabaseCreateVldr) Validate(wg *sync.WaitGroup) {
 	for _, s := range v.Ctx.Stmts {
 		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
 		node := s.StmtNode
+		v.Seek(s)
 		if cd, ok := node.(*ast.CreateDatabaseStmt); !ok {
 			// 类型断言不成功
 			continue
@@ -78,6 +79,9 @@ func (v *DatabaseCreateVldr) AvailableCharsets(s *models.Statement, r *models.Ru
 			Description: fmt.Sprintf(r.Message, useCharset, charsets),
 			Level:       r.Level,
 		}
+		if len(charsets) > 0 {
+			c.Fix = fmt.Sprintf("DEFAULT CHARACTER SET %s", charsets[0])
+		}
 		s.Violations.Append(c)
 	}
 }
@@ -108,6 +112,9 @@ func (v *DatabaseCreateVldr) AvailableCollates(s *models.Statement, r *models.Ru
 			Description: fmt.Sprintf(r.Message, useCollate, collates),
 			Level:       r.Level,
 		}
+		if len(collates) > 0 {
+			c.Fix = fmt.Sprintf("DEFAULT COLLATE %s", collates[0])
+		}
 		s.Violations.Append(c)
 	}
 }
@@ -149,6 +156,7 @@ func (v *DatabaseCreateVldr) DatabaseNameQualified(s *models.Statement, r *model
 		c := &models.Clause{
 			Description: err.Error(),
 			Level:       r.Level,
+			Object:      dbName,
 		}
 		s.Violations.Append(c)
 	}
@@ -163,6 +171,7 @@ func (v *DatabaseCreateVldr) DatabaseNameLowerCaseRequired(s *models.Statement,
 		c := &models.Clause{
 			Description: err.Error(),
 			Level:       r.Level,
+			Object:      dbName,
 		}
 		s.Violations.Append(c)
 	}
@@ -178,6 +187,7 @@ func (v *DatabaseCreateVldr) DatabaseNameMaxLength(s *models.Statement, r *model
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, dbName, threshold),
 			Level:       r.Level,
+			Object:      dbName,
 		}
 		s.Violations.Append(c)
 	}
@@ -192,6 +202,7 @@ func (v *DatabaseCreateVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, database),
 			Level:       r.Level,
+			Object:      database,
 		}
 		s.Violations.Append(c)
 	}
@@ -220,6 +231,7 @@ func (v *DatabaseAlterVldr) Validate(wg *sync.WaitGroup) {
 	for _, s := range v.Ctx.Stmts {
 		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
 		node := s.StmtNode
+		v.Seek(s)
 		if ad, ok := node.(*ast.AlterDatabaseStmt); !ok {
 			// 类型断言不成功
 			continue
@@ -264,6 +276,9 @@ func (v *DatabaseAlterVldr) AvailableCharsets(s *models.Statement, r *models.Rul
 			Description: fmt.Sprintf(r.Message, useCharset, charsets),
 			Level:       r.Level,
 		}
+		if len(charsets) > 0 {
+			c.Fix = fmt.Sprintf("DEFAULT CHARACTER SET %s", charsets[0])
+		}
 		s.Violations.Append(c)
 	}
 }
@@ -296,6 +311,9 @@ func (v *DatabaseAlterVldr) AvailableCollates(s *models.Statement, r *models.Rul
 			Description: fmt.Sprintf(r.Message, useCollate, collates),
 			Level:       r.Level,
 		}
+		if len(collates) > 0 {
+			c.Fix = fmt.Sprintf("DEFAULT COLLATE %s", collates[0])
+		}
 		s.Violations.Append(c)
 	}
 }
@@ -346,6 +364,7 @@ func (v *DatabaseAlterVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *m
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, database),
 			Level:       r.Level,
+			Object:      database,
 		}
 		s.Violations.Append(c)
 	}
@@ -374,6 +393,7 @@ func (v *DatabaseDropVldr) Validate(wg *sync.WaitGroup) {
 	for _, s := range v.Ctx.Stmts {
 		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
 		node := s.StmtNode
+		v.Seek(s)
 		if dd, ok := node.(*ast.DropDatabaseStmt); !ok {
 			// 类型断言不成功
 			continue
@@ -399,6 +419,7 @@ func (v *DatabaseDropVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *mo
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, database),
 			Level:       r.Level,
+			Object:      database,
 		}
 		s.Violations.Append(c)
 	}
@@ -33,6 +33,7 @@ func (v *DeleteVldr) Validate(wg *sync.WaitGroup) {
 	for _, s := range v.Ctx.Stmts {
 		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
 		node := s.StmtNode
+		v.Seek(s)
 		if dd, ok := node.(*ast.DeleteStmt); !ok {
 			// 类型断言不成功
 			continue
@@ -62,11 +63,11 @@ func (v *DeleteVldr) WithoutWhereNotAllowed(s *models.Statement, r *models.Rule)
 	}
 }
 
-// MaxAllowedDeleteRows 单次删除的最大行数
+// MaxAllowedDeleteRows 单次删除的最大行数，行数来自目标群集上的执行计划
 // RULE: DEL-L3-001
 func (v *DeleteVldr) MaxAllowedDeleteRows(s *models.Statement, r *models.Rule) {
 	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
-	// TODO:
+	v.maxAllowedRows(s, r)
 }
 
 // TargetDatabaseDoesNotExist 目标库是否存在
@@ -78,6 +79,7 @@ func (v *DeleteVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.R
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, ti.Database),
 				Level:       r.Level,
+				Object:      ti.Database,
 			}
 			s.Violations.Append(c)
 		}
@@ -96,6 +98,7 @@ func (v *DeleteVldr) TargetTableDoesNotDoesNotExist(s *models.Statement, r *mode
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, fmt.Sprintf("`%s`.`%s`", ti.Database, ti.Table.Name)),
 				Level:       r.Level,
+				Object:      ti.Table.Name,
 			}
 			s.Violations.Append(c)
 		}
@@ -106,5 +109,5 @@ func (v *DeleteVldr) TargetTableDoesNotDoesNotExist(s *models.Statement, r *mode
 // RULE: DEL-L3-004
 func (v *DeleteVldr) TargetColumnDoesNotDoesNotExist(s *models.Statement, r *models.Rule) {
 	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
-	// TODO:
+	v.columnExists(s, r)
 }
@@ -1,14 +1,21 @@
 package validate
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 
+	log "github.com/sirupsen/logrus"
+
 	"github.com/mia0x75/halo/models"
 )
 
 // EventCreateVldr 创建事件语句相关的审核规则
 type EventCreateVldr struct {
 	vldr
+
+	ce *CreateEventStmt
 }
 
 // Call 利用反射方法动态调用审核函数
@@ -24,41 +31,143 @@ func (v *EventCreateVldr) Enabled() bool {
 // Validate 规则组的审核入口
 func (v *EventCreateVldr) Validate(wg *sync.WaitGroup) {
 	defer wg.Done()
+	for _, s := range v.Ctx.Stmts {
+		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
+		node := s.StmtNode
+		v.Seek(s)
+		if ce, ok := node.(*CreateEventStmt); !ok {
+			// 类型断言不成功
+			continue
+		} else {
+			v.ce = ce
+		}
+		for _, r := range v.Rules {
+			if r.Bitwise&1 != 1 {
+				continue
+			}
+			v.Call(r.Func, s, r)
+		}
+	}
 }
 
 // EventNameQualified 事件名标识符规则
 // RULE: CEV-L2-001
 func (v *EventCreateVldr) EventNameQualified(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	eventName := v.ce.Name.Name
+	if err := Match(r, eventName, eventName, r.Values); err != nil {
+		c := &models.Clause{
+			Description: err.Error(),
+			Level:       r.Level,
+			Object:      eventName,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // EventNameLowerCaseRequired 事件名大小写规则
 // RULE: CEV-L2-002
 func (v *EventCreateVldr) EventNameLowerCaseRequired(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	eventName := v.ce.Name.Name
+	if err := Match(r, eventName, eventName); err != nil {
+		c := &models.Clause{
+			Description: err.Error(),
+			Level:       r.Level,
+			Object:      eventName,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // EventNameMaxLength 事件名长度规则
 // RULE: CEV-L2-003
 func (v *EventCreateVldr) EventNameMaxLength(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	eventName := v.ce.Name.Name
+	threshold, _ := strconv.Atoi(r.Values)
+	if len(eventName) > threshold {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, eventName, threshold),
+			Level:       r.Level,
+			Object:      eventName,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // EventNamePrefixRequired 事件名前缀规则
 // RULE: CEV-L2-004
 func (v *EventCreateVldr) EventNamePrefixRequired(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	eventName := strings.TrimSpace(v.ce.Name.Name)
+	if len(eventName) > 0 {
+		if err := Match(r, eventName, r.Values); err != nil {
+			c := &models.Clause{
+				Description: fmt.Sprintf(r.Message, eventName, r.Values),
+				Level:       r.Level,
+				Object:      eventName,
+			}
+			s.Violations.Append(c)
+		}
+	}
 }
 
 // TargetDatabaseDoesNotExist 目标库是否存在
 // RULE: CEV-L3-001
 func (v *EventCreateVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	v.targetDatabase(s, r, v.ce.Name)
 }
 
 // TargetEventDoesNotExist 目标事件是否存在
 // RULE: CEV-L3-002
 func (v *EventCreateVldr) TargetEventDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	// 使用IF NOT EXISTS时，事件已存在不会报错
+	if v.ce.IfNotExists {
+		return
+	}
+	if v.EventInfo(v.ce.Name.Schema, v.ce.Name.Name) != nil {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, v.ce.Name.Name),
+			Level:       r.Level,
+			Object:      v.ce.Name.Name,
+		}
+		s.Violations.Append(c)
+	}
+}
+
+// EventSchedulerDisabled 事件调度器是否开启，离线审核时不知道群集的配置，不做检查
+// RULE: CEV-L3-003
+func (v *EventCreateVldr) EventSchedulerDisabled(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	scheduler, ok := v.Ctx.Variables["event_scheduler"]
+	if !ok {
+		return
+	}
+	if !strings.EqualFold(scheduler, "ON") {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, v.ce.Name.Name),
+			Level:       r.Level,
+			Object:      v.ce.Name.Name,
+		}
+		s.Violations.Append(c)
+	}
+}
+
+// EventBodyValidated 事件中的语句是否通过审核
+// RULE: CEV-L3-004
+func (v *EventCreateVldr) EventBodyValidated(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	v.validateBody(s, r, v.ce.Name.Name, v.ce.Body)
 }
 
 // EventAlterVldr 修改事件语句相关的审核规则
 type EventAlterVldr struct {
 	vldr
+
+	ae *AlterEventStmt
 }
 
 // Call 利用反射方法动态调用审核函数
@@ -74,21 +183,58 @@ func (v *EventAlterVldr) Enabled() bool {
 // Validate 规则组的审核入口
 func (v *EventAlterVldr) Validate(wg *sync.WaitGroup) {
 	defer wg.Done()
+	for _, s := range v.Ctx.Stmts {
+		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
+		node := s.StmtNode
+		v.Seek(s)
+		if ae, ok := node.(*AlterEventStmt); !ok {
+			// 类型断言不成功
+			continue
+		} else {
+			v.ae = ae
+		}
+		for _, r := range v.Rules {
+			if r.Bitwise&1 != 1 {
+				continue
+			}
+			v.Call(r.Func, s, r)
+		}
+	}
 }
 
 // TargetDatabaseDoesNotExist 目标库是否存在
 // RULE: MEV-L3-001
 func (v *EventAlterVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	v.targetDatabase(s, r, v.ae.Name)
 }
 
 // TargetEventDoesNotExist 目标事件是否存在
 // RULE: MEV-L3-002
 func (v *EventAlterVldr) TargetEventDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	if v.EventInfo(v.ae.Name.Schema, v.ae.Name.Name) == nil {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, v.ae.Name.Name),
+			Level:       r.Level,
+			Object:      v.ae.Name.Name,
+		}
+		s.Violations.Append(c)
+	}
+}
+
+// EventBodyValidated 事件中的语句是否通过审核，没有修改事件的语句时不做检查
+// RULE: MEV-L3-003
+func (v *EventAlterVldr) EventBodyValidated(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	v.validateBody(s, r, v.ae.Name.Name, v.ae.Body)
 }
 
 // EventDropVldr 删除事件语句相关的审核规则
 type EventDropVldr struct {
 	vldr
+
+	de *DropEventStmt
 }
 
 // Call 利用反射方法动态调用审核函数
@@ -104,14 +250,46 @@ func (v *EventDropVldr) Enabled() bool {
 // Validate 规则组的审核入口
 func (v *EventDropVldr) Validate(wg *sync.WaitGroup) {
 	defer wg.Done()
+	for _, s := range v.Ctx.Stmts {
+		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
+		node := s.StmtNode
+		v.Seek(s)
+		if de, ok := node.(*DropEventStmt); !ok {
+			// 类型断言不成功
+			continue
+		} else {
+			v.de = de
+		}
+		for _, r := range v.Rules {
+			if r.Bitwise&1 != 1 {
+				continue
+			}
+			v.Call(r.Func, s, r)
+		}
+	}
 }
 
 // TargetDatabaseDoesNotExist 目标库是否存在
 // RULE: DEV-L3-001
 func (v *EventDropVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	v.targetDatabase(s, r, v.de.Name)
 }
 
 // TargetEventDoesNotExist 目标事件是否存在
 // RULE: DEV-L3-002
 func (v *EventDropVldr) TargetEventDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	// 使用IF EXISTS时，事件不存在不会报错
+	if v.de.IfExists {
+		return
+	}
+	if v.EventInfo(v.de.Name.Schema, v.de.Name.Name) == nil {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, v.de.Name.Name),
+			Level:       r.Level,
+			Object:      v.de.Name.Name,
+		}
+		s.Violations.Append(c)
+	}
 }
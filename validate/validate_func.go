@@ -1,14 +1,21 @@
 package validate
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 
+	log "github.com/sirupsen/logrus"
+
 	"github.com/mia0x75/halo/models"
 )
 
 // FuncCreateVldr 创建函数语句相关的审核规则
 type FuncCreateVldr struct {
 	vldr
+
+	cf *CreateFunctionStmt
 }
 
 // Call 利用反射方法动态调用审核函数
@@ -24,41 +31,118 @@ func (v *FuncCreateVldr) Enabled() bool {
 // Validate 规则组的审核入口
 func (v *FuncCreateVldr) Validate(wg *sync.WaitGroup) {
 	defer wg.Done()
+	for _, s := range v.Ctx.Stmts {
+		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
+		node := s.StmtNode
+		v.Seek(s)
+		if cf, ok := node.(*CreateFunctionStmt); !ok {
+			// 类型断言不成功
+			continue
+		} else {
+			v.cf = cf
+		}
+		for _, r := range v.Rules {
+			if r.Bitwise&1 != 1 {
+				continue
+			}
+			v.Call(r.Func, s, r)
+		}
+	}
 }
 
 // FuncNameQuilified 函数名标识符规则
 // RULE: CFU-L2-001
 func (v *FuncCreateVldr) FuncNameQuilified(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	funcName := v.cf.Name.Name
+	if err := Match(r, funcName, funcName, r.Values); err != nil {
+		c := &models.Clause{
+			Description: err.Error(),
+			Level:       r.Level,
+			Object:      funcName,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // FuncNameLowerCaseRequired 函数名大小写规则
 // RULE: CFU-L2-002
 func (v *FuncCreateVldr) FuncNameLowerCaseRequired(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	funcName := v.cf.Name.Name
+	if err := Match(r, funcName, funcName); err != nil {
+		c := &models.Clause{
+			Description: err.Error(),
+			Level:       r.Level,
+			Object:      funcName,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // FuncNameMaxLength 函数名长度规则
 // RULE: CFU-L2-003
 func (v *FuncCreateVldr) FuncNameMaxLength(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	funcName := v.cf.Name.Name
+	threshold, _ := strconv.Atoi(r.Values)
+	if len(funcName) > threshold {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, funcName, threshold),
+			Level:       r.Level,
+			Object:      funcName,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // FuncNamePrefixRequired 函数名前缀规则
 // RULE: CFU-L2-004
 func (v *FuncCreateVldr) FuncNamePrefixRequired(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	funcName := strings.TrimSpace(v.cf.Name.Name)
+	if len(funcName) > 0 {
+		if err := Match(r, funcName, r.Values); err != nil {
+			c := &models.Clause{
+				Description: fmt.Sprintf(r.Message, funcName, r.Values),
+				Level:       r.Level,
+				Object:      funcName,
+			}
+			s.Violations.Append(c)
+		}
+	}
 }
 
 // TargetDatabaseDoesNotExist 目标库是否存在
 // RULE: CFU-L3-001
 func (v *FuncCreateVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	v.targetDatabase(s, r, v.cf.Name)
 }
 
 // TargetFuncDoesNotExist 目标函数是否存在
 // RULE: CFU-L3-002
 func (v *FuncCreateVldr) TargetFuncDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	// 使用IF NOT EXISTS时，函数已存在不会报错
+	if v.cf.IfNotExists {
+		return
+	}
+	if v.RoutineInfo(v.cf.Name.Schema, v.cf.Name.Name, ProgramFunction) != nil {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, v.cf.Name.Name),
+			Level:       r.Level,
+			Object:      v.cf.Name.Name,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // FuncAlterVldr 修改函数语句相关的审核规则
 type FuncAlterVldr struct {
 	vldr
+
+	af *AlterFunctionStmt
 }
 
 // Call 利用反射方法动态调用审核函数
@@ -74,21 +158,51 @@ func (v *FuncAlterVldr) Enabled() bool {
 // Validate 规则组的审核入口
 func (v *FuncAlterVldr) Validate(wg *sync.WaitGroup) {
 	defer wg.Done()
+	for _, s := range v.Ctx.Stmts {
+		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
+		node := s.StmtNode
+		v.Seek(s)
+		if af, ok := node.(*AlterFunctionStmt); !ok {
+			// 类型断言不成功
+			continue
+		} else {
+			v.af = af
+		}
+		for _, r := range v.Rules {
+			if r.Bitwise&1 != 1 {
+				continue
+			}
+			v.Call(r.Func, s, r)
+		}
+	}
 }
 
 // TargetDatabaseDoesNotExist 目标库是否存在
 // RULE: MFU-L3-001
 func (v *FuncAlterVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	v.targetDatabase(s, r, v.af.Name)
 }
 
 // TargetFuncDoesNotExist 目标函数是否存在
 // RULE: MFU-L3-002
 func (v *FuncAlterVldr) TargetFuncDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	if v.RoutineInfo(v.af.Name.Schema, v.af.Name.Name, ProgramFunction) == nil {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, v.af.Name.Name),
+			Level:       r.Level,
+			Object:      v.af.Name.Name,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // FuncDropVldr 删除函数语句相关的审核规则
 type FuncDropVldr struct {
 	vldr
+
+	df *DropFunctionStmt
 }
 
 // Call 利用反射方法动态调用审核函数
@@ -104,14 +218,46 @@ func (v *FuncDropVldr) Enabled() bool {
 // Validate 规则组的审核入口
 func (v *FuncDropVldr) Validate(wg *sync.WaitGroup) {
 	defer wg.Done()
+	for _, s := range v.Ctx.Stmts {
+		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
+		node := s.StmtNode
+		v.Seek(s)
+		if df, ok := node.(*DropFunctionStmt); !ok {
+			// 类型断言不成功
+			continue
+		} else {
+			v.df = df
+		}
+		for _, r := range v.Rules {
+			if r.Bitwise&1 != 1 {
+				continue
+			}
+			v.Call(r.Func, s, r)
+		}
+	}
 }
 
 // TargetDatabaseDoesNotExist 目标库是否存在
 // RULE: DFU-L3-001
 func (v *FuncDropVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	v.targetDatabase(s, r, v.df.Name)
 }
 
 // TargetFuncDoesNotExist 目标函数是否存在
 // RULE: DFU-L3-002
 func (v *FuncDropVldr) TargetFuncDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	// 使用IF EXISTS时，函数不存在不会报错
+	if v.df.IfExists {
+		return
+	}
+	if v.RoutineInfo(v.df.Name.Schema, v.df.Name.Name, ProgramFunction) == nil {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, v.df.Name.Name),
+			Level:       r.Level,
+			Object:      v.df.Name.Name,
+		}
+		s.Violations.Append(c)
+	}
 }
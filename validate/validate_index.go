@@ -34,6 +34,7 @@ func (v *IndexCreateVldr) Validate(wg *sync.WaitGroup) {
 	for _, s := range v.Ctx.Stmts {
 		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
 		node := s.StmtNode
+		v.Seek(s)
 		if ci, ok := node.(*ast.CreateIndexStmt); !ok {
 			// 类型断言不成功
 			continue
@@ -58,6 +59,7 @@ func (v *IndexCreateVldr) MaxAllowedIndexColumnCount(s *models.Statement, r *mod
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, v.ci.IndexName, threshold),
 			Level:       r.Level,
+			Object:      v.ci.IndexName,
 		}
 		s.Violations.Append(c)
 	}
@@ -72,6 +74,7 @@ func (v *IndexCreateVldr) IndexNameQualified(s *models.Statement, r *models.Rule
 		c := &models.Clause{
 			Description: err.Error(),
 			Level:       r.Level,
+			Object:      indexName,
 		}
 		s.Violations.Append(c)
 	}
@@ -86,6 +89,7 @@ func (v *IndexCreateVldr) IndexNameLowerCaseRequired(s *models.Statement, r *mod
 		c := &models.Clause{
 			Description: err.Error(),
 			Level:       r.Level,
+			Object:      indexName,
 		}
 		s.Violations.Append(c)
 	}
@@ -100,6 +104,7 @@ func (v *IndexCreateVldr) IndexNameMaxLength(s *models.Statement, r *models.Rule
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, indexName, threshold),
 			Level:       r.Level,
+			Object:      indexName,
 		}
 		s.Violations.Append(c)
 		return
@@ -115,6 +120,7 @@ func (v *IndexCreateVldr) IndexNamePrefixRequired(s *models.Statement, r *models
 		c := &models.Clause{
 			Description: err.Error(),
 			Level:       r.Level,
+			Object:      indexName,
 		}
 		s.Violations.Append(c)
 	}
@@ -129,6 +135,7 @@ func (v *IndexCreateVldr) IndexColumnDuplicate(s *models.Statement, r *models.Ru
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, v.ci.IndexName),
 				Level:       r.Level,
+				Object:      v.ci.IndexName,
 			}
 			s.Violations.Append(c)
 			return
@@ -146,6 +153,7 @@ func (v *IndexCreateVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *mod
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, ti.Database),
 				Level:       r.Level,
+				Object:      ti.Database,
 			}
 			s.Violations.Append(c)
 		}
@@ -164,6 +172,7 @@ func (v *IndexCreateVldr) TargetTableDoesNotExist(s *models.Statement, r *models
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, fmt.Sprintf("`%s`.`%s`", ti.Database, ti.Table.Name)),
 				Level:       r.Level,
+				Object:      ti.Table.Name,
 			}
 			s.Violations.Append(c)
 		}
@@ -183,6 +192,7 @@ func (v *IndexCreateVldr) TargetColumnDoesNotExist(s *models.Statement, r *model
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, col.Column.Name.O),
 				Level:       r.Level,
+				Object:      col.Column.Name.O,
 			}
 			s.Violations.Append(c)
 		}
@@ -207,6 +217,7 @@ func (v *IndexCreateVldr) IndexNameDuplicate(s *models.Statement, r *models.Rule
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, v.ci.IndexName, v.ci.Table.Name.O),
 			Level:       r.Level,
+			Object:      v.ci.IndexName,
 		}
 		s.Violations.Append(c)
 	}
@@ -250,6 +261,7 @@ func (v *IndexCreateVldr) IndexOnBlobColumnNotAllowed(s *models.Statement, r *mo
 				c := &models.Clause{
 					Description: fmt.Sprintf(r.Message, ci.Name),
 					Level:       r.Level,
+					Object:      ci.Name,
 				}
 				s.Violations.Append(c)
 			}
@@ -280,6 +292,7 @@ func (v *IndexDropVldr) Validate(wg *sync.WaitGroup) {
 	for _, s := range v.Ctx.Stmts {
 		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
 		node := s.StmtNode
+		v.Seek(s)
 		if di, ok := node.(*ast.DropIndexStmt); !ok {
 			// 类型断言不成功
 			continue
@@ -305,6 +318,7 @@ func (v *IndexDropVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *model
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, ti.Database),
 				Level:       r.Level,
+				Object:      ti.Database,
 			}
 			s.Violations.Append(c)
 		}
@@ -323,6 +337,7 @@ func (v *IndexDropVldr) TargetTableDoesNotExist(s *models.Statement, r *models.R
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, fmt.Sprintf("`%s`.`%s`", ti.Database, ti.Table.Name)),
 				Level:       r.Level,
+				Object:      ti.Table.Name,
 			}
 			s.Violations.Append(c)
 		}
@@ -341,6 +356,7 @@ func (v *IndexDropVldr) TargetIndexDoesNotExist(s *models.Statement, r *models.R
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, v.di.IndexName),
 			Level:       r.Level,
+			Object:      v.di.IndexName,
 		}
 		s.Violations.Append(c)
 	}
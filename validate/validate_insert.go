@@ -34,8 +34,9 @@ func (v *InsertVldr) Validate(wg *sync.WaitGroup) {
 	for _, s := range v.Ctx.Stmts {
 		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
 		node := s.StmtNode
-		if id, ok := node.(*ast.InsertStmt); !ok {
-			// 类型断言不成功
+		v.Seek(s)
+		if id, ok := node.(*ast.InsertStmt); !ok || id.IsReplace {
+			// 类型断言不成功，REPLACE语句由ReplaceVldr审核
 			continue
 		} else {
 			v.id = id
@@ -54,10 +55,6 @@ func (v *InsertVldr) Validate(wg *sync.WaitGroup) {
 // RULE: INS-L2-001
 func (v *InsertVldr) ExplicitColumnRequired(s *models.Statement, r *models.Rule) {
 	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
-	if v.id.IsReplace {
-		return
-	}
-
 	if v.id.Columns == nil || len(v.id.Columns) == 0 {
 		c := &models.Clause{
 			Description: r.Message,
@@ -71,10 +68,6 @@ func (v *InsertVldr) ExplicitColumnRequired(s *models.Statement, r *models.Rule)
 // RULE: INS-L2-002
 func (v *InsertVldr) UsingSelectNotAllowed(s *models.Statement, r *models.Rule) {
 	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
-	if v.id.IsReplace {
-		return
-	}
-
 	if v.id.Select != nil {
 		c := &models.Clause{
 			Description: r.Message,
@@ -126,6 +119,7 @@ func (v *InsertVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.R
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, ti.Database),
 				Level:       r.Level,
+				Object:      ti.Database,
 			}
 			s.Violations.Append(c)
 		}
@@ -144,6 +138,7 @@ func (v *InsertVldr) TargetTableDoesNotExist(s *models.Statement, r *models.Rule
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, fmt.Sprintf("`%s`.`%s`", ti.Database, ti.Table.Name)),
 				Level:       r.Level,
+				Object:      ti.Table.Name,
 			}
 			s.Violations.Append(c)
 		}
@@ -154,7 +149,7 @@ func (v *InsertVldr) TargetTableDoesNotExist(s *models.Statement, r *models.Rule
 // RULE: INS-L3-003
 func (v *InsertVldr) TargetColumnDoesNotExist(s *models.Statement, r *models.Rule) {
 	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
-	// TODO:
+	v.columnExists(s, r)
 }
 
 // ValueForNotNullColumnRequired 非空列是否有值
@@ -164,11 +159,9 @@ func (v *InsertVldr) ValueForNotNullColumnRequired(s *models.Statement, r *model
 	// TODO:
 }
 
-// ReplaceVldr 插入数据语句相关的审核规则
+// ReplaceVldr 替换数据语句相关的审核规则，规则与INSERT语句相同
 type ReplaceVldr struct {
-	vldr
-
-	id *ast.InsertStmt
+	InsertVldr
 }
 
 // Call 利用反射方法动态调用审核函数
@@ -187,11 +180,13 @@ func (v *ReplaceVldr) Validate(wg *sync.WaitGroup) {
 	for _, s := range v.Ctx.Stmts {
 		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
 		node := s.StmtNode
-		if id, ok := node.(*ast.InsertStmt); !ok {
+		v.Seek(s)
+		if id, ok := node.(*ast.InsertStmt); !ok || !id.IsReplace {
 			// 类型断言不成功
 			continue
 		} else {
 			v.id = id
+			v.Walk(v.id)
 		}
 		for _, r := range v.Rules {
 			if r.Bitwise&1 != 1 {
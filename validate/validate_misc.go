@@ -132,6 +132,7 @@ func (v *MiscVldr) MergeRequired(r *models.Rule) {
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, name),
 				Level:       r.Level,
+				Object:      name,
 			}
 			s.Violations.Append(c)
 		} else {
@@ -1,14 +1,21 @@
 package validate
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 
+	log "github.com/sirupsen/logrus"
+
 	"github.com/mia0x75/halo/models"
 )
 
 // ProcCreateVldr 创建存储过程语句相关的审核规则
 type ProcCreateVldr struct {
 	vldr
+
+	cp *CreateProcedureStmt
 }
 
 // Call 利用反射方法动态调用审核函数
@@ -24,41 +31,125 @@ func (v *ProcCreateVldr) Enabled() bool {
 // Validate 规则组的审核入口
 func (v *ProcCreateVldr) Validate(wg *sync.WaitGroup) {
 	defer wg.Done()
+	for _, s := range v.Ctx.Stmts {
+		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
+		node := s.StmtNode
+		v.Seek(s)
+		if cp, ok := node.(*CreateProcedureStmt); !ok {
+			// 类型断言不成功
+			continue
+		} else {
+			v.cp = cp
+		}
+		for _, r := range v.Rules {
+			if r.Bitwise&1 != 1 {
+				continue
+			}
+			v.Call(r.Func, s, r)
+		}
+	}
 }
 
 // ProcNameQualified 存储过程名标识符规则
 // RULE: CSP-L2-001
 func (v *ProcCreateVldr) ProcNameQualified(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	procName := v.cp.Name.Name
+	if err := Match(r, procName, procName, r.Values); err != nil {
+		c := &models.Clause{
+			Description: err.Error(),
+			Level:       r.Level,
+			Object:      procName,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // ProcNameLowerCaseRequired 存储过程名大小写规则
 // RULE: CSP-L2-002
 func (v *ProcCreateVldr) ProcNameLowerCaseRequired(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	procName := v.cp.Name.Name
+	if err := Match(r, procName, procName); err != nil {
+		c := &models.Clause{
+			Description: err.Error(),
+			Level:       r.Level,
+			Object:      procName,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // ProcNameMaxLength 存储过程名长度规则
 // RULE: CSP-L2-003
 func (v *ProcCreateVldr) ProcNameMaxLength(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	procName := v.cp.Name.Name
+	threshold, _ := strconv.Atoi(r.Values)
+	if len(procName) > threshold {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, procName, threshold),
+			Level:       r.Level,
+			Object:      procName,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // ProcNamePrefixRequired 存储过程名前缀规则
 // RULE: CSP-L2-004
 func (v *ProcCreateVldr) ProcNamePrefixRequired(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	procName := strings.TrimSpace(v.cp.Name.Name)
+	if len(procName) > 0 {
+		if err := Match(r, procName, r.Values); err != nil {
+			c := &models.Clause{
+				Description: fmt.Sprintf(r.Message, procName, r.Values),
+				Level:       r.Level,
+				Object:      procName,
+			}
+			s.Violations.Append(c)
+		}
+	}
 }
 
 // TargetDatabaseDoesNotExist 目标库是否存在
 // RULE: CSP-L3-001
 func (v *ProcCreateVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	v.targetDatabase(s, r, v.cp.Name)
 }
 
 // TargetProcDoesNotExist 目标存储过程是否存在
 // RULE: CSP-L3-002
 func (v *ProcCreateVldr) TargetProcDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	// 使用IF NOT EXISTS时，存储过程已存在不会报错
+	if v.cp.IfNotExists {
+		return
+	}
+	if v.RoutineInfo(v.cp.Name.Schema, v.cp.Name.Name, ProgramProcedure) != nil {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, v.cp.Name.Name),
+			Level:       r.Level,
+			Object:      v.cp.Name.Name,
+		}
+		s.Violations.Append(c)
+	}
+}
+
+// ProcBodyValidated 存储过程中的语句使用对应的规则组审核，例如其中的DELETE语句同样需要有WHERE条件
+// RULE: CSP-L3-003
+func (v *ProcCreateVldr) ProcBodyValidated(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	v.validateBody(s, r, v.cp.Name.Name, v.cp.Body)
 }
 
 // ProcAlterVldr 修改存储过程语句相关的审核规则
 type ProcAlterVldr struct {
 	vldr
+
+	ap *AlterProcedureStmt
 }
 
 // Call 利用反射方法动态调用审核函数
@@ -74,21 +165,51 @@ func (v *ProcAlterVldr) Enabled() bool {
 // Validate 规则组的审核入口
 func (v *ProcAlterVldr) Validate(wg *sync.WaitGroup) {
 	defer wg.Done()
+	for _, s := range v.Ctx.Stmts {
+		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
+		node := s.StmtNode
+		v.Seek(s)
+		if ap, ok := node.(*AlterProcedureStmt); !ok {
+			// 类型断言不成功
+			continue
+		} else {
+			v.ap = ap
+		}
+		for _, r := range v.Rules {
+			if r.Bitwise&1 != 1 {
+				continue
+			}
+			v.Call(r.Func, s, r)
+		}
+	}
 }
 
 // TargetDatabaseDoesNotExist 目标库是否存在
 // RULE: MSP-L3-001
 func (v *ProcAlterVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	v.targetDatabase(s, r, v.ap.Name)
 }
 
 // TargetProcDoesNotExist 目标存储过程是否存在
 // RULE: MSP-L3-002
 func (v *ProcAlterVldr) TargetProcDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	if v.RoutineInfo(v.ap.Name.Schema, v.ap.Name.Name, ProgramProcedure) == nil {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, v.ap.Name.Name),
+			Level:       r.Level,
+			Object:      v.ap.Name.Name,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // ProcDropVldr 删除存储过程语句相关的审核规则
 type ProcDropVldr struct {
 	vldr
+
+	dp *DropProcedureStmt
 }
 
 // Call 利用反射方法动态调用审核函数
@@ -104,14 +225,46 @@ func (v *ProcDropVldr) Enabled() bool {
 // Validate 规则组的审核入口
 func (v *ProcDropVldr) Validate(wg *sync.WaitGroup) {
 	defer wg.Done()
+	for _, s := range v.Ctx.Stmts {
+		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
+		node := s.StmtNode
+		v.Seek(s)
+		if dp, ok := node.(*DropProcedureStmt); !ok {
+			// 类型断言不成功
+			continue
+		} else {
+			v.dp = dp
+		}
+		for _, r := range v.Rules {
+			if r.Bitwise&1 != 1 {
+				continue
+			}
+			v.Call(r.Func, s, r)
+		}
+	}
 }
 
 // TargetDatabaseDoesNotExist 目标库是否存在
 // RULE: DSP-L3-001
 func (v *ProcDropVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	v.targetDatabase(s, r, v.dp.Name)
 }
 
 // TargetProcDoesNotExist 目标存储过程是否存在
 // RULE: DSP-L3-002
 func (v *ProcDropVldr) TargetProcDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	// 使用IF EXISTS时，存储过程不存在不会报错
+	if v.dp.IfExists {
+		return
+	}
+	if v.RoutineInfo(v.dp.Name.Schema, v.dp.Name.Name, ProgramProcedure) == nil {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, v.dp.Name.Name),
+			Level:       r.Level,
+			Object:      v.dp.Name.Name,
+		}
+		s.Violations.Append(c)
+	}
 }
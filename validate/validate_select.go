@@ -33,11 +33,13 @@ func (v *SelectVldr) Validate(wg *sync.WaitGroup) {
 	for _, s := range v.Ctx.Stmts {
 		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
 		node := s.StmtNode
+		v.Seek(s)
 		if sd, ok := node.(*ast.SelectStmt); !ok {
 			// 类型断言不成功
 			continue
 		} else {
 			v.sd = sd
+			v.Walk(v.sd)
 		}
 		for _, r := range v.Rules {
 			if r.Bitwise&1 != 1 {
@@ -112,6 +114,7 @@ func (v *SelectVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.R
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, ti.Database),
 				Level:       r.Level,
+				Object:      ti.Database,
 			}
 			s.Violations.Append(c)
 		}
@@ -130,40 +133,33 @@ func (v *SelectVldr) TargetTableDoesNotExist(s *models.Statement, r *models.Rule
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, fmt.Sprintf("`%s`.`%s`", ti.Database, ti.Table.Name)),
 				Level:       r.Level,
+				Object:      ti.Table.Name,
 			}
 			s.Violations.Append(c)
 		}
 	}
 }
 
-// TargetColumnDoesNotDoesNotExist 目标列必须已存在，从抽象语法数解出来所有的关联字段信息，包括FieldList/Where/OrderBy/GroupBy
+// TargetColumnDoesNotDoesNotExist 目标列必须已存在，包括FieldList/Where/OrderBy/GroupBy以及子查询中引用的全部列
 // RULE: SEL-L3-003
 func (v *SelectVldr) TargetColumnDoesNotDoesNotExist(s *models.Statement, r *models.Rule) {
 	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
-	for _, field := range v.sd.Fields.Fields {
-		if field.WildCard != nil {
-			// 如果是SELECT *或者SELECT alias.*则不处理
-			continue
-		}
-		// TODO:
-		fmt.Printf("Expr: %T, %+v\n", field.Expr, field.Expr)
-	}
+	v.columnExists(s, r)
 }
 
 // ReturnBlobOrTextNotAllowed 是否允许返回BLOB/TEXT列
 // RULE: SEL-L3-004
 func (v *SelectVldr) ReturnBlobOrTextNotAllowed(s *models.Statement, r *models.Rule) {
 	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
-	fields := []*ast.SelectField{}
-	for _, field := range v.sd.Fields.Fields {
-		if field.WildCard != nil {
-			// 如果是SELECT *或者SELECT alias.*则需要展开对应表的所有字段
-			// TODO:
+	// SELECT *或者SELECT alias.*已经展开为对应表的所有字段
+	for _, col := range v.Fields {
+		if col.SQLType.IsBlob() || col.SQLType.IsText() {
+			c := &models.Clause{
+				Description: fmt.Sprintf(r.Message, col.Name),
+				Level:       r.Level,
+				Object:      col.Name,
+			}
+			s.Violations.Append(c)
 		}
-		fields = append(fields, field)
-	}
-	for _, field := range fields {
-		// TODO:
-		fmt.Printf("Expr: %T, %+v\n", field.Expr, field.Expr)
 	}
 }
@@ -39,6 +39,7 @@ func (v *TableCreateVldr) Validate(wg *sync.WaitGroup) {
 	for _, s := range v.Ctx.Stmts {
 		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
 		node := s.StmtNode
+		v.Seek(s)
 		if ct, ok := node.(*ast.CreateTableStmt); !ok {
 			// 类型断言不成功
 			continue
@@ -88,6 +89,9 @@ func (v *TableCreateVldr) AvailableCharsets(s *models.Statement, r *models.Rule)
 			Description: fmt.Sprintf(r.Message, useCharset, charsets),
 			Level:       r.Level,
 		}
+		if len(charsets) > 0 {
+			c.Fix = fmt.Sprintf("DEFAULT CHARACTER SET %s", charsets[0])
+		}
 		s.Violations.Append(c)
 	}
 }
@@ -125,6 +129,9 @@ func (v *TableCreateVldr) AvailableCollates(s *models.Statement, r *models.Rule)
 			Description: fmt.Sprintf(r.Message, useCollate, collates),
 			Level:       r.Level,
 		}
+		if len(collates) > 0 {
+			c.Fix = fmt.Sprintf("DEFAULT COLLATE %s", collates[0])
+		}
 		s.Violations.Append(c)
 	}
 }
@@ -194,6 +201,9 @@ func (v *TableCreateVldr) AvailableEngines(s *models.Statement, r *models.Rule)
 			Description: fmt.Sprintf(r.Message, useEngine, engines),
 			Level:       r.Level,
 		}
+		if len(engines) > 0 {
+			c.Fix = fmt.Sprintf("ENGINE = %s", engines[0])
+		}
 		s.Violations.Append(c)
 	}
 }
@@ -207,6 +217,7 @@ func (v *TableCreateVldr) TableNameQualified(s *models.Statement, r *models.Rule
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, tableName, r.Values),
 			Level:       r.Level,
+			Object:      tableName,
 		}
 		s.Violations.Append(c)
 	}
@@ -221,6 +232,7 @@ func (v *TableCreateVldr) TableNameLowerCaseRequired(s *models.Statement, r *mod
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, tableName),
 			Level:       r.Level,
+			Object:      tableName,
 		}
 		s.Violations.Append(c)
 	}
@@ -239,6 +251,7 @@ func (v *TableCreateVldr) TableNameMaxLength(s *models.Statement, r *models.Rule
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, tableName, threshold),
 			Level:       r.Level,
+			Object:      tableName,
 		}
 		s.Violations.Append(c)
 	}
@@ -268,6 +281,7 @@ func (v *TableCreateVldr) TableCommentRequired(s *models.Statement, r *models.Ru
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, tableName),
 			Level:       r.Level,
+			Object:      tableName,
 		}
 		s.Violations.Append(c)
 	}
@@ -303,6 +317,7 @@ func (v *TableCreateVldr) ColumnNameQualified(s *models.Statement, r *models.Rul
 			c := &models.Clause{
 				Description: err.Error(),
 				Level:       r.Level,
+				Object:      colName,
 			}
 			s.Violations.Append(c)
 		}
@@ -322,6 +337,7 @@ func (v *TableCreateVldr) ColumnNameLowerCaseRequired(s *models.Statement, r *mo
 			c := &models.Clause{
 				Description: err.Error(),
 				Level:       r.Level,
+				Object:      colName,
 			}
 			s.Violations.Append(c)
 		}
@@ -342,6 +358,7 @@ func (v *TableCreateVldr) ColumnNameMaxLength(s *models.Statement, r *models.Rul
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, colName, threshold),
 				Level:       r.Level,
+				Object:      colName,
 			}
 			s.Violations.Append(c)
 		}
@@ -361,6 +378,7 @@ func (v *TableCreateVldr) ColumnNameDuplicate(s *models.Statement, r *models.Rul
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, tableName, colName),
 				Level:       r.Level,
+				Object:      tableName,
 			}
 			s.Violations.Append(c)
 		} else {
@@ -384,6 +402,7 @@ func (v *TableCreateVldr) MaxAllowedColumnCount(s *models.Statement, r *models.R
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, tableName, len(v.ct.Cols), threshold),
 			Level:       r.Level,
+			Object:      tableName,
 		}
 		s.Violations.Append(c)
 	}
@@ -407,6 +426,7 @@ func (v *TableCreateVldr) ColumnTypesDoesNotExpect(s *models.Statement, r *model
 				c := &models.Clause{
 					Description: fmt.Sprintf(r.Message, col.Name, colType, availableTypes),
 					Level:       r.Level,
+					Object:      col.Name.Name.O,
 				}
 				s.Violations.Append(c)
 			}
@@ -435,6 +455,7 @@ func (v *TableCreateVldr) ColumnCommentRequired(s *models.Statement, r *models.R
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, col.Name.Name.O),
 				Level:       r.Level,
+				Object:      col.Name.Name.O,
 			}
 			s.Violations.Append(c)
 		}
@@ -467,6 +488,7 @@ func (v *TableCreateVldr) ColumnAvailableCharsets(s *models.Statement, r *models
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, col.Name, colCharset, charsets),
 				Level:       r.Level,
+				Object:      col.Name.Name.O,
 			}
 			s.Violations.Append(c)
 		}
@@ -498,6 +520,7 @@ func (v *TableCreateVldr) ColumnAvailableCollates(s *models.Statement, r *models
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, col.Name, colCollate, collates),
 				Level:       r.Level,
+				Object:      col.Name.Name.O,
 			}
 			s.Violations.Append(c)
 		}
@@ -522,6 +545,7 @@ func (v *TableCreateVldr) ColumnCharsetCollateMustMatch(s *models.Statement, r *
 				c := &models.Clause{
 					Description: fmt.Sprintf(r.Message, col.Name, colCharset, colCollate),
 					Level:       r.Level,
+					Object:      col.Name.Name.O,
 				}
 				s.Violations.Append(c)
 			}
@@ -564,6 +588,7 @@ func (v *TableCreateVldr) ColumnNotNullWithDefaultRequired(s *models.Statement,
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, name),
 				Level:       r.Level,
+				Object:      name,
 			}
 			s.Violations.Append(c)
 		}
@@ -595,6 +620,7 @@ func (v *TableCreateVldr) ColumnAutoIncAvailableTypes(s *models.Statement, r *mo
 					c := &models.Clause{
 						Description: fmt.Sprintf(r.Message, col.Name, colType, availableTypes),
 						Level:       r.Level,
+						Object:      col.Name.Name.O,
 					}
 					s.Violations.Append(c)
 				}
@@ -620,6 +646,7 @@ func (v *TableCreateVldr) ColumnAutoIncUnsignedRequired(s *models.Statement, r *
 					c := &models.Clause{
 						Description: fmt.Sprintf(r.Message, col.Name),
 						Level:       r.Level,
+						Object:      col.Name.Name.O,
 					}
 					s.Violations.Append(c)
 				}
@@ -673,6 +700,7 @@ func (v *TableCreateVldr) ColumnAutoIncMustPrimaryKey(s *models.Statement, r *mo
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, colName),
 				Level:       r.Level,
+				Object:      colName,
 			}
 			s.Violations.Append(c)
 		}
@@ -696,6 +724,7 @@ func (v *TableCreateVldr) MaxAllowedTimestampCount(s *models.Statement, r *model
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, tableName),
 			Level:       r.Level,
+			Object:      tableName,
 		}
 		s.Violations.Append(c)
 	}
@@ -713,6 +742,7 @@ func (v *TableCreateVldr) MaxAllowedIndexColumnCount(s *models.Statement, r *mod
 				c := &models.Clause{
 					Description: fmt.Sprintf(r.Message, c.Name, threshold),
 					Level:       r.Level,
+					Object:      c.Name,
 				}
 				s.Violations.Append(c)
 			}
@@ -770,6 +800,7 @@ func (v *TableCreateVldr) PrimaryKeyNameExplicit(s *models.Statement, r *models.
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, tableName),
 			Level:       r.Level,
+			Object:      tableName,
 		}
 		s.Violations.Append(c)
 	}
@@ -792,6 +823,7 @@ func (v *TableCreateVldr) PrimaryKeyNameQualified(s *models.Statement, r *models
 				c := &models.Clause{
 					Description: err.Error(),
 					Level:       r.Level,
+					Object:      keyName,
 				}
 				s.Violations.Append(c)
 				// TODO:
@@ -812,6 +844,7 @@ func (v *TableCreateVldr) PrimryKeyLowerCaseRequired(s *models.Statement, r *mod
 					c := &models.Clause{
 						Description: err.Error(),
 						Level:       r.Level,
+						Object:      c.Name,
 					}
 					s.Violations.Append(c)
 				}
@@ -837,6 +870,7 @@ func (v *TableCreateVldr) PrimryKeyMaxLength(s *models.Statement, r *models.Rule
 				c := &models.Clause{
 					Description: fmt.Sprintf(r.Message, keyName, threshold),
 					Level:       r.Level,
+					Object:      keyName,
 				}
 				s.Violations.Append(c)
 			}
@@ -859,6 +893,7 @@ func (v *TableCreateVldr) PrimryKeyPrefixRequired(s *models.Statement, r *models
 					c := &models.Clause{
 						Description: fmt.Sprintf(r.Message, c.Name, r.Values),
 						Level:       r.Level,
+						Object:      c.Name,
 					}
 					s.Violations.Append(c)
 				}
@@ -900,6 +935,7 @@ func (v *TableCreateVldr) IndexNameQualified(s *models.Statement, r *models.Rule
 				c := &models.Clause{
 					Description: err.Error(),
 					Level:       r.Level,
+					Object:      keyName,
 				}
 				s.Violations.Append(c)
 			}
@@ -921,6 +957,7 @@ func (v *TableCreateVldr) IndexNameLowerCaseRequired(s *models.Statement, r *mod
 				c := &models.Clause{
 					Description: err.Error(),
 					Level:       r.Level,
+					Object:      keyName,
 				}
 				s.Violations.Append(c)
 			}
@@ -943,6 +980,7 @@ func (v *TableCreateVldr) IndexNameMaxLength(s *models.Statement, r *models.Rule
 				c := &models.Clause{
 					Description: fmt.Sprintf(r.Message, keyName, threshold),
 					Level:       r.Level,
+					Object:      keyName,
 				}
 				s.Violations.Append(c)
 			}
@@ -964,6 +1002,7 @@ func (v *TableCreateVldr) IndexNamePrefixRequired(s *models.Statement, r *models
 				c := &models.Clause{
 					Description: err.Error(),
 					Level:       r.Level,
+					Object:      keyName,
 				}
 				s.Violations.Append(c)
 			}
@@ -1004,6 +1043,7 @@ func (v *TableCreateVldr) UniqueNameQualified(s *models.Statement, r *models.Rul
 				c := &models.Clause{
 					Description: err.Error(),
 					Level:       r.Level,
+					Object:      keyName,
 				}
 				s.Violations.Append(c)
 			}
@@ -1025,6 +1065,7 @@ func (v *TableCreateVldr) UniqueNameLowerCaseRequired(s *models.Statement, r *mo
 				c := &models.Clause{
 					Description: err.Error(),
 					Level:       r.Level,
+					Object:      keyName,
 				}
 				s.Violations.Append(c)
 			}
@@ -1047,6 +1088,7 @@ func (v *TableCreateVldr) UniqueNameMaxLength(s *models.Statement, r *models.Rul
 				c := &models.Clause{
 					Description: fmt.Sprintf(r.Message, keyName, threshold),
 					Level:       r.Level,
+					Object:      keyName,
 				}
 				s.Violations.Append(c)
 			}
@@ -1068,6 +1110,7 @@ func (v *TableCreateVldr) UniqueNamePrefixRequired(s *models.Statement, r *model
 				c := &models.Clause{
 					Description: err.Error(),
 					Level:       r.Level,
+					Object:      keyName,
 				}
 				s.Violations.Append(c)
 			}
@@ -1139,6 +1182,7 @@ func (v *TableCreateVldr) ForeignKeyNameQualified(s *models.Statement, r *models
 				c := &models.Clause{
 					Description: err.Error(),
 					Level:       r.Level,
+					Object:      keyName,
 				}
 				s.Violations.Append(c)
 			}
@@ -1160,6 +1204,7 @@ func (v *TableCreateVldr) ForeignKeyNameLowerCaseRequired(s *models.Statement, r
 				c := &models.Clause{
 					Description: err.Error(),
 					Level:       r.Level,
+					Object:      keyName,
 				}
 				s.Violations.Append(c)
 			}
@@ -1182,6 +1227,7 @@ func (v *TableCreateVldr) ForeignKeyNameMaxLength(s *models.Statement, r *models
 				c := &models.Clause{
 					Description: fmt.Sprintf(r.Message, keyName, threshold),
 					Level:       r.Level,
+					Object:      keyName,
 				}
 				s.Violations.Append(c)
 			}
@@ -1203,6 +1249,7 @@ func (v *TableCreateVldr) ForeignKeyNamePrefixRequired(s *models.Statement, r *m
 				c := &models.Clause{
 					Description: err.Error(),
 					Level:       r.Level,
+					Object:      keyName,
 				}
 				s.Violations.Append(c)
 			}
@@ -1250,6 +1297,7 @@ func (v *TableCreateVldr) MaxAllowedIndexCount(s *models.Statement, r *models.Ru
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, tableName, count, threshold),
 			Level:       r.Level,
+			Object:      tableName,
 		}
 		s.Violations.Append(c)
 	}
@@ -1286,6 +1334,7 @@ func (v *TableCreateVldr) AutoIncColumnDuplicate(s *models.Statement, r *models.
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, v.ct.Table.Name.O),
 			Level:       r.Level,
+			Object:      v.ct.Table.Name.O,
 		}
 		s.Violations.Append(c)
 	}
@@ -1313,6 +1362,7 @@ func (v *TableCreateVldr) PrimaryKeyDuplicate(s *models.Statement, r *models.Rul
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, v.ct.Table.Name.O),
 			Level:       r.Level,
+			Object:      v.ct.Table.Name.O,
 		}
 		s.Violations.Append(c)
 	}
@@ -1330,6 +1380,7 @@ func (v *TableCreateVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *mod
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, database),
 			Level:       r.Level,
+			Object:      database,
 		}
 		s.Violations.Append(c)
 	}
@@ -1347,6 +1398,7 @@ func (v *TableCreateVldr) TargetTableDoesNotExist(s *models.Statement, r *models
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, fmt.Sprintf("`%s`.`%s`", database, v.ct.Table.Name.O)),
 			Level:       r.Level,
+			Object:      v.ct.Table.Name.O,
 		}
 		s.Violations.Append(c)
 	}
@@ -1375,6 +1427,7 @@ func (v *TableAlterVldr) Validate(wg *sync.WaitGroup) {
 	for _, s := range v.Ctx.Stmts {
 		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
 		node := s.StmtNode
+		v.Seek(s)
 		if at, ok := node.(*ast.AlterTableStmt); !ok {
 			// 类型断言不成功
 			continue
@@ -1426,6 +1479,9 @@ func (v *TableAlterVldr) AvailableCharsets(s *models.Statement, r *models.Rule)
 			Description: fmt.Sprintf(r.Message, useCharset, charsets),
 			Level:       r.Level,
 		}
+		if len(charsets) > 0 {
+			c.Fix = fmt.Sprintf("DEFAULT CHARACTER SET %s", charsets[0])
+		}
 		s.Violations.Append(c)
 	}
 }
@@ -1466,6 +1522,9 @@ func (v *TableAlterVldr) AvailableCollates(s *models.Statement, r *models.Rule)
 			Description: fmt.Sprintf(r.Message, useCollate, collates),
 			Level:       r.Level,
 		}
+		if len(collates) > 0 {
+			c.Fix = fmt.Sprintf("DEFAULT COLLATE %s", collates[0])
+		}
 		s.Violations.Append(c)
 	}
 }
@@ -1554,6 +1613,9 @@ func (v *TableAlterVldr) AvailableEngines(s *models.Statement, r *models.Rule) {
 			Description: fmt.Sprintf(r.Message, useEngine, engines),
 			Level:       r.Level,
 		}
+		if len(engines) > 0 {
+			c.Fix = fmt.Sprintf("ENGINE = %s", engines[0])
+		}
 		s.Violations.Append(c)
 	}
 }
@@ -1609,6 +1671,7 @@ func (v *TableAlterVldr) ColumnNameQualified(s *models.Statement, r *models.Rule
 				c := &models.Clause{
 					Description: err.Error(),
 					Level:       r.Level,
+					Object:      colName,
 				}
 				s.Violations.Append(c)
 			}
@@ -1640,6 +1703,7 @@ func (v *TableAlterVldr) ColumnNameLowerCaseRequired(s *models.Statement, r *mod
 				c := &models.Clause{
 					Description: err.Error(),
 					Level:       r.Level,
+					Object:      colName,
 				}
 				s.Violations.Append(c)
 			}
@@ -1672,6 +1736,7 @@ func (v *TableAlterVldr) ColumnNameMaxLength(s *models.Statement, r *models.Rule
 				c := &models.Clause{
 					Description: fmt.Sprintf(r.Message, colName, threshold),
 					Level:       r.Level,
+					Object:      colName,
 				}
 				s.Violations.Append(c)
 			}
@@ -1706,6 +1771,7 @@ func (v *TableAlterVldr) ColumnUnwantedTypes(s *models.Statement, r *models.Rule
 					c := &models.Clause{
 						Description: fmt.Sprintf(r.Message, col.Name, colType, availableTypes),
 						Level:       r.Level,
+						Object:      col.Name.Name.O,
 					}
 					s.Violations.Append(c)
 				}
@@ -1745,6 +1811,7 @@ func (v *TableAlterVldr) ColumnCommentRequired(s *models.Statement, r *models.Ru
 				c := &models.Clause{
 					Description: fmt.Sprintf(r.Message, col.Name.Name.O),
 					Level:       r.Level,
+					Object:      col.Name.Name.O,
 				}
 				s.Violations.Append(c)
 			}
@@ -1787,6 +1854,7 @@ func (v *TableAlterVldr) ColumnAvailableCharsets(s *models.Statement, r *models.
 				c := &models.Clause{
 					Description: fmt.Sprintf(r.Message, col.Name, colCharset, charsets),
 					Level:       r.Level,
+					Object:      col.Name.Name.O,
 				}
 				s.Violations.Append(c)
 			}
@@ -1829,6 +1897,7 @@ func (v *TableAlterVldr) ColumnAvailableCollates(s *models.Statement, r *models.
 				c := &models.Clause{
 					Description: fmt.Sprintf(r.Message, col.Name, colCollate, collates),
 					Level:       r.Level,
+					Object:      col.Name.Name.O,
 				}
 				s.Violations.Append(c)
 			}
@@ -1865,6 +1934,7 @@ func (v *TableAlterVldr) ColumnCharsetCollateMustMatch(s *models.Statement, r *m
 					c := &models.Clause{
 						Description: fmt.Sprintf(r.Message, col.Name, colCharset, colCollate),
 						Level:       r.Level,
+						Object:      col.Name.Name.O,
 					}
 					s.Violations.Append(c)
 				}
@@ -1938,6 +2008,7 @@ func (v *TableAlterVldr) ColumnNotNullWithDefaultRequired(s *models.Statement, r
 				c := &models.Clause{
 					Description: fmt.Sprintf(r.Message, col.Name),
 					Level:       r.Level,
+					Object:      col.Name.Name.O,
 				}
 				s.Violations.Append(c)
 			}
@@ -2012,6 +2083,7 @@ func (v *TableAlterVldr) IndexNameQualified(s *models.Statement, r *models.Rule)
 			c := &models.Clause{
 				Description: err.Error(),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2050,6 +2122,7 @@ func (v *TableAlterVldr) IndexNameLowerCaseRequired(s *models.Statement, r *mode
 			c := &models.Clause{
 				Description: err.Error(),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2093,6 +2166,7 @@ func (v *TableAlterVldr) IndexNameMaxLength(s *models.Statement, r *models.Rule)
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, keyName, threshold),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2130,6 +2204,7 @@ func (v *TableAlterVldr) IndexNamePrefixRequired(s *models.Statement, r *models.
 			c := &models.Clause{
 				Description: err.Error(),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2191,6 +2266,7 @@ func (v *TableAlterVldr) UniqueNameQualified(s *models.Statement, r *models.Rule
 			c := &models.Clause{
 				Description: err.Error(),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2221,6 +2297,7 @@ func (v *TableAlterVldr) UniqueNameLowerCaseRequired(s *models.Statement, r *mod
 			c := &models.Clause{
 				Description: err.Error(),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2253,6 +2330,7 @@ func (v *TableAlterVldr) UniqueNameMaxLength(s *models.Statement, r *models.Rule
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, keyName, threshold),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2283,6 +2361,7 @@ func (v *TableAlterVldr) UniqueNamePrefixRequired(s *models.Statement, r *models
 			c := &models.Clause{
 				Description: err.Error(),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2366,6 +2445,7 @@ func (v *TableAlterVldr) ForeignKeyNameQualified(s *models.Statement, r *models.
 			c := &models.Clause{
 				Description: err.Error(),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2396,6 +2476,7 @@ func (v *TableAlterVldr) ForeignKeyNameLowerCaseRequired(s *models.Statement, r
 			c := &models.Clause{
 				Description: err.Error(),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2428,6 +2509,7 @@ func (v *TableAlterVldr) ForeignKeyNameMaxLength(s *models.Statement, r *models.
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, keyName, threshold),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2458,6 +2540,7 @@ func (v *TableAlterVldr) ForeignKeyNamePrefixRequired(s *models.Statement, r *mo
 			c := &models.Clause{
 				Description: err.Error(),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2480,6 +2563,7 @@ func (v *TableAlterVldr) NewTableNameQualified(s *models.Statement, r *models.Ru
 			c := &models.Clause{
 				Description: err.Error(),
 				Level:       r.Level,
+				Object:      newName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2502,6 +2586,7 @@ func (v *TableAlterVldr) NewTableNameLowerCaseRequired(s *models.Statement, r *m
 			c := &models.Clause{
 				Description: err.Error(),
 				Level:       r.Level,
+				Object:      newName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2525,6 +2610,7 @@ func (v *TableAlterVldr) NewTableNameMaxLength(s *models.Statement, r *models.Ru
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, newName, threshold),
 				Level:       r.Level,
+				Object:      newName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2547,6 +2633,7 @@ func (v *TableAlterVldr) FullTextIndexNotAllowed(s *models.Statement, r *models.
 			c := &models.Clause{
 				Description: err.Error(),
 				Level:       r.Level,
+				Object:      c.Name,
 			}
 			s.Violations.Append(c)
 		}
@@ -2597,6 +2684,7 @@ func (v *TableAlterVldr) FullTextIndexNameQualified(s *models.Statement, r *mode
 			c := &models.Clause{
 				Description: err.Error(),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2623,6 +2711,7 @@ func (v *TableAlterVldr) FullTextIndexNameLowerCaseRequired(s *models.Statement,
 			c := &models.Clause{
 				Description: err.Error(),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2650,6 +2739,7 @@ func (v *TableAlterVldr) FullTextIndexNameMaxLength(s *models.Statement, r *mode
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, keyName, threshold),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2676,12 +2766,64 @@ func (v *TableAlterVldr) FullTextIndexNamePrefixRequired(s *models.Statement, r
 			c := &models.Clause{
 				Description: err.Error(),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
 	}
 }
 
+// FullTextIndexNameDuplicate 添加全文索引时名称不可重复
+// RULE: MTB-L3-022
+func (v *TableAlterVldr) FullTextIndexNameDuplicate(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	for _, spec := range v.at.Specs {
+		if spec.Tp != ast.AlterTableAddConstraint {
+			continue
+		}
+		constraint := spec.Constraint
+		if constraint.Tp != ast.ConstraintFulltext {
+			continue
+		}
+		keyName := strings.TrimSpace(constraint.Name)
+		if keyName == "" {
+			continue
+		}
+		if v.IndexInfo(v.at.Table.Schema.O, v.at.Table.Name.O, keyName) == nil {
+			continue
+		}
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, v.at.Table.Name.O, keyName),
+			Level:       r.Level,
+			Object:      v.at.Table.Name.O,
+		}
+		s.Violations.Append(c)
+	}
+}
+
+// FullTextIndexNameDoesNotExist 删除全文索引时索引必须存在
+// RULE: MTB-L3-023
+func (v *TableAlterVldr) FullTextIndexNameDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	if v.TableInfo(v.at.Table.Schema.O, v.at.Table.Name.O) == nil {
+		return
+	}
+	for _, spec := range v.at.Specs {
+		if spec.Tp != ast.AlterTableDropIndex {
+			continue
+		}
+		if v.IndexInfo(v.at.Table.Schema.O, v.at.Table.Name.O, spec.Name) != nil {
+			continue
+		}
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, v.at.Table.Name.O, spec.Name),
+			Level:       r.Level,
+			Object:      v.at.Table.Name.O,
+		}
+		s.Violations.Append(c)
+	}
+}
+
 // MaxAllowedIndexColumnCount 单一索引最大列数
 // RULE: MTB-L2-039
 func (v *TableAlterVldr) MaxAllowedIndexColumnCount(s *models.Statement, r *models.Rule) {
@@ -2703,12 +2845,58 @@ func (v *TableAlterVldr) MaxAllowedIndexColumnCount(s *models.Statement, r *mode
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, keyName, threshold),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
 	}
 }
 
+// CopyAlterMaxRows 需要复制表的ALTER TABLE，目标表的最大行数
+// RULE: MTB-L2-040
+func (v *TableAlterVldr) CopyAlterMaxRows(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	threshold, err := strconv.ParseInt(r.Values, 10, 64)
+	if err != nil {
+		return
+	}
+	p, ok := v.Ctx.Predictions[s]
+	if !ok || p.Algorithm != AlgorithmCopy || p.Stat == nil {
+		return
+	}
+	if p.Stat.Rows > threshold {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, p.Table, p.Stat.Rows, threshold),
+			Level:       r.Level,
+			Object:      p.Table,
+		}
+		s.Violations.Append(c)
+	}
+}
+
+// CopyAlterMaxSize 需要复制表的ALTER TABLE，目标表的最大尺寸，单位是MB
+// RULE: MTB-L2-041
+func (v *TableAlterVldr) CopyAlterMaxSize(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	threshold, err := strconv.ParseInt(r.Values, 10, 64)
+	if err != nil {
+		return
+	}
+	p, ok := v.Ctx.Predictions[s]
+	if !ok || p.Algorithm != AlgorithmCopy || p.Stat == nil {
+		return
+	}
+	size := (p.Stat.DataLength + p.Stat.IndexLength) >> 20
+	if size > threshold {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, p.Table, size, threshold),
+			Level:       r.Level,
+			Object:      p.Table,
+		}
+		s.Violations.Append(c)
+	}
+}
+
 // TargetDatabaseDoesNotExist 单一索引最大列数
 // RULE: MTB-L2-039
 func (v *TableAlterVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.Rule) {
@@ -2718,6 +2906,7 @@ func (v *TableAlterVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *mode
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, ti.Database),
 				Level:       r.Level,
+				Object:      ti.Database,
 			}
 			s.Violations.Append(c)
 		}
@@ -2736,6 +2925,7 @@ func (v *TableAlterVldr) TargetTableDoesNotExist(s *models.Statement, r *models.
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, fmt.Sprintf("`%s`.`%s`", ti.Database, ti.Table.Name)),
 				Level:       r.Level,
+				Object:      ti.Table.Name,
 			}
 			s.Violations.Append(c)
 		}
@@ -2762,6 +2952,7 @@ func (v *TableAlterVldr) ColumnNameDuplicate(s *models.Statement, r *models.Rule
 				c := &models.Clause{
 					Description: fmt.Sprintf(r.Message, v.at.Table.Name.O, col.Name.Name.O),
 					Level:       r.Level,
+					Object:      v.at.Table.Name.O,
 				}
 				s.Violations.Append(c)
 			}
@@ -2798,6 +2989,7 @@ func (v *TableAlterVldr) MaxAllowedColumnCount(s *models.Statement, r *models.Ru
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, v.at.Table.Name.O, total, threshold),
 			Level:       r.Level,
+			Object:      v.at.Table.Name.O,
 		}
 		s.Violations.Append(c)
 	}
@@ -2825,6 +3017,7 @@ func (v *TableAlterVldr) ColumnNameDoesNotExist(s *models.Statement, r *models.R
 				c := &models.Clause{
 					Description: fmt.Sprintf(r.Message, spec.OldColumnName.Name.O),
 					Level:       r.Level,
+					Object:      spec.OldColumnName.Name.O,
 				}
 				s.Violations.Append(c)
 			}
@@ -2836,6 +3029,7 @@ func (v *TableAlterVldr) ColumnNameDoesNotExist(s *models.Statement, r *models.R
 				c := &models.Clause{
 					Description: fmt.Sprintf(r.Message, col.Name.Name.O),
 					Level:       r.Level,
+					Object:      col.Name.Name.O,
 				}
 				s.Violations.Append(c)
 			}
@@ -2863,6 +3057,7 @@ func (v *TableAlterVldr) IndexNameDuplicate(s *models.Statement, r *models.Rule)
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, keyName, v.at.Table.Name.O),
 			Level:       r.Level,
+			Object:      keyName,
 		}
 		s.Violations.Append(c)
 	}
@@ -2896,6 +3091,7 @@ func (v *TableAlterVldr) IndexCountLimit(s *models.Statement, r *models.Rule) {
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, v.at.Table.Name.O, total, threshold),
 			Level:       r.Level,
+			Object:      v.at.Table.Name.O,
 		}
 		s.Violations.Append(c)
 	}
@@ -2923,6 +3119,7 @@ func (v *TableAlterVldr) IndexDoesNotExist(s *models.Statement, r *models.Rule)
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, keyName),
 				Level:       r.Level,
+				Object:      keyName,
 			}
 			s.Violations.Append(c)
 		}
@@ -2948,6 +3145,7 @@ func (v *TableAlterVldr) IndexColumnDoesNotExist(s *models.Statement, r *models.
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, col.Column.Name.O),
 				Level:       r.Level,
+				Object:      col.Column.Name.O,
 			}
 			s.Violations.Append(c)
 		}
@@ -2971,6 +3169,7 @@ func (v *TableAlterVldr) TargetTableDuplicate(s *models.Statement, r *models.Rul
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, fmt.Sprintf("`%s`.`%s`", database, spec.NewTable.Name.O)),
 				Level:       r.Level,
+				Object:      spec.NewTable.Name.O,
 			}
 			s.Violations.Append(c)
 		}
@@ -3024,6 +3223,7 @@ func (v *TableAlterVldr) IndexOnBlobColumnNotAllowed(s *models.Statement, r *mod
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, ci.Name),
 				Level:       r.Level,
+				Object:      ci.Name,
 			}
 			s.Violations.Append(c)
 		}
@@ -3065,6 +3265,7 @@ func (v *TableRenameVldr) Validate(wg *sync.WaitGroup) {
 	for _, s := range v.Ctx.Stmts {
 		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
 		node := s.StmtNode
+		v.Seek(s)
 		if rt, ok := node.(*ast.RenameTableStmt); !ok {
 			// 类型断言不成功
 			continue
@@ -3081,9 +3282,9 @@ func (v *TableRenameVldr) Validate(wg *sync.WaitGroup) {
 	}
 }
 
-// TableRenameTablesIdentical 目标表跟源表是同一个表
+// TablesIdentical 目标表跟源表是同一个表
 // RULE: RTB-L2-001
-func (v *TableRenameVldr) TableRenameTablesIdentical(s *models.Statement, r *models.Rule) {
+func (v *TableRenameVldr) TablesIdentical(s *models.Statement, r *models.Rule) {
 	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
 	tableName := v.rt.OldTable.Schema.O + v.rt.OldTable.Name.O
 	newName := v.rt.NewTable.Schema.O + v.rt.NewTable.Name.O
@@ -3091,43 +3292,46 @@ func (v *TableRenameVldr) TableRenameTablesIdentical(s *models.Statement, r *mod
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, tableName, newName),
 			Level:       r.Level,
+			Object:      tableName,
 		}
 		s.Violations.Append(c)
 	}
 }
 
-// TableRenameTargetTableNameQualified 目标表名标识符规则
+// TargetTableNameQualified 目标表名标识符规则
 // RULE: RTB-L2-002
-func (v *TableRenameVldr) TableRenameTargetTableNameQualified(s *models.Statement, r *models.Rule) {
+func (v *TableRenameVldr) TargetTableNameQualified(s *models.Statement, r *models.Rule) {
 	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
 	newName := v.rt.NewTable.Name.O
 	if err := Match(r, newName, newName, r.Values); err != nil {
 		c := &models.Clause{
 			Description: err.Error(),
 			Level:       r.Level,
+			Object:      newName,
 		}
 		s.Violations.Append(c)
 	}
 
 }
 
-// TableRenameTargetTableNameLowerCaseRequired 目标表名大小写规则
+// TargetTableNameLowerCaseRequired 目标表名大小写规则
 // RULE: RTB-L2-003
-func (v *TableRenameVldr) TableRenameTargetTableNameLowerCaseRequired(s *models.Statement, r *models.Rule) {
+func (v *TableRenameVldr) TargetTableNameLowerCaseRequired(s *models.Statement, r *models.Rule) {
 	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
 	newName := v.rt.NewTable.Name.O
 	if err := Match(r, newName, newName); err != nil {
 		c := &models.Clause{
 			Description: err.Error(),
 			Level:       r.Level,
+			Object:      newName,
 		}
 		s.Violations.Append(c)
 	}
 }
 
-// TableRenameTargetTableNameMaxLength 目标表名长度规则
+// TargetTableNameMaxLength 目标表名长度规则
 // RULE: RTB-L2-004
-func (v *TableRenameVldr) TableRenameTargetTableNameMaxLength(s *models.Statement, r *models.Rule) {
+func (v *TableRenameVldr) TargetTableNameMaxLength(s *models.Statement, r *models.Rule) {
 	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
 	threshold, err := strconv.Atoi(r.Values)
 	if err != nil {
@@ -3139,28 +3343,68 @@ func (v *TableRenameVldr) TableRenameTargetTableNameMaxLength(s *models.Statemen
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, tableName, threshold),
 			Level:       r.Level,
+			Object:      tableName,
 		}
 		s.Violations.Append(c)
 	}
 
 }
 
-// TableRenameSourceTableDoesNotExist 源库是否存在
+// SourceDatabaseDoesNotExist 源库是否存在
 // RULE: RTB-L3-001
-func (v *TableRenameVldr) TableRenameSourceTableDoesNotExist(s *models.Statement, r *models.Rule) {
+func (v *TableRenameVldr) SourceDatabaseDoesNotExist(s *models.Statement, r *models.Rule) {
 	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	v.renameDatabase(s, r, v.rt.OldTable)
 }
 
-// TableRenameSourceDatabaseDoesNotExist 源表是否存在
+// SourceTableDoesNotExist 源表是否存在
 // RULE: RTB-L3-002
-func (v *TableRenameVldr) TableRenameSourceDatabaseDoesNotExist(s *models.Statement, r *models.Rule) {
+func (v *TableRenameVldr) SourceTableDoesNotExist(s *models.Statement, r *models.Rule) {
 	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	if v.TableInfo(v.rt.OldTable.Schema.O, v.rt.OldTable.Name.O) == nil {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, v.rt.OldTable.Name.O),
+			Level:       r.Level,
+			Object:      v.rt.OldTable.Name.O,
+		}
+		s.Violations.Append(c)
+	}
 }
 
-// TableRenameTargetTableDoesNotExist 目标库是否存在
+// TargetDatabaseDoesNotExist 目标库是否存在
 // RULE: RTB-L3-003
-func (v *TableRenameVldr) TableRenameTargetTableDoesNotExist(s *models.Statement, r *models.Rule) {
+func (v *TableRenameVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	v.renameDatabase(s, r, v.rt.NewTable)
+}
+
+// TargetTableDoesNotExist 目标表必须不存在
+// RULE: RTB-L3-004
+func (v *TableRenameVldr) TargetTableDoesNotExist(s *models.Statement, r *models.Rule) {
 	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	if v.TableInfo(v.rt.NewTable.Schema.O, v.rt.NewTable.Name.O) != nil {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, v.rt.NewTable.Name.O),
+			Level:       r.Level,
+			Object:      v.rt.NewTable.Name.O,
+		}
+		s.Violations.Append(c)
+	}
+}
+
+func (v *TableRenameVldr) renameDatabase(s *models.Statement, r *models.Rule, table *ast.TableName) {
+	database := table.Schema.O
+	if database == "" {
+		database = v.Ctx.Ticket.Database
+	}
+	if v.DatabaseInfo(database) == nil {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, database),
+			Level:       r.Level,
+			Object:      database,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // TableDropVldr 删除表语句相关的审核规则
@@ -3186,6 +3430,7 @@ func (v *TableDropVldr) Validate(wg *sync.WaitGroup) {
 	for _, s := range v.Ctx.Stmts {
 		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
 		node := s.StmtNode
+		v.Seek(s)
 		if dt, ok := node.(*ast.DropTableStmt); !ok {
 			// 类型断言不成功
 			continue
@@ -3211,6 +3456,7 @@ func (v *TableDropVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *model
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, ti.Database),
 				Level:       r.Level,
+				Object:      ti.Database,
 			}
 			s.Violations.Append(c)
 		}
@@ -3229,6 +3475,7 @@ func (v *TableDropVldr) TargetTableDoesNotExist(s *models.Statement, r *models.R
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, fmt.Sprintf("`%s`.`%s`", ti.Database, ti.Table.Name)),
 				Level:       r.Level,
+				Object:      ti.Table.Name,
 			}
 			s.Violations.Append(c)
 		}
@@ -1,14 +1,21 @@
 package validate
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 
+	log "github.com/sirupsen/logrus"
+
 	"github.com/mia0x75/halo/models"
 )
 
 // TriggerCreateVldr 创建触发器语句相关的审核规则
 type TriggerCreateVldr struct {
 	vldr
+
+	ct *CreateTriggerStmt
 }
 
 // Call 利用反射方法动态调用审核函数
@@ -24,41 +31,157 @@ func (v *TriggerCreateVldr) Enabled() bool {
 // Validate 规则组的审核入口
 func (v *TriggerCreateVldr) Validate(wg *sync.WaitGroup) {
 	defer wg.Done()
+	for _, s := range v.Ctx.Stmts {
+		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
+		node := s.StmtNode
+		v.Seek(s)
+		if ct, ok := node.(*CreateTriggerStmt); !ok {
+			// 类型断言不成功
+			continue
+		} else {
+			v.ct = ct
+		}
+		for _, r := range v.Rules {
+			if r.Bitwise&1 != 1 {
+				continue
+			}
+			v.Call(r.Func, s, r)
+		}
+	}
 }
 
 // TriggerNameQualified 触发器名标识符规则
 // RULE: CTG-L2-001
 func (v *TriggerCreateVldr) TriggerNameQualified(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	triggerName := v.ct.Name.Name
+	if err := Match(r, triggerName, triggerName, r.Values); err != nil {
+		c := &models.Clause{
+			Description: err.Error(),
+			Level:       r.Level,
+			Object:      triggerName,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // TriggerNameLowerCaseRequired 触发器名大小写规则
 // RULE: CTG-L2-002
 func (v *TriggerCreateVldr) TriggerNameLowerCaseRequired(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	triggerName := v.ct.Name.Name
+	if err := Match(r, triggerName, triggerName); err != nil {
+		c := &models.Clause{
+			Description: err.Error(),
+			Level:       r.Level,
+			Object:      triggerName,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // TriggerNameMaxLength 触发器名长度规则
 // RULE: CTG-L2-003
 func (v *TriggerCreateVldr) TriggerNameMaxLength(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	triggerName := v.ct.Name.Name
+	threshold, _ := strconv.Atoi(r.Values)
+	if len(triggerName) > threshold {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, triggerName, threshold),
+			Level:       r.Level,
+			Object:      triggerName,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // TriggerPrefixRequired 触发器名前缀规则
 // RULE: CTG-L2-004
 func (v *TriggerCreateVldr) TriggerPrefixRequired(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	triggerName := strings.TrimSpace(v.ct.Name.Name)
+	if len(triggerName) > 0 {
+		if err := Match(r, triggerName, r.Values); err != nil {
+			c := &models.Clause{
+				Description: fmt.Sprintf(r.Message, triggerName, r.Values),
+				Level:       r.Level,
+				Object:      triggerName,
+			}
+			s.Violations.Append(c)
+		}
+	}
 }
 
 // TargetDatabaseDoesNotExist 目标库是否存在
 // RULE: CTG-L3-001
 func (v *TriggerCreateVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	v.targetDatabase(s, r, v.ct.Name)
 }
 
 // TargetTableDoesNotExist 目标表是否存在
 // RULE: CTG-L3-002
 func (v *TriggerCreateVldr) TargetTableDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	database := v.ct.Table.Schema
+	if database == "" {
+		database = v.ct.Name.Schema
+	}
+	if v.TableInfo(database, v.ct.Table.Name) == nil {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, v.ct.Table.Name),
+			Level:       r.Level,
+			Object:      v.ct.Table.Name,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // TargetTriggerDoesNotExist 目标触发器是否存在
 // RULE: CTG-L3-003
 func (v *TriggerCreateVldr) TargetTriggerDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	// 使用IF NOT EXISTS时，触发器已存在不会报错
+	if v.ct.IfNotExists {
+		return
+	}
+	if v.TriggerInfo(v.ct.Name.Schema, v.ct.Name.Name) != nil {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, v.ct.Name.Name),
+			Level:       r.Level,
+			Object:      v.ct.Name.Name,
+		}
+		s.Violations.Append(c)
+	}
+}
+
+// TriggerTimingEventDuplicated 同一张表的同一时机和事件只能有一个触发器
+// RULE: CTG-L3-004
+func (v *TriggerCreateVldr) TriggerTimingEventDuplicated(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	database := v.ct.Name.Schema
+	if database == "" {
+		database = v.Ctx.Ticket.Database
+	}
+	for _, t := range v.current().Triggers {
+		if t.Database != database || t.Table != v.ct.Table.Name {
+			continue
+		}
+		if !strings.EqualFold(t.Timing, v.ct.Timing) || !strings.EqualFold(t.Event, v.ct.Event) {
+			continue
+		}
+		// 同名的触发器由TargetTriggerDoesNotExist检查
+		if strings.EqualFold(t.Name, v.ct.Name.Name) {
+			continue
+		}
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, t.Table, v.ct.Timing, v.ct.Event, t.Name),
+			Level:       r.Level,
+			Object:      t.Table,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // TriggerAlterVldr 修改触发器语句相关的审核规则
@@ -76,13 +199,14 @@ func (v *TriggerAlterVldr) Enabled() bool {
 	return true
 }
 
-// Validate 规则组的审核入口
+// Validate 规则组的审核入口，MySQL没有ALTER TRIGGER语句，修改触发器只能先删除再重建，
+// 分别由TriggerDropVldr和TriggerCreateVldr审核
 func (v *TriggerAlterVldr) Validate(wg *sync.WaitGroup) {
 	defer wg.Done()
 }
 
 // TargetDatabaseDoesNotExist 目标库是否存在
-// RULE:DTG-L3-001
+// RULE: MTG-L3-001
 func (v *TriggerAlterVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.Rule) {
 }
 
@@ -99,6 +223,8 @@ func (v *TriggerAlterVldr) TargetTriggerDoesNotExist(s *models.Statement, r *mod
 // TriggerDropVldr 删除触发器语句相关的审核规则
 type TriggerDropVldr struct {
 	vldr
+
+	dt *DropTriggerStmt
 }
 
 // Call 利用反射方法动态调用审核函数
@@ -114,19 +240,64 @@ func (v *TriggerDropVldr) Enabled() bool {
 // Validate 规则组的审核入口
 func (v *TriggerDropVldr) Validate(wg *sync.WaitGroup) {
 	defer wg.Done()
+	for _, s := range v.Ctx.Stmts {
+		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
+		node := s.StmtNode
+		v.Seek(s)
+		if dt, ok := node.(*DropTriggerStmt); !ok {
+			// 类型断言不成功
+			continue
+		} else {
+			v.dt = dt
+		}
+		for _, r := range v.Rules {
+			if r.Bitwise&1 != 1 {
+				continue
+			}
+			v.Call(r.Func, s, r)
+		}
+	}
 }
 
 // TargetDatabaseDoesNotExist 目标库是否存在
-// RULE:DTG-L3-001
+// RULE: DTG-L3-001
 func (v *TriggerDropVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	v.targetDatabase(s, r, v.dt.Name)
 }
 
-// TargetTableDoesNotExist 目标表是否存在
-// RULE:DTG-L3-002
+// TargetTableDoesNotExist 目标表是否存在，DROP TRIGGER语句中没有表名，检查触发器所在的表
+// RULE: DTG-L3-002
 func (v *TriggerDropVldr) TargetTableDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	t := v.TriggerInfo(v.dt.Name.Schema, v.dt.Name.Name)
+	if t == nil {
+		return
+	}
+	if v.TableInfo(t.Database, t.Table) == nil {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, t.Table),
+			Level:       r.Level,
+			Object:      t.Table,
+		}
+		s.Violations.Append(c)
+	}
 }
 
 // TargetTriggerDoesNotExist 目标触发器是否存在
-// RULE:DTG-L3-003
+// RULE: DTG-L3-003
 func (v *TriggerDropVldr) TargetTriggerDoesNotExist(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	// 使用IF EXISTS时，触发器不存在不会报错
+	if v.dt.IfExists {
+		return
+	}
+	if v.TriggerInfo(v.dt.Name.Schema, v.dt.Name.Name) == nil {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, v.dt.Name.Name),
+			Level:       r.Level,
+			Object:      v.dt.Name.Name,
+		}
+		s.Violations.Append(c)
+	}
 }
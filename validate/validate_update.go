@@ -33,6 +33,7 @@ func (v *UpdateVldr) Validate(wg *sync.WaitGroup) {
 	for _, s := range v.Ctx.Stmts {
 		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
 		node := s.StmtNode
+		v.Seek(s)
 		if ud, ok := node.(*ast.UpdateStmt); !ok {
 			// 类型断言不成功
 			continue
@@ -71,6 +72,7 @@ func (v *UpdateVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *models.R
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, ti.Database),
 				Level:       r.Level,
+				Object:      ti.Database,
 			}
 			s.Violations.Append(c)
 		}
@@ -89,6 +91,7 @@ func (v *UpdateVldr) TargetTableDoesNotExist(s *models.Statement, r *models.Rule
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, fmt.Sprintf("`%s`.`%s`", ti.Database, ti.Table.Name)),
 				Level:       r.Level,
+				Object:      ti.Table.Name,
 			}
 			s.Violations.Append(c)
 		}
@@ -99,12 +102,12 @@ func (v *UpdateVldr) TargetTableDoesNotExist(s *models.Statement, r *models.Rule
 // RULE: UPD-L3-003
 func (v *UpdateVldr) TargetColumnDoesNotExist(s *models.Statement, r *models.Rule) {
 	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
-	// TODO:
+	v.columnExists(s, r)
 }
 
-// MaxAllowedUpdateRows 允许单次更新的最大行数
-// RULE: UPD-L3-004
+// MaxAllowedUpdateRows 允许单次更新的最大行数，行数来自目标群集上的执行计划
+// RULE: UPD-L3-005
 func (v *UpdateVldr) MaxAllowedUpdateRows(s *models.Statement, r *models.Rule) {
 	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
-	// TODO:
+	v.maxAllowedRows(s, r)
 }
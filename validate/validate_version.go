@@ -0,0 +1,191 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mia0x75/parser/ast"
+	"github.com/mia0x75/parser/mysql"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mia0x75/halo/models"
+)
+
+// VersionVldr 与目标群集版本相关的审核规则，拒绝目标版本不支持的特性，
+// 规则的values是各个分支要求的最低版本，例如{"MySQL": "8.0.12", "MariaDB": "10.3.2"}，没有列出的分支表示不支持
+type VersionVldr struct {
+	vldr
+}
+
+// Call 利用反射方法动态调用审核函数
+func (v *VersionVldr) Call(method string, params ...interface{}) {
+	Call(v, method, params...)
+}
+
+// Enabled 当前规则组是否生效
+func (v *VersionVldr) Enabled() bool {
+	return true
+}
+
+// Validate 规则组的审核入口，目标群集的版本未知时不做检查
+func (v *VersionVldr) Validate(wg *sync.WaitGroup) {
+	defer wg.Done()
+	if v.Ctx.Version == nil {
+		return
+	}
+	for _, s := range v.Ctx.Stmts {
+		v.Seek(s)
+		for _, r := range v.Rules {
+			if r.Bitwise&1 != 1 {
+				continue
+			}
+			v.Call(r.Func, s, r)
+		}
+	}
+}
+
+// require 目标群集不满足规则的版本要求时，对语句中使用的每一个特性报告问题
+func (v *VersionVldr) require(s *models.Statement, r *models.Rule, features []string) {
+	if len(features) == 0 {
+		return
+	}
+	req, err := ParseRequirement(r.Values)
+	if err != nil {
+		log.Errorf("[E] RULE: %s, %s", r.Name, err.Error())
+		return
+	}
+	if req.Satisfied(v.Ctx.Version) {
+		return
+	}
+	for _, feature := range features {
+		c := &models.Clause{
+			Description: fmt.Sprintf(r.Message, feature, v.Ctx.Version.String(), req.String()),
+			Level:       r.Level,
+			Object:      feature,
+		}
+		s.Violations.Append(c)
+	}
+}
+
+// CollationVersionRequired 基于UCA 9.0.0的排序规则(utf8mb4_0900_ai_ci等)只有MySQL 8.0才支持
+// RULE: VER-L1-001
+func (v *VersionVldr) CollationVersionRequired(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	features := []string{}
+	for _, collate := range collations(s.StmtNode) {
+		if strings.Contains(strings.ToLower(collate), "_0900_") {
+			features = append(features, collate)
+		}
+	}
+	v.require(s, r, features)
+}
+
+// JSONColumnVersionRequired JSON数据类型的版本要求
+// RULE: VER-L1-002
+func (v *VersionVldr) JSONColumnVersionRequired(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	features := []string{}
+	for _, col := range columnDefs(s.StmtNode) {
+		if col.Tp != nil && col.Tp.Tp == mysql.TypeJSON {
+			features = append(features, col.Name.Name.O)
+		}
+	}
+	v.require(s, r, features)
+}
+
+// GeneratedColumnVersionRequired 生成列的版本要求
+// RULE: VER-L1-003
+func (v *VersionVldr) GeneratedColumnVersionRequired(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	features := []string{}
+	for _, col := range columnDefs(s.StmtNode) {
+		for _, op := range col.Options {
+			if op.Tp == ast.ColumnOptionGenerated {
+				features = append(features, col.Name.Name.O)
+				break
+			}
+		}
+	}
+	v.require(s, r, features)
+}
+
+// InstantAlgorithmVersionRequired ALGORITHM=INSTANT的版本要求
+// RULE: VER-L1-004
+func (v *VersionVldr) InstantAlgorithmVersionRequired(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	at, ok := s.StmtNode.(*ast.AlterTableStmt)
+	if !ok {
+		return
+	}
+	features := []string{}
+	for _, spec := range at.Specs {
+		if spec.Tp == ast.AlterTableAlgorithm && spec.Algorithm == ast.AlterAlgorithmInstant {
+			features = append(features, "ALGORITHM=INSTANT")
+		}
+	}
+	v.require(s, r, features)
+}
+
+// StoredProgramVersionRequired 存储函数、存储过程、触发器和事件的版本要求，TiDB不支持存储程序
+// RULE: VER-L1-005
+func (v *VersionVldr) StoredProgramVersionRequired(s *models.Statement, r *models.Rule) {
+	log.Debugf("[D] RULE: %s, %s", r.Name, r.Func)
+	feature := ""
+	switch s.StmtNode.(type) {
+	case *CreateFunctionStmt:
+		feature = "FUNCTION"
+	case *CreateProcedureStmt:
+		feature = "PROCEDURE"
+	case *CreateTriggerStmt:
+		feature = "TRIGGER"
+	case *CreateEventStmt:
+		feature = "EVENT"
+	default:
+		return
+	}
+	v.require(s, r, []string{feature})
+}
+
+// collations 语句中显式指定的排序规则，包括库、表和列
+func collations(node ast.StmtNode) []string {
+	L := []string{}
+	tableOption := func(options []*ast.TableOption) {
+		for _, op := range options {
+			if op.Tp == ast.TableOptionCollate {
+				L = append(L, op.StrValue)
+			}
+		}
+	}
+	switch x := node.(type) {
+	case *ast.CreateDatabaseStmt:
+		for _, op := range x.Options {
+			if op.Tp == ast.DatabaseOptionCollate {
+				L = append(L, op.Value)
+			}
+		}
+	case *ast.AlterDatabaseStmt:
+		for _, op := range x.Options {
+			if op.Tp == ast.DatabaseOptionCollate {
+				L = append(L, op.Value)
+			}
+		}
+	case *ast.CreateTableStmt:
+		tableOption(x.Options)
+	case *ast.AlterTableStmt:
+		for _, spec := range x.Specs {
+			tableOption(spec.Options)
+		}
+	}
+	for _, col := range columnDefs(node) {
+		if col.Tp != nil && col.Tp.Collate != "" {
+			L = append(L, col.Tp.Collate)
+		}
+		for _, op := range col.Options {
+			if op.Tp == ast.ColumnOptionCollate {
+				L = append(L, op.StrValue)
+			}
+		}
+	}
+	return L
+}
@@ -35,6 +35,7 @@ func (v *ViewCreateVldr) Validate(wg *sync.WaitGroup) {
 	for _, s := range v.Ctx.Stmts {
 		// 该方法不能放到结构体vldr是因为，反射时找不到子类的方法
 		node := s.StmtNode
+		v.Seek(s)
 		if cv, ok := node.(*ast.CreateViewStmt); !ok {
 			// 类型断言不成功
 			continue
@@ -58,6 +59,7 @@ func (v *ViewCreateVldr) ViewNameQualified(s *models.Statement, r *models.Rule)
 		c := &models.Clause{
 			Description: err.Error(),
 			Level:       r.Level,
+			Object:      viewName,
 		}
 		s.Violations.Append(c)
 	}
@@ -71,6 +73,7 @@ func (v *ViewCreateVldr) ViewNameLowerCaseRequired(s *models.Statement, r *model
 		c := &models.Clause{
 			Description: err.Error(),
 			Level:       r.Level,
+			Object:      viewName,
 		}
 		s.Violations.Append(c)
 	}
@@ -85,6 +88,7 @@ func (v *ViewCreateVldr) ViewNameMaxLength(s *models.Statement, r *models.Rule)
 		c := &models.Clause{
 			Description: fmt.Sprintf(r.Message, viewName, threshold),
 			Level:       r.Level,
+			Object:      viewName,
 		}
 		s.Violations.Append(c)
 	}
@@ -100,6 +104,7 @@ func (v *ViewCreateVldr) ViewNamePrefixRequired(s *models.Statement, r *models.R
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, viewName, r.Values),
 				Level:       r.Level,
+				Object:      viewName,
 			}
 			s.Violations.Append(c)
 		}
@@ -116,6 +121,7 @@ func (v *ViewCreateVldr) TargetDatabaseDoesNotExist(s *models.Statement, r *mode
 			c := &models.Clause{
 				Description: fmt.Sprintf(r.Message, ti.Database),
 				Level:       r.Level,
+				Object:      ti.Database,
 			}
 			s.Violations.Append(c)
 		}
@@ -0,0 +1,131 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 目标群集的分支，Percona Server与官方版本的特性一致，按MySQL处理
+const (
+	FlavorMySQL   = "MySQL"
+	FlavorMariaDB = "MariaDB"
+	FlavorTiDB    = "TiDB"
+)
+
+// 版本号中的数字部分，例如5.7.30-log中的5.7.30、5.7.25-TiDB-v4.0.0中的4.0.0
+var (
+	versionPattern = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?`)
+	tidbPattern    = regexp.MustCompile(`(?i)TiDB-v?(\d+\.\d+(?:\.\d+)?)`)
+	mariadbPattern = regexp.MustCompile(`(?i)^(?:5\.5\.5-)?(\d+\.\d+(?:\.\d+)?)-MariaDB`)
+)
+
+// ServerVersion 目标群集的版本，来自VERSION()和@@version_comment
+type ServerVersion struct {
+	Flavor string // 分支
+	Major  int
+	Minor  int
+	Patch  int
+	Raw    string // VERSION()的原始结果
+}
+
+// ParseVersion 解析VERSION()和@@version_comment，无法识别时返回nil，
+// TiDB的VERSION()以兼容的MySQL版本开头，真实的版本在TiDB-之后
+func ParseVersion(version string, comment string) *ServerVersion {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return nil
+	}
+	v := &ServerVersion{
+		Flavor: FlavorMySQL,
+		Raw:    version,
+	}
+	number := version
+	switch {
+	case tidbPattern.MatchString(version):
+		v.Flavor = FlavorTiDB
+		number = tidbPattern.FindStringSubmatch(version)[1]
+	case strings.Contains(strings.ToLower(comment), "tidb"):
+		v.Flavor = FlavorTiDB
+	case mariadbPattern.MatchString(version):
+		v.Flavor = FlavorMariaDB
+		number = mariadbPattern.FindStringSubmatch(version)[1]
+	case strings.Contains(strings.ToLower(comment), "mariadb"):
+		v.Flavor = FlavorMariaDB
+	}
+
+	match := versionPattern.FindStringSubmatch(number)
+	if match == nil {
+		return nil
+	}
+	v.Major, _ = strconv.Atoi(match[1])
+	v.Minor, _ = strconv.Atoi(match[2])
+	v.Patch, _ = strconv.Atoi(match[3])
+	return v
+}
+
+// String 分支和版本号，例如MySQL 8.0.12
+func (v *ServerVersion) String() string {
+	return fmt.Sprintf("%s %d.%d.%d", v.Flavor, v.Major, v.Minor, v.Patch)
+}
+
+// AtLeast 版本号是否不低于给定的版本，给定的版本无法解析时视为满足
+func (v *ServerVersion) AtLeast(version string) bool {
+	match := versionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return true
+	}
+	L := [3]int{}
+	for i := range L {
+		L[i], _ = strconv.Atoi(match[i+1])
+	}
+	for i, n := range []int{v.Major, v.Minor, v.Patch} {
+		if n != L[i] {
+			return n > L[i]
+		}
+	}
+	return true
+}
+
+// Requirement 规则要求的最低版本，键为分支，没有列出的分支表示不支持，
+// 例如{"MySQL": "8.0.12", "MariaDB": "10.3.2"}
+type Requirement map[string]string
+
+// ParseRequirement 解析规则的values
+func ParseRequirement(values string) (Requirement, error) {
+	req := Requirement{}
+	if err := json.Unmarshal([]byte(values), &req); err != nil {
+		return nil, fmt.Errorf("错误代码: 1500, 错误信息: 版本要求`%s`无法解析，%s。", values, err.Error())
+	}
+	return req, nil
+}
+
+// Satisfied 目标群集是否满足版本要求
+func (req Requirement) Satisfied(v *ServerVersion) bool {
+	version, ok := req[v.Flavor]
+	return ok && v.AtLeast(version)
+}
+
+// String 版本要求的可读形式，例如MariaDB 10.3.2+, MySQL 8.0.12+
+func (req Requirement) String() string {
+	L := []string{}
+	for flavor, version := range req {
+		L = append(L, fmt.Sprintf("%s %s+", flavor, version))
+	}
+	sort.Strings(L)
+	return strings.Join(L, ", ")
+}
+
+// version 从全局变量中获取目标群集的版本，快照和导出文件中没有时使用群集上记录的版本
+func (ctx *Context) version() *ServerVersion {
+	if v := ParseVersion(ctx.Variables["version"], ctx.Variables["version_comment"]); v != nil {
+		return v
+	}
+	if ctx.Cluster != nil {
+		return ParseVersion(ctx.Cluster.ServerVersion, ctx.Cluster.VersionComment)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mia0x75/halo/models"
+)
+
+func TestParseVersion(t *testing.T) {
+	cases := []struct {
+		version string
+		comment string
+		expect  string
+	}{
+		{"5.7.30-log", "MySQL Community Server (GPL)", "MySQL 5.7.30"},
+		{"8.0.21", "MySQL Community Server - GPL", "MySQL 8.0.21"},
+		{"5.7.30-33-log", "Percona Server (GPL), Release 33, Revision 6517692", "MySQL 5.7.30"},
+		{"10.3.22-MariaDB-log", "MariaDB Server", "MariaDB 10.3.22"},
+		{"5.5.5-10.4.12-MariaDB", "", "MariaDB 10.4.12"},
+		{"5.7.25-TiDB-v4.0.0", "TiDB Server (Apache License 2.0) Community Edition, MySQL 5.7 compatible", "TiDB 4.0.0"},
+		{"8.0", "", "MySQL 8.0.0"},
+	}
+	for _, c := range cases {
+		v := ParseVersion(c.version, c.comment)
+		if assert.NotNil(t, v, c.version) {
+			assert.Equal(t, c.expect, v.String())
+			assert.Equal(t, c.version, v.Raw)
+		}
+	}
+	assert.Nil(t, ParseVersion("", ""))
+	assert.Nil(t, ParseVersion("unknown", ""))
+
+	v := ParseVersion("8.0.12", "")
+	assert.True(t, v.AtLeast("8.0.12"))
+	assert.True(t, v.AtLeast("5.7.30"))
+	assert.False(t, v.AtLeast("8.0.13"))
+	assert.False(t, v.AtLeast("10.2"))
+}
+
+func TestRequirement(t *testing.T) {
+	req, err := ParseRequirement(`{"MySQL": "8.0.12", "MariaDB": "10.3.2"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "MariaDB 10.3.2+, MySQL 8.0.12+", req.String())
+	assert.True(t, req.Satisfied(ParseVersion("8.0.21", "")))
+	assert.False(t, req.Satisfied(ParseVersion("5.7.30-log", "")))
+	assert.True(t, req.Satisfied(ParseVersion("10.4.12-MariaDB", "")))
+	// 没有列出的分支不支持
+	assert.False(t, req.Satisfied(ParseVersion("5.7.25-TiDB-v4.0.0", "")))
+
+	_, err = ParseRequirement(`8.0.12`)
+	assert.Error(t, err)
+}
+
+func TestContextVersion(t *testing.T) {
+	// 导出文件头部记录的版本
+	ctx := &Context{}
+	assert.NoError(t, ctx.Load(ParseDump("-- Server version\t5.7.30-log\nCREATE DATABASE db1;", "")))
+	assert.Equal(t, "MySQL 5.7.30", ctx.Version.String())
+
+	// 没有版本时使用群集上记录的版本
+	ctx = &Context{
+		Cluster: &models.Cluster{ServerVersion: "10.3.22-MariaDB-log"},
+	}
+	assert.NoError(t, ctx.Load(ParseDump("CREATE DATABASE db1;", "")))
+	assert.Equal(t, "MariaDB 10.3.22", ctx.Version.String())
+
+	ctx = &Context{}
+	assert.NoError(t, ctx.Load(ParseDump("CREATE DATABASE db1;", "")))
+	assert.Nil(t, ctx.Version)
+}
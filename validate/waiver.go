@@ -0,0 +1,89 @@
+package validate
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mia0x75/halo/caches"
+	"github.com/mia0x75/halo/models"
+)
+
+// IgnoreAnnotation 内联豁免注释的标记，例如：/* halo:ignore CTB-L2-008,CTB-L2-009 reason="legacy" */
+const IgnoreAnnotation = "halo:ignore"
+
+var (
+	commentPattern = regexp.MustCompile(`(?s)/\*.*?\*/|(?:--|#)[^\n]*`)
+	ignorePattern  = regexp.MustCompile(`halo:ignore\s+([\w-]+(?:\s*,\s*[\w-]+)*)(?:\s+reason\s*=\s*"([^"]*)")?`)
+)
+
+// Suppressions 解析语句注释中的内联豁免，返回规则名称到豁免理由的映射
+func Suppressions(sql string) map[string]string {
+	if !strings.Contains(sql, IgnoreAnnotation) {
+		return nil
+	}
+	m := map[string]string{}
+	for _, comment := range commentPattern.FindAllString(sql, -1) {
+		for _, match := range ignorePattern.FindAllStringSubmatch(comment, -1) {
+			for _, name := range strings.Split(match[1], ",") {
+				if name = strings.ToUpper(strings.TrimSpace(name)); name != "" {
+					m[name] = strings.TrimSpace(match[2])
+				}
+			}
+		}
+	}
+	return m
+}
+
+// waive 规则在语句上是否被豁免，内联注释优先，其次是管理员批准的豁免，返回批准人和理由
+func (ctx *Context) waive(s *models.Statement, r *models.Rule) (by string, reason string, ok bool) {
+	sql := s.Content
+	if s.StmtNode != nil && s.StmtNode.Text() != "" {
+		// 语句的原始文本保留了注释
+		sql = s.StmtNode.Text()
+	}
+	if reason, ok = Suppressions(sql)[r.Name]; ok {
+		// 内联注释由工单的发起人添加
+		by = IgnoreAnnotation
+		if ctx.Ticket != nil {
+			if name := userName(ctx.Ticket.UserID); name != "" {
+				by = name
+			}
+		}
+		return
+	}
+
+	if len(ctx.Waivers) == 0 || ctx.Ticket == nil {
+		return
+	}
+	var clusterID uint
+	if ctx.Cluster != nil {
+		clusterID = ctx.Cluster.ClusterID
+	}
+	now := uint(time.Now().Unix())
+	tables := tablesOf(s.StmtNode, ctx.Ticket.Database)
+	for _, w := range ctx.Waivers {
+		if w.RuleName != r.Name || w.Expired(now) {
+			continue
+		}
+		covered := w.TicketID != 0 && w.TicketID == ctx.Ticket.TicketID
+		for i := 0; !covered && i < len(tables); i++ {
+			covered = w.Covers(ctx.Ticket.TicketID, clusterID, tables[i][0], tables[i][1])
+		}
+		if covered {
+			return userName(w.UserID), w.Reason, true
+		}
+	}
+	return
+}
+
+// userName 用户的名称，用户不存在时返回空
+func userName(userID uint) string {
+	user := caches.UsersMap.Any(func(elem *models.User) bool {
+		return elem.UserID == userID
+	})
+	if user == nil {
+		return ""
+	}
+	return user.Name
+}
@@ -0,0 +1,88 @@
+package validate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mia0x75/halo/caches"
+	"github.com/mia0x75/halo/models"
+)
+
+func TestSuppressions(t *testing.T) {
+	m := Suppressions("/* halo:ignore CTB-L2-008 reason=\"legacy\" */ CREATE TABLE t1 (id INT)")
+	assert.Equal(t, map[string]string{"CTB-L2-008": "legacy"}, m)
+
+	m = Suppressions("-- halo:ignore ctb-l2-008, CTB-L2-009\nUPDATE t1 SET c1 = 1 # halo:ignore UPD-L2-001 reason=\"batch\"")
+	assert.Equal(t, map[string]string{"CTB-L2-008": "", "CTB-L2-009": "", "UPD-L2-001": "batch"}, m)
+
+	// 注释之外的文本不是豁免
+	assert.Empty(t, Suppressions("SELECT 'halo:ignore CTB-L2-008' FROM t1"))
+	assert.Nil(t, Suppressions("CREATE TABLE t1 (id INT)"))
+}
+
+func TestWaive(t *testing.T) {
+	caches.UsersMap.Lock()
+	users := caches.UsersMap.M
+	caches.UsersMap.M = []*models.User{{UserID: 1, Name: "dev"}, {UserID: 2, Name: "admin"}}
+	caches.UsersMap.Unlock()
+	defer func() {
+		caches.UsersMap.Lock()
+		caches.UsersMap.M = users
+		caches.UsersMap.Unlock()
+	}()
+
+	nodes, err := Parse("/* halo:ignore CUS-L1-001 reason=\"legacy\" */ UPDATE t1 SET c1 = 1;" +
+		"DELETE FROM db2.t2;" +
+		"DELETE FROM t3")
+	assert.NoError(t, err)
+	stmts := []*models.Statement{}
+	for i, node := range nodes {
+		stmts = append(stmts, &models.Statement{
+			Sequence:   uint16(i + 1),
+			StmtNode:   node,
+			Violations: &models.Violations{},
+		})
+	}
+
+	expire := uint(time.Now().Add(time.Hour).Unix())
+	v := &customVldr{}
+	v.SetContext(&Context{
+		Ticket:  &models.Ticket{TicketID: 7, UserID: 1, Database: "db1"},
+		Cluster: &models.Cluster{ClusterID: 3},
+		Waivers: []*models.Waiver{
+			{RuleName: "CUS-L1-001", ClusterID: 3, Database: "DB2", Table: "t2", Reason: "archive", UserID: 2, ExpireAt: expire},
+			{RuleName: "CUS-L1-002", TicketID: 7, ClusterID: 3, Reason: "one-off", UserID: 2, ExpireAt: expire},
+			{RuleName: "CUS-L1-001", ClusterID: 3, Database: "db1", Table: "t3", Reason: "expired", UserID: 2, ExpireAt: 1},
+		},
+	})
+	r1 := &models.Rule{Name: "CUS-L1-001", Message: "custom", Level: 1}
+	r2 := &models.Rule{Name: "CUS-L1-002", Message: "custom", Level: 1}
+	for _, s := range stmts {
+		assert.NoError(t, Call(v, "CustomMethod", s, r1))
+		assert.NoError(t, Call(v, "CustomMethod", s, r2))
+	}
+
+	// 内联注释豁免，批准人是工单的发起人
+	clauses := stmts[0].Violations.Clauses()
+	assert.Equal(t, 2, len(clauses))
+	assert.True(t, clauses[0].Waived)
+	assert.Equal(t, "dev", clauses[0].WaivedBy)
+	assert.Equal(t, "legacy", clauses[0].Reason)
+	// 工单上的豁免
+	assert.True(t, clauses[1].Waived)
+	assert.Equal(t, "admin", clauses[1].WaivedBy)
+	assert.Equal(t, "one-off", clauses[1].Reason)
+
+	// 表上的豁免
+	clauses = stmts[1].Violations.Clauses()
+	assert.True(t, clauses[0].Waived)
+	assert.Equal(t, "archive", clauses[0].Reason)
+
+	// 过期的豁免不再生效
+	clauses = stmts[2].Violations.Clauses()
+	assert.False(t, clauses[0].Waived)
+	assert.True(t, clauses[1].Waived)
+	assert.Contains(t, stmts[2].Violations.Marshal(), `"WaivedBy":"admin"`)
+}